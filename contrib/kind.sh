@@ -854,7 +854,9 @@ install_ovn() {
   pushd ${MANIFEST_OUTPUT_DIR}
 
   run_kubectl apply -f k8s.ovn.org_egressfirewalls.yaml
+  run_kubectl apply -f k8s.ovn.org_clusteregressfirewalls.yaml
   run_kubectl apply -f k8s.ovn.org_egressips.yaml
+  run_kubectl apply -f k8s.ovn.org_egressippools.yaml
   run_kubectl apply -f k8s.ovn.org_egressqoses.yaml
   run_kubectl apply -f k8s.ovn.org_egressservices.yaml
   run_kubectl apply -f k8s.ovn.org_adminpolicybasedexternalroutes.yaml
@@ -866,6 +868,7 @@ install_ovn() {
     run_kubectl apply -f k8s.ovn.org_clusternetworkconnects.yaml
   fi
   run_kubectl apply -f k8s.ovn.org_vteps.yaml
+  run_kubectl apply -f k8s.ovn.org_networkobservabilities.yaml
   # NOTE: When you update vendoring versions for the ANP & BANP APIs, we must update the version of the CRD we pull from in the below URL
   run_kubectl apply -f https://raw.githubusercontent.com/kubernetes-sigs/network-policy-api/v0.1.5/config/crd/experimental/policy.networking.k8s.io_adminnetworkpolicies.yaml
   run_kubectl apply -f https://raw.githubusercontent.com/kubernetes-sigs/network-policy-api/v0.1.5/config/crd/experimental/policy.networking.k8s.io_baselineadminnetworkpolicies.yaml
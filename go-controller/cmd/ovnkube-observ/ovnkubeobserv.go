@@ -2,13 +2,21 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"hash/fnv"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	observ "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/exporter"
 )
 
 func main() {
@@ -28,14 +36,80 @@ func main() {
 	logCookie := flag.Bool("log-cookie", false, "Print raw sample cookie with psample group_id.")
 	printPacket := flag.Bool("print-full-packet", false, "Print full received packet. When false, only src and dst ips are printed with every sample.")
 	addOVSCollector := flag.Bool("add-ovs-collector", false, "Add ovs collector to enable sampling. Use with caution. Make sure no one else is using observability.")
+	enableDropSampling := flag.Bool("enable-drop-sampling", false, "Enable OVN drop sampling to decode packets dropped by OVN logical flows. "+
+		"Requires enable-enrichment, and add-ovs-collector unless the default collector set is configured otherwise.")
 	outputFile := flag.String("output-file", "", "Output file to write the samples to.")
 	filterSrcIP := flag.String("filter-src-ip", "", "Filter in only packets from a given source ip.")
 	filterDstIP := flag.String("filter-dst-ip", "", "Filter in only packets to a given destination ip.")
+	ipfixCollector := flag.String("ipfix-collector", "", "Export samples to the given IPFIX collector (host:port) over UDP. "+
+		"When exporting, samples are only printed if output-file is set.")
+	ipfixEnterpriseNumber := flag.Uint("ipfix-enterprise-number", exporter.IPFIXEnterpriseNumber, "IPFIX Private Enterprise Number of the OVN-K specific information elements.")
+	otlpEndpoint := flag.String("otlp-endpoint", "", "Export samples as logs to the given OpenTelemetry collector OTLP/HTTP endpoint, e.g. http://collector:4318. "+
+		"When exporting, samples are only printed if output-file is set.")
+	metricsBindAddress := flag.String("metrics-bind-address", "", "Expose per-policy samples counters on the given address, e.g. :9476.")
 	flag.Parse()
 
 	reader := observ.NewSampleReader(*enableDecoder, *logCookie, *printPacket, *addOVSCollector, *filterSrcIP, *filterDstIP, *outputFile)
-	err := reader.ReadSamples(ctx)
+	exporters, err := newExporters(ctx, *ipfixCollector, uint32(*ipfixEnterpriseNumber), *otlpEndpoint, *metricsBindAddress)
 	if err != nil {
 		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	reader.SetExporters(exporters...)
+	if *enableDropSampling {
+		reader.EnableDropSampling()
+	}
+	err = reader.ReadSamples(ctx)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+	for _, e := range exporters {
+		if err = e.Close(); err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+}
+
+func newExporters(ctx context.Context, ipfixCollector string, ipfixEnterpriseNumber uint32, otlpEndpoint, metricsBindAddress string) ([]exporter.Exporter, error) {
+	var exporters []exporter.Exporter
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hostname: %w", err)
+	}
+	if ipfixCollector != "" {
+		// observation domain ID identifies the exporting node
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(hostname))
+		ipfixExporter, err := exporter.NewIPFIXExporter(ipfixCollector, hash.Sum32(), ipfixEnterpriseNumber)
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, ipfixExporter)
+	}
+	if otlpEndpoint != "" {
+		exporters = append(exporters, exporter.NewOTLPExporter(otlpEndpoint,
+			map[string]string{"service.name": "ovnkube-observ", "host.name": hostname},
+			func(err error) { fmt.Println("ERROR: OTLP export failed:", err) }))
+	}
+	if metricsBindAddress != "" {
+		registry := prometheus.NewRegistry()
+		metricsExporter, err := exporter.NewMetricsExporter(registry)
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, metricsExporter)
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		server := &http.Server{Addr: metricsBindAddress, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				fmt.Println("ERROR: metrics server failed:", err)
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			_ = server.Close()
+		}()
 	}
+	return exporters, nil
 }
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+
+	networkconnectclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/clusternetworkconnect/v1/apis/clientset/versioned"
+	networkconnectfake "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/clusternetworkconnect/v1/apis/clientset/versioned/fake"
+	networkconnectscheme "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/clusternetworkconnect/v1/apis/clientset/versioned/scheme"
+)
+
+const (
+	// Suffixes of the database snapshot files in an offline database directory, as found in must-gathers.
+	nbdbFileSuffix = "_nbdb"
+	sbdbFileSuffix = "_sbdb"
+
+	// offlineZoneName is the zone of the databases provided with -offline-nbdb and -offline-sbdb, which hold the whole cluster.
+	offlineZoneName = "global"
+
+	ovsdbServerStartTimeout = 10 * time.Second
+)
+
+// offline holds the locally started databases when ovnkube-trace runs in offline mode, nil otherwise.
+var offline *offlineCluster
+
+// offlineZone is a pair of NB and SB databases served by a local ovsdb-server.
+type offlineZone struct {
+	name   string
+	nbDB   string
+	sbDB   string
+	nbSock string
+	sbSock string
+	server *exec.Cmd
+}
+
+// offlineCluster runs ovnkube-trace against database snapshots instead of a live cluster. Every command that would be run in
+// an ovnkube-node pod is run locally instead, against an ovsdb-server started for the databases of the pod's zone.
+type offlineCluster struct {
+	workDir string
+	// zones maps a node name to the databases of its zone. With a single pair of databases, all nodes map to the same zone.
+	zones map[string]*offlineZone
+	// global is the zone of all nodes when a single pair of databases was provided.
+	global *offlineZone
+	// stopOnce makes stop safe to call more than once, e.g. from a deferred call and an error path.
+	stopOnce sync.Once
+}
+
+// newOfflineCluster loads the Kubernetes objects from objectsFile and starts an ovsdb-server for the database snapshots,
+// either the single pair nbDB and sbDB or every <node or ovnkube-node pod>_nbdb and _sbdb pair found in dbDir.
+// It returns Kubernetes and ClusterNetworkConnect clients serving the loaded objects.
+func newOfflineCluster(objectsFile, dbDir, nbDB, sbDB string) (*offlineCluster, kubernetes.Interface, networkconnectclientset.Interface, error) {
+	for _, binary := range []string{"ovsdb-server", "ovsdb-tool", "ovn-nbctl", "ovn-sbctl", "ovn-trace"} {
+		if _, err := exec.LookPath(binary); err != nil {
+			return nil, nil, nil, fmt.Errorf("offline mode requires %s: %w", binary, err)
+		}
+	}
+
+	kubeObjects, cncObjects, err := loadOfflineObjects(objectsFile)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load Kubernetes objects from %s: %w", objectsFile, err)
+	}
+	kubeClient := fake.NewSimpleClientset(kubeObjects...)
+	cncClient := networkconnectfake.NewSimpleClientset(cncObjects...)
+
+	workDir, err := os.MkdirTemp("", "ovnkube-trace-")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	oc := &offlineCluster{
+		workDir: workDir,
+		zones:   map[string]*offlineZone{},
+	}
+
+	if dbDir == "" {
+		oc.global = &offlineZone{name: offlineZoneName, nbDB: nbDB, sbDB: sbDB}
+		err = oc.startZone(oc.global)
+	} else {
+		err = oc.startZones(dbDir, kubeObjects)
+	}
+	if err != nil {
+		oc.stop()
+		return nil, nil, nil, err
+	}
+	return oc, kubeClient, cncClient, nil
+}
+
+// loadOfflineObjects decodes a JSON Kubernetes object or List, e.g. the output of
+// `kubectl get nodes,pods,services,endpointslices,clusternetworkconnects -A -o json`.
+func loadOfflineObjects(objectsFile string) ([]runtime.Object, []runtime.Object, error) {
+	b, err := os.ReadFile(objectsFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	list := struct {
+		Items []json.RawMessage `json:"items"`
+	}{}
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, nil, err
+	}
+	if list.Items == nil {
+		list.Items = []json.RawMessage{b}
+	}
+
+	var kubeObjects, cncObjects []runtime.Object
+	for _, item := range list.Items {
+		if obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(item, nil, nil); err == nil {
+			kubeObjects = append(kubeObjects, obj)
+			continue
+		}
+		obj, gvk, err := networkconnectscheme.Codecs.UniversalDeserializer().Decode(item, nil, nil)
+		if err != nil {
+			klog.V(5).Infof("Skipping object that is not used by ovnkube-trace: %v", err)
+			continue
+		}
+		klog.V(5).Infof("Loaded %s", gvk)
+		cncObjects = append(cncObjects, obj)
+	}
+	klog.V(1).Infof("Loaded %d Kubernetes objects and %d ClusterNetworkConnects from %s", len(kubeObjects), len(cncObjects), objectsFile)
+	return kubeObjects, cncObjects, nil
+}
+
+// startZones starts one zone per pair of databases found in dbDir. Database files are named after the node or the
+// ovnkube-node pod of their zone.
+func (oc *offlineCluster) startZones(dbDir string, kubeObjects []runtime.Object) error {
+	podNodes := map[string]string{}
+	for _, obj := range kubeObjects {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			podNodes[pod.Name] = pod.Spec.NodeName
+		}
+	}
+
+	entries, err := os.ReadDir(dbDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".gz")
+		if entry.IsDir() || !strings.HasSuffix(name, nbdbFileSuffix) {
+			continue
+		}
+		owner := strings.TrimSuffix(name, nbdbFileSuffix)
+		sbDB, err := findSnapshot(dbDir, owner+sbdbFileSuffix)
+		if err != nil {
+			return err
+		}
+		nodeName := owner
+		if podNode, ok := podNodes[owner]; ok {
+			nodeName = podNode
+		}
+		zone := &offlineZone{name: nodeName, nbDB: filepath.Join(dbDir, entry.Name()), sbDB: sbDB}
+		if err := oc.startZone(zone); err != nil {
+			return err
+		}
+		oc.zones[nodeName] = zone
+	}
+	if len(oc.zones) == 0 {
+		return fmt.Errorf("no *%s database snapshots found in %s", nbdbFileSuffix, dbDir)
+	}
+	return nil
+}
+
+// findSnapshot returns the path of the given database snapshot in dir, compressed or not.
+func findSnapshot(dir, name string) (string, error) {
+	for _, candidate := range []string{name, name + ".gz"} {
+		path := filepath.Join(dir, candidate)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("database snapshot %s not found in %s", name, dir)
+}
+
+// startZone prepares standalone copies of the zone's databases and serves them with an ovsdb-server.
+func (oc *offlineCluster) startZone(zone *offlineZone) error {
+	zoneDir := filepath.Join(oc.workDir, zone.name)
+	if err := os.MkdirAll(zoneDir, 0o755); err != nil {
+		return err
+	}
+	nbDB, err := prepareSnapshot(zone.nbDB, filepath.Join(zoneDir, "ovnnb_db.db"))
+	if err != nil {
+		return err
+	}
+	sbDB, err := prepareSnapshot(zone.sbDB, filepath.Join(zoneDir, "ovnsb_db.db"))
+	if err != nil {
+		return err
+	}
+
+	// A single ovsdb-server serves both databases, each of them is reachable through either socket.
+	zone.nbSock = filepath.Join(zoneDir, "ovnnb_db.sock")
+	zone.sbSock = filepath.Join(zoneDir, "ovnsb_db.sock")
+	zone.server = exec.Command("ovsdb-server",
+		"--remote=punix:"+zone.nbSock,
+		"--remote=punix:"+zone.sbSock,
+		"--unixctl="+filepath.Join(zoneDir, "ovsdb-server.ctl"),
+		"--log-file="+filepath.Join(zoneDir, "ovsdb-server.log"),
+		nbDB, sbDB)
+	// ovnkube-trace exits right away on failures, make sure the server does not outlive it.
+	zone.server.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+	if err := zone.server.Start(); err != nil {
+		return fmt.Errorf("failed to start ovsdb-server for zone %s: %w", zone.name, err)
+	}
+	klog.V(1).Infof("Started ovsdb-server for zone %s with databases %s and %s", zone.name, zone.nbDB, zone.sbDB)
+
+	deadline := time.Now().Add(ovsdbServerStartTimeout)
+	for {
+		_, nbErr := os.Stat(zone.nbSock)
+		_, sbErr := os.Stat(zone.sbSock)
+		if nbErr == nil && sbErr == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for ovsdb-server of zone %s, see %s", zone.name, filepath.Join(zoneDir, "ovsdb-server.log"))
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// prepareSnapshot copies the database snapshot to dst, decompressing it if needed and converting it to a standalone
+// database if it was taken from a clustered (RAFT) database.
+func prepareSnapshot(src, dst string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+	var r io.Reader = in
+	if strings.HasSuffix(src, ".gz") {
+		gz, err := gzip.NewReader(in)
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress %s: %w", src, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	copied := dst + ".orig"
+	out, err := os.Create(copied)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+
+	// db-is-clustered exits with 0 for clustered databases and with 1 for standalone ones.
+	if err := exec.Command("ovsdb-tool", "db-is-clustered", copied).Run(); err != nil {
+		return copied, nil
+	}
+	if out, err := exec.Command("ovsdb-tool", "cluster-to-standalone", dst, copied).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to convert clustered database %s to standalone: %w: %s", src, err, out)
+	}
+	return dst, nil
+}
+
+// zoneOf returns the databases of the given node's zone.
+func (oc *offlineCluster) zoneOf(nodeName string) (*offlineZone, error) {
+	if oc.global != nil {
+		return oc.global, nil
+	}
+	zone, ok := oc.zones[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("no database snapshots for the zone of node %s", nodeName)
+	}
+	return zone, nil
+}
+
+// setDatabaseURIs sets the database information of podInfo to the local ovsdb-server of its node's zone. It replaces
+// getDatabaseURIs, which inspects the ovnkube processes of a live cluster.
+func (oc *offlineCluster) setDatabaseURIs(podInfo *PodInfo) error {
+	zone, err := oc.zoneOf(podInfo.NodeName)
+	if err != nil {
+		return err
+	}
+	podInfo.OvnKubeContainerName = ""
+	podInfo.IsInterConnect = oc.global == nil
+	if podInfo.IsInterConnect {
+		podInfo.InterConnectZoneName = zone.name
+	}
+	podInfo.NbURI = "unix:" + zone.nbSock
+	podInfo.SbURI = "unix:" + zone.sbSock
+	// The local ovsdb-servers only listen on unix sockets, which do not use the ssl cert keys of the cluster.
+	podInfo.SslCertKeys = ""
+	podInfo.NbCommand = "--db " + podInfo.NbURI
+	podInfo.SbCommand = "--db " + podInfo.SbURI
+	return nil
+}
+
+// exec runs a command locally instead of in an ovnkube-node pod. Returns Stdout, Stderr, err.
+func (oc *offlineCluster) exec(cmd, in string) (string, string, error) {
+	klog.V(5).Infof("Running command locally: cmd: %s, stdin: %s%s%s", cmd, italic, in, reset)
+	c := exec.Command("bash", "-c", cmd)
+	if in != "" {
+		c.Stdin = strings.NewReader(in)
+	}
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	err := c.Run()
+	return stdout.String(), stderr.String(), err
+}
+
+// getK8sMgmtPortMAC returns the MAC address of the node's management port from its logical switch port, for host
+// networked pods, as the OVS database of the node is not available offline.
+func (oc *offlineCluster) getK8sMgmtPortMAC(podInfo *PodInfo) (string, error) {
+	cmd := fmt.Sprintf("ovn-nbctl --no-leader-only %s --bare --no-heading get Logical_Switch_Port %s addresses", podInfo.NbCommand,
+		podInfo.LogicalPortName)
+	stdout, stderr, err := oc.exec(cmd, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get the management port addresses of node %s: %w, stderr: %s", podInfo.NodeName, err, stderr)
+	}
+	// The addresses are formatted as ["0a:58:0a:f4:00:02 10.244.0.2"].
+	addresses := strings.Fields(strings.Trim(strings.TrimSpace(stdout), `[]"`))
+	if len(addresses) == 0 {
+		return "", fmt.Errorf("no addresses on the management port of node %s", podInfo.NodeName)
+	}
+	return addresses[0], nil
+}
+
+// stop stops the ovsdb-servers and removes the working directory. Calls after the first one do nothing.
+func (oc *offlineCluster) stop() {
+	oc.stopOnce.Do(oc.doStop)
+}
+
+func (oc *offlineCluster) doStop() {
+	zones := []*offlineZone{}
+	if oc.global != nil {
+		zones = append(zones, oc.global)
+	}
+	for _, zone := range oc.zones {
+		zones = append(zones, zone)
+	}
+	for _, zone := range zones {
+		if zone.server == nil || zone.server.Process == nil {
+			continue
+		}
+		if err := zone.server.Process.Kill(); err != nil {
+			klog.Warningf("Failed to stop ovsdb-server of zone %s: %v", zone.name, err)
+		}
+		_ = zone.server.Wait()
+	}
+	if err := os.RemoveAll(oc.workDir); err != nil {
+		klog.Warningf("Failed to remove %s: %v", oc.workDir, err)
+	}
+}
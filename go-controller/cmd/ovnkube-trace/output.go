@@ -0,0 +1,460 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Output formats supported by -output.
+	textOutput = "text"
+	jsonOutput = "json"
+	yamlOutput = "yaml"
+)
+
+// Verdict is the overall outcome of a trace run.
+type Verdict string
+
+const (
+	// VerdictSuccess means that every trace step reached its expected output port.
+	VerdictSuccess Verdict = "Success"
+	// VerdictFailure means that at least one trace step did not reach its expected output port.
+	VerdictFailure Verdict = "Failure"
+	// VerdictError means that a trace command could not be executed.
+	VerdictError Verdict = "Error"
+)
+
+var (
+	// outputFormat is the format selected with -output.
+	outputFormat = textOutput
+	// traceResult collects the trace steps when a structured output format is selected.
+	traceResult *TraceResult
+
+	ovnTraceDatapathRegex = regexp.MustCompile(`^\s*(ingress|egress)\(dp="([^"]*)"(?:, inport="([^"]*)")?(?:, outport="([^"]*)")?\)`)
+	ovnTraceStageRegex    = regexp.MustCompile(`^\s*(\d+)\. (\S+) \(([^)]*)\): (.*), priority (\d+), uuid ([0-9a-f]+)`)
+	ovnTraceNATRegex      = regexp.MustCompile(`\b(ct_dnat_in_czone|ct_snat_in_czone|ct_dnat|ct_snat|lb_force_snat)\b(?:\(([^)]*)\))?`)
+	ovnTraceLBRegex       = regexp.MustCompile(`\b(ct_lb_mark|ct_lb)\b(?:\(([^)]*)\))?`)
+	ovnTraceOutputRegex   = regexp.MustCompile(`output to "([^"]*)"`)
+)
+
+// TraceResult is the structured result of an ovnkube-trace run.
+type TraceResult struct {
+	Source        *PodInfo `json:"source"`
+	Destination   *PodInfo `json:"destination,omitempty"`
+	Service       *SvcInfo `json:"service,omitempty"`
+	DestinationIP string   `json:"destinationIP,omitempty"`
+	// NetworkConnect is the ClusterNetworkConnect joining the source and destination networks, if they differ.
+	NetworkConnect string       `json:"networkConnect,omitempty"`
+	Protocol       string       `json:"protocol"`
+	DstPort        string       `json:"dstPort"`
+	Steps          []*TraceStep `json:"steps"`
+	Verdict        Verdict      `json:"verdict"`
+	// Reason explains a Failure or Error verdict.
+	Reason string `json:"reason,omitempty"`
+}
+
+// TraceStep is the result of a single ovn-trace, ofproto/trace or ovn-detrace command.
+type TraceStep struct {
+	Description string `json:"description"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Success     bool   `json:"success"`
+	// Expected is the regular expression that the command output had to match.
+	Expected string `json:"expected,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// Hops are the logical datapaths traversed by an ovn-trace command.
+	Hops []*LogicalHop `json:"hops,omitempty"`
+	// ACLs are the ACL stages matched by an ovn-trace command.
+	ACLs []*ACLMatch `json:"acls,omitempty"`
+	// NAT and LoadBalancer are the NAT and load balancing decisions taken by an ovn-trace command.
+	NAT          []*NATDecision `json:"nat,omitempty"`
+	LoadBalancer []*NATDecision `json:"loadBalancer,omitempty"`
+	Output       string         `json:"output,omitempty"`
+}
+
+// LogicalHop is a pipeline traversal of a logical datapath as reported by ovn-trace.
+type LogicalHop struct {
+	Pipeline string          `json:"pipeline"`
+	Datapath string          `json:"datapath"`
+	InPort   string          `json:"inport,omitempty"`
+	OutPort  string          `json:"outport,omitempty"`
+	Stages   []*LogicalStage `json:"stages,omitempty"`
+}
+
+// LogicalStage is a logical flow hit by ovn-trace within a LogicalHop.
+type LogicalStage struct {
+	Table    int      `json:"table"`
+	Name     string   `json:"name"`
+	Source   string   `json:"source,omitempty"`
+	Match    string   `json:"match"`
+	Priority int      `json:"priority"`
+	UUID     string   `json:"uuid"`
+	Actions  []string `json:"actions,omitempty"`
+}
+
+// ACLMatch is an ACL stage hit during an ovn-trace together with the NB ACL that owns it, when it could be resolved.
+type ACLMatch struct {
+	Datapath    string            `json:"datapath"`
+	Stage       string            `json:"stage"`
+	Priority    int               `json:"priority"`
+	Match       string            `json:"match"`
+	FlowUUID    string            `json:"flowUUID"`
+	Actions     []string          `json:"actions,omitempty"`
+	ACLUUID     string            `json:"aclUUID,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Action      string            `json:"action,omitempty"`
+	Direction   string            `json:"direction,omitempty"`
+	ExternalIDs map[string]string `json:"externalIDs,omitempty"`
+}
+
+// NATDecision is a NAT or load balancing action taken on a logical datapath.
+type NATDecision struct {
+	Datapath string `json:"datapath"`
+	Stage    string `json:"stage,omitempty"`
+	Action   string `json:"action"`
+	Target   string `json:"target,omitempty"`
+}
+
+// isStructuredOutput returns true if the trace result is to be written as JSON or YAML.
+func isStructuredOutput() bool {
+	return outputFormat != textOutput
+}
+
+// setOutputFormat validates and sets the output format selected with -output.
+func setOutputFormat(format string) error {
+	switch format {
+	case textOutput, jsonOutput, yamlOutput:
+		outputFormat = format
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q, must be one of %s, %s or %s", format, textOutput, jsonOutput, yamlOutput)
+}
+
+// traceError ends a trace run that did not succeed, with the verdict to report for it.
+type traceError struct {
+	verdict Verdict
+	reason  string
+}
+
+func (e *traceError) Error() string {
+	return e.reason
+}
+
+// recordStep appends the step to the trace result. A failed step ends the trace, mirroring the behavior of the text output.
+func recordStep(step *TraceStep) error {
+	traceResult.Steps = append(traceResult.Steps, step)
+	if step.Error != "" {
+		return &traceError{verdict: VerdictError, reason: fmt.Sprintf("%s: %s", step.Description, step.Error)}
+	}
+	if !step.Success {
+		return &traceError{verdict: VerdictFailure, reason: failureReason(step)}
+	}
+	return nil
+}
+
+// failureReason summarizes why a step did not reach its expected destination.
+func failureReason(step *TraceStep) string {
+	for i := len(step.ACLs) - 1; i >= 0; i-- {
+		acl := step.ACLs[i]
+		if acl.Action == "drop" || acl.Action == "reject" || containsDropAction(acl.Actions) {
+			owner := acl.Name
+			if owner == "" {
+				owner = acl.FlowUUID
+			}
+			return fmt.Sprintf("%s from %s to %s dropped by ACL %s on %s", step.Description, step.Source, step.Destination, owner, acl.Datapath)
+		}
+	}
+	if out := lastOvnTraceOutput(step.Output); out != "" {
+		return fmt.Sprintf("%s from %s to %s reached %q, expected %q", step.Description, step.Source, step.Destination, out, step.Expected)
+	}
+	return fmt.Sprintf("%s from %s to %s did not match %q", step.Description, step.Source, step.Destination, step.Expected)
+}
+
+func containsDropAction(actions []string) bool {
+	for _, action := range actions {
+		if strings.HasPrefix(action, "drop") || strings.HasPrefix(action, "reject") {
+			return true
+		}
+	}
+	return false
+}
+
+// finishTrace sets the verdict of the trace given the error that ended it, if any, and writes the trace result to stdout.
+// Errors other than a failed step, e.g. a pod that could not be found, are reported with an Error verdict.
+func finishTrace(traceErr error) error {
+	if traceResult == nil {
+		// The trace ended before the source pod was found.
+		traceResult = &TraceResult{}
+	}
+	traceResult.Verdict, traceResult.Reason = traceVerdict(traceErr)
+	return writeTraceResult(os.Stdout, traceResult, outputFormat)
+}
+
+// traceVerdict returns the verdict and its reason for the error that ended a trace run.
+func traceVerdict(traceErr error) (Verdict, string) {
+	if traceErr == nil {
+		return VerdictSuccess, ""
+	}
+	var tErr *traceError
+	if errors.As(traceErr, &tErr) {
+		return tErr.verdict, tErr.reason
+	}
+	return VerdictError, traceErr.Error()
+}
+
+// writeTraceResult marshals the trace result in the given format.
+func writeTraceResult(w io.Writer, result *TraceResult, format string) error {
+	var b []byte
+	var err error
+	switch format {
+	case jsonOutput:
+		b, err = json.MarshalIndent(result, "", "  ")
+		b = append(b, '\n')
+	case yamlOutput:
+		b, err = yaml.Marshal(result)
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// newTraceStep builds a TraceStep from a command's output. If searchString is set, the step succeeds only if it matches the
+// command's stdout.
+func newTraceStep(commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) *TraceStep {
+	step := &TraceStep{
+		Description: commandDescription,
+		Source:      src,
+		Destination: dst,
+		Expected:    searchString,
+		Output:      commandStdout,
+	}
+	if err != nil {
+		step.Error = fmt.Sprintf("%v, stderr: %s", err, commandStderr)
+		return step
+	}
+	step.Success = true
+	if searchString != "" {
+		match, err := regexp.MatchString(searchString, commandStdout)
+		if err != nil {
+			step.Error = fmt.Sprintf("failed matching regex %q: %v", searchString, err)
+			return step
+		}
+		step.Success = match
+	}
+	return step
+}
+
+// parseOvnTrace parses the detailed output of ovn-trace into the logical hops, ACLs and NAT/LB decisions of the step.
+func parseOvnTrace(step *TraceStep, ovnTraceOutput string) {
+	var hop *LogicalHop
+	var stage *LogicalStage
+	scanner := bufio.NewScanner(strings.NewReader(ovnTraceOutput))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := ovnTraceDatapathRegex.FindStringSubmatch(line); m != nil {
+			hop = &LogicalHop{
+				Pipeline: m[1],
+				Datapath: m[2],
+				InPort:   m[3],
+				OutPort:  m[4],
+			}
+			step.Hops = append(step.Hops, hop)
+			stage = nil
+			continue
+		}
+		if hop == nil {
+			continue
+		}
+		if m := ovnTraceStageRegex.FindStringSubmatch(line); m != nil {
+			table, _ := strconv.Atoi(m[1])
+			priority, _ := strconv.Atoi(m[5])
+			stage = &LogicalStage{
+				Table:    table,
+				Name:     m[2],
+				Source:   m[3],
+				Match:    m[4],
+				Priority: priority,
+				UUID:     m[6],
+			}
+			hop.Stages = append(hop.Stages, stage)
+			continue
+		}
+		// Lines that are not indented are section headers such as the conntrack recirculations, e.g. "ct_lb_mark".
+		if line != "" && line[0] != ' ' {
+			stage = nil
+			continue
+		}
+		action := strings.TrimSpace(line)
+		if stage == nil || action == "" || strings.HasPrefix(action, "---") {
+			continue
+		}
+		stage.Actions = append(stage.Actions, action)
+	}
+
+	for _, hop := range step.Hops {
+		for _, stage := range hop.Stages {
+			if strings.Contains(stage.Name, "_acl") && !strings.Contains(stage.Name, "_acl_hint") {
+				step.ACLs = append(step.ACLs, &ACLMatch{
+					Datapath: hop.Datapath,
+					Stage:    stage.Name,
+					Priority: stage.Priority,
+					Match:    stage.Match,
+					FlowUUID: stage.UUID,
+					Actions:  stage.Actions,
+				})
+			}
+			for _, action := range stage.Actions {
+				for _, m := range ovnTraceNATRegex.FindAllStringSubmatch(action, -1) {
+					step.NAT = append(step.NAT, &NATDecision{Datapath: hop.Datapath, Stage: stage.Name, Action: m[1], Target: m[2]})
+				}
+				for _, m := range ovnTraceLBRegex.FindAllStringSubmatch(action, -1) {
+					step.LoadBalancer = append(step.LoadBalancer, &NATDecision{Datapath: hop.Datapath, Stage: stage.Name, Action: m[1], Target: m[2]})
+				}
+			}
+		}
+	}
+}
+
+// resolveACLOwners looks up the NB ACL that generated each ACL logical flow of the step, using the stage-hint of the SB
+// Logical_Flow, and copies its name, action, direction and external IDs (the owner of the ACL) into the step.
+func resolveACLOwners(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo, step *TraceStep) {
+	for _, acl := range step.ACLs {
+		cmd := fmt.Sprintf("ovn-sbctl --no-leader-only %s --bare --no-heading get Logical_Flow %s external_ids:stage-hint", podInfo.SbCommand, acl.FlowUUID)
+		stdout, stderr, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, cmd, "")
+		if err != nil {
+			// Not all ACL stage flows are generated from an ACL, e.g. the default allow flows.
+			klog.V(5).Infof("No stage-hint for logical flow %s: %v, stderr: %s", acl.FlowUUID, err, stderr)
+			continue
+		}
+		stageHint := strings.Trim(strings.TrimSpace(stdout), "\"")
+		if stageHint == "" {
+			continue
+		}
+		cmd = fmt.Sprintf("ovn-nbctl --no-leader-only %s --format=json --columns=_uuid,name,action,direction,external_ids list ACL %s", podInfo.NbCommand, stageHint)
+		stdout, stderr, err = execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, cmd, "")
+		if err != nil {
+			klog.V(5).Infof("No ACL found for stage-hint %s of logical flow %s: %v, stderr: %s", stageHint, acl.FlowUUID, err, stderr)
+			continue
+		}
+		if err := parseACLListOutput(acl, stdout); err != nil {
+			klog.V(5).Infof("Failed to parse ACL %s: %v", stageHint, err)
+		}
+	}
+}
+
+// parseACLListOutput parses the output of `ovn-nbctl --format=json --columns=_uuid,name,action,direction,external_ids list ACL`.
+func parseACLListOutput(acl *ACLMatch, nbctlOutput string) error {
+	table := struct {
+		Headings []string `json:"headings"`
+		Data     [][]any  `json:"data"`
+	}{}
+	if err := json.Unmarshal([]byte(nbctlOutput), &table); err != nil {
+		return err
+	}
+	if len(table.Data) != 1 {
+		return fmt.Errorf("expected exactly one ACL, got %d", len(table.Data))
+	}
+	for i, heading := range table.Headings {
+		if i >= len(table.Data[0]) {
+			break
+		}
+		value := table.Data[0][i]
+		switch heading {
+		case "_uuid":
+			acl.ACLUUID = ovsdbAtom(value)
+		case "name":
+			acl.Name = ovsdbAtom(value)
+		case "action":
+			acl.Action = ovsdbAtom(value)
+		case "direction":
+			acl.Direction = ovsdbAtom(value)
+		case "external_ids":
+			acl.ExternalIDs = ovsdbMap(value)
+		}
+	}
+	return nil
+}
+
+// ovsdbAtom returns the string representation of an OVSDB JSON atom: a plain string, a ["uuid", "..."] pair or a
+// one element ["set", [...]]. An empty set is returned as "".
+func ovsdbAtom(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		if len(v) != 2 {
+			return ""
+		}
+		kind, _ := v[0].(string)
+		switch kind {
+		case "uuid":
+			s, _ := v[1].(string)
+			return s
+		case "set":
+			elems, _ := v[1].([]any)
+			if len(elems) == 1 {
+				return ovsdbAtom(elems[0])
+			}
+		}
+	}
+	return ""
+}
+
+// ovsdbMap returns the Go representation of an OVSDB JSON ["map", [[k, v], ...]].
+func ovsdbMap(value any) map[string]string {
+	v, ok := value.([]any)
+	if !ok || len(v) != 2 || v[0] != "map" {
+		return nil
+	}
+	pairs, _ := v[1].([]any)
+	m := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		kv, ok := pair.([]any)
+		if !ok || len(kv) != 2 {
+			continue
+		}
+		m[ovsdbAtom(kv[0])] = ovsdbAtom(kv[1])
+	}
+	return m
+}
+
+// reportOvnTrace reports the result of an ovn-trace command. With text output it behaves exactly like printSuccessOrFailure.
+// With structured output the trace is parsed into hops, ACLs and NAT/LB decisions and the owners of the matched ACLs are
+// looked up in the NB database of the node that ran the trace.
+func reportOvnTrace(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo,
+	commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) error {
+	if !isStructuredOutput() {
+		return printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandStderr, err, searchString)
+	}
+	step := newTraceStep(commandDescription, src, dst, commandStdout, commandStderr, err, searchString)
+	if step.Error == "" {
+		parseOvnTrace(step, commandStdout)
+		resolveACLOwners(coreclient, restconfig, ovnNamespace, podInfo, step)
+	}
+	return recordStep(step)
+}
+
+// lastOvnTraceOutput returns the output port reported last by an ovn-trace command, if any.
+func lastOvnTraceOutput(ovnTraceOutput string) string {
+	matches := ovnTraceOutputRegex.FindAllStringSubmatch(ovnTraceOutput, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[len(matches)-1][1]
+}
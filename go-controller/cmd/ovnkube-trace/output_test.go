@@ -0,0 +1,267 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOvnTraceOutput = `# tcp,reg14=0x3,vlan_tci=0x0000,dl_src=0a:58:0a:f4:01:03,dl_dst=0a:58:0a:f4:01:01,nw_src=10.244.1.3,nw_dst=10.244.1.5
+ingress(dp="ovn-worker", inport="default_client")
+-------------------------------------------------
+ 0. ls_in_check_port_sec (northd.c:8691): 1, priority 50, uuid 6a2e1b2c
+    reg0[15] = check_in_port_sec();
+    next;
+ 4. ls_in_acl_hint (northd.c:6203): ct.new && !ct.est, priority 7, uuid 1f5e4d3c
+    reg0[7] = 1;
+    next;
+ 8. ls_in_acl_eval (northd.c:6855): reg0[7] == 1 && (ip4.dst == 10.244.1.5), priority 2001, uuid 0bb0a7f1
+    reg8[16] = 1;
+    ct_commit { ct_mark.blocked = 1; };
+ 13. ls_in_pre_stateful (northd.c:6367): reg0[2] == 1, priority 110, uuid 9c8d7e6f
+    ct_lb_mark(backends=10.244.1.5:8080);
+
+ct_lb_mark /* default (use --ct to customize) */
+------------------------------------------------
+ 14. ls_in_lb (northd.c:7000): ct.est, priority 65535, uuid 2a3b4c5d
+    ct_dnat(10.244.1.5);
+    next;
+
+egress(dp="ovn-worker", inport="default_client", outport="default_server")
+--------------------------------------------------------------------------
+ 9. ls_out_check_port_sec (northd.c:5678): 1, priority 0, uuid abcd1234
+    output;
+    /* output to "default_server", type "" */
+`
+
+func TestParseOvnTrace(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   *TraceStep
+	}{
+		{
+			name:   "parses the hops, ACLs and NAT/LB decisions",
+			output: testOvnTraceOutput,
+			want: &TraceStep{
+				Hops: []*LogicalHop{
+					{
+						Pipeline: "ingress",
+						Datapath: "ovn-worker",
+						InPort:   "default_client",
+						Stages: []*LogicalStage{
+							{Table: 0, Name: "ls_in_check_port_sec", Source: "northd.c:8691", Match: "1", Priority: 50, UUID: "6a2e1b2c",
+								Actions: []string{"reg0[15] = check_in_port_sec();", "next;"}},
+							{Table: 4, Name: "ls_in_acl_hint", Source: "northd.c:6203", Match: "ct.new && !ct.est", Priority: 7, UUID: "1f5e4d3c",
+								Actions: []string{"reg0[7] = 1;", "next;"}},
+							{Table: 8, Name: "ls_in_acl_eval", Source: "northd.c:6855", Match: "reg0[7] == 1 && (ip4.dst == 10.244.1.5)", Priority: 2001, UUID: "0bb0a7f1",
+								Actions: []string{"reg8[16] = 1;", "ct_commit { ct_mark.blocked = 1; };"}},
+							{Table: 13, Name: "ls_in_pre_stateful", Source: "northd.c:6367", Match: "reg0[2] == 1", Priority: 110, UUID: "9c8d7e6f",
+								Actions: []string{"ct_lb_mark(backends=10.244.1.5:8080);"}},
+							{Table: 14, Name: "ls_in_lb", Source: "northd.c:7000", Match: "ct.est", Priority: 65535, UUID: "2a3b4c5d",
+								Actions: []string{"ct_dnat(10.244.1.5);", "next;"}},
+						},
+					},
+					{
+						Pipeline: "egress",
+						Datapath: "ovn-worker",
+						InPort:   "default_client",
+						OutPort:  "default_server",
+						Stages: []*LogicalStage{
+							{Table: 9, Name: "ls_out_check_port_sec", Source: "northd.c:5678", Match: "1", Priority: 0, UUID: "abcd1234",
+								Actions: []string{"output;", `/* output to "default_server", type "" */`}},
+						},
+					},
+				},
+				ACLs: []*ACLMatch{
+					{Datapath: "ovn-worker", Stage: "ls_in_acl_eval", Priority: 2001, Match: "reg0[7] == 1 && (ip4.dst == 10.244.1.5)", FlowUUID: "0bb0a7f1",
+						Actions: []string{"reg8[16] = 1;", "ct_commit { ct_mark.blocked = 1; };"}},
+				},
+				NAT: []*NATDecision{
+					{Datapath: "ovn-worker", Stage: "ls_in_lb", Action: "ct_dnat", Target: "10.244.1.5"},
+				},
+				LoadBalancer: []*NATDecision{
+					{Datapath: "ovn-worker", Stage: "ls_in_pre_stateful", Action: "ct_lb_mark", Target: "backends=10.244.1.5:8080"},
+				},
+			},
+		},
+		{
+			name: "ignores the stages before the first datapath",
+			output: ` 0. ls_in_check_port_sec (northd.c:8691): 1, priority 50, uuid 6a2e1b2c
+    next;
+`,
+			want: &TraceStep{},
+		},
+		{
+			name:   "empty output",
+			output: "",
+			want:   &TraceStep{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := &TraceStep{}
+			parseOvnTrace(step, tt.output)
+			assert.Equal(t, tt.want, step)
+		})
+	}
+}
+
+func TestParseACLListOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    *ACLMatch
+		wantErr bool
+	}{
+		{
+			name: "network policy ACL",
+			output: `{"data":[[["uuid","7f1c2d3e-0000-4000-8000-000000000001"],"NP:default:deny-all:Ingress:0","drop","to-lport",` +
+				`["map",[["k8s.ovn.org/name","default:deny-all"],["k8s.ovn.org/owner-type","NetworkPolicy"]]]]],` +
+				`"headings":["_uuid","name","action","direction","external_ids"]}`,
+			want: &ACLMatch{
+				ACLUUID:   "7f1c2d3e-0000-4000-8000-000000000001",
+				Name:      "NP:default:deny-all:Ingress:0",
+				Action:    "drop",
+				Direction: "to-lport",
+				ExternalIDs: map[string]string{
+					"k8s.ovn.org/name":       "default:deny-all",
+					"k8s.ovn.org/owner-type": "NetworkPolicy",
+				},
+			},
+		},
+		{
+			name: "ACL without a name",
+			output: `{"data":[[["uuid","7f1c2d3e-0000-4000-8000-000000000002"],["set",[]],"allow-related","from-lport",["map",[]]]],` +
+				`"headings":["_uuid","name","action","direction","external_ids"]}`,
+			want: &ACLMatch{
+				ACLUUID:     "7f1c2d3e-0000-4000-8000-000000000002",
+				Action:      "allow-related",
+				Direction:   "from-lport",
+				ExternalIDs: map[string]string{},
+			},
+		},
+		{
+			name:    "no ACL",
+			output:  `{"data":[],"headings":["_uuid","name","action","direction","external_ids"]}`,
+			want:    &ACLMatch{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid output",
+			output:  "ovn-nbctl: no row in table ACL",
+			want:    &ACLMatch{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acl := &ACLMatch{}
+			err := parseACLListOutput(acl, tt.output)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, acl)
+		})
+	}
+}
+
+func TestFailureReason(t *testing.T) {
+	tests := []struct {
+		name string
+		step *TraceStep
+		want string
+	}{
+		{
+			name: "dropped by a resolved ACL",
+			step: &TraceStep{
+				ACLs: []*ACLMatch{
+					{Datapath: "ovn-worker", FlowUUID: "1f5e4d3c", Action: "allow-related", Name: "NP:default:allow-dns:Egress:0"},
+					{Datapath: "ovn-worker", FlowUUID: "0bb0a7f1", Action: "drop", Name: "NP:default:deny-all:Ingress:0"},
+				},
+			},
+			want: "ovn-trace source pod to destination pod from client to server dropped by ACL NP:default:deny-all:Ingress:0 on ovn-worker",
+		},
+		{
+			name: "dropped by an ACL flow without an ACL",
+			step: &TraceStep{
+				ACLs: []*ACLMatch{
+					{Datapath: "ovn-worker", FlowUUID: "0bb0a7f1", Actions: []string{"reg8[16] = 1;", "drop;"}},
+				},
+			},
+			want: "ovn-trace source pod to destination pod from client to server dropped by ACL 0bb0a7f1 on ovn-worker",
+		},
+		{
+			name: "output to another port",
+			step: &TraceStep{
+				Output: `/* output to "stor-ovn-worker", type "patch" */
+/* output to "k8s-ovn-worker", type "" */`,
+			},
+			want: `ovn-trace source pod to destination pod from client to server reached "k8s-ovn-worker", expected "output to \"default_server\""`,
+		},
+		{
+			name: "no output",
+			step: &TraceStep{},
+			want: `ovn-trace source pod to destination pod from client to server did not match "output to \"default_server\""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.step.Description = "ovn-trace source pod to destination pod"
+			tt.step.Source = "client"
+			tt.step.Destination = "server"
+			tt.step.Expected = `output to "default_server"`
+			assert.Equal(t, tt.want, failureReason(tt.step))
+		})
+	}
+}
+
+func TestTraceVerdict(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantVerdict Verdict
+		wantReason  string
+	}{
+		{
+			name:        "success",
+			wantVerdict: VerdictSuccess,
+		},
+		{
+			name:        "failed step",
+			err:         &traceError{verdict: VerdictFailure, reason: "dropped by ACL"},
+			wantVerdict: VerdictFailure,
+			wantReason:  "dropped by ACL",
+		},
+		{
+			name:        "wrapped failed step",
+			err:         fmt.Errorf("trace: %w", &traceError{verdict: VerdictFailure, reason: "dropped by ACL"}),
+			wantVerdict: VerdictFailure,
+			wantReason:  "dropped by ACL",
+		},
+		{
+			name:        "step that could not run",
+			err:         &traceError{verdict: VerdictError, reason: "ovn-trace: command terminated with exit code 1"},
+			wantVerdict: VerdictError,
+			wantReason:  "ovn-trace: command terminated with exit code 1",
+		},
+		{
+			name:        "any other error",
+			err:         errors.New("failed to get information from pod client: not found"),
+			wantVerdict: VerdictError,
+			wantReason:  "failed to get information from pod client: not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, reason := traceVerdict(tt.err)
+			assert.Equal(t, tt.wantVerdict, verdict)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ import (
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	discoveryv1client "k8s.io/client-go/kubernetes/typed/discovery/v1"
 	"k8s.io/client-go/rest"
@@ -27,6 +29,7 @@ import (
 	utilnet "k8s.io/utils/net"
 	"k8s.io/utils/strings/slices"
 
+	networkconnectclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/clusternetworkconnect/v1/apis/clientset/versioned"
 	types "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
 	util "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -115,6 +118,12 @@ type PodInfo struct {
 	SslCertKeys          string // ssl cert keys string to access ovn nbdb/sbdb
 	NbCommand            string // contains subset of nb command string to execute on ovn nbdb
 	SbCommand            string // contains subset of sb command string to execute on ovn sbdb
+	NetworkName          string // name of the pod's primary network, "default" for the cluster default network
+	NetworkID            string // ID of the pod's primary network if it is a user defined network
+	NADKey               string // <namespace>/<name> of the NAD of the pod's primary network, "default" for the cluster default network
+	Topology             string // topology of the pod's primary network, layer3 or layer2
+	LogicalPortName      string // name of the pod's logical switch port on its primary network
+	LogicalSwitch        string // name of the logical switch that the pod's logical switch port is attached to
 }
 
 // String returns a JSON representation of the SvcInfo object, or "" on failure.
@@ -153,7 +162,11 @@ func (pi *PodInfo) FullyQualifiedPodName() string {
 }
 
 // execInPod runs a command inside the given container. Requires bash. Returns Stdout, Stderr, err.
-func execInPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, namespace string, podName string, containerName string, cmd string, in string) (string, string, error) {
+// In offline mode, the command runs locally instead.
+func execInPod(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, namespace string, podName string, containerName string, cmd string, in string) (string, string, error) {
+	if offline != nil {
+		return offline.exec(cmd, in)
+	}
 	klog.V(5).Infof(
 		"Running command inside container: namespace: %s, podName: %s, containerName: %s, cmd: %s, stdin: %s%s%s",
 		namespace,
@@ -165,7 +178,7 @@ func execInPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, n
 
 	scheme := runtime.NewScheme()
 	if err := corev1.AddToScheme(scheme); err != nil {
-		klog.Exitf("Error adding to scheme: %v", err)
+		return "", "", err
 	}
 	parameterCodec := runtime.NewParameterCodec(scheme)
 
@@ -222,7 +235,7 @@ func execInPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, n
 // In order to do so, it looks for annotation 'k8s.ovn.org/l3-gateway-config' on the provided node.
 // That annotation should contain a JSON string like: '{"default":{"mode":"shared", ...}}'.
 // It will then determine the routing mode from that annotation if it is valid or return error otherwise.
-func isRoutingViaHost(coreclient *corev1client.CoreV1Client, nodeName string) (bool, error) {
+func isRoutingViaHost(coreclient corev1client.CoreV1Interface, nodeName string) (bool, error) {
 	node, err := coreclient.Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
 	if err != nil {
 		return false, err
@@ -257,21 +270,8 @@ func isRoutingViaHost(coreclient *corev1client.CoreV1Client, nodeName string) (b
 	return false, fmt.Errorf("could not determine gateway mode from annotations on node %s, unknown mode in l3GwConfig: %s", node.Name, defaultL3GwConfigParsed.Mode)
 }
 
-// getPodMAC returns the pod's MAC address.
-func getPodMAC(pod *corev1.Pod) (podMAC string, err error) {
-	podAnnotation, err := util.UnmarshalPodAnnotation(pod.ObjectMeta.Annotations, types.DefaultNetworkName)
-	if err != nil {
-		return "", err
-	}
-	if podAnnotation != nil {
-		podMAC = podAnnotation.MAC.String()
-	}
-
-	return podMAC, nil
-}
-
 // getOvnKubePodOnNode returns the name of the ovnkube-node pod that is running on a given node.
-func getOvnKubePodOnNode(coreclient *corev1client.CoreV1Client, ovnNamespace string, nodeName string) (string, error) {
+func getOvnKubePodOnNode(coreclient corev1client.CoreV1Interface, ovnNamespace string, nodeName string) (string, error) {
 	// Get pods in the openshift-ovn-kubernetes namespace
 	podsOvn, errOvn := coreclient.Pods(ovnNamespace).List(context.TODO(), metav1.ListOptions{})
 	if errOvn != nil {
@@ -301,7 +301,7 @@ func getOvnKubePodOnNode(coreclient *corev1client.CoreV1Client, ovnNamespace str
 // about this pod's OVS interface and returns the name and ofport fields.
 // It will run `ovs-vsctl --columns name,ofport find interface external_ids:iface-id=%s` with the given `$namespace-$pod` tuple and it will then parse the
 // result into a map[string]string that maps the keys to their values.
-func getPodOvsInterfaceNameAndOfport(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, fullyQualifiedPodName string) (*OvsInterface, error) {
+func getPodOvsInterfaceNameAndOfport(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, fullyQualifiedPodName string) (*OvsInterface, error) {
 	var interfaceInfo OvsInterface
 
 	findInterfaceCmd := fmt.Sprintf("ovs-vsctl --columns name,ofport find interface external_ids:iface-id=%s", fullyQualifiedPodName)
@@ -345,7 +345,7 @@ func getPodOvsInterfaceNameAndOfport(coreclient *corev1client.CoreV1Client, rest
 }
 
 // getSvcInfo builds the SvcInfo object for this service. PodName/PodNamespace/PodIP are for the first valid endpoint pod that can be found for this service.
-func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, svcName string, ovnNamespace string, namespace, addressFamily string) (svcInfo *SvcInfo, err error) {
+func getSvcInfo(coreclient corev1client.CoreV1Interface, discoveryclient discoveryv1client.DiscoveryV1Interface, restconfig *rest.Config, svcName string, ovnNamespace string, namespace, addressFamily string) (svcInfo *SvcInfo, err error) {
 	// Get service with the name supplied by svcName
 	svc, err := coreclient.Services(namespace).Get(context.TODO(), svcName, metav1.GetOptions{})
 	if err != nil {
@@ -366,12 +366,7 @@ func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		ClusterIP:    clusterIPStr,
 	}
 
-	discoveryClient, err := discoveryv1client.NewForConfig(restconfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create discovery client: %w", err)
-	}
-
-	es, err := discoveryClient.EndpointSlices(namespace).List(context.TODO(), metav1.ListOptions{
+	es, err := discoveryclient.EndpointSlices(namespace).List(context.TODO(), metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("kubernetes.io/service-name=%s", svcName),
 	})
 	if err != nil {
@@ -390,7 +385,7 @@ func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 // extractEndpointSliceInfo copies information from the endpoint slices into the SvcInfo object.
 // Modifies the svcInfo object the pointer of which is passed to it.
 // slice is *discoveryv1.EndpointSlice slices is
-func extractEndpointSliceInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, slices []discoveryv1.EndpointSlice, svcInfo *SvcInfo, ovnNamespace, addressFamily string) error {
+func extractEndpointSliceInfo(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, slices []discoveryv1.EndpointSlice, svcInfo *SvcInfo, ovnNamespace, addressFamily string) error {
 
 	for _, slice := range slices {
 		klog.V(5).Infof("==> Trying to extract information for service %s in namespace %s from slice %v",
@@ -436,7 +431,7 @@ func extractEndpointSliceInfo(coreclient *corev1client.CoreV1Client, restconfig
 			// Get info needed for the src Pod
 			svcPodInfo, err := getPodInfo(coreclient, restconfig, endpoint.TargetRef.Name, ovnNamespace, endpoint.TargetRef.Namespace, addressFamily)
 			if err != nil {
-				klog.Exitf("Failed to get information from pod %s: %v", endpoint.TargetRef.Name, err)
+				return fmt.Errorf("failed to get information from pod %s: %w", endpoint.TargetRef.Name, err)
 			}
 			klog.V(5).Infof("svcPodInfo is %s\n", svcPodInfo)
 
@@ -453,7 +448,7 @@ func extractEndpointSliceInfo(coreclient *corev1client.CoreV1Client, restconfig
 }
 
 // getPodInfo returns a pointer to a fully populated PodInfo struct, or error on failure.
-func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName string, ovnNamespace string, namespace, addressFamily string) (podInfo *PodInfo, err error) {
+func getPodInfo(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, podName string, ovnNamespace string, namespace, addressFamily string) (podInfo *PodInfo, err error) {
 	// Create a PodInfo object with the base information already added, such as
 	// IP, PodName, ContainerName, NodeName, HostNetwork, Namespace, PrimaryInterfaceName
 	pod, err := coreclient.Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
@@ -462,43 +457,94 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		return nil, err
 	}
 
-	podIP, err := getDesiredPodIP(pod, addressFamily)
-	if err != nil {
-		klog.V(1).Infof("Pod %s in namespace %s doesn't have desired ip address configured\n", podName, namespace)
-		return nil, err
-	}
-
 	podInfo = &PodInfo{
-		IP:            podIP,
 		IPVer:         addressFamily,
 		PodName:       pod.Name,
 		ContainerName: pod.Spec.Containers[0].Name,
 		HostNetwork:   pod.Spec.HostNetwork,
 		PodNamespace:  pod.Namespace,
+		NetworkName:   types.DefaultNetworkName,
+		NADKey:        types.DefaultNetworkName,
+		Topology:      types.Layer3Topology,
 	}
 	podInfo.NodeName = pod.Spec.NodeName
 
-	// Get the pod's ovnkubePod.
-	podInfo.OvnKubePodName, err = getOvnKubePodOnNode(coreclient, ovnNamespace, podInfo.NodeName)
+	// Find the pod's primary network, which is either the cluster default network or a primary user defined network.
+	var podAnnotation *util.PodAnnotation
+	if !pod.Spec.HostNetwork {
+		podInfo.NADKey, podAnnotation, err = getPodPrimaryNetwork(pod)
+		if err != nil {
+			klog.V(1).Infof("Problem obtaining the primary network of Pod %s in namespace %s\n", podName, namespace)
+			return nil, err
+		}
+	}
+
+	// The pod's status only reports the IPs of the cluster default network.
+	if podInfo.isUserDefinedNetworkNAD() {
+		podInfo.IP, err = getPodAnnotationIP(podAnnotation, addressFamily)
+	} else {
+		podInfo.IP, err = getDesiredPodIP(pod, addressFamily)
+	}
 	if err != nil {
-		klog.V(1).Infof("Problem obtaining ovnkube pod name of Pod %s in namespace %s\n", podName, namespace)
+		klog.V(1).Infof("Pod %s in namespace %s doesn't have desired ip address configured\n", podName, namespace)
 		return nil, err
 	}
 
+	// Get the pod's ovnkubePod. Offline, commands run locally against the databases of the node's zone instead.
+	if offline == nil {
+		podInfo.OvnKubePodName, err = getOvnKubePodOnNode(coreclient, ovnNamespace, podInfo.NodeName)
+		if err != nil {
+			klog.V(1).Infof("Problem obtaining ovnkube pod name of Pod %s in namespace %s\n", podName, namespace)
+			return nil, err
+		}
+	}
+
 	// Get the node's gateway mode
 	podInfo.RoutingViaHost, err = isRoutingViaHost(coreclient, podInfo.NodeName)
 	if err != nil {
 		return nil, err
 	}
 
-	podInfo, err = getDatabaseURIs(coreclient, restconfig, ovnNamespace, podInfo)
+	if offline != nil {
+		err = offline.setDatabaseURIs(podInfo)
+	} else {
+		podInfo, err = getDatabaseURIs(coreclient, restconfig, ovnNamespace, podInfo)
+	}
 	if err != nil {
-		klog.Exitf("Failed to get database URIs: %v\n", err)
+		return nil, fmt.Errorf("failed to get database URIs: %w", err)
+	}
+
+	// Find the pod's logical switch port and, for user defined networks, the network's name and topology.
+	switch {
+	case pod.Spec.HostNetwork:
+		podInfo.LogicalPortName = types.K8sPrefix + podInfo.NodeName
+	case podInfo.isUserDefinedNetworkNAD():
+		podInfo.LogicalPortName = util.GetUserDefinedNetworkLogicalPortName(podInfo.PodNamespace, podInfo.PodName, podInfo.NADKey)
+		podInfo.NetworkName, podInfo.Topology, err = getLogicalSwitchPortNetwork(coreclient, restconfig, ovnNamespace, podInfo, podInfo.LogicalPortName)
+		if err != nil {
+			return nil, err
+		}
+		podInfo.NetworkID, err = getNetworkID(coreclient, podInfo.NodeName, podInfo.NetworkName)
+		if err != nil {
+			return nil, err
+		}
+		klog.V(5).Infof("==> Pod %s is on user defined network %s (topology %s, NAD %s)", podInfo.FullyQualifiedPodName(),
+			podInfo.NetworkName, podInfo.Topology, podInfo.NADKey)
+	default:
+		podInfo.LogicalPortName = podInfo.FullyQualifiedPodName()
 	}
+	podInfo.LogicalSwitch = podInfo.logicalSwitchName()
 
 	// Get the pod's MAC address.
 	// If hostnetwork, use mp0 mac
-	if pod.Spec.HostNetwork {
+	switch {
+	case pod.Spec.HostNetwork && offline != nil:
+		podInfo.OvnK8sMp0PortName = types.K8sMgmtIntfName
+		podInfo.MAC, err = offline.getK8sMgmtPortMAC(podInfo)
+		if err != nil {
+			return nil, err
+		}
+	case pod.Spec.HostNetwork:
 		podInfo.OvnK8sMp0PortName = types.K8sMgmtIntfName
 		portCmd := fmt.Sprintf("ovs-vsctl get Interface %s mac_in_use", podInfo.OvnK8sMp0PortName)
 		localOutput, localError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, portCmd, "")
@@ -507,47 +553,57 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		}
 		localOutput = strings.ReplaceAll(localOutput, "\n", "")
 		podInfo.MAC = strings.ReplaceAll(localOutput, "\"", "")
-	} else {
-		podInfo.MAC, err = getPodMAC(pod)
-		if err != nil {
-			klog.V(1).Infof("Problem obtaining Ethernet address of Pod %s in namespace %s\n", podName, namespace)
-			return nil, err
-		}
+	default:
+		podInfo.MAC = podAnnotation.MAC.String()
 	}
 
 	// Find rtos MAC (this is the pod's first hop router).
-	podInfo.RtosMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, types.RouterToSwitchPrefix)
+	podInfo.RtosMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, podInfo.routerToSwitchPortName())
 	if err != nil {
 		return nil, err
 	}
 
 	// Find rtots MAC (this is the pod's first hop router when ovn is in interconnected zone).
-	if podInfo.IsInterConnect {
-		podInfo.RtotsMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, types.RouterToTransitSwitchPrefix)
+	// Layer2 networks have no transit switch, their logical switch spans all the zones.
+	if podInfo.IsInterConnect && podInfo.Topology != types.Layer2Topology {
+		podInfo.RtotsMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, podInfo.routerToTransitSwitchPortName())
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	// Set information specific to ovn-k8s-mp0. This info is required for routingViaHost gateway mode traffic to an external IP
-	// destination.
+	// destination. User defined networks have their own management port.
 	podInfo.OvnK8sMp0PortName = types.K8sMgmtIntfName
-	portCmd := fmt.Sprintf("ovs-vsctl get Interface %s ofport", podInfo.OvnK8sMp0PortName)
-	localOutput, localError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, portCmd, "")
-	if err != nil {
-		return nil, fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, localError, localOutput, podInfo)
+	if podInfo.NetworkID != "" {
+		networkID, err := strconv.Atoi(podInfo.NetworkID)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected networkID '%s': %w", podInfo.NetworkID, err)
+		}
+		podInfo.OvnK8sMp0PortName = util.GetNetworkScopedK8sMgmtHostIntfName(uint(networkID))
+	}
+	// The node's OVS database, and with it the ofports and interface names used by ofproto/trace, is not available offline.
+	if offline == nil {
+		portCmd := fmt.Sprintf("ovs-vsctl get Interface %s ofport", podInfo.OvnK8sMp0PortName)
+		localOutput, localError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, portCmd, "")
+		if err != nil {
+			return nil, fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, localError, localOutput, podInfo)
+		}
+		podInfo.OvnK8sMp0OfportNum = strings.Replace(localOutput, "\n", "", -1)
 	}
-	podInfo.OvnK8sMp0OfportNum = strings.Replace(localOutput, "\n", "", -1)
 
 	// Set information specific to host networked pods or non-host networked pods.
-	if podInfo.HostNetwork {
+	switch {
+	case podInfo.HostNetwork:
 		podInfo.PrimaryInterfaceName = util.GetLegacyK8sMgmtIntfName(podInfo.NodeName)
 		podInfo.K8sNodeNamePort = types.K8sPrefix + podInfo.NodeName
 		podInfo.VethName = podInfo.OvnK8sMp0PortName
 		podInfo.OfportNum = podInfo.OvnK8sMp0OfportNum
-	} else {
+	case offline != nil:
+		podInfo.PrimaryInterfaceName = "eth0"
+	default:
 		// Get the pod's interface information
-		ovsInterfaceInformation, err := getPodOvsInterfaceNameAndOfport(coreclient, restconfig, podInfo, ovnNamespace, podInfo.FullyQualifiedPodName())
+		ovsInterfaceInformation, err := getPodOvsInterfaceNameAndOfport(coreclient, restconfig, podInfo, ovnNamespace, podInfo.LogicalPortName)
 		if err != nil {
 			return nil, err
 		}
@@ -564,8 +620,9 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	return podInfo, err
 }
 
-func getRouterPortMacAddress(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, portPrefix string) (string, error) {
-	tspCmd := "ovn-sbctl --no-leader-only " + podInfo.SbCommand + " --bare --no-heading --column=mac list Port_Binding " + portPrefix + podInfo.NodeName
+// getRouterPortMacAddress returns the MAC address of the given logical router port.
+func getRouterPortMacAddress(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, portName string) (string, error) {
+	tspCmd := "ovn-sbctl --no-leader-only " + podInfo.SbCommand + " --bare --no-heading --column=mac list Port_Binding " + portName
 	ipOutput, ipError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, tspCmd, "")
 	if err != nil {
 		return "", fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, ipError, ipOutput, podInfo)
@@ -581,7 +638,7 @@ func getRouterPortMacAddress(coreclient *corev1client.CoreV1Client, restconfig *
 }
 
 // getNodeExternalBridgeName gets the name of the external bridge of this node, e.g. breth0 or br-ex.
-func getNodeExternalBridgeName(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo) (string, error) {
+func getNodeExternalBridgeName(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo) (string, error) {
 	cmd := "ovn-sbctl --no-leader-only " + podInfo.SbCommand + " --bare --no-heading --column=logical_port find Port_Binding options:network_name=" + types.PhysicalNetworkName
 	stdout, stderr, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, cmd, "")
 	if err != nil {
@@ -602,7 +659,7 @@ func getNodeExternalBridgeName(coreclient *corev1client.CoreV1Client, restconfig
 
 // getOvnNamespace searches all namespaces for pods with the label selector app=ovnkube-node.
 // If it can find such pods, it returns the namespace that they reside in, or error otherwise.
-func getOvnNamespace(coreclient *corev1client.CoreV1Client, override string) (string, error) {
+func getOvnNamespace(coreclient corev1client.CoreV1Interface, override string) (string, error) {
 	if override != "" {
 		return override, nil
 	}
@@ -621,7 +678,7 @@ func getOvnNamespace(coreclient *corev1client.CoreV1Client, override string) (st
 
 // Get the OVN Database URIs from the first container found in any pod in the ovn-kubernetes namespace with name "ovnkube-node"
 // Returns nbAddress, sbAddress, protocol == "ssl", nil
-func getDatabaseURIs(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo) (*PodInfo, error) {
+func getDatabaseURIs(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo) (*PodInfo, error) {
 	podName := podInfo.OvnKubePodName
 	var ovnContainerName string
 	pod, err := coreclient.Pods(ovnNamespace).Get(context.TODO(), podName, metav1.GetOptions{})
@@ -707,16 +764,20 @@ func getDatabaseURIs(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 
 // printSuccessOrFailure will print a success or failure message. If searchString is set, then we expect to find a match for the
 // regexp given in searchString.
-func printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) {
+// With structured output, the result is recorded in the trace result instead. Returns an error if the trace failed.
+func printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) error {
+	if isStructuredOutput() {
+		return recordStep(newTraceStep(commandDescription, src, dst, commandStdout, commandStderr, err, searchString))
+	}
 	if err != nil {
-		klog.Exitf("%s error %v stdOut: %s\n stdErr: %s", commandDescription, err, commandStdout, commandStderr)
+		return fmt.Errorf("%s error %v stdOut: %s\n stdErr: %s", commandDescription, err, commandStdout, commandStderr)
 	}
 	klog.V(2).Infof("%s Output:\n%s%s%s\n", commandDescription, italic, commandStdout, reset)
 
 	if searchString != "" {
 		match, err := regexp.MatchString(searchString, commandStdout)
 		if err != nil {
-			klog.Exitf("Unexpected failure matching regex '%s' to commandStdout '%s', err: %s", searchString, commandStdout, err)
+			return fmt.Errorf("unexpected failure matching regex '%s' to commandStdout '%s', err: %s", searchString, commandStdout, err)
 		}
 		if match {
 			// Write the result to stdout.
@@ -728,84 +789,78 @@ func printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandS
 			fmt.Printf("%s%s%s indicates failure from %s to %s%s\n", red, bold, commandDescription, src, dst, reset)
 			// Log further info on log level 1.
 			klog.V(1).Infof("%sSearch string not matched:\n%s%s\n", red, searchString, reset)
-			os.Exit(-1)
+			return &traceError{verdict: VerdictFailure, reason: fmt.Sprintf("%s indicates failure from %s to %s", commandDescription, src, dst)}
 		}
 	} else {
 		// Write the result to stdout.
 		fmt.Printf("%s%s%s indicates success from %s to %s%s\n", green, bold, commandDescription, src, dst, reset)
 	}
+	return nil
 }
 
 // runOvnTraceToService runs an ovntrace from src pod to dst service. If dstSvcInfo == nil, then skip all steps.
-func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstSvcInfo *SvcInfo, ovnNamespace, protocol, dstPort string) {
-	var inport string
-	inport = srcPodInfo.FullyQualifiedPodName()
-	if srcPodInfo.HostNetwork {
-		inport = srcPodInfo.K8sNodeNamePort
-	}
+// cncName is the ClusterNetworkConnect joining the networks of the source pod and of the service, if they differ.
+func runOvnTraceToService(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, srcPodInfo *PodInfo, dstSvcInfo *SvcInfo, ovnNamespace, protocol, dstPort, cncName string) error {
 	svcL3Ver := dstSvcInfo.getL3Ver()
 	if srcPodInfo.IPVer != svcL3Ver {
-		klog.Exitf("Pod src IP address family (address: %s) and service IP address family (address: %s) do not match",
+		return fmt.Errorf("pod src IP address family (address: %s) and service IP address family (address: %s) do not match",
 			srcPodInfo.IP, dstSvcInfo.ClusterIP)
 	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s --ct=new `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888' --lb-dst %[12]s:%[13]s`,
-		srcPodInfo.SbCommand,  // 1
-		srcPodInfo.NodeName,   // 2
-		inport,                // 3
-		srcPodInfo.MAC,        // 4
-		srcPodInfo.RtosMAC,    // 5
-		srcPodInfo.IPVer,      // 6
-		srcPodInfo.IP,         // 7
-		svcL3Ver,              // 8
-		dstSvcInfo.ClusterIP,  // 9
-		protocol,              // 10
-		dstPort,               // 11
-		dstSvcInfo.PodInfo.IP, // 12
-		dstSvcInfo.PodPort,    // 13
+		srcPodInfo.SbCommand,       // 1
+		srcPodInfo.LogicalSwitch,   // 2
+		srcPodInfo.LogicalPortName, // 3
+		srcPodInfo.MAC,             // 4
+		srcPodInfo.RtosMAC,         // 5
+		srcPodInfo.IPVer,           // 6
+		srcPodInfo.IP,              // 7
+		svcL3Ver,                   // 8
+		dstSvcInfo.ClusterIP,       // 9
+		protocol,                   // 10
+		dstPort,                    // 11
+		dstSvcInfo.PodInfo.IP,      // 12
+		dstSvcInfo.PodPort,         // 13
 	)
 	klog.V(4).Infof("ovn-trace command from src to service clusterIP is %s", cmd)
 
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	var successString string
-	if !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstSvcInfo.PodInfo) {
-		successString = fmt.Sprintf(`output to "%s"`, dstSvcInfo.FullyQualifiedPodName())
-	} else {
-		successString = fmt.Sprintf(`output to "tstor-%s"`, dstSvcInfo.PodInfo.NodeName)
-	}
+	successString := fmt.Sprintf(`output to "%s"`, ovnTraceEgressPort(srcPodInfo, dstSvcInfo.PodInfo, cncName))
 	direction := "source pod to service clusterIP"
-	printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstSvcInfo.SvcName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
-	runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstSvcInfo.PodInfo, ovnNamespace, protocol, dstPort)
-
+	err = reportOvnTrace(coreclient, restconfig, ovnNamespace, srcPodInfo, "ovn-trace "+direction, srcPodInfo.PodName, dstSvcInfo.SvcName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	if err != nil {
+		return err
+	}
+	return runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstSvcInfo.PodInfo, ovnNamespace, protocol, dstPort, cncName)
 }
 
 // runOvnTraceToIP runs an ovntrace from src pod to dst IP address (should be external to the cluster).
-// Returns the node that the trace will exit on.
-func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, parsedDstIP net.IP, ovnNamespace, protocol, dstPort string) (string, string) {
+// Returns the node and bridge that the trace will exit on.
+func runOvnTraceToIP(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, srcPodInfo *PodInfo, parsedDstIP net.IP, ovnNamespace, protocol, dstPort string) (string, string, error) {
 	if srcPodInfo.HostNetwork {
-		klog.Exitf("Pod cannot be on Host Network when tracing to an IP address; use ping\n")
+		return "", "", fmt.Errorf("pod cannot be on Host Network when tracing to an IP address; use ping")
 	}
 
 	l3ver := getIPVer(parsedDstIP)
 
 	if srcPodInfo.IPVer != l3ver {
-		klog.Exitf("Pod src IP address family (address: %s) and destination IP address family (address: %s) do not match",
+		return "", "", fmt.Errorf("pod src IP address family (address: %s) and destination IP address family (address: %s) do not match",
 			srcPodInfo.IP, parsedDstIP)
 	}
 
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888'`,
-		srcPodInfo.SbCommand,               // 1
-		srcPodInfo.NodeName,                // 2
-		srcPodInfo.FullyQualifiedPodName(), // 3
-		srcPodInfo.MAC,                     // 4
-		srcPodInfo.RtosMAC,                 // 5
-		l3ver,                              // 6
-		srcPodInfo.IP,                      // 7
-		l3ver,                              // 8
-		parsedDstIP,                        // 9
-		protocol,                           // 10
-		dstPort,                            // 11
+		srcPodInfo.SbCommand,       // 1
+		srcPodInfo.LogicalSwitch,   // 2
+		srcPodInfo.LogicalPortName, // 3
+		srcPodInfo.MAC,             // 4
+		srcPodInfo.RtosMAC,         // 5
+		l3ver,                      // 6
+		srcPodInfo.IP,              // 7
+		l3ver,                      // 8
+		parsedDstIP,                // 9
+		protocol,                   // 10
+		dstPort,                    // 11
 	)
 	klog.V(4).Infof("ovn-trace command from pod to IP is %s", cmd)
 
@@ -813,10 +868,15 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 	// a) if this is routingViaHost gateway mode, output to "k8s-<nodename>"
 	// b) for routingViaHost gateway egressip and routingViaOVN gateway mode, go out of <bridge name>_<node name>
 	// c) when interconnect enabled and egressip available for the pod, then go out of tstor-<egress-node> with type "remote".
-	successString := fmt.Sprintf(`output to "(.*)_(.*)", type "localnet"|output to "k8s-%s"|remote`, srcPodInfo.NodeName)
+	// For user defined networks, the node names in the port names are prefixed with the network prefix.
+	networkPrefix := regexp.QuoteMeta(srcPodInfo.networkScopedName(""))
+	successString := fmt.Sprintf(`output to "(.*)_%s(.*)", type "localnet"|output to "%s"|remote`, networkPrefix, regexp.QuoteMeta(srcPodInfo.k8sMgmtPortName()))
 	// Run the command and check if succesString was found.
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace from pod to IP", srcPodInfo.PodName, parsedDstIP.String(), ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	err = reportOvnTrace(coreclient, restconfig, ovnNamespace, srcPodInfo, "ovn-trace from pod to IP", srcPodInfo.PodName, parsedDstIP.String(), ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	if err != nil {
+		return "", "", err
+	}
 
 	// Print some additional information about the node where this request leaves from as well
 	// as the SNAT IP address.
@@ -830,57 +890,58 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 		subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 		// We should never hit this (printSuccessOrFailure checks the same already above).
 		if len(subMatches) < 3 {
-			klog.Exitf("Could not determine the output port for this trace command, subMatches: %q\n", subMatches)
+			return "", "", fmt.Errorf("could not determine the output port for this trace command, subMatches: %q", subMatches)
 		}
 		node := subMatches[len(subMatches)-1]
 		bridgeName := subMatches[len(subMatches)-2]
 		klog.V(1).Infof("%sout on node %s via Logical_Switch_Port %s with SNAT %s%s\n", green, node, bridgeName, snat, reset)
 
-		return string(node), string(bridgeName)
+		return string(node), string(bridgeName), nil
 	}
 
 	// Try to find egress node name when ovnSrcDstOut contains "output to tstor-<egress-node>"".
-	nodeNameRegex := `output to "tstor-(.*)",`
+	nodeNameRegex := fmt.Sprintf(`output to "%s%s(.*)",`, networkPrefix, types.TransitSwitchToRouterPrefix)
 	re = regexp.MustCompile(nodeNameRegex)
 	subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 	if len(subMatches) > 1 {
 		node := subMatches[len(subMatches)-1]
 		klog.V(1).Infof("%sout on node %s%s\n", green, node, reset)
-		return string(node), ""
+		return string(node), "", nil
 	}
 
 	klog.V(5).Infof("Could not find SNAT for this trace command, this must be routingViaHost gateway mode without EgressIP.")
-	nodeNameRegex = `output to "k8s-(.*)",`
+	nodeNameRegex = fmt.Sprintf(`output to "k8s-%s(.*)",`, networkPrefix)
 	re = regexp.MustCompile(nodeNameRegex)
 	subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 	if len(subMatches) < 2 {
-		klog.Exitf("Could not determine node name / bridge name of egress node in runOvnTraceToIP()")
+		return "", "", fmt.Errorf("could not determine node name / bridge name of egress node in runOvnTraceToIP()")
 	}
 	node := subMatches[len(subMatches)-1]
 	klog.V(1).Infof("%sout on node %s%s\n", green, node, reset)
-	return string(node), ""
+	return string(node), "", nil
 }
 
 // runOvnTraceToPod runs an ovntrace from src pod to dst pod.
-func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) {
-	var inport string
-	inport = srcPodInfo.FullyQualifiedPodName()
-	if srcPodInfo.HostNetwork {
-		inport = srcPodInfo.K8sNodeNamePort
+// cncName is the ClusterNetworkConnect joining the networks of both pods, if they differ.
+func runOvnTraceToPod(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort, cncName string) error {
+	// Pods on the same layer2 network are on the same subnet and address each other directly.
+	ethDst := srcPodInfo.RtosMAC
+	if srcPodInfo.Topology == types.Layer2Topology && srcPodInfo.sameNetwork(dstPodInfo) && !dstPodInfo.HostNetwork {
+		ethDst = dstPodInfo.MAC
 	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888'`,
-		srcPodInfo.SbCommand, // 1
-		srcPodInfo.NodeName,  // 2
-		inport,               // 3
-		srcPodInfo.MAC,       // 4
-		srcPodInfo.RtosMAC,   // 5
-		srcPodInfo.IPVer,     // 6
-		srcPodInfo.IP,        // 7
-		dstPodInfo.IPVer,     // 8
-		dstPodInfo.IP,        // 9
-		protocol,             // 10
-		dstPort,              // 11
+		srcPodInfo.SbCommand,       // 1
+		srcPodInfo.LogicalSwitch,   // 2
+		srcPodInfo.LogicalPortName, // 3
+		srcPodInfo.MAC,             // 4
+		ethDst,                     // 5
+		srcPodInfo.IPVer,           // 6
+		srcPodInfo.IP,              // 7
+		dstPodInfo.IPVer,           // 8
+		dstPodInfo.IP,              // 9
+		protocol,                   // 10
+		dstPort,                    // 11
 	)
 	klog.V(4).Infof("ovn-trace command from %s is %s", direction, cmd)
 
@@ -890,41 +951,78 @@ func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 		// routingViaHost gateway mode or if both pods are on the same node example: k8s-ovn-worker.
 		// routingViaOVN gateway mode example: "breth0_ovn-worker2.
 		if srcPodInfo.RoutingViaHost || srcPodInfo.NodeName == dstPodInfo.NodeName {
-			successString = fmt.Sprintf(`output to "%s%s"`, types.K8sPrefix, srcPodInfo.NodeName)
+			successString = fmt.Sprintf(`output to "%s"`, srcPodInfo.k8sMgmtPortName())
 		} else {
-			successString = fmt.Sprintf(`output to "%s_%s"`, srcPodInfo.NodeExternalBridgeName, srcPodInfo.NodeName)
+			successString = fmt.Sprintf(`output to "%s_%s"`, srcPodInfo.NodeExternalBridgeName, srcPodInfo.networkScopedName(srcPodInfo.NodeName))
 		}
-	} else if !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstPodInfo) {
-		successString = fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
 	} else {
-		successString = fmt.Sprintf(`output to "tstor-%s"`, dstPodInfo.NodeName)
+		successString = fmt.Sprintf(`output to "%s"`, ovnTraceEgressPort(srcPodInfo, dstPodInfo, cncName))
 	}
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
-	runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstPodInfo, ovnNamespace, protocol, dstPort)
+	err = reportOvnTrace(coreclient, restconfig, ovnNamespace, srcPodInfo, "ovn-trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	if err != nil {
+		return err
+	}
+	return runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstPodInfo, ovnNamespace, protocol, dstPort, cncName)
 }
 
-func runOvnTraceToRemotePod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) {
+// ovnTraceEgressPort returns the logical port that an ovn-trace from the src pod to the dst pod is expected to output to
+// in the src pod's zone. That is the dst pod's port itself if both pods are in the same zone or on the same layer2
+// network, and otherwise the port leading to the dst pod's node: the transit switch port or, for networks joined by a
+// ClusterNetworkConnect, the connect router port.
+func ovnTraceEgressPort(srcPodInfo, dstPodInfo *PodInfo, cncName string) string {
+	if !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstPodInfo) || dstPodInfo.Topology == types.Layer2Topology {
+		return dstPodInfo.LogicalPortName
+	}
+	if cncName != "" {
+		return dstPodInfo.connectRouterToNetworkRouterPortName(cncName)
+	}
+	return dstPodInfo.transitSwitchToRouterPortName()
+}
+
+// ovnTraceRemoteIngress returns the logical port and destination MAC address with which traffic from the src pod enters
+// the logical pipeline of the dst pod's zone.
+func ovnTraceRemoteIngress(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, cncName string) (string, string, error) {
+	if cncName != "" {
+		// The traffic enters the dst pod's network router from the connect router.
+		inport := dstPodInfo.networkRouterToConnectRouterPortName(cncName)
+		mac, err := getRouterPortMacAddress(coreclient, restconfig, dstPodInfo, ovnNamespace, inport)
+		return inport, mac, err
+	}
+	if dstPodInfo.Topology == types.Layer2Topology {
+		// The layer2 switch spans all the zones, the src pod's port is a remote port in the dst pod's zone.
+		return srcPodInfo.LogicalPortName, dstPodInfo.MAC, nil
+	}
+	return srcPodInfo.transitSwitchToRouterPortName(), dstPodInfo.RtotsMAC, nil
+}
+
+// runOvnTraceToRemotePod runs an ovntrace in the dst pod's zone for the traffic from the src pod that left the src pod's
+// zone. cncName is the ClusterNetworkConnect joining the networks of both pods, if they differ.
+func runOvnTraceToRemotePod(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort, cncName string) error {
 	if dstPodInfo.HostNetwork || !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstPodInfo) {
-		return
+		return nil
+	}
+	inport, ethDst, err := ovnTraceRemoteIngress(coreclient, restconfig, srcPodInfo, dstPodInfo, ovnNamespace, cncName)
+	if err != nil {
+		return fmt.Errorf("failed to find the ingress port of %s in the zone of node %s: %w", srcPodInfo.PodName, dstPodInfo.NodeName, err)
 	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s `+
 		`'inport=="%[2]s" && eth.src==%[3]s && eth.dst==%[4]s && %[5]s.src==%[6]s && %[7]s.dst==%[8]s && ip.ttl==64 && %[9]s.dst==%[10]s && %[9]s.src==52888'`,
 		dstPodInfo.SbCommand, // 1
-		types.TransitSwitchToRouterPrefix+srcPodInfo.NodeName, // 2
-		srcPodInfo.MAC,      // 3
-		dstPodInfo.RtotsMAC, // 4
-		srcPodInfo.IPVer,    // 5
-		srcPodInfo.IP,       // 6
-		dstPodInfo.IPVer,    // 7
-		dstPodInfo.IP,       // 8
-		protocol,            // 9
-		dstPort,             // 10
+		inport,               // 2
+		srcPodInfo.MAC,       // 3
+		ethDst,               // 4
+		srcPodInfo.IPVer,     // 5
+		srcPodInfo.IP,        // 6
+		dstPodInfo.IPVer,     // 7
+		dstPodInfo.IP,        // 8
+		protocol,             // 9
+		dstPort,              // 10
 	)
 	klog.V(4).Infof("ovn-trace command on destination pod node is %s", cmd)
-	successString := fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
+	successString := fmt.Sprintf(`output to "%s"`, dstPodInfo.LogicalPortName)
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, dstPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace (remote) "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	return reportOvnTrace(coreclient, restconfig, ovnNamespace, dstPodInfo, "ovn-trace (remote) "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
 }
 
 func podsInSameInterconnectZone(srcPodInfo, dstPodInfo *PodInfo) bool {
@@ -933,7 +1031,7 @@ func podsInSameInterconnectZone(srcPodInfo, dstPodInfo *PodInfo) bool {
 }
 
 // runOfprotoTraceToPod runs an ofproto/trace command from the src to the destination pod.
-func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) string {
+func runOfprotoTraceToPod(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) (string, error) {
 	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, net.ParseIP(dstPodInfo.IP))
 	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace br-int `+
 		`"in_port=%[1]s, %[9]s, dl_src=%[3]s, dl_dst=%[4]s, %[10]s=%[5]s, %[11]s=%[6]s, nw_ttl=64, %[7]s_dst=%[8]s, %[7]s_src=12345"`,
@@ -972,16 +1070,16 @@ func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *res
 		successString = "-> output to kernel tunnel"
 	}
 	appSrcDstOut, appSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovs-appctl ofproto/trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, appSrcDstOut, appSrcDstErr, err, successString)
+	err = printSuccessOrFailure("ovs-appctl ofproto/trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, appSrcDstOut, appSrcDstErr, err, successString)
 
-	return appSrcDstOut
+	return appSrcDstOut, err
 }
 
 // runOfprotoTraceToIP runs an ofproto/trace command from the src to the destination pod.
 // egressNodeName is the exit node, as determined by an ovn-trace command that was run earlier.
 // egressBridgeName is the name of the exit bridge (for EgressIPs, EgressGW and also for routingViaOVN mode).
 // If egressBridgeName == "", then this is routingViaHost Gateway mode without an EgressIP / EgressGW.
-func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstIP net.IP, ovnNamespace, protocol, dstPort, egressNodeName, egressBridgeName string) string {
+func runOfprotoTraceToIP(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, srcPodInfo *PodInfo, dstIP net.IP, ovnNamespace, protocol, dstPort, egressNodeName, egressBridgeName string) (string, error) {
 	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, dstIP)
 	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace br-int `+
 		`"in_port=%[1]s, %[8]s, dl_src=%[3]s, dl_dst=%[4]s, %[9]s=%[5]s, %[10]s=%[6]s, nw_ttl=64, %[2]s_dst=%[7]s, %[2]s_src=12345"`,
@@ -1015,9 +1113,9 @@ func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest
 		}
 	}
 	appSrcDstOut, appSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure(fmt.Sprintf("ovs-appctl ofproto/trace %s", direction), srcPodInfo.PodName, dstIP.String(), appSrcDstOut, appSrcDstErr, err, successString)
+	err = printSuccessOrFailure(fmt.Sprintf("ovs-appctl ofproto/trace %s", direction), srcPodInfo.PodName, dstIP.String(), appSrcDstOut, appSrcDstErr, err, successString)
 
-	return appSrcDstOut
+	return appSrcDstOut, err
 }
 
 // getOfprotoIPFamilyArgs generates the protocol parameter name and the src and dst parameter names.
@@ -1036,7 +1134,7 @@ func getOfprotoIPFamilyArgs(protocol string, ip net.IP) (string, string, string)
 
 // installOvnDetraceDependencies installs dependencies for ovn-detrace with pip3 in case they are missing (for older images).
 // Returns error if dependencies are missing but cannot be installed.
-func installOvnDetraceDependencies(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace string) error {
+func installOvnDetraceDependencies(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace string) error {
 	dependencies := map[string]string{
 		"ovs":       "if type -p ovn-detrace >/dev/null 2>&1; then echo 'true' ; fi",
 		"pyOpenSSL": "if python -c 'import ssl; print(ssl.OPENSSL_VERSION)' > /dev/null; then echo 'true'; fi",
@@ -1068,7 +1166,7 @@ func installOvnDetraceDependencies(coreclient *corev1client.CoreV1Client, restco
 	return nil
 }
 
-func verifyDependency(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, dependency, depCheckCommand string) (string, string, error) {
+func verifyDependency(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, dependency, depCheckCommand string) (string, string, error) {
 	depVerifyOut, depVerifyErr, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, depCheckCommand, "")
 	if err != nil {
 		return "", "", fmt.Errorf("ovn-detrace error while verifying dependency %s in pod %s, container %s. Error '%v', stdOut: '%s'\n stdErr: %s",
@@ -1079,17 +1177,20 @@ func verifyDependency(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 	return trueFalse, depVerifyErr, nil
 }
 
+// errOvnDetraceSkipped is returned by runOvnDetrace when ovn-detrace cannot run in the ovnkube pod.
+var errOvnDetraceSkipped = errors.New("skipped ovn-detrace")
+
 // runOvnDetrace runs an ovn-detrace command for the given input.
-// Returns error if dependencies are not met (allows for graceful handling of those issues).
-func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo *PodInfo,
+// Returns errOvnDetraceSkipped if dependencies are not met (allows for graceful handling of those issues).
+func runOvnDetrace(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, direction string, srcPodInfo *PodInfo,
 	dstName string, appSrcDstOut, ovnNamespace string) error {
 	// If NBDB connectivity is not available do not run ovn-detrace.
 	if _, stdErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, fmt.Sprintf("ovn-nbctl %s get-connection", srcPodInfo.NbCommand), ""); err != nil {
-		return fmt.Errorf("nbdb is not available %q", stdErr)
+		return fmt.Errorf("%w due to: nbdb is not available %q", errOvnDetraceSkipped, stdErr)
 	}
 	// If dependencies aren't satisfied do not run ovn-detrace.
 	if err := installOvnDetraceDependencies(coreclient, restconfig, srcPodInfo, ovnNamespace); err != nil {
-		return fmt.Errorf("dependencies check failed: %q", err)
+		return fmt.Errorf("%w due to: dependencies check failed: %q", errOvnDetraceSkipped, err)
 	}
 
 	cmd := fmt.Sprintf(`ovn-detrace --ovnnb=%[1]s --ovnsb=%[2]s %[3]s --ovsdb=unix:/var/run/openvswitch/db.sock`,
@@ -1100,17 +1201,15 @@ func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Confi
 	klog.V(4).Infof("ovn-detrace command from %s is %s", direction, cmd)
 
 	dtraceSrcDstOut, dtraceSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, appSrcDstOut)
-	printSuccessOrFailure("ovn-detrace "+direction, srcPodInfo.PodName, dstName, dtraceSrcDstOut, dtraceSrcDstErr, err, "")
-
-	return nil
+	return printSuccessOrFailure("ovn-detrace "+direction, srcPodInfo.PodName, dstName, dtraceSrcDstOut, dtraceSrcDstErr, err, "")
 }
 
 // displayNodeInfo shows a summary about nodes in this cluster.
-func displayNodeInfo(coreclient *corev1client.CoreV1Client) {
+func displayNodeInfo(coreclient corev1client.CoreV1Interface) error {
 	// List all Nodes.
 	nodes, err := coreclient.Nodes().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		return err
 	}
 
 	masters := make(map[string]string)
@@ -1148,6 +1247,7 @@ func displayNodeInfo(coreclient *corev1client.CoreV1Client) {
 	if len(masters) < 3 {
 		klog.V(5).Infof("Cluster does not have 3 masters, found %d", len(masters))
 	}
+	return nil
 }
 
 func getDesiredPodIP(pod *corev1.Pod, addressFamily string) (string, error) {
@@ -1168,17 +1268,60 @@ func getIPVer(ip net.IP) string {
 }
 
 // setLogLevel sets the log level for this application.
-func setLogLevel(loglevel string) {
+func setLogLevel(loglevel string) error {
 	klog.InitFlags(nil)
 	klog.SetOutput(os.Stderr)
 	err := level.Set(loglevel)
 	if err != nil {
-		klog.Exitf("fatal: cannot set logging level\n")
+		return fmt.Errorf("cannot set logging level: %w", err)
 	}
 	klog.V(1).Infof("Log level set to: %s", loglevel)
+	return nil
+}
+
+// getRestConfig gets the ClientConfig.
+// This might work better?  https://godoc.org/sigs.k8s.io/controller-runtime/pkg/client/config
+// When supplied the kubeconfig supplied via cli takes precedence
+func getRestConfig(cliConfig string) (*rest.Config, error) {
+	if cliConfig != "" {
+		// use the current context in kubeconfig
+		return clientcmd.BuildConfigFromFlags("", cliConfig)
+	}
+	// Instantiate loader for kubeconfig file.
+	kubeconfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	)
+
+	// Get a rest.Config from the kubeconfig file.  This will be passed into all
+	// the client objects we create.
+	return kubeconfig.ClientConfig()
 }
 
 func main() {
+	err := run()
+	if isStructuredOutput() && (err != nil || traceResult != nil) {
+		if writeErr := finishTrace(err); writeErr != nil {
+			klog.Exitf("Failed to write trace result: %v", writeErr)
+		}
+		if err != nil {
+			klog.FlushAndExit(klog.ExitFlushTimeout, -1)
+		}
+		return
+	}
+	if err != nil {
+		var tErr *traceError
+		if errors.As(err, &tErr) && tErr.verdict == VerdictFailure {
+			// The failed step was already printed.
+			klog.FlushAndExit(klog.ExitFlushTimeout, -1)
+		}
+		klog.Exitf("%v", err)
+	}
+}
+
+// run runs the trace selected by the CLI flags. With structured output, the steps are recorded in traceResult and main
+// writes it out with the verdict for the returned error.
+func run() error {
 	var protocol string
 	var parsedDstIP net.IP
 	var err error
@@ -1198,75 +1341,96 @@ func main() {
 	addressFamily := flag.String("addr-family", ip4, "Address family (ip4 or ip6) to be used for tracing")
 	skipOvnDetrace := flag.Bool("skip-detrace", false, "skip ovn-detrace command")
 	dumpVRFTableIDs := flag.Bool("dump-udn-vrf-table-ids", false, "Dump the VRF table ID per node for all the user defined networks")
+	output := flag.String("output", textOutput, "output format of the trace result: text, json or yaml")
+	offlineObjects := flag.String("offline-objects", "", "trace offline: JSON file with the cluster's Kubernetes objects, e.g. from kubectl get -o json")
+	offlineDBDir := flag.String("offline-db-dir", "", "trace offline: directory with the <node>_nbdb and <node>_sbdb database snapshots of every zone")
+	offlineNBDB := flag.String("offline-nbdb", "", "trace offline: NB database snapshot of a cluster with a single zone")
+	offlineSBDB := flag.String("offline-sbdb", "", "trace offline: SB database snapshot of a cluster with a single zone")
 	loglevel := flag.String("loglevel", "0", "loglevel: klog level")
 	flag.Parse()
 
 	// Set the application's log level.
-	setLogLevel(*loglevel)
+	if err := setLogLevel(*loglevel); err != nil {
+		return err
+	}
 
-	// Get the ClientConfig.
-	// This might work better?  https://godoc.org/sigs.k8s.io/controller-runtime/pkg/client/config
-	// When supplied the kubeconfig supplied via cli takes precedence
+	if err := setOutputFormat(*output); err != nil {
+		return fmt.Errorf("usage: %w", err)
+	}
+
+	// Create the Kubernetes clients, either for the cluster or, offline, for the objects dumped from it.
+	var kubeclient kubernetes.Interface
+	var cncclient networkconnectclientset.Interface
 	var restconfig *rest.Config
-	if *cliConfig != "" {
-		// use the current context in kubeconfig
-		restconfig, err = clientcmd.BuildConfigFromFlags("", *cliConfig)
+	if *offlineObjects != "" {
+		if *offlineDBDir == "" && (*offlineNBDB == "" || *offlineSBDB == "") {
+			return fmt.Errorf("usage: offline mode requires either -offline-db-dir or both -offline-nbdb and -offline-sbdb")
+		}
+		if *offlineDBDir != "" && (*offlineNBDB != "" || *offlineSBDB != "") {
+			return fmt.Errorf("usage: -offline-db-dir cannot be combined with -offline-nbdb and -offline-sbdb")
+		}
+		if *dumpVRFTableIDs {
+			return fmt.Errorf("usage: -dump-udn-vrf-table-ids is not supported offline")
+		}
+		offline, kubeclient, cncclient, err = newOfflineCluster(*offlineObjects, *offlineDBDir, *offlineNBDB, *offlineSBDB)
 		if err != nil {
-			klog.Exitf(" Unexpected error: %v", err)
+			return fmt.Errorf("failed to set up offline mode: %w", err)
 		}
+		defer offline.stop()
 	} else {
-		// Instantiate loader for kubeconfig file.
-		kubeconfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-			clientcmd.NewDefaultClientConfigLoadingRules(),
-			&clientcmd.ConfigOverrides{},
-		)
-
-		// Get a rest.Config from the kubeconfig file.  This will be passed into all
-		// the client objects we create.
-		restconfig, err = kubeconfig.ClientConfig()
+		if *offlineDBDir != "" || *offlineNBDB != "" || *offlineSBDB != "" {
+			return fmt.Errorf("usage: offline database snapshots require -offline-objects")
+		}
+		restconfig, err = getRestConfig(*cliConfig)
 		if err != nil {
-			klog.Exitf(" Unexpected error: %v", err)
+			return err
+		}
+		kubeclient, err = kubernetes.NewForConfig(restconfig)
+		if err != nil {
+			return err
+		}
+		cncclient, err = networkconnectclientset.NewForConfig(restconfig)
+		if err != nil {
+			return err
 		}
 	}
+	coreclient := kubeclient.CoreV1()
 
-	// Create a Kubernetes core/v1 client.
-	coreclient, err := corev1client.NewForConfig(restconfig)
-	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
-	}
-
-	// Get the namespace that OVN pods reside in.
-	ovnNamespace, err := getOvnNamespace(coreclient, *cfgNamespace)
-	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+	// Get the namespace that OVN pods reside in. Offline, no command runs in the ovnkube pods.
+	ovnNamespace := *cfgNamespace
+	if offline == nil {
+		ovnNamespace, err = getOvnNamespace(coreclient, *cfgNamespace)
+		if err != nil {
+			return err
+		}
 	}
 
 	klog.V(5).Infof("OVN-Kubernetes namespace is %s", ovnNamespace)
 	if *dumpVRFTableIDs {
 		nodesVRFTableIDs, err := findUserDefinedNetworkVRFTableIDs(coreclient, restconfig, ovnNamespace)
 		if err != nil {
-			klog.Exitf("Failed dumping VRF table IDs: %s", err)
+			return fmt.Errorf("failed dumping VRF table IDs: %w", err)
 		}
 		fmt.Println(string(nodesVRFTableIDs))
-		return
+		return nil
 	}
 
 	// Verify CLI flags.
 	if *srcPodName == "" {
-		klog.Exitf("Usage: source pod must be specified")
+		return fmt.Errorf("usage: source pod must be specified")
 	}
 	if !*tcp && !*udp {
-		klog.Exitf("Usage: either tcp or udp must be specified")
+		return fmt.Errorf("usage: either tcp or udp must be specified")
 	}
 	if *udp && *tcp {
-		klog.Exitf("Usage: Both tcp and udp cannot be specified at the same time")
+		return fmt.Errorf("usage: Both tcp and udp cannot be specified at the same time")
 	}
 	if *tcp {
 		protocol = "tcp"
 	}
 	if *udp {
 		if *dstSvcName != "" {
-			klog.Exitf("Usage: udp option is not compatible with destination service trace")
+			return fmt.Errorf("usage: udp option is not compatible with destination service trace")
 		}
 		protocol = "udp"
 	}
@@ -1281,38 +1445,62 @@ func main() {
 		targetOptions++
 		parsedDstIP = net.ParseIP(*dstIP)
 		if parsedDstIP == nil {
-			klog.Exitf("Usage: cannot parse IP address provided in -dst-ip")
+			return fmt.Errorf("usage: cannot parse IP address provided in -dst-ip")
 		}
 	}
 	if targetOptions != 1 {
-		klog.Exitf("Usage: exactly one of -dst, -service or -dst-ip must be set")
+		return fmt.Errorf("usage: exactly one of -dst, -service or -dst-ip must be set")
 	}
 
 	// Show some information about the nodes in this cluster - only if log level 5 or higher.
 	if lvl, err := strconv.Atoi(*loglevel); err == nil && lvl >= 5 {
-		displayNodeInfo(coreclient)
+		if err := displayNodeInfo(coreclient); err != nil {
+			return err
+		}
 	}
 
 	// Get info needed for the src Pod
 	srcPodInfo, err := getPodInfo(coreclient, restconfig, *srcPodName, ovnNamespace, *srcNamespace, *addressFamily)
 	if err != nil {
-		klog.Exitf("Failed to get information from pod %s: %v", *srcPodName, err)
+		return fmt.Errorf("failed to get information from pod %s: %w", *srcPodName, err)
 	}
 	klog.V(5).Infof("srcPodInfo is %s\n", srcPodInfo)
 
+	if isStructuredOutput() {
+		traceResult = &TraceResult{
+			Source:   srcPodInfo,
+			Protocol: protocol,
+			DstPort:  *dstPort,
+		}
+	}
+
 	// 1) Either run a trace from source pod to destination IP and return ...
 	if parsedDstIP != nil {
 		klog.V(5).Infof("Running a trace to an IP address")
-		egressNodeName, egressBridgeName := runOvnTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort)
-		appSrcDstOut := runOfprotoTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort, egressNodeName, egressBridgeName)
+		if traceResult != nil {
+			traceResult.DestinationIP = parsedDstIP.String()
+		}
+		egressNodeName, egressBridgeName, err := runOvnTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort)
+		if err != nil {
+			return err
+		}
+		if offline != nil {
+			klog.V(1).Infof("Skipping ofproto/trace and ovn-detrace, they require the nodes' OVS databases which are not available offline")
+			return nil
+		}
+		appSrcDstOut, err := runOfprotoTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort, egressNodeName, egressBridgeName)
+		if err != nil {
+			return err
+		}
 		if *skipOvnDetrace {
-			return
+			return nil
 		}
 		err = runOvnDetrace(coreclient, restconfig, "pod to external IP", srcPodInfo, parsedDstIP.String(), appSrcDstOut, ovnNamespace)
-		if err != nil {
-			klog.Infof("Skipped ovn-detrace due to: %q", err)
+		if errors.Is(err, errOvnDetraceSkipped) {
+			klog.Info(err)
+			return nil
 		}
-		return
+		return err
 	}
 
 	// 2) ... or run a trace to destination service / destination pod.
@@ -1321,9 +1509,9 @@ func main() {
 	var dstSvcInfo *SvcInfo
 	if *dstSvcName != "" {
 		// Get dst service
-		dstSvcInfo, err = getSvcInfo(coreclient, restconfig, *dstSvcName, ovnNamespace, *dstNamespace, *addressFamily)
+		dstSvcInfo, err = getSvcInfo(coreclient, kubeclient.DiscoveryV1(), restconfig, *dstSvcName, ovnNamespace, *dstNamespace, *addressFamily)
 		if err != nil {
-			klog.Exitf("Failed to get information from service %s: %v", *dstSvcName, err)
+			return fmt.Errorf("failed to get information from service %s: %w", *dstSvcName, err)
 		}
 		klog.V(5).Infof("dstSvcInfo is %s\n", dstSvcInfo)
 		// Set dst pod name, we'll use this to run through pod-pod tests as if use supplied this pod
@@ -1334,38 +1522,75 @@ func main() {
 	// Now get info needed for the dst Pod
 	dstPodInfo, err := getPodInfo(coreclient, restconfig, *dstPodName, ovnNamespace, *dstNamespace, *addressFamily)
 	if err != nil {
-		klog.Exitf("Failed to get information from pod %s: %v", *dstPodName, err)
+		return fmt.Errorf("failed to get information from pod %s: %w", *dstPodName, err)
 	}
 	klog.V(5).Infof("dstPodInfo is %s\n", dstPodInfo)
+	if traceResult != nil {
+		traceResult.Destination = dstPodInfo
+		traceResult.Service = dstSvcInfo
+	}
 
 	// At least one pod must not be on the Host Network
 	if srcPodInfo.HostNetwork && dstPodInfo.HostNetwork {
-		klog.Exitf("Both pods cannot be on Host Network; use ping")
+		return fmt.Errorf("both pods cannot be on Host Network; use ping")
+	}
+
+	// Find the ClusterNetworkConnect joining both pods' networks when they are on different user defined networks.
+	cncName, err := findClusterNetworkConnect(cncclient, srcPodInfo, dstPodInfo)
+	if err != nil {
+		return fmt.Errorf("failed to find the ClusterNetworkConnect between networks %s and %s: %w", srcPodInfo.NetworkName, dstPodInfo.NetworkName, err)
+	}
+	if cncName != "" {
+		klog.V(1).Infof("Networks %s and %s are connected by ClusterNetworkConnect %s", srcPodInfo.NetworkName, dstPodInfo.NetworkName, cncName)
+	}
+	if traceResult != nil {
+		traceResult.NetworkConnect = cncName
 	}
 
 	// ovn-trace commands
 	if dstSvcInfo != nil {
-		runOvnTraceToService(coreclient, restconfig, srcPodInfo, dstSvcInfo, ovnNamespace, protocol, *dstPort)
+		if err := runOvnTraceToService(coreclient, restconfig, srcPodInfo, dstSvcInfo, ovnNamespace, protocol, *dstPort, cncName); err != nil {
+			return err
+		}
+	}
+	if err := runOvnTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort, cncName); err != nil {
+		return err
+	}
+	if err := runOvnTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort, cncName); err != nil {
+		return err
+	}
+
+	if offline != nil {
+		klog.V(1).Infof("Skipping ofproto/trace and ovn-detrace, they require the nodes' OVS databases which are not available offline")
+		return nil
 	}
-	runOvnTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort)
-	runOvnTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort)
 
 	// ovs-appctl ofproto/trace commands
-	appSrcDstOut := runOfprotoTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort)
-	appDstSrcOut := runOfprotoTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort)
+	appSrcDstOut, err := runOfprotoTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort)
+	if err != nil {
+		return err
+	}
+	appDstSrcOut, err := runOfprotoTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort)
+	if err != nil {
+		return err
+	}
 
 	// ovn-detrace commands below
 	if *skipOvnDetrace {
-		return
+		return nil
 	}
 	err = runOvnDetrace(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo.PodName, appSrcDstOut, ovnNamespace)
 	if err != nil {
-		klog.Infof("Skipped ovn-detrace due to: %q", err)
-		return
+		if errors.Is(err, errOvnDetraceSkipped) {
+			klog.Info(err)
+			return nil
+		}
+		return err
 	}
 	err = runOvnDetrace(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo.PodName, appDstSrcOut, ovnNamespace)
-	if err != nil {
-		klog.Infof("Skipped ovn-detrace due to: %q", err)
-		return
+	if errors.Is(err, errOvnDetraceSkipped) {
+		klog.Info(err)
+		return nil
 	}
+	return err
 }
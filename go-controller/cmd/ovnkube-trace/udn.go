@@ -5,18 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 
+	networkconnectclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/clusternetworkconnect/v1/apis/clientset/versioned"
 	types "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
 	util "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
 )
 
-func findUserDefinedNetworkVRFTableIDs(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace string) (string, error) {
+func findUserDefinedNetworkVRFTableIDs(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, ovnNamespace string) (string, error) {
 	nodeList, err := coreclient.Nodes().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		return "", err
@@ -46,7 +49,7 @@ func findUserDefinedNetworkVRFTableIDs(coreclient *corev1client.CoreV1Client, re
 	return string(nodesTableIDsJSON), nil
 }
 
-func findUserDefinedNetworkVRFTableID(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, node *corev1.Node, ovnNamespace string, networkID string) (*uint, error) {
+func findUserDefinedNetworkVRFTableID(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, node *corev1.Node, ovnNamespace string, networkID string) (*uint, error) {
 	ovnKubePodName, err := getOvnKubePodOnNode(coreclient, ovnNamespace, node.Name)
 	if err != nil {
 		return nil, err
@@ -89,3 +92,185 @@ func findUDNNetworks(node *corev1.Node) (map[string]string, error) {
 	delete(networks, types.DefaultNetworkName)
 	return networks, nil
 }
+
+// getPodPrimaryNetwork returns the NAD key and the annotation of the pod's primary network, as found in the pod's
+// k8s.ovn.org/pod-networks annotation. For pods on the cluster default network, the NAD key is "default".
+func getPodPrimaryNetwork(pod *corev1.Pod) (string, *util.PodAnnotation, error) {
+	podNetworks, err := util.UnmarshalPodAnnotationAllNetworks(pod.Annotations)
+	if err != nil {
+		return "", nil, err
+	}
+	nadKey := types.DefaultNetworkName
+	for key, podNetwork := range podNetworks {
+		if podNetwork.Role == types.NetworkRolePrimary {
+			nadKey = key
+			break
+		}
+	}
+	podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return nadKey, podAnnotation, nil
+}
+
+// getPodAnnotationIP returns the pod's IP address of the given address family from its network annotation.
+func getPodAnnotationIP(podAnnotation *util.PodAnnotation, addressFamily string) (string, error) {
+	for _, ipNet := range podAnnotation.IPs {
+		if getIPVer(ipNet.IP) == addressFamily {
+			return ipNet.IP.String(), nil
+		}
+	}
+	return "", fmt.Errorf("could not find desired pod ip address for the given address family in the pod network annotation")
+}
+
+// getLogicalSwitchPortNetwork returns the network name and topology of a user defined network logical switch port as
+// set in its external IDs by ovnkube-controller.
+func getLogicalSwitchPortNetwork(coreclient corev1client.CoreV1Interface, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo, portName string) (string, string, error) {
+	cmd := fmt.Sprintf(`ovn-nbctl --no-leader-only %s --bare --no-heading get Logical_Switch_Port %s 'external_ids:"%s"' 'external_ids:"%s"'`,
+		podInfo.NbCommand, portName, types.NetworkExternalID, types.TopologyExternalID)
+	stdout, stderr, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, cmd, "")
+	if err != nil {
+		return "", "", fmt.Errorf("execInPod() failed with %s stderr %s stdout %s", err, stderr, stdout)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 {
+		return "", "", fmt.Errorf("unexpected network external IDs %q for logical switch port %s", stdout, portName)
+	}
+	return strings.Trim(lines[0], "\""), strings.Trim(lines[1], "\""), nil
+}
+
+// isUserDefinedNetworkNAD returns true if the pod's primary network, as found in its annotation, is a user defined network.
+func (pi *PodInfo) isUserDefinedNetworkNAD() bool {
+	return pi.NADKey != "" && pi.NADKey != types.DefaultNetworkName
+}
+
+// isDefaultNetwork returns true if the pod is attached to the cluster default network.
+func (pi *PodInfo) isDefaultNetwork() bool {
+	return pi.NetworkName == "" || pi.NetworkName == types.DefaultNetworkName
+}
+
+// sameNetwork returns true if both pods are attached to the same primary network.
+func (pi *PodInfo) sameNetwork(other *PodInfo) bool {
+	return pi.isDefaultNetwork() && other.isDefaultNetwork() || pi.NetworkName == other.NetworkName
+}
+
+// networkScopedName returns the name of a logical entity of the pod's network, like util.NetInfo.GetNetworkScopedName.
+func (pi *PodInfo) networkScopedName(name string) string {
+	if pi.isDefaultNetwork() {
+		return name
+	}
+	return util.GetUserDefinedNetworkPrefix(pi.NetworkName) + name
+}
+
+// logicalSwitchName returns the name of the logical switch the pod is attached to, like
+// util.NetInfo.GetNetworkScopedSwitchName.
+func (pi *PodInfo) logicalSwitchName() string {
+	if pi.Topology == types.Layer2Topology {
+		return pi.networkScopedName(types.OVNLayer2Switch)
+	}
+	return pi.networkScopedName(pi.NodeName)
+}
+
+// routerToSwitchPortName returns the name of the router port that is the first hop of the pod, like
+// util.NetInfo.GetNetworkScopedRouterToSwitchPortName.
+func (pi *PodInfo) routerToSwitchPortName() string {
+	if pi.Topology == types.Layer2Topology {
+		return types.TransitRouterToSwitchPrefix + pi.logicalSwitchName()
+	}
+	return types.RouterToSwitchPrefix + pi.logicalSwitchName()
+}
+
+// transitSwitchToRouterPortName returns the name of the transit switch port connected to the router of the pod's node.
+func (pi *PodInfo) transitSwitchToRouterPortName() string {
+	return pi.networkScopedName(types.TransitSwitchToRouterPrefix + pi.NodeName)
+}
+
+// routerToTransitSwitchPortName returns the name of the router port of the pod's node connected to the transit switch.
+func (pi *PodInfo) routerToTransitSwitchPortName() string {
+	return pi.networkScopedName(types.RouterToTransitSwitchPrefix + pi.NodeName)
+}
+
+// k8sMgmtPortName returns the name of the logical switch port of the management port of the pod's node.
+func (pi *PodInfo) k8sMgmtPortName() string {
+	return util.GetK8sMgmtIntfName(pi.networkScopedName(pi.NodeName))
+}
+
+// connectRouterToNetworkRouterPortName returns the name of the port of the ClusterNetworkConnect's connect router towards
+// the router of the pod's network. Layer3 networks have one such port per node.
+func (pi *PodInfo) connectRouterToNetworkRouterPortName(cncName string) string {
+	if pi.Topology == types.Layer2Topology {
+		return types.ConnectRouterToRouterPrefix + cncName + "_" + pi.NetworkName
+	}
+	return types.ConnectRouterToRouterPrefix + cncName + "_" + pi.NetworkName + "_" + pi.NodeName
+}
+
+// networkRouterToConnectRouterPortName returns the name of the port of the router of the pod's network towards the
+// ClusterNetworkConnect's connect router. Layer3 networks have one such port per node.
+func (pi *PodInfo) networkRouterToConnectRouterPortName(cncName string) string {
+	if pi.Topology == types.Layer2Topology {
+		return types.RouterToConnectRouterPrefix + pi.NetworkName + "_" + cncName
+	}
+	return types.RouterToConnectRouterPrefix + pi.NetworkName + "_" + pi.NodeName + "_" + cncName
+}
+
+// findClusterNetworkConnect returns the name of the ClusterNetworkConnect that joins the primary networks of both pods, or
+// "" if the pods are on the same network or their networks are not connected.
+func findClusterNetworkConnect(cncClient networkconnectclientset.Interface, srcPodInfo, dstPodInfo *PodInfo) (string, error) {
+	if srcPodInfo.sameNetwork(dstPodInfo) || srcPodInfo.isDefaultNetwork() || dstPodInfo.isDefaultNetwork() {
+		return "", nil
+	}
+	srcOwner, err := networkOwner(srcPodInfo)
+	if err != nil {
+		return "", err
+	}
+	dstOwner, err := networkOwner(dstPodInfo)
+	if err != nil {
+		return "", err
+	}
+
+	cncs, err := cncClient.K8sV1().ClusterNetworkConnects().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list ClusterNetworkConnects: %w", err)
+	}
+	for i := range cncs.Items {
+		cnc := &cncs.Items[i]
+		subnets, err := util.ParseNetworkConnectSubnetAnnotation(cnc)
+		if err != nil {
+			klog.V(5).Infof("Skipping ClusterNetworkConnect %s: %v", cnc.Name, err)
+			continue
+		}
+		_, hasSrc := subnets[srcOwner]
+		_, hasDst := subnets[dstOwner]
+		if hasSrc && hasDst {
+			return cnc.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// getNetworkID returns the ID of the given network from the node's k8s.ovn.org/network-ids annotation.
+func getNetworkID(coreclient corev1client.CoreV1Interface, nodeName, networkName string) (string, error) {
+	node, err := coreclient.Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	networkIDs, err := findUDNNetworks(node)
+	if err != nil {
+		return "", err
+	}
+	networkID, ok := networkIDs[networkName]
+	if !ok {
+		return "", fmt.Errorf("could not find the network ID of network %s on node %s", networkName, nodeName)
+	}
+	return networkID, nil
+}
+
+// networkOwner returns the owner key used by ClusterNetworkConnect annotations for the pod's network, e.g. "layer3_1".
+func networkOwner(podInfo *PodInfo) (string, error) {
+	id, err := strconv.Atoi(podInfo.NetworkID)
+	if err != nil {
+		return "", fmt.Errorf("unexpected networkID '%s' for network %s: %w", podInfo.NetworkID, podInfo.NetworkName, err)
+	}
+	return util.ComputeNetworkOwner(podInfo.Topology, id), nil
+}
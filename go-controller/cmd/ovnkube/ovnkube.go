@@ -325,7 +325,8 @@ func startOvnKube(ctx *cli.Context, cancel context.CancelFunc) error {
 	// Non LE master instances also are required to expose the metrics server.
 	if config.Metrics.BindAddress != "" && !combineMetricsEndpoints(runMode) {
 		metrics.StartMetricsServer(config.Metrics.BindAddress, config.Metrics.EnablePprof,
-			config.Metrics.NodeServerCert, config.Metrics.NodeServerPrivKey, ctx.Done(), ovnKubeStartWg)
+			runMode.node && config.Metrics.EnableNodeDebug, config.Metrics.NodeServerCert,
+			config.Metrics.NodeServerPrivKey, ovnClientset.KubeClient, ctx.Done(), ovnKubeStartWg)
 	}
 
 	// no need for leader election in node mode
@@ -637,6 +638,7 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 				// Reuse the default registry (and its gatherer) so ovnkube-node metrics and OVN metrics share one endpoint.
 				opts.Registerer = prometheus.DefaultRegisterer
 				opts.EnablePprof = config.Metrics.EnablePprof
+				opts.EnableNodeDebug = config.Metrics.EnableNodeDebug
 			}
 
 			if !config.OVNKubernetesFeature.EnableInterconnect {
@@ -646,6 +648,18 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 				opts.EnableOVNDBMetrics = false
 			}
 
+			if config.Metrics.EnableACLStats && config.OVNKubernetesFeature.EnableInterconnect {
+				// ACL stats map OpenFlow flows to ACLs of the local zone, only available with interconnect
+				nbClient, err := libovsdb.NewNBACLClient(ctx.Done())
+				if err != nil {
+					klog.Errorf("Not registering ACL stats metrics, failed to initialize libovsdb NB client: %v", err)
+				} else {
+					opts.EnableACLStatsMetrics = true
+					opts.ACLStatsMaxSeries = config.Metrics.ACLStatsMaxSeries
+					opts.NBClient = nbClient
+				}
+			}
+
 			metricsServer := metrics.StartOVNMetricsServer(opts, ovsClient, ovnClientset.KubeClient, ctx.Done(), wg)
 
 			if !config.OVNKubernetesFeature.EnableInterconnect {
@@ -129,8 +129,12 @@ sed -i -e':begin;$!N;s/.*metadata:\n.*type: object/&\n            properties:\n
 echo "Copying the CRDs to dist/templates as j2 files... Add them to your commit..."
 echo "Copying egressFirewall CRD"
 cp _output/crds/k8s.ovn.org_egressfirewalls.yaml ../dist/templates/k8s.ovn.org_egressfirewalls.yaml.j2
+echo "Copying clusterEgressFirewall CRD"
+cp _output/crds/k8s.ovn.org_clusteregressfirewalls.yaml ../dist/templates/k8s.ovn.org_clusteregressfirewalls.yaml.j2
 echo "Copying egressIP CRD"
 cp _output/crds/k8s.ovn.org_egressips.yaml ../dist/templates/k8s.ovn.org_egressips.yaml.j2
+echo "Copying egressIPPool CRD"
+cp _output/crds/k8s.ovn.org_egressippools.yaml ../dist/templates/k8s.ovn.org_egressippools.yaml.j2
 echo "Copying egressQoS CRD"
 cp _output/crds/k8s.ovn.org_egressqoses.yaml ../dist/templates/k8s.ovn.org_egressqoses.yaml.j2
 echo "Copying adminpolicybasedexternalroutes CRD"
@@ -149,3 +153,5 @@ echo "Copying clusterNetworkConnect CRD"
 cp _output/crds/k8s.ovn.org_clusternetworkconnects.yaml ../dist/templates/k8s.ovn.org_clusternetworkconnects.yaml.j2
 echo "Copying vtep CRD"
 cp _output/crds/k8s.ovn.org_vteps.yaml ../dist/templates/k8s.ovn.org_vteps.yaml.j2
+echo "Copying networkObservability CRD"
+cp _output/crds/k8s.ovn.org_networkobservabilities.yaml ../dist/templates/k8s.ovn.org_networkobservabilities.yaml.j2
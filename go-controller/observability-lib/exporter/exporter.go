@@ -0,0 +1,112 @@
+// Package exporter ships decoded network events to external systems.
+package exporter
+
+import (
+	"time"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/model"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/sampledecoder"
+)
+
+// Event is a decoded sample enriched with local pod data.
+type Event struct {
+	Time time.Time
+	// NetworkEvent is nil if the sample couldn't be decoded.
+	NetworkEvent model.NetworkEvent
+	SrcIP        string
+	DstIP        string
+	// Src and Dst are only set for local pods.
+	Src *sampledecoder.PodInterface
+	Dst *sampledecoder.PodInterface
+}
+
+// Exporter exports network events.
+type Exporter interface {
+	Export(event *Event) error
+	// Close flushes pending events and releases resources.
+	Close() error
+}
+
+// eventField is a named Event field, shared by exporters to keep the same set of fields.
+type eventField struct {
+	name  string
+	value func(e *Event) string
+}
+
+// eventACL returns the ACL event, or the ACL of a drop event, nil if the event is not related to an ACL.
+func eventACL(e *Event) *model.ACLEvent {
+	switch event := e.NetworkEvent.(type) {
+	case *model.ACLEvent:
+		return event
+	case *model.DropEvent:
+		return event.ACL
+	}
+	return nil
+}
+
+func aclEventField(value func(e *model.ACLEvent) string) func(e *Event) string {
+	return func(e *Event) string {
+		if aclEvent := eventACL(e); aclEvent != nil {
+			return value(aclEvent)
+		}
+		return ""
+	}
+}
+
+func dropEventField(value func(e *model.DropEvent) string) func(e *Event) string {
+	return func(e *Event) string {
+		if dropEvent, ok := e.NetworkEvent.(*model.DropEvent); ok {
+			return value(dropEvent)
+		}
+		return ""
+	}
+}
+
+func verdict(e *Event) string {
+	if _, ok := e.NetworkEvent.(*model.DropEvent); ok {
+		return model.VerdictDrop
+	}
+	if aclEvent := eventACL(e); aclEvent != nil {
+		return aclEvent.Verdict()
+	}
+	return ""
+}
+
+func podField(pod func(e *Event) *sampledecoder.PodInterface, value func(p *sampledecoder.PodInterface) string) func(e *Event) string {
+	return func(e *Event) string {
+		if p := pod(e); p != nil {
+			return value(p)
+		}
+		return ""
+	}
+}
+
+func srcPod(e *Event) *sampledecoder.PodInterface { return e.Src }
+func dstPod(e *Event) *sampledecoder.PodInterface { return e.Dst }
+
+// eventFields are exported by every exporter in the given order, IP addresses are handled separately,
+// since exporters encode them differently. New fields must be added in the end, IPFIX elements are numbered after them.
+var eventFields = []eventField{
+	{"verdict", verdict},
+	{"action", aclEventField(func(e *model.ACLEvent) string { return e.Action })},
+	{"actor", aclEventField(func(e *model.ACLEvent) string { return e.Actor })},
+	{"policy.name", aclEventField(func(e *model.ACLEvent) string { return e.Name })},
+	{"policy.namespace", aclEventField(func(e *model.ACLEvent) string { return e.Namespace })},
+	{"direction", aclEventField(func(e *model.ACLEvent) string { return e.Direction })},
+	{"message", message},
+	{"source.namespace", podField(srcPod, func(p *sampledecoder.PodInterface) string { return p.Namespace })},
+	{"source.pod", podField(srcPod, func(p *sampledecoder.PodInterface) string { return p.Pod })},
+	{"source.udn", podField(srcPod, func(p *sampledecoder.PodInterface) string { return p.UDN })},
+	{"destination.namespace", podField(dstPod, func(p *sampledecoder.PodInterface) string { return p.Namespace })},
+	{"destination.pod", podField(dstPod, func(p *sampledecoder.PodInterface) string { return p.Pod })},
+	{"destination.udn", podField(dstPod, func(p *sampledecoder.PodInterface) string { return p.UDN })},
+	{"drop.stage", dropEventField(func(e *model.DropEvent) string { return e.Stage })},
+	{"drop.datapath", dropEventField(func(e *model.DropEvent) string { return e.Datapath })},
+}
+
+func message(e *Event) string {
+	if e.NetworkEvent == nil {
+		return "sample could not be decoded"
+	}
+	return e.NetworkEvent.String()
+}
@@ -0,0 +1,222 @@
+package exporter
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/model"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/sampledecoder"
+)
+
+func newTestEvent(srcIP, dstIP string) *Event {
+	return &Event{
+		Time: time.UnixMilli(1700000000123),
+		NetworkEvent: &model.ACLEvent{
+			Action:    "drop",
+			Actor:     "NetworkPolicy",
+			Name:      "deny-all",
+			Namespace: "ns1",
+			Direction: "Ingress",
+		},
+		SrcIP: srcIP,
+		DstIP: dstIP,
+		Dst: &sampledecoder.PodInterface{
+			Namespace: "ns1",
+			Pod:       "pod1",
+			UDN:       "ns1/udn1",
+		},
+	}
+}
+
+// readVariableLengthString decodes a variable-length IE and returns it with the remaining bytes.
+func readVariableLengthString(t *testing.T, b []byte) (string, []byte) {
+	length := int(b[0])
+	b = b[1:]
+	if length == 255 {
+		length = int(binary.BigEndian.Uint16(b))
+		b = b[2:]
+	}
+	require.GreaterOrEqual(t, len(b), length)
+	return string(b[:length]), b[length:]
+}
+
+func TestIPFIXExporter(t *testing.T) {
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+	e, err := NewIPFIXExporter(collector.LocalAddr().String(), 7, IPFIXEnterpriseNumber)
+	require.NoError(t, err)
+	defer e.Close()
+
+	readMessage := func() []byte {
+		buf := make([]byte, 65535)
+		require.NoError(t, collector.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := collector.ReadFrom(buf)
+		require.NoError(t, err)
+		msg := buf[:n]
+		require.Equal(t, uint16(ipfixVersion), binary.BigEndian.Uint16(msg[0:]))
+		require.Equal(t, uint16(n), binary.BigEndian.Uint16(msg[2:]))
+		require.Equal(t, uint32(7), binary.BigEndian.Uint32(msg[12:]))
+		return msg
+	}
+
+	// first message has templates
+	require.NoError(t, e.Export(newTestEvent("10.0.0.1", "10.0.0.2")))
+	msg := readMessage()
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:]))
+	set := msg[16:]
+	require.Equal(t, uint16(ipfixTemplateSetID), binary.BigEndian.Uint16(set[0:]))
+	templateSetLen := binary.BigEndian.Uint16(set[2:])
+	require.Equal(t, uint16(ipfixIPv4TemplateID), binary.BigEndian.Uint16(set[4:]))
+	assert.Equal(t, uint16(3+len(eventFields)), binary.BigEndian.Uint16(set[6:]))
+	// enterprise-specific field specifier
+	assert.Equal(t, uint16(ipfixEnterpriseBit|1), binary.BigEndian.Uint16(set[20:]))
+	assert.Equal(t, uint16(ipfixVariableLength), binary.BigEndian.Uint16(set[22:]))
+	assert.Equal(t, uint32(IPFIXEnterpriseNumber), binary.BigEndian.Uint32(set[24:]))
+
+	set = set[templateSetLen:]
+	require.Equal(t, uint16(ipfixIPv4TemplateID), binary.BigEndian.Uint16(set[0:]))
+	require.Equal(t, int(binary.BigEndian.Uint16(set[2:])), len(set))
+	record := set[4:]
+	assert.Equal(t, uint64(1700000000123), binary.BigEndian.Uint64(record))
+	assert.Equal(t, net.ParseIP("10.0.0.1").To4(), net.IP(record[8:12]))
+	assert.Equal(t, net.ParseIP("10.0.0.2").To4(), net.IP(record[12:16]))
+	values := map[string]string{}
+	record = record[16:]
+	for _, field := range eventFields {
+		values[field.name], record = readVariableLengthString(t, record)
+	}
+	assert.Empty(t, record)
+	assert.Equal(t, model.VerdictDrop, values["verdict"])
+	assert.Equal(t, "NetworkPolicy", values["actor"])
+	assert.Equal(t, "deny-all", values["policy.name"])
+	assert.Equal(t, "pod1", values["destination.pod"])
+	assert.Equal(t, "ns1/udn1", values["destination.udn"])
+	assert.Equal(t, "", values["source.pod"])
+
+	// templates are not re-sent, IPv6 template is used for IPv6 addresses
+	require.NoError(t, e.Export(newTestEvent("fd00::1", "fd00::2")))
+	msg = readMessage()
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(msg[8:]))
+	set = msg[16:]
+	require.Equal(t, uint16(ipfixIPv6TemplateID), binary.BigEndian.Uint16(set[0:]))
+	require.Equal(t, int(binary.BigEndian.Uint16(set[2:])), len(set))
+	assert.Equal(t, net.ParseIP("fd00::1"), net.IP(set[12:28]))
+
+	require.Error(t, e.Export(newTestEvent("", "10.0.0.2")))
+}
+
+func TestAppendVariableLengthString(t *testing.T) {
+	for _, length := range []int{0, 254, 255, ipfixMaxStringLen, ipfixMaxStringLen + 1} {
+		s := strings.Repeat("a", length)
+		decoded, rest := readVariableLengthString(t, appendVariableLengthString(nil, s))
+		assert.Equal(t, s[:min(length, ipfixMaxStringLen)], decoded)
+		assert.Empty(t, rest)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var lock sync.Mutex
+	var requests []otlpLogsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, otlpLogsPath, r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := otlpLogsRequest{}
+		assert.NoError(t, json.Unmarshal(body, &req))
+		lock.Lock()
+		requests = append(requests, req)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	e := NewOTLPExporter(server.URL+"/", map[string]string{"service.name": "test"}, func(err error) {
+		t.Errorf("unexpected error: %v", err)
+	})
+	require.NoError(t, e.Export(newTestEvent("10.0.0.1", "10.0.0.2")))
+	require.NoError(t, e.Export(&Event{Time: time.Now(), SrcIP: "10.0.0.3", DstIP: "10.0.0.4"}))
+	require.NoError(t, e.Close())
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].ResourceLogs, 1)
+	resourceLogs := requests[0].ResourceLogs[0]
+	assert.Equal(t, []otlpKeyValue{otlpString("service.name", "test")}, resourceLogs.Resource.Attributes)
+	require.Len(t, resourceLogs.ScopeLogs, 1)
+	records := resourceLogs.ScopeLogs[0].LogRecords
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "1700000000123000000", records[0].TimeUnixNano)
+	assert.Equal(t, otlpSeverityWarn, records[0].SeverityNumber)
+	assert.Equal(t, "Dropped by network policy deny-all in namespace ns1, direction Ingress", records[0].Body.StringValue)
+	assert.Contains(t, records[0].Attributes, otlpString("source.address", "10.0.0.1"))
+	assert.Contains(t, records[0].Attributes, otlpString("ovn.verdict", model.VerdictDrop))
+	assert.Contains(t, records[0].Attributes, otlpString("ovn.destination.udn", "ns1/udn1"))
+	assert.NotContains(t, records[0].Attributes, otlpString("ovn.source.udn", ""))
+
+	assert.Equal(t, otlpSeverityInfo, records[1].SeverityNumber)
+	assert.Equal(t, "sample could not be decoded", records[1].Body.StringValue)
+	assert.Equal(t, []otlpKeyValue{
+		otlpString("source.address", "10.0.0.3"),
+		otlpString("destination.address", "10.0.0.4"),
+	}, records[1].Attributes)
+}
+
+func TestOTLPExporterError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	e := NewOTLPExporter(server.URL, nil, nil)
+	require.NoError(t, e.Export(newTestEvent("10.0.0.1", "10.0.0.2")))
+	require.ErrorContains(t, e.Close(), "503")
+}
+
+func TestMetricsExporter(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	e, err := NewMetricsExporter(registry)
+	require.NoError(t, err)
+
+	require.NoError(t, e.Export(newTestEvent("10.0.0.1", "10.0.0.2")))
+	require.NoError(t, e.Export(newTestEvent("10.0.0.1", "10.0.0.3")))
+	allowed := newTestEvent("10.0.0.1", "10.0.0.2")
+	allowed.NetworkEvent.(*model.ACLEvent).Action = "allow-related"
+	require.NoError(t, e.Export(allowed))
+	require.NoError(t, e.Export(&Event{}))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	counters := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := []string{family.GetName()}
+			for _, label := range metric.GetLabel() {
+				labels = append(labels, label.GetName()+"="+label.GetValue())
+			}
+			counters[strings.Join(labels, ",")] = metric.GetCounter().GetValue()
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"ovnkube_observ_network_events_total,actor=NetworkPolicy,direction=Ingress,name=deny-all,namespace=ns1,verdict=drop":  2,
+		"ovnkube_observ_network_events_total,actor=NetworkPolicy,direction=Ingress,name=deny-all,namespace=ns1,verdict=allow": 1,
+		"ovnkube_observ_undecoded_network_events_total":                                                                       1,
+	}, counters)
+
+	_, err = NewMetricsExporter(registry)
+	require.Error(t, err)
+}
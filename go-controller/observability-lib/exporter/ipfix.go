@@ -0,0 +1,178 @@
+package exporter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// IPFIX (RFC 7011) constants
+const (
+	ipfixVersion       = 10
+	ipfixTemplateSetID = 2
+	// template IDs must be > 255
+	ipfixIPv4TemplateID = 256
+	ipfixIPv6TemplateID = 257
+
+	ipfixEnterpriseBit  = 0x8000
+	ipfixVariableLength = 0xFFFF
+
+	// IANA information elements
+	ieFlowStartMilliseconds    = 152
+	ieSourceIPv4Address        = 8
+	ieDestinationIPv4Address   = 12
+	ieSourceIPv6Address        = 27
+	ieDestinationIPv6Address   = 28
+	ieFlowStartMillisecondsLen = 8
+
+	// IPFIXEnterpriseNumber is the default Private Enterprise Number (Red Hat) used for the
+	// enterprise-specific information elements, numbered after eventFields starting from 1.
+	IPFIXEnterpriseNumber = 2312
+	// ipfixMaxStringLen limits the length of exported strings to keep messages under the UDP datagram size.
+	ipfixMaxStringLen = 1024
+	// ipfixTemplateRefreshInterval is how often templates are re-sent, UDP collectors may miss them
+	// or be restarted.
+	ipfixTemplateRefreshInterval = time.Minute
+)
+
+// IPFIXExporter exports events as IPFIX data records over UDP.
+// Every event is sent in a separate message.
+type IPFIXExporter struct {
+	lock                sync.Mutex
+	conn                net.Conn
+	observationDomainID uint32
+	enterpriseNumber    uint32
+	sequenceNumber      uint32
+	lastTemplateSent    time.Time
+	templates           []byte
+}
+
+// NewIPFIXExporter creates an IPFIXExporter sending to the given collector address (host:port).
+func NewIPFIXExporter(collectorAddress string, observationDomainID, enterpriseNumber uint32) (*IPFIXExporter, error) {
+	conn, err := net.Dial("udp", collectorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to IPFIX collector %s: %w", collectorAddress, err)
+	}
+	e := &IPFIXExporter{
+		conn:                conn,
+		observationDomainID: observationDomainID,
+		enterpriseNumber:    enterpriseNumber,
+	}
+	e.templates = e.templateSet()
+	return e, nil
+}
+
+func (e *IPFIXExporter) Export(event *Event) error {
+	data, err := e.dataSet(event)
+	if err != nil {
+		return err
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	now := time.Now()
+	sets := [][]byte{data}
+	if now.Sub(e.lastTemplateSent) >= ipfixTemplateRefreshInterval {
+		sets = [][]byte{e.templates, data}
+	}
+	msg := e.message(now, sets...)
+	if _, err = e.conn.Write(msg); err != nil {
+		return fmt.Errorf("failed to send IPFIX message: %w", err)
+	}
+	if len(sets) == 2 {
+		e.lastTemplateSent = now
+	}
+	// sequence number counts data records only
+	e.sequenceNumber++
+	return nil
+}
+
+func (e *IPFIXExporter) Close() error {
+	return e.conn.Close()
+}
+
+// message builds an IPFIX message from the given sets.
+func (e *IPFIXExporter) message(exportTime time.Time, sets ...[]byte) []byte {
+	length := 16
+	for _, set := range sets {
+		length += len(set)
+	}
+	msg := make([]byte, 16, length)
+	binary.BigEndian.PutUint16(msg[0:], ipfixVersion)
+	binary.BigEndian.PutUint16(msg[2:], uint16(length))
+	binary.BigEndian.PutUint32(msg[4:], uint32(exportTime.Unix()))
+	binary.BigEndian.PutUint32(msg[8:], e.sequenceNumber)
+	binary.BigEndian.PutUint32(msg[12:], e.observationDomainID)
+	for _, set := range sets {
+		msg = append(msg, set...)
+	}
+	return msg
+}
+
+// templateSet returns the template set with both IPv4 and IPv6 templates.
+func (e *IPFIXExporter) templateSet() []byte {
+	set := make([]byte, 4)
+	set = e.appendTemplate(set, ipfixIPv4TemplateID, ieSourceIPv4Address, ieDestinationIPv4Address, net.IPv4len)
+	set = e.appendTemplate(set, ipfixIPv6TemplateID, ieSourceIPv6Address, ieDestinationIPv6Address, net.IPv6len)
+	binary.BigEndian.PutUint16(set[0:], ipfixTemplateSetID)
+	binary.BigEndian.PutUint16(set[2:], uint16(len(set)))
+	return set
+}
+
+func (e *IPFIXExporter) appendTemplate(set []byte, templateID, srcIE, dstIE uint16, ipLen int) []byte {
+	set = binary.BigEndian.AppendUint16(set, templateID)
+	set = binary.BigEndian.AppendUint16(set, uint16(3+len(eventFields)))
+	set = binary.BigEndian.AppendUint16(set, ieFlowStartMilliseconds)
+	set = binary.BigEndian.AppendUint16(set, ieFlowStartMillisecondsLen)
+	set = binary.BigEndian.AppendUint16(set, srcIE)
+	set = binary.BigEndian.AppendUint16(set, uint16(ipLen))
+	set = binary.BigEndian.AppendUint16(set, dstIE)
+	set = binary.BigEndian.AppendUint16(set, uint16(ipLen))
+	for i := range eventFields {
+		set = binary.BigEndian.AppendUint16(set, ipfixEnterpriseBit|uint16(i+1))
+		set = binary.BigEndian.AppendUint16(set, ipfixVariableLength)
+		set = binary.BigEndian.AppendUint32(set, e.enterpriseNumber)
+	}
+	return set
+}
+
+// dataSet returns a data set with a single data record for the given event.
+func (e *IPFIXExporter) dataSet(event *Event) ([]byte, error) {
+	srcIP := net.ParseIP(event.SrcIP)
+	dstIP := net.ParseIP(event.DstIP)
+	if srcIP == nil || dstIP == nil {
+		return nil, fmt.Errorf("invalid IP addresses src=%q dst=%q", event.SrcIP, event.DstIP)
+	}
+	templateID := uint16(ipfixIPv6TemplateID)
+	if srcIP.To4() != nil && dstIP.To4() != nil {
+		templateID = ipfixIPv4TemplateID
+		srcIP, dstIP = srcIP.To4(), dstIP.To4()
+	} else {
+		srcIP, dstIP = srcIP.To16(), dstIP.To16()
+	}
+	set := make([]byte, 4)
+	set = binary.BigEndian.AppendUint64(set, uint64(event.Time.UnixMilli()))
+	set = append(set, srcIP...)
+	set = append(set, dstIP...)
+	for _, field := range eventFields {
+		set = appendVariableLengthString(set, field.value(event))
+	}
+	binary.BigEndian.PutUint16(set[0:], templateID)
+	binary.BigEndian.PutUint16(set[2:], uint16(len(set)))
+	return set, nil
+}
+
+// appendVariableLengthString encodes a variable-length IE as defined in RFC 7011 section 7.
+func appendVariableLengthString(b []byte, s string) []byte {
+	if len(s) > ipfixMaxStringLen {
+		s = s[:ipfixMaxStringLen]
+	}
+	if len(s) < 255 {
+		b = append(b, byte(len(s)))
+	} else {
+		b = append(b, 255)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	}
+	return append(b, s...)
+}
@@ -0,0 +1,64 @@
+package exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/model"
+)
+
+const metricsNamespace = "ovnkube_observ"
+
+// dropActor is the actor label value for packets dropped by OVN logical flows not generated for ACLs.
+const dropActor = "OVN"
+
+// MetricsExporter counts events per policy and verdict as Prometheus metrics.
+type MetricsExporter struct {
+	events          *prometheus.CounterVec
+	undecodedEvents prometheus.Counter
+}
+
+// NewMetricsExporter creates a MetricsExporter and registers its metrics with the given registerer.
+func NewMetricsExporter(registerer prometheus.Registerer) (*MetricsExporter, error) {
+	e := &MetricsExporter{
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "network_events_total",
+			Help:      "The total number of sampled network events per policy and verdict.",
+		}, []string{"verdict", "actor", "namespace", "name", "direction"}),
+		undecodedEvents: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "undecoded_network_events_total",
+			Help:      "The total number of sampled network events that could not be decoded.",
+		}),
+	}
+	for _, collector := range []prometheus.Collector{e.events, e.undecodedEvents} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func (e *MetricsExporter) Export(event *Event) error {
+	switch networkEvent := event.NetworkEvent.(type) {
+	case *model.ACLEvent:
+		e.events.WithLabelValues(networkEvent.Verdict(), networkEvent.Actor, networkEvent.Namespace, networkEvent.Name,
+			networkEvent.Direction).Inc()
+	case *model.DropEvent:
+		if networkEvent.ACL != nil {
+			aclEvent := networkEvent.ACL
+			e.events.WithLabelValues(model.VerdictDrop, aclEvent.Actor, aclEvent.Namespace, aclEvent.Name,
+				aclEvent.Direction).Inc()
+		} else {
+			// drops not caused by ACLs are counted per logical pipeline stage
+			e.events.WithLabelValues(model.VerdictDrop, dropActor, "", networkEvent.Stage, "").Inc()
+		}
+	default:
+		e.undecodedEvents.Inc()
+	}
+	return nil
+}
+
+func (e *MetricsExporter) Close() error {
+	return nil
+}
@@ -0,0 +1,232 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/model"
+)
+
+const (
+	otlpLogsPath      = "/v1/logs"
+	otlpScopeName     = "ovnkube-observ"
+	otlpBatchSize     = 512
+	otlpMaxQueueSize  = 10 * otlpBatchSize
+	otlpFlushInterval = 5 * time.Second
+	otlpTimeout       = 10 * time.Second
+	// https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-severitynumber
+	otlpSeverityInfo = 9
+	otlpSeverityWarn = 13
+)
+
+// OTLP/HTTP JSON encoding of the logs protocol, only fields used by the exporter are defined.
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 otlpAnyValue   `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// OTLPExporter exports events as OpenTelemetry log records over OTLP/HTTP with JSON encoding.
+// Events are batched and sent every otlpFlushInterval, or as soon as otlpBatchSize events are queued.
+type OTLPExporter struct {
+	url                string
+	client             *http.Client
+	resourceAttributes []otlpKeyValue
+	// errorFunc is called for asynchronous send errors
+	errorFunc func(error)
+
+	lock    sync.Mutex
+	records []otlpLogRecord
+
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewOTLPExporter creates an OTLPExporter sending to the given collector endpoint, e.g. http://collector:4318.
+// resourceAttributes are added to the resource of every request, errorFunc is called for send errors.
+func NewOTLPExporter(endpoint string, resourceAttributes map[string]string, errorFunc func(error)) *OTLPExporter {
+	e := &OTLPExporter{
+		url:       strings.TrimSuffix(endpoint, "/") + otlpLogsPath,
+		client:    &http.Client{Timeout: otlpTimeout},
+		errorFunc: errorFunc,
+		flushCh:   make(chan struct{}, 1),
+		stopCh:    make(chan struct{}),
+	}
+	for _, key := range slices.Sorted(maps.Keys(resourceAttributes)) {
+		e.resourceAttributes = append(e.resourceAttributes, otlpString(key, resourceAttributes[key]))
+	}
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		e.run()
+	}()
+	return e
+}
+
+func (e *OTLPExporter) Export(event *Event) error {
+	record := newOTLPLogRecord(event)
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if len(e.records) >= otlpMaxQueueSize {
+		return fmt.Errorf("OTLP queue is full, dropping event")
+	}
+	e.records = append(e.records, record)
+	if len(e.records) >= otlpBatchSize {
+		select {
+		case e.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close sends the queued events and stops the exporter.
+func (e *OTLPExporter) Close() error {
+	close(e.stopCh)
+	e.wg.Wait()
+	return e.flush()
+}
+
+func (e *OTLPExporter) run() {
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+		case <-e.flushCh:
+		}
+		if err := e.flush(); err != nil && e.errorFunc != nil {
+			e.errorFunc(err)
+		}
+	}
+}
+
+func (e *OTLPExporter) flush() error {
+	e.lock.Lock()
+	records := e.records
+	e.records = nil
+	e.lock.Unlock()
+	for len(records) > 0 {
+		batch := records[:min(len(records), otlpBatchSize)]
+		records = records[len(batch):]
+		if err := e.send(batch); err != nil {
+			return fmt.Errorf("failed to send %d events to %s: %w", len(batch)+len(records), e.url, err)
+		}
+	}
+	return nil
+}
+
+func (e *OTLPExporter) send(records []otlpLogRecord) error {
+	body, err := json.Marshal(otlpLogsRequest{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: otlpResource{Attributes: e.resourceAttributes},
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: otlpScopeName},
+				LogRecords: records,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), otlpTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body to reuse the connection
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+func newOTLPLogRecord(event *Event) otlpLogRecord {
+	record := otlpLogRecord{
+		TimeUnixNano:         strconv.FormatInt(event.Time.UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(time.Now().UnixNano(), 10),
+		SeverityNumber:       otlpSeverityInfo,
+		SeverityText:         "INFO",
+		Body:                 otlpAnyValue{StringValue: message(event)},
+	}
+	record.Attributes = append(record.Attributes,
+		otlpString("source.address", event.SrcIP),
+		otlpString("destination.address", event.DstIP))
+	for _, field := range eventFields {
+		if field.name == "message" {
+			// message is the record body
+			continue
+		}
+		value := field.value(event)
+		if value == "" {
+			continue
+		}
+		if field.name == "verdict" && value == model.VerdictDrop {
+			record.SeverityNumber = otlpSeverityWarn
+			record.SeverityText = "WARN"
+		}
+		record.Attributes = append(record.Attributes, otlpString("ovn."+field.name, value))
+	}
+	return record
+}
+
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: value}}
+}
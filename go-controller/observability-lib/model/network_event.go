@@ -10,6 +10,7 @@ const (
 
 	// libovsdb constants: see also github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops
 	egressFirewallOwnerType             = "EgressFirewall"
+	clusterEgressFirewallOwnerType      = "ClusterEgressFirewall"
 	adminNetworkPolicyOwnerType         = "AdminNetworkPolicy"
 	baselineAdminNetworkPolicyOwnerType = "BaselineAdminNetworkPolicy"
 	networkPolicyOwnerType              = "NetworkPolicy"
@@ -41,6 +42,27 @@ type ACLEvent struct {
 	Direction string
 }
 
+// Verdicts returned by ACLEvent.Verdict
+const (
+	VerdictAllow = "allow"
+	VerdictDrop  = "drop"
+	VerdictPass  = "pass"
+)
+
+// Verdict returns the ACL action normalized to VerdictAllow, VerdictDrop or VerdictPass.
+// Unknown actions are returned as is.
+func (e *ACLEvent) Verdict() string {
+	switch e.Action {
+	case aclActionAllow, aclActionAllowRelated, aclActionAllowStateless:
+		return VerdictAllow
+	case aclActionDrop, aclActionReject:
+		return VerdictDrop
+	case aclActionPass:
+		return VerdictPass
+	}
+	return e.Action
+}
+
 func (e *ACLEvent) String() string {
 	var action string
 	switch e.Action {
@@ -75,8 +97,34 @@ func (e *ACLEvent) String() string {
 		msg = fmt.Sprintf("network policies isolation in namespace %s, direction %s", e.Namespace, e.Direction)
 	case egressFirewallOwnerType:
 		msg = fmt.Sprintf("egress firewall in namespace %s", e.Namespace)
+	case clusterEgressFirewallOwnerType:
+		msg = fmt.Sprintf("cluster egress firewall %s in namespace %s", e.Name, e.Namespace)
 	case udnIsolationOwnerType:
 		msg = fmt.Sprintf("UDN isolation of type %s", e.Name)
 	}
 	return fmt.Sprintf("%s by %s", action, msg)
 }
+
+// DropEvent is generated for packets dropped by OVN logical flows, when OVN debug drop sampling is enabled.
+type DropEvent struct {
+	// Stage is the logical pipeline stage of the dropping logical flow, e.g. ls_in_acl_eval
+	Stage string
+	// Datapath is the logical switch or router name
+	Datapath string
+	// ACL is set when the dropping logical flow was generated for an ACL.
+	ACL *ACLEvent
+	// Object describes the OVN object the dropping logical flow was generated for, when it is not an ACL.
+	// Empty if unknown.
+	Object string
+}
+
+func (e *DropEvent) String() string {
+	if e.ACL != nil {
+		return fmt.Sprintf("%s (stage %s of %s)", e.ACL.String(), e.Stage, e.Datapath)
+	}
+	msg := fmt.Sprintf("Dropped by OVN in stage %s of %s", e.Stage, e.Datapath)
+	if e.Object != "" {
+		msg += ", generated for " + e.Object
+	}
+	return msg
+}
@@ -9,6 +9,7 @@ import (
 
 var mapping = map[string]string{
 	egressFirewallOwnerType:             libovsdbops.EgressFirewallOwnerType,
+	clusterEgressFirewallOwnerType:      libovsdbops.ClusterEgressFirewallOwnerType,
 	adminNetworkPolicyOwnerType:         libovsdbops.AdminNetworkPolicyOwnerType,
 	baselineAdminNetworkPolicyOwnerType: libovsdbops.BaselineAdminNetworkPolicyOwnerType,
 	networkPolicyOwnerType:              libovsdbops.NetworkPolicyOwnerType,
@@ -33,3 +34,33 @@ func TestConstantsMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestDropEventString(t *testing.T) {
+	for _, tc := range []struct {
+		event    *DropEvent
+		expected string
+	}{
+		{
+			event:    &DropEvent{Stage: "ls_in_port_sec_l2", Datapath: "node1"},
+			expected: "Dropped by OVN in stage ls_in_port_sec_l2 of node1",
+		},
+		{
+			event:    &DropEvent{Stage: "lr_in_policy", Datapath: "ovn_cluster_router", Object: "logical router policy for EgressIP eip1"},
+			expected: "Dropped by OVN in stage lr_in_policy of ovn_cluster_router, generated for logical router policy for EgressIP eip1",
+		},
+		{
+			event: &DropEvent{Stage: "ls_in_acl_eval", Datapath: "node1", ACL: &ACLEvent{
+				Action:    aclActionDrop,
+				Actor:     networkPolicyOwnerType,
+				Name:      "deny",
+				Namespace: "ns1",
+				Direction: "Ingress",
+			}},
+			expected: "Dropped by network policy deny in namespace ns1, direction Ingress (stage ls_in_acl_eval of node1)",
+		},
+	} {
+		if s := tc.event.String(); s != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, s)
+		}
+	}
+}
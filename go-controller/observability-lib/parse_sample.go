@@ -11,6 +11,7 @@ import (
 	"os"
 	"strings"
 	"syscall"
+	"time"
 	"unsafe"
 
 	"github.com/google/gopacket"
@@ -19,7 +20,9 @@ import (
 	"github.com/vishvananda/netlink/nl"
 	"golang.org/x/sys/unix"
 
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/exporter"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/sampledecoder"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/observability"
 )
 
 const (
@@ -57,8 +60,17 @@ type SampleReader struct {
 
 	decoder   *sampledecoder.SampleDecoder
 	cookieStr []string
+
+	exporters    []exporter.Exporter
+	dropSampling bool
+	// podInterfaces maps local pod IPs to their interfaces, refreshed every podInterfacesRefreshInterval
+	podInterfaces        map[string]*sampledecoder.PodInterface
+	podInterfacesUpdated time.Time
 }
 
+// podInterfacesRefreshInterval is how often local pod interfaces used for events enrichment are refreshed.
+const podInterfacesRefreshInterval = 30 * time.Second
+
 func NewSampleReader(enableDecoder, logCookie, printFullPacket, addOVSCollector bool, srcIP, dstIP, outputFile string) *SampleReader {
 	r := &SampleReader{
 		enableDecoder:   enableDecoder,
@@ -75,7 +87,24 @@ func NewSampleReader(enableDecoder, logCookie, printFullPacket, addOVSCollector
 	return r
 }
 
+// SetExporters configures exporters that every sample is sent to. Exported events are enriched with
+// local pod data when the decoder is enabled. Samples are only printed when an output file is set.
+// Exporters are not closed by the SampleReader.
+func (r *SampleReader) SetExporters(exporters ...exporter.Exporter) {
+	r.exporters = exporters
+}
+
+// EnableDropSampling makes the reader enable OVN drop sampling to the default collector set, so that every packet
+// dropped by OVN is sampled and decoded. Requires the decoder, and the default collector set, see addOVSCollector.
+// Drop sampling is disabled when ReadSamples returns.
+func (r *SampleReader) EnableDropSampling() {
+	r.dropSampling = true
+}
+
 func (r *SampleReader) ReadSamples(ctx context.Context) error {
+	if r.dropSampling && !r.enableDecoder {
+		return fmt.Errorf("drop sampling requires the decoder to be enabled")
+	}
 	if r.enableDecoder {
 		var err error
 		// currently only local nbdb connection is supported.
@@ -86,12 +115,29 @@ func (r *SampleReader) ReadSamples(ctx context.Context) error {
 				return fmt.Errorf("error creating decoder: %w", err)
 			}
 			defer r.decoder.Shutdown()
+		} else if len(r.exporters) > 0 {
+			// OVSDB client is needed for the pod data enrichment
+			r.decoder, err = sampledecoder.NewSampleDecoderWithOVSDB(ctx, nbdbSocketPath)
+			if err != nil {
+				return fmt.Errorf("error creating decoder: %w", err)
+			}
 		} else {
 			r.decoder, err = sampledecoder.NewSampleDecoder(ctx, nbdbSocketPath)
 			if err != nil {
 				return fmt.Errorf("error creating decoder: %w", err)
 			}
 		}
+		if r.dropSampling {
+			if !r.addOVSCollector {
+				defer r.decoder.Shutdown()
+			}
+			// currently only local sbdb connection is supported.
+			sbdbSocketPath := "/var/run/ovn/ovnsb_db.sock"
+			err = r.decoder.EnableDropSampling(ctx, sbdbSocketPath, observability.DefaultObservabilityCollectorSetID)
+			if err != nil {
+				return fmt.Errorf("error enabling drop sampling: %w", err)
+			}
+		}
 	}
 	var writer io.Writer
 	if r.outputFile != "" {
@@ -100,7 +146,11 @@ func (r *SampleReader) ReadSamples(ctx context.Context) error {
 			return fmt.Errorf("error creating output file: %w", err)
 		}
 		defer file.Close()
-		writer = bufio.NewWriter(file)
+		bufWriter := bufio.NewWriter(file)
+		defer bufWriter.Flush()
+		writer = bufWriter
+	} else if len(r.exporters) > 0 {
+		writer = io.Discard
 	} else {
 		writer = os.Stdout
 	}
@@ -108,6 +158,11 @@ func (r *SampleReader) ReadSamples(ctx context.Context) error {
 	printlnFunc := func(a ...any) {
 		l.Println(a...)
 	}
+	// errors are printed to stdout even when samples are not
+	errorFunc := printlnFunc
+	if writer == io.Discard {
+		errorFunc = log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lmicroseconds).Println
+	}
 
 	fam, err := netlink.GenlFamilyGet(PSAMPLE_GENL_NAME)
 	if err != nil {
@@ -151,11 +206,11 @@ func (r *SampleReader) ReadSamples(ctx context.Context) error {
 				if err == syscall.EAGAIN {
 					continue
 				}
-				printlnFunc("ERROR: receive failed:", err)
+				errorFunc("ERROR: receive failed:", err)
 				continue
 			}
-			if err = r.parseMsg(msgs, printlnFunc); err != nil {
-				printlnFunc("ERROR: ", err)
+			if err = r.parseMsg(msgs, printlnFunc, errorFunc); err != nil {
+				errorFunc("ERROR: ", err)
 			}
 		}
 	}
@@ -177,9 +232,11 @@ func getHostEndian() binary.ByteOrder {
 
 var hostEndian = getHostEndian()
 
-func (r *SampleReader) parseMsg(msgs []syscall.NetlinkMessage, printlnFunc func(a ...any)) error {
+func (r *SampleReader) parseMsg(msgs []syscall.NetlinkMessage, printlnFunc, errorFunc func(a ...any)) error {
 	for _, msg := range msgs {
 		var packetStr, sampleStr string
+		event := &exporter.Event{}
+		hasNetworkLayer := false
 		data := msg.Data[nl.SizeofGenlmsg:]
 		for attr := range nl.ParseAttributes(data) {
 			if r.logCookie && attr.Type == PSAMPLE_ATTR_SAMPLE_GROUP {
@@ -193,6 +250,9 @@ func (r *SampleReader) parseMsg(msgs []syscall.NetlinkMessage, printlnFunc func(
 					r.cookieStr[0] = fmt.Sprintf("group_id=%v", g)
 				}
 			}
+			if attr.Type == PSAMPLE_ATTR_TIMESTAMP && len(attr.Value) == 8 {
+				event.Time = time.Unix(0, int64(hostEndian.Uint64(attr.Value)))
+			}
 			if attr.Type == PSAMPLE_ATTR_USER_COOKIE && (r.logCookie || r.decoder != nil) {
 				if uint64(len(attr.Value)) == sampledecoder.CookieSize {
 					c := sampledecoder.Cookie{}
@@ -210,27 +270,32 @@ func (r *SampleReader) parseMsg(msgs []syscall.NetlinkMessage, printlnFunc func(
 							sampleStr = fmt.Sprintf("decoding failed: %v", err)
 						} else {
 							sampleStr = fmt.Sprintf("OVN-K message: %s", decoded.String())
+							event.NetworkEvent = decoded
 						}
 					}
 				}
 			}
 			if attr.Type == PSAMPLE_ATTR_DATA {
 				packet := gopacket.NewPacket(attr.Value, layers.LayerTypeEthernet, gopacket.Lazy)
-				networkLayer := packet.NetworkLayer().NetworkFlow()
+				if packet.NetworkLayer() != nil {
+					hasNetworkLayer = true
+					networkLayer := packet.NetworkLayer().NetworkFlow()
+					event.SrcIP = networkLayer.Src().String()
+					event.DstIP = networkLayer.Dst().String()
+				}
 				if r.printFullPacket {
 					packetStr = packet.String()
 				} else {
-					packetStr = fmt.Sprintf("src=%s, dst=%v\n",
-						networkLayer.Src().String(), networkLayer.Dst().String())
-				}
-				if r.srcIP != "" && r.srcIP != networkLayer.Src().String() {
-					return nil
-				}
-				if r.dstIP != "" && r.dstIP != networkLayer.Dst().String() {
-					return nil
+					packetStr = fmt.Sprintf("src=%s, dst=%v\n", event.SrcIP, event.DstIP)
 				}
 			}
 		}
+		if r.srcIP != "" && r.srcIP != event.SrcIP {
+			continue
+		}
+		if r.dstIP != "" && r.dstIP != event.DstIP {
+			continue
+		}
 		if r.logCookie {
 			printlnFunc(strings.Join(r.cookieStr, ", "))
 		}
@@ -238,6 +303,45 @@ func (r *SampleReader) parseMsg(msgs []syscall.NetlinkMessage, printlnFunc func(
 			printlnFunc(sampleStr)
 		}
 		printlnFunc(packetStr)
+		if len(r.exporters) > 0 && hasNetworkLayer {
+			r.export(event, errorFunc)
+		}
 	}
 	return nil
 }
+
+func (r *SampleReader) export(event *exporter.Event, errorFunc func(a ...any)) {
+	if event.Time.IsZero() {
+		// kernel doesn't report sample timestamps
+		event.Time = time.Now()
+	}
+	if r.decoder != nil {
+		r.refreshPodInterfaces(errorFunc)
+		event.Src = r.podInterfaces[event.SrcIP]
+		event.Dst = r.podInterfaces[event.DstIP]
+	}
+	for _, e := range r.exporters {
+		if err := e.Export(event); err != nil {
+			errorFunc("ERROR: export failed:", err)
+		}
+	}
+}
+
+func (r *SampleReader) refreshPodInterfaces(errorFunc func(a ...any)) {
+	if time.Since(r.podInterfacesUpdated) < podInterfacesRefreshInterval {
+		return
+	}
+	// don't retry on every sample if OVSDB is not available
+	r.podInterfacesUpdated = time.Now()
+	podIfaces, err := r.decoder.GetPodInterfaces()
+	if err != nil {
+		errorFunc("ERROR: failed to get pod interfaces:", err)
+		return
+	}
+	r.podInterfaces = map[string]*sampledecoder.PodInterface{}
+	for _, podIface := range podIfaces {
+		for _, ip := range podIface.IPs {
+			r.podInterfaces[ip] = podIface
+		}
+	}
+}
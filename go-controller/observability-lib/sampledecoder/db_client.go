@@ -15,6 +15,7 @@ import (
 
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/ovsdb"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/sbdb"
 )
 
 const OVSDBTimeout = 10 * time.Second
@@ -42,6 +43,14 @@ func NewNBClientWithConfig(ctx context.Context, cfg dbConfig) (client.Client, er
 		c.NewMonitor(
 			client.WithTable(&nbdb.ACL{}),
 			client.WithTable(&nbdb.Sample{}),
+			// tables below are used to decode drop samples
+			client.WithTable(&nbdb.NBGlobal{}),
+			client.WithTable(&nbdb.LogicalRouterPolicy{}),
+			client.WithTable(&nbdb.LogicalRouterStaticRoute{}),
+			client.WithTable(&nbdb.LogicalRouterPort{}),
+			client.WithTable(&nbdb.LogicalSwitchPort{}),
+			client.WithTable(&nbdb.LoadBalancer{}),
+			client.WithTable(&nbdb.NAT{}),
 		),
 	)
 
@@ -53,6 +62,31 @@ func NewNBClientWithConfig(ctx context.Context, cfg dbConfig) (client.Client, er
 	return c, nil
 }
 
+func NewSBClientWithConfig(ctx context.Context, cfg dbConfig) (client.Client, error) {
+	dbModel, err := sbdb.FullDatabaseModel()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := newClient(cfg, dbModel)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.Monitor(ctx,
+		c.NewMonitor(
+			client.WithTable(&sbdb.LogicalFlow{}),
+			client.WithTable(&sbdb.DatapathBinding{}),
+		),
+	)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
 func NewOVSDBClientWithConfig(ctx context.Context, cfg dbConfig) (client.Client, error) {
 	dbModel, err := ovsdb.ObservDatabaseModel()
 	if err != nil {
@@ -0,0 +1,241 @@
+package sampledecoder
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ovn-kubernetes/libovsdb/client"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/model"
+	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/observability"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/sbdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
+)
+
+const (
+	// NB_Global options enabling OVN debug drop sampling, see ovn-nb(5)
+	debugDropDomainIDOption     = "debug_drop_domain_id"
+	debugDropCollectorSetOption = "debug_drop_collector_set"
+
+	// Logical_Flow external IDs set by northd
+	lflowStageNameExternalID = "stage-name"
+	// stage-hint is the first 32 bits of the UUID of the NB object the logical flow was generated for
+	lflowStageHintExternalID = "stage-hint"
+
+	datapathNameExternalID = "name"
+
+	// datapath tunnel key is stored in the lower 24 bits of the observation domain ID
+	obsDomainDatapathMask = 0xFFFFFF
+)
+
+func getLocalSBClient(ctx context.Context, address string) (client.Client, error) {
+	config := dbConfig{
+		address: "unix:" + address,
+		scheme:  "unix",
+	}
+	libovsdbOvnSBClient, err := NewSBClientWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating libovsdb SB client: %w ", err)
+	}
+	return libovsdbOvnSBClient, nil
+}
+
+// EnableDropSampling connects to the local SBDB and configures OVN to sample every packet dropped by a logical flow
+// to the given collector set. Dropped packets are decoded as model.DropEvent.
+// If drop sampling is already enabled with a different collector set, an error is returned.
+// Shutdown should be called to disable drop sampling.
+func (d *SampleDecoder) EnableDropSampling(ctx context.Context, sbdbSocketPath string, collectorSetID int) error {
+	if d.sbClient == nil {
+		sbClient, err := getLocalSBClient(ctx, sbdbSocketPath)
+		if err != nil {
+			return err
+		}
+		d.sbClient = sbClient
+	}
+	nbGlobal, err := libovsdbops.GetNBGlobal(d.nbClient, &nbdb.NBGlobal{})
+	if err != nil {
+		return fmt.Errorf("failed to get NB_Global: %w", err)
+	}
+	domainID := strconv.Itoa(observability.DropSamplingID)
+	collectorSet := strconv.Itoa(collectorSetID)
+	if existing := nbGlobal.Options[debugDropCollectorSetOption]; existing != "" {
+		if existing != collectorSet || nbGlobal.Options[debugDropDomainIDOption] != domainID {
+			return fmt.Errorf("drop sampling is already enabled with collector set %s and domain ID %s",
+				existing, nbGlobal.Options[debugDropDomainIDOption])
+		}
+		// enabled by someone else, don't clean up
+		return nil
+	}
+	err = libovsdbops.UpdateNBGlobalSetOptions(d.nbClient, &nbdb.NBGlobal{
+		Options: map[string]string{
+			debugDropDomainIDOption:     domainID,
+			debugDropCollectorSetOption: collectorSet,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable drop sampling: %w", err)
+	}
+	d.cleanupDropSampling = true
+	return nil
+}
+
+func (d *SampleDecoder) disableDropSampling() error {
+	// empty values remove the options
+	return libovsdbops.UpdateNBGlobalSetOptions(d.nbClient, &nbdb.NBGlobal{
+		Options: map[string]string{
+			debugDropDomainIDOption:     "",
+			debugDropCollectorSetOption: "",
+		},
+	})
+}
+
+// decodeDropIDs decodes OVN debug drop samples. For those samples obsPointID is the first 32 bits of the
+// dropping logical flow UUID, and obsDomainID has the datapath tunnel key in the lower 24 bits.
+func (d *SampleDecoder) decodeDropIDs(obsDomainID, obsPointID uint32) (*model.DropEvent, error) {
+	if d.sbClient == nil {
+		return nil, fmt.Errorf("drop sampling is not enabled")
+	}
+	lflowUUIDPrefix := fmt.Sprintf("%08x", obsPointID)
+	lflows := []*sbdb.LogicalFlow{}
+	err := d.sbClient.WhereCache(func(lflow *sbdb.LogicalFlow) bool {
+		return strings.HasPrefix(lflow.UUID, lflowUUIDPrefix)
+	}).List(context.Background(), &lflows)
+	if err != nil {
+		return nil, fmt.Errorf("find logical flow failed: %w", err)
+	}
+	// 32 bits of UUID may collide, but it is unlikely and there is no way to tell which flow was hit.
+	if len(lflows) == 0 {
+		return nil, fmt.Errorf("logical flow %s not found", lflowUUIDPrefix)
+	}
+	lflow := lflows[0]
+	event := &model.DropEvent{
+		Stage:    lflow.ExternalIDs[lflowStageNameExternalID],
+		Datapath: d.getDatapathName(int(obsDomainID & obsDomainDatapathMask)),
+	}
+	if stageHint := lflow.ExternalIDs[lflowStageHintExternalID]; stageHint != "" {
+		if err = d.setDropEventObject(event, stageHint); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
+
+func (d *SampleDecoder) getDatapathName(tunnelKey int) string {
+	datapaths := []*sbdb.DatapathBinding{}
+	err := d.sbClient.WhereCache(func(datapath *sbdb.DatapathBinding) bool {
+		return datapath.TunnelKey == tunnelKey
+	}).List(context.Background(), &datapaths)
+	if err != nil || len(datapaths) != 1 || datapaths[0].ExternalIDs[datapathNameExternalID] == "" {
+		return fmt.Sprintf("datapath %d", tunnelKey)
+	}
+	return datapaths[0].ExternalIDs[datapathNameExternalID]
+}
+
+// setDropEventObject finds the NB object with the given UUID prefix and sets it on the event.
+func (d *SampleDecoder) setDropEventObject(event *model.DropEvent, uuidPrefix string) error {
+	acl, err := findByUUIDPrefix[nbdb.ACL](d.nbClient, uuidPrefix)
+	if err != nil {
+		return err
+	}
+	if acl != nil {
+		event.ACL, err = newACLEvent(acl)
+		if err != nil {
+			return fmt.Errorf("failed to build ACL network event: %w", err)
+		}
+		return nil
+	}
+	describers := []func() (string, error){
+		func() (string, error) {
+			return describeByUUIDPrefix(d.nbClient, uuidPrefix, func(o *nbdb.LogicalRouterPolicy) string {
+				return describeNBObject("logical router policy", "", o.ExternalIDs)
+			})
+		},
+		func() (string, error) {
+			return describeByUUIDPrefix(d.nbClient, uuidPrefix, func(o *nbdb.LogicalRouterStaticRoute) string {
+				return describeNBObject("logical router static route", o.IPPrefix, o.ExternalIDs)
+			})
+		},
+		func() (string, error) {
+			return describeByUUIDPrefix(d.nbClient, uuidPrefix, func(o *nbdb.NAT) string {
+				return describeNBObject("NAT", o.Type+" "+o.LogicalIP, o.ExternalIDs)
+			})
+		},
+		func() (string, error) {
+			return describeByUUIDPrefix(d.nbClient, uuidPrefix, func(o *nbdb.LoadBalancer) string {
+				return describeNBObject("load balancer", o.Name, o.ExternalIDs)
+			})
+		},
+		func() (string, error) {
+			return describeByUUIDPrefix(d.nbClient, uuidPrefix, func(o *nbdb.LogicalSwitchPort) string {
+				return describeNBObject("logical switch port", o.Name, o.ExternalIDs)
+			})
+		},
+		func() (string, error) {
+			return describeByUUIDPrefix(d.nbClient, uuidPrefix, func(o *nbdb.LogicalRouterPort) string {
+				return describeNBObject("logical router port", o.Name, o.ExternalIDs)
+			})
+		},
+	}
+	for _, describe := range describers {
+		desc, err := describe()
+		if err != nil {
+			return err
+		}
+		if desc != "" {
+			event.Object = desc
+			return nil
+		}
+	}
+	// unknown NB object, stage and datapath are still useful
+	return nil
+}
+
+// describeByUUIDPrefix returns the description of the first object of type T with the given UUID prefix,
+// or an empty string if not found.
+func describeByUUIDPrefix[T any, PT interface {
+	*T
+	GetUUID() string
+}](nbClient client.Client, uuidPrefix string, describe func(PT) string) (string, error) {
+	obj, err := findByUUIDPrefix[T, PT](nbClient, uuidPrefix)
+	if err != nil || obj == nil {
+		return "", err
+	}
+	return describe(obj), nil
+}
+
+// findByUUIDPrefix returns the first object of type T with the given UUID prefix, or nil if not found.
+func findByUUIDPrefix[T any, PT interface {
+	*T
+	GetUUID() string
+}](nbClient client.Client, uuidPrefix string) (PT, error) {
+	found := []PT{}
+	err := nbClient.WhereCache(func(obj PT) bool {
+		return strings.HasPrefix(obj.GetUUID(), uuidPrefix)
+	}).List(context.Background(), &found)
+	if err != nil {
+		var zero T
+		return nil, fmt.Errorf("find %T by UUID prefix %s failed: %w", zero, uuidPrefix, err)
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	return found[0], nil
+}
+
+// describeNBObject builds a readable description of an NB object with the Kubernetes object that owns it, if known.
+func describeNBObject(objType, name string, externalIDs map[string]string) string {
+	desc := objType
+	if name != "" {
+		desc += " " + name
+	}
+	if ownerType := externalIDs[libovsdbops.OwnerTypeKey.String()]; ownerType != "" {
+		desc += fmt.Sprintf(" for %s %s", ownerType, externalIDs[libovsdbops.ObjectNameKey.String()])
+	} else if kind := externalIDs[types.LoadBalancerKindExternalID]; kind != "" {
+		desc += fmt.Sprintf(" for %s %s", kind, externalIDs[types.LoadBalancerOwnerExternalID])
+	}
+	return desc
+}
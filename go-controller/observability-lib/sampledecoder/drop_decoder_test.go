@@ -0,0 +1,151 @@
+package sampledecoder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovn-kubernetes/libovsdb/client"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/model"
+	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/observability"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/sbdb"
+	libovsdbtest "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+)
+
+func newTestDropDecoder(t *testing.T, nbGlobalOptions map[string]string) *SampleDecoder {
+	aclUUID := "a1a1a1a1-0000-0000-0000-000000000001"
+	acl := &nbdb.ACL{
+		UUID:      aclUUID,
+		Action:    nbdb.ACLActionDrop,
+		Direction: nbdb.ACLDirectionToLport,
+		Match:     "ip4",
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():       libovsdbops.NetworkPolicyOwnerType,
+			libovsdbops.ObjectNameKey.String():      "ns1:deny",
+			libovsdbops.PolicyDirectionKey.String(): string(libovsdbutil.ACLIngress),
+		},
+	}
+	nbClient, sbClient, cleanup, err := libovsdbtest.NewNBSBTestHarness(libovsdbtest.TestSetup{
+		NBData: []libovsdbtest.TestData{
+			&nbdb.NBGlobal{UUID: "nb-global", Options: nbGlobalOptions},
+			acl,
+			// ACLs are garbage collected unless referenced
+			&nbdb.PortGroup{UUID: "pg", Name: "pg", ACLs: []string{aclUUID}},
+			&nbdb.LogicalRouterPolicy{
+				UUID:     "b2b2b2b2-0000-0000-0000-000000000001",
+				Priority: 100,
+				Match:    "ip4.src == 10.244.0.5",
+				Action:   nbdb.LogicalRouterPolicyActionDrop,
+				ExternalIDs: map[string]string{
+					libovsdbops.OwnerTypeKey.String():  libovsdbops.EgressIPOwnerType,
+					libovsdbops.ObjectNameKey.String(): "eip1",
+				},
+			},
+			&nbdb.LogicalRouter{
+				UUID:     "router",
+				Name:     "ovn_cluster_router",
+				Policies: []string{"b2b2b2b2-0000-0000-0000-000000000001"},
+			},
+		},
+		SBData: []libovsdbtest.TestData{
+			&sbdb.DatapathBinding{
+				UUID:        "dp",
+				TunnelKey:   5,
+				ExternalIDs: map[string]string{datapathNameExternalID: "node1"},
+			},
+			&sbdb.LogicalFlow{
+				UUID:     "11111111-0000-0000-0000-000000000001",
+				Pipeline: sbdb.LogicalFlowPipelineIngress,
+				ExternalIDs: map[string]string{
+					lflowStageNameExternalID: "ls_in_acl_eval",
+					lflowStageHintExternalID: "a1a1a1a1",
+				},
+			},
+			&sbdb.LogicalFlow{
+				UUID:     "22222222-0000-0000-0000-000000000001",
+				Pipeline: sbdb.LogicalFlowPipelineIngress,
+				ExternalIDs: map[string]string{
+					lflowStageNameExternalID: "lr_in_policy",
+					lflowStageHintExternalID: "b2b2b2b2",
+				},
+			},
+			&sbdb.LogicalFlow{
+				UUID:     "33333333-0000-0000-0000-000000000001",
+				Pipeline: sbdb.LogicalFlowPipelineIngress,
+				ExternalIDs: map[string]string{
+					lflowStageNameExternalID: "ls_in_port_sec_l2",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(cleanup.Cleanup)
+	// test harness SB client doesn't monitor logical flows
+	_, err = sbClient.Monitor(t.Context(), sbClient.NewMonitor(client.WithTable(&sbdb.LogicalFlow{})))
+	require.NoError(t, err)
+	return &SampleDecoder{nbClient: nbClient, sbClient: sbClient}
+}
+
+func TestDecodeDropSamples(t *testing.T) {
+	decoder := newTestDropDecoder(t, nil)
+	obsDomainID := uint32(observability.DropSamplingID)<<24 | 5
+
+	event, err := decoder.DecodeCookieIDs(obsDomainID, 0x11111111)
+	require.NoError(t, err)
+	assert.Equal(t, &model.DropEvent{
+		Stage:    "ls_in_acl_eval",
+		Datapath: "node1",
+		ACL: &model.ACLEvent{
+			Action:    nbdb.ACLActionDrop,
+			Actor:     libovsdbops.NetworkPolicyOwnerType,
+			Name:      "deny",
+			Namespace: "ns1",
+			Direction: string(libovsdbutil.ACLIngress),
+		},
+	}, event)
+
+	event, err = decoder.DecodeCookieIDs(obsDomainID, 0x22222222)
+	require.NoError(t, err)
+	assert.Equal(t, "Dropped by OVN in stage lr_in_policy of node1, generated for logical router policy for EgressIP eip1",
+		event.String())
+
+	// unknown datapath
+	event, err = decoder.DecodeCookieIDs(uint32(observability.DropSamplingID)<<24|6, 0x33333333)
+	require.NoError(t, err)
+	assert.Equal(t, "Dropped by OVN in stage ls_in_port_sec_l2 of datapath 6", event.String())
+
+	_, err = decoder.DecodeCookieIDs(obsDomainID, 0x44444444)
+	require.ErrorContains(t, err, "logical flow 44444444 not found")
+
+	_, err = (&SampleDecoder{}).DecodeCookieIDs(obsDomainID, 0x11111111)
+	require.ErrorContains(t, err, "drop sampling is not enabled")
+}
+
+func TestEnableDropSampling(t *testing.T) {
+	decoder := newTestDropDecoder(t, map[string]string{"foo": "bar"})
+	require.NoError(t, decoder.EnableDropSampling(t.Context(), "", 42))
+	nbGlobal, err := libovsdbops.GetNBGlobal(decoder.nbClient, &nbdb.NBGlobal{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"foo":                       "bar",
+		debugDropDomainIDOption:     "1",
+		debugDropCollectorSetOption: "42",
+	}, nbGlobal.Options)
+
+	decoder.Shutdown()
+	nbGlobal, err = libovsdbops.GetNBGlobal(decoder.nbClient, &nbdb.NBGlobal{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"foo": "bar"}, nbGlobal.Options)
+
+	// enabled by someone else
+	decoder = newTestDropDecoder(t, map[string]string{debugDropDomainIDOption: "1", debugDropCollectorSetOption: "42"})
+	require.NoError(t, decoder.EnableDropSampling(t.Context(), "", 42))
+	assert.False(t, decoder.cleanupDropSampling)
+	require.ErrorContains(t, decoder.EnableDropSampling(t.Context(), "", 43),
+		"drop sampling is already enabled with collector set 42")
+}
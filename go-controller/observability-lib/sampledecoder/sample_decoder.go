@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/ovn-kubernetes/libovsdb/client"
@@ -21,6 +22,9 @@ type SampleDecoder struct {
 	nbClient          client.Client
 	ovsdbClient       client.Client
 	cleanupCollectors []int
+	// sbClient is only initialized with drop sampling enabled, see EnableDropSampling
+	sbClient            client.Client
+	cleanupDropSampling bool
 }
 
 type dbConfig struct {
@@ -101,7 +105,29 @@ func NewSampleDecoder(ctx context.Context, nbdbSocketPath string) (*SampleDecode
 	}, nil
 }
 
+// NewSampleDecoderWithOVSDB creates a new SampleDecoder and initializes the NBDB and OVSDB clients.
+// OVSDB client is required to enrich samples with local pod interfaces data, see GetPodInterfaces.
+func NewSampleDecoderWithOVSDB(ctx context.Context, nbdbSocketPath string) (*SampleDecoder, error) {
+	nbClient, err := getLocalNBClient(ctx, nbdbSocketPath)
+	if err != nil {
+		return nil, err
+	}
+	ovsdbClient, err := getLocalOVSDBClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &SampleDecoder{
+		nbClient:    nbClient,
+		ovsdbClient: ovsdbClient,
+	}, nil
+}
+
 func (d *SampleDecoder) Shutdown() {
+	if d.cleanupDropSampling {
+		if err := d.disableDropSampling(); err != nil {
+			fmt.Printf("Error disabling drop sampling: %v", err)
+		}
+	}
 	for _, collectorID := range d.cleanupCollectors {
 		err := d.DeleteCollector(collectorID)
 		if err != nil {
@@ -122,6 +148,10 @@ func findACLBySample(nbClient client.Client, acl *nbdb.ACL) ([]*nbdb.ACL, error)
 }
 
 func (d *SampleDecoder) DecodeCookieIDs(obsDomainID, obsPointID uint32) (model.NetworkEvent, error) {
+	if getObservAppID(obsDomainID) == observability.DropSamplingID {
+		// drop samples are not using nbdb Samples
+		return d.decodeDropIDs(obsDomainID, obsPointID)
+	}
 	// Find sample using obsPointID
 	sample, err := libovsdbops.FindSample(d.nbClient, int(obsPointID))
 	if err != nil || sample == nil {
@@ -197,6 +227,10 @@ func newACLEvent(o *nbdb.ACL) (*model.ACLEvent, error) {
 	case libovsdbops.EgressFirewallOwnerType:
 		event.Namespace = o.ExternalIDs[libovsdbops.ObjectNameKey.String()]
 		event.Direction = "Egress"
+	case libovsdbops.ClusterEgressFirewallOwnerType:
+		event.Name = o.ExternalIDs[libovsdbops.ObjectNameKey.String()]
+		event.Namespace = o.ExternalIDs[libovsdbops.NamespaceKey.String()]
+		event.Direction = "Egress"
 	case libovsdbops.UDNIsolationOwnerType:
 		event.Name = o.ExternalIDs[libovsdbops.ObjectNameKey.String()]
 	case libovsdbops.NetpolNodeOwnerType:
@@ -310,6 +344,9 @@ func networkNameToUDNNamespacedName(networkName string) string {
 // default network or NAD that is not created by (C)UDN is represented by an empty string.
 // UDN namespace+name are joined by "/", CUDN will just have a name.
 func (d *SampleDecoder) GetInterfaceUDNs() (map[string]string, error) {
+	if d.ovsdbClient == nil {
+		return nil, fmt.Errorf("OVSDB client is not initialized")
+	}
 	res := map[string]string{}
 	ifaces := []*ovsdb.Interface{}
 	err := d.ovsdbClient.List(context.Background(), &ifaces)
@@ -329,3 +366,64 @@ func (d *SampleDecoder) GetInterfaceUDNs() (map[string]string, error) {
 	}
 	return res, nil
 }
+
+// PodInterface describes a local pod interface.
+type PodInterface struct {
+	// Name is the OVS interface name
+	Name      string
+	Namespace string
+	Pod       string
+	// UDN is the (C)UDN namespaced name, see GetInterfaceUDNs.
+	UDN string
+	IPs []string
+}
+
+// GetPodInterfaces returns all local pod interfaces.
+func (d *SampleDecoder) GetPodInterfaces() ([]*PodInterface, error) {
+	if d.ovsdbClient == nil {
+		return nil, fmt.Errorf("OVSDB client is not initialized")
+	}
+	ifaces := []*ovsdb.Interface{}
+	err := d.ovsdbClient.List(context.Background(), &ifaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing interfaces: %w", err)
+	}
+	res := make([]*PodInterface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		podIface := newPodInterface(iface)
+		if podIface != nil {
+			res = append(res, podIface)
+		}
+	}
+	return res, nil
+}
+
+func newPodInterface(iface *ovsdb.Interface) *PodInterface {
+	ifaceID := iface.ExternalIDs["iface-id"]
+	if iface.ExternalIDs["iface-id-ver"] == "" || ifaceID == "" {
+		// not a pod interface
+		return nil
+	}
+	podIface := &PodInterface{
+		Name: iface.Name,
+	}
+	// iface-id is <namespace>_<pod> for the default network, and is prefixed with "<network prefix>_"
+	// for user-defined networks. Namespace and pod names can't contain "_".
+	if parts := strings.Split(ifaceID, "_"); len(parts) >= 2 {
+		podIface.Namespace = parts[len(parts)-2]
+		podIface.Pod = parts[len(parts)-1]
+	}
+	if network := iface.ExternalIDs["k8s.ovn.org/network"]; network != "" {
+		podIface.UDN = networkNameToUDNNamespacedName(network)
+	}
+	if ipAddresses := iface.ExternalIDs["ip_addresses"]; ipAddresses != "" {
+		for _, ipAddress := range strings.Split(ipAddresses, ",") {
+			ip, _, err := net.ParseCIDR(ipAddress)
+			if err != nil {
+				continue
+			}
+			podIface.IPs = append(podIface.IPs, ip.String())
+		}
+	}
+	return podIface
+}
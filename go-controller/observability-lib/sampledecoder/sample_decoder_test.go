@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/observability-lib/ovsdb"
 	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
@@ -66,3 +67,39 @@ func TestCreateOrUpdateACL(t *testing.T) {
 	assert.Equal(t, "Allowed by default allow from local node policy, direction Ingress", event.String())
 	assert.Equal(t, "Ingress", event.Direction)
 }
+
+func TestNewPodInterface(t *testing.T) {
+	assert.Nil(t, newPodInterface(&ovsdb.Interface{Name: "ovn-k8s-mp0"}))
+
+	podIface := newPodInterface(&ovsdb.Interface{
+		Name: "abcd1234",
+		ExternalIDs: map[string]string{
+			"iface-id":     "ns1_pod1",
+			"iface-id-ver": "uid",
+			"ip_addresses": "10.244.0.5/24,fd00:10:244::5/64",
+		},
+	})
+	assert.Equal(t, &PodInterface{
+		Name:      "abcd1234",
+		Namespace: "ns1",
+		Pod:       "pod1",
+		IPs:       []string{"10.244.0.5", "fd00:10:244::5"},
+	}, podIface)
+
+	podIface = newPodInterface(&ovsdb.Interface{
+		Name: "abcd5678",
+		ExternalIDs: map[string]string{
+			"iface-id":            "ns1_udn1_ns1_pod1",
+			"iface-id-ver":        "uid",
+			"ip_addresses":        "10.128.0.5/16",
+			"k8s.ovn.org/network": "ns1_udn1",
+		},
+	})
+	assert.Equal(t, &PodInterface{
+		Name:      "abcd5678",
+		Namespace: "ns1",
+		Pod:       "pod1",
+		UDN:       "ns1/udn1",
+		IPs:       []string{"10.128.0.5"},
+	}, podIface)
+}
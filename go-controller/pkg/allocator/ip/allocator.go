@@ -35,6 +35,8 @@ type StaticAllocator interface {
 	CIDR() net.IPNet
 	Has(ip net.IP) bool
 	Reserved(ip net.IP) bool
+	Free() int
+	Used() int
 }
 
 // ContinuousAllocator extends StaticAllocator with next-available allocation support.
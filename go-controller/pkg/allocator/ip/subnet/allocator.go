@@ -38,6 +38,24 @@ type Allocator interface {
 	ConditionalIPRelease(name string, ips []*net.IPNet, predicate func() (bool, error)) (bool, error)
 	ForSubnet(name string) NamedAllocator
 	GetSubnetName(subnets []*net.IPNet) (string, bool)
+	Usage(name string) ([]SubnetUsage, error)
+}
+
+// SubnetUsage reports the IP allocation usage of one of the subnets managed
+// for a given name
+type SubnetUsage struct {
+	// Subnet is the CIDR of the IPAM instance
+	Subnet *net.IPNet
+	// Reserved is true when Subnet is a reserved subnet that only supports
+	// static allocations
+	Reserved bool
+	// Size is the number of IPs of Subnet that can be allocated
+	Size int
+	// Allocated is the number of IPs currently allocated in Subnet
+	Allocated int
+	// Excluded is the number of IPs of Subnet withheld from allocation, like
+	// those of reserved, infrastructure or excluded subnets
+	Excluded int
 }
 
 // NamedAllocator manages the allocation of IPs within a specific subnet
@@ -59,6 +77,10 @@ type subnetInfo struct {
 	ipams []ipallocator.ContinuousAllocator
 	// staticIPAMs holds static IP allocators for reserved subnets that support static IP allocation (currently only supported for Layer2 primary networks)
 	staticIPAMs []ipallocator.StaticAllocator
+	// excluded and staticExcluded hold the number of IPs excluded from
+	// allocation in each of ipams and staticIPAMs respectively
+	excluded       []int
+	staticExcluded []int
 }
 
 type continuousIPAMFactoryFunc func(*net.IPNet) (ipallocator.ContinuousAllocator, error)
@@ -143,7 +165,13 @@ func (allocator *allocator) AddOrUpdateSubnet(config SubnetConfig) error {
 		}
 	}
 
+	excluded := make([]int, 0, len(ipams))
+	for _, ipam := range ipams {
+		excluded = append(excluded, ipam.Used())
+	}
+
 	var staticIPAMs []ipallocator.StaticAllocator
+	var staticExcluded []int
 	for _, reservedSubnet := range config.ReservedSubnets {
 		ipam, err := allocator.reservedIPAMFunc(reservedSubnet)
 		if err != nil {
@@ -159,11 +187,14 @@ func (allocator *allocator) AddOrUpdateSubnet(config SubnetConfig) error {
 				}
 			}
 		}
+		staticExcluded = append(staticExcluded, ipam.Used())
 	}
 	allocator.cache[config.Name] = subnetInfo{
-		subnets:     config.Subnets,
-		ipams:       ipams,
-		staticIPAMs: staticIPAMs,
+		subnets:        config.Subnets,
+		ipams:          ipams,
+		staticIPAMs:    staticIPAMs,
+		excluded:       excluded,
+		staticExcluded: staticExcluded,
 	}
 	return nil
 }
@@ -476,6 +507,37 @@ func (allocator *allocator) GetSubnetName(subnets []*net.IPNet) (string, bool) {
 	return "", false
 }
 
+// Usage returns the IP allocation usage of each of the subnets of the given
+// subnet set, including reserved subnets.
+func (allocator *allocator) Usage(name string) ([]SubnetUsage, error) {
+	allocator.RLock()
+	defer allocator.RUnlock()
+	subnetInfo, ok := allocator.cache[name]
+	if !ok {
+		return nil, fmt.Errorf("failed to get IP usage for %s: %w", name, ErrSubnetNotFound)
+	}
+
+	usage := make([]SubnetUsage, 0, len(subnetInfo.ipams)+len(subnetInfo.staticIPAMs))
+	for i, ipam := range subnetInfo.ipams {
+		usage = append(usage, newSubnetUsage(ipam, false, subnetInfo.excluded[i]))
+	}
+	for i, ipam := range subnetInfo.staticIPAMs {
+		usage = append(usage, newSubnetUsage(ipam, true, subnetInfo.staticExcluded[i]))
+	}
+	return usage, nil
+}
+
+func newSubnetUsage(ipam ipallocator.StaticAllocator, reserved bool, excluded int) SubnetUsage {
+	cidr := ipam.CIDR()
+	return SubnetUsage{
+		Subnet:    &cidr,
+		Reserved:  reserved,
+		Size:      ipam.Free() + ipam.Used() - excluded,
+		Allocated: ipam.Used() - excluded,
+		Excluded:  excluded,
+	}
+}
+
 type IPAllocator struct {
 	allocator *allocator
 	name      string
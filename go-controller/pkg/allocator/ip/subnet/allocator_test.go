@@ -334,6 +334,60 @@ var _ = ginkgo.Describe("Subnet IP allocator operations", func() {
 		gomega.Expect(err).To(gomega.HaveOccurred())
 	})
 
+	ginkgo.It("reports IP usage of dynamic and reserved subnets", func() {
+		subnets := []string{
+			"10.1.1.0/24",
+		}
+		reservedSubnets := []string{
+			"10.1.1.0/28", // Reserve 10.1.1.0-15
+		}
+		excludeSubnets := []string{
+			"10.1.1.200/29", // Exclude 10.1.1.200-207
+		}
+
+		_, err := allocator.Usage(subnetName)
+		gomega.Expect(err).To(gomega.MatchError(ErrSubnetNotFound))
+
+		err = allocator.AddOrUpdateSubnet(SubnetConfig{
+			Name:            subnetName,
+			Subnets:         ovntest.MustParseIPNets(subnets...),
+			ExcludeSubnets:  ovntest.MustParseIPNets(excludeSubnets...),
+			ReservedSubnets: ovntest.MustParseIPNets(reservedSubnets...),
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		_, err = allocator.AllocateNextIPs(subnetName)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = allocator.AllocateIPPerSubnet(subnetName, ovntest.MustParseIPNets("10.1.1.5/24"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		usage, err := allocator.Usage(subnetName)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(usage).To(gomega.Equal([]SubnetUsage{
+			{
+				// 254 usable IPs minus 10.1.1.1-15 and 10.1.1.200-207
+				Subnet:    ovntest.MustParseIPNet("10.1.1.0/24"),
+				Size:      231,
+				Allocated: 1,
+				Excluded:  23,
+			},
+			{
+				// 16 IPs minus the network address
+				Subnet:    ovntest.MustParseIPNet("10.1.1.0/28"),
+				Reserved:  true,
+				Size:      15,
+				Allocated: 1,
+				Excluded:  1,
+			},
+		}))
+
+		err = allocator.ReleaseIPs(subnetName, ovntest.MustParseIPNets("10.1.1.5/24"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		usage, err = allocator.Usage(subnetName)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(usage[1].Allocated).To(gomega.BeZero())
+	})
+
 })
 
 func TestSubnetIPAllocator(t *testing.T) {
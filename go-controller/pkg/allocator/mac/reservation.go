@@ -9,6 +9,7 @@ import (
 type Register interface {
 	Reserve(owner string, mac net.HardwareAddr) error
 	Release(owner string, mac net.HardwareAddr) error
+	Count() int
 }
 
 // ReservationManager tracks reserved MAC addresses requests of pods and detect MAC conflicts,
@@ -72,3 +73,11 @@ func (n *ReservationManager) Release(owner string, mac net.HardwareAddr) error {
 
 	return nil
 }
+
+// Count returns the number of reserved MAC addresses.
+func (n *ReservationManager) Count() int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return len(n.store)
+}
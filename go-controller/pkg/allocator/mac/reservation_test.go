@@ -70,4 +70,15 @@ var _ = Describe("ReservationManager", func() {
 	It("release non reserved mac should succeed (no-op)", func() {
 		Expect(testMgr.Release(owner1, mac1)).To(Succeed())
 	})
+
+	It("should count reserved macs", func() {
+		Expect(testMgr.Count()).To(BeZero())
+		Expect(testMgr.Reserve(owner1, mac1)).To(Succeed())
+		Expect(testMgr.Reserve(owner1, mac1)).To(MatchError(mac.ErrMACReserved))
+		Expect(testMgr.Count()).To(Equal(1))
+		Expect(testMgr.Release(owner2, mac1)).To(MatchError(mac.ErrReleaseMismatchOwner))
+		Expect(testMgr.Count()).To(Equal(1))
+		Expect(testMgr.Release(owner1, mac1)).To(Succeed())
+		Expect(testMgr.Count()).To(BeZero())
+	})
 })
@@ -66,6 +66,15 @@ func (allocator *PodAnnotationAllocator) ReleasePodReservedMacAddress(pod *corev
 	return nil
 }
 
+// ReservedMACCount returns the number of MAC addresses reserved in the network
+// and whether the network tracks MAC reservations at all.
+func (allocator *PodAnnotationAllocator) ReservedMACCount() (int, bool) {
+	if allocator.macRegistry == nil {
+		return 0, false
+	}
+	return allocator.macRegistry.Count(), true
+}
+
 // InitializeMACRegistry initializes MAC reservation tracker with MAC addresses in use in the network.
 func (allocator *PodAnnotationAllocator) InitializeMACRegistry() error {
 	networkName := allocator.netInfo.GetNetworkName()
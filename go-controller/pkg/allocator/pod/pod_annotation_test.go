@@ -118,6 +118,10 @@ func (m *macRegistryStub) Release(_ string, mac net.HardwareAddr) error {
 	return nil
 }
 
+func (m *macRegistryStub) Count() int {
+	return 0
+}
+
 func Test_allocatePodAnnotationWithRollback(t *testing.T) {
 	randomMac, err := util.GenerateRandMAC()
 	if err != nil {
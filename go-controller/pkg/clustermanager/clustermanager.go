@@ -16,6 +16,7 @@ import (
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/clustermanager/dnsnameresolver"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/clustermanager/egressservice"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/clustermanager/endpointslicemirror"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/clustermanager/ipsec"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/clustermanager/networkconnect"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/clustermanager/nooverlay"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/clustermanager/routeadvertisements"
@@ -67,6 +68,8 @@ type ClusterManager struct {
 
 	raController        *routeadvertisements.Controller
 	noOverlayController *nooverlay.Controller
+	// Controller signing the IPsec certificates of the nodes
+	ipsecController *ipsec.Controller
 }
 
 // NewClusterManager creates a new cluster manager to manage the cluster nodes.
@@ -195,6 +198,13 @@ func NewClusterManager(
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableIPsec {
+		cm.ipsecController, err = ipsec.NewController(ovnClient.KubeClient, wf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create IPsec certificate signer: %w", err)
+		}
+	}
+
 	return cm, nil
 }
 
@@ -274,6 +284,12 @@ func (cm *ClusterManager) Start(ctx context.Context) error {
 		}
 	}
 
+	if cm.ipsecController != nil {
+		if err := cm.ipsecController.Start(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -310,6 +326,9 @@ func (cm *ClusterManager) Stop() {
 		cm.noOverlayController.Stop()
 		cm.noOverlayController = nil
 	}
+	if cm.ipsecController != nil {
+		cm.ipsecController.Stop()
+	}
 }
 
 func (cm *ClusterManager) NewNetworkController(netInfo util.NetInfo) (networkmanager.NetworkController, error) {
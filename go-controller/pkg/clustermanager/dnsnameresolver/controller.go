@@ -37,6 +37,10 @@ type Controller struct {
 	efController controller.Controller
 	// Lister for egress firewall
 	efLister egressfirewalllister.EgressFirewallLister
+	// controller for cluster egress firewall
+	cefController controller.Controller
+	// Lister for cluster egress firewall
+	cefLister egressfirewalllister.ClusterEgressFirewallLister
 	// controller for dns name resolver
 	dnsController controller.Controller
 	// Lister for dns name resolver
@@ -71,6 +75,18 @@ func (c *Controller) initControllers(watchFactory *factory.WatchFactory) {
 	}
 	c.efController = controller.NewController[egressfirewall.EgressFirewall]("cm-ef-controller", efConfig)
 
+	cefSharedIndexInformer := watchFactory.ClusterEgressFirewallInformer().Informer()
+	c.cefLister = watchFactory.ClusterEgressFirewallInformer().Lister()
+	cefConfig := &controller.ControllerConfig[egressfirewall.ClusterEgressFirewall]{
+		RateLimiter:    workqueue.NewTypedItemFastSlowRateLimiter[string](time.Second, 5*time.Second, 5),
+		Informer:       cefSharedIndexInformer,
+		Lister:         c.cefLister.List,
+		ObjNeedsUpdate: cefNeedsUpdate,
+		Reconcile:      c.reconcileClusterEgressFirewall,
+		Threadiness:    1,
+	}
+	c.cefController = controller.NewController[egressfirewall.ClusterEgressFirewall]("cm-cef-controller", cefConfig)
+
 	dnsSharedIndexInformer := watchFactory.DNSNameResolverInformer().Informer()
 	c.dnsLister = ocpnetworklisterv1alpha1.NewDNSNameResolverLister(dnsSharedIndexInformer.GetIndexer())
 	dnsConfig := &controller.ControllerConfig[ocpnetworkapiv1alpha1.DNSNameResolver]{
@@ -94,6 +110,15 @@ func efNeedsUpdate(oldObj, newObj *egressfirewall.EgressFirewall) bool {
 	return !reflect.DeepEqual(oldObj.Spec, newObj.Spec)
 }
 
+// cefNeedsUpdate returns true if a cluster egress firewall object is either
+// added or deleted, or if the .spec of the object is modified.
+func cefNeedsUpdate(oldObj, newObj *egressfirewall.ClusterEgressFirewall) bool {
+	if oldObj == nil || newObj == nil {
+		return true
+	}
+	return !reflect.DeepEqual(oldObj.Spec, newObj.Spec)
+}
+
 // dnsNeedsUpdate returns true if a dns name resolver object is either added
 // or deleted. The spec of a dns name resolver object is immutable. If the
 // status of a dns name resolver is updated, then dnsNeedsUpdate returns
@@ -105,19 +130,19 @@ func dnsNeedsUpdate(oldObj, newObj *ocpnetworkapiv1alpha1.DNSNameResolver) bool
 	return false
 }
 
-// Start initializes the handlers for EgressFirewall and DNSNameResolver
-// by watching the corresponding resource types.
+// Start initializes the handlers for EgressFirewall, ClusterEgressFirewall and
+// DNSNameResolver by watching the corresponding resource types.
 func (c *Controller) Start() error {
-	if err := controller.StartWithInitialSync(c.syncDNSNames, c.efController, c.dnsController); err != nil {
+	if err := controller.StartWithInitialSync(c.syncDNSNames, c.efController, c.cefController, c.dnsController); err != nil {
 		return fmt.Errorf("unable to start egress firewall and dns name resolver controllers %w", err)
 	}
 	return nil
 }
 
-// Stop gracefully stops the controller. The handlers for EgressFirewall
-// and DNSNameResolver are removed.
+// Stop gracefully stops the controller. The handlers for EgressFirewall,
+// ClusterEgressFirewall and DNSNameResolver are removed.
 func (c *Controller) Stop() {
-	controller.Stop(c.efController, c.dnsController)
+	controller.Stop(c.efController, c.cefController, c.dnsController)
 }
 
 // syncDNSNames syncs the existing EgressFirewall and DNSNameResolver objects
@@ -150,6 +175,16 @@ func (c *Controller) syncDNSNames() error {
 		namespaceToDNSNames[egressFirewall.Namespace] = util.GetDNSNames(egressFirewall)
 	}
 
+	// Fetch the existing ClusterEgressFirewall objects. Their DNS names are
+	// tracked with a key which can't collide with a namespace name.
+	clusterEgressFirewalls, err := c.cefLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("syncDNSNames unable to get Cluster Egress Firewalls: %w", err)
+	}
+	for _, cef := range clusterEgressFirewalls {
+		namespaceToDNSNames[util.GetClusterEgressFirewallDNSOwner(cef.Name)] = util.GetClusterEgressFirewallDNSNames(cef)
+	}
+
 	c.resInfo.SyncResolverInfo(dnsNameToResolver, namespaceToDNSNames)
 
 	return nil
@@ -184,6 +219,24 @@ func (c *Controller) reconcileEgressFirewall(key string) error {
 	return c.resInfo.ModifyDNSNamesForNamespace(util.GetDNSNames(ef), namespace)
 }
 
+// reconcileClusterEgressFirewall reconciles a ClusterEgressFirewall object.
+func (c *Controller) reconcileClusterEgressFirewall(key string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	owner := util.GetClusterEgressFirewallDNSOwner(key)
+	cef, err := c.cefLister.Get(key)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// ClusterEgressFirewall object was deleted. Delete all the DNSNameResolver
+			// objects corresponding to the DNS names used only by this object.
+			return c.resInfo.DeleteDNSNamesForNamespace(owner)
+		}
+		return fmt.Errorf("failed to fetch cluster egress firewall %s", key)
+	}
+
+	return c.resInfo.ModifyDNSNamesForNamespace(util.GetClusterEgressFirewallDNSNames(cef), owner)
+}
+
 // reconcileDNSNameResolver reconciles a DNSNameResolver object. If an object
 // was deleted, but it was not supposed to, then it is recreated. If an object
 // is created, but it was not supposed to, then it is deleted.
@@ -8,6 +8,7 @@ import (
 	"net"
 	"os"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	ocpcloudnetworkapi "github.com/openshift/api/cloudnetwork/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -377,6 +379,9 @@ type egressIPClusterController struct {
 	// health-checking and tracking allocations made
 	nodeAllocator nodeAllocator
 	markAllocator id.Allocator
+	// egressIPPools holds the allocator of each EgressIPPool, indexed by name.
+	// Protected by egressIPAssignmentMutex.
+	egressIPPools map[string]*egressIPPool
 	// watchFactory watching k8s objects
 	watchFactory *factory.WatchFactory
 	// EgressIP Node reachability total timeout configuration
@@ -416,6 +421,7 @@ func newEgressIPController(ovnClient *util.OVNClusterManagerClientset, wf *facto
 		pendingCloudPrivateIPConfigsOps:   make(map[string]map[string]*cloudPrivateIPConfigOp),
 		nodeAllocator:                     nodeAllocator{&sync.Mutex{}, make(map[string]*egressNode)},
 		markAllocator:                     markAllocator,
+		egressIPPools:                     map[string]*egressIPPool{},
 		watchFactory:                      wf,
 		recorder:                          recorder,
 		egressIPTotalTimeout:              config.OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout,
@@ -460,6 +466,9 @@ func (eIPC *egressIPClusterController) Start() error {
 	if eIPC.egressIPHandler, err = eIPC.WatchEgressIP(); err != nil {
 		return err
 	}
+	if err = eIPC.WatchEgressIPPools(); err != nil {
+		return fmt.Errorf("unable to watch egress IP pools %w", err)
+	}
 	if util.PlatformTypeIsEgressIPCloudProvider() {
 		if eIPC.cloudPrivateIPConfigHandler, err = eIPC.WatchCloudPrivateIPConfig(); err != nil {
 			return err
@@ -792,7 +801,7 @@ func (eIPC *egressIPClusterController) addEgressNode(nodeName string) error {
 	}
 	for _, egressIP := range egressIPs {
 		egressIP := *egressIP
-		if len(egressIP.Spec.EgressIPs) != len(egressIP.Status.Items) {
+		if len(getEgressIPs(&egressIP)) != len(egressIP.Status.Items) {
 			// Send a "synthetic update" on all egress IPs which are not fully
 			// assigned, the reconciliation loop for WatchEgressIP will try to
 			// assign stuff to this new node. The workqueue's delta FIFO
@@ -949,14 +958,19 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 	if old != nil {
 		name = old.Name
 		status = old.Status.Items
-		staleEgressIPs.Insert(old.Spec.EgressIPs...)
+		staleEgressIPs.Insert(getEgressIPs(old)...)
 	}
 	if new != nil {
+		// Allocate the egress IPs requested from an EgressIPPool first, they
+		// are handled as any egress IP of the spec from here on.
+		if new, err = eIPC.reconcileEgressIPPoolIPs(new); err != nil {
+			return err
+		}
 		newEIP = new
 		name = newEIP.Name
 		status = newEIP.Status.Items
 		if staleEgressIPs.Len() > 0 {
-			for _, egressIP := range newEIP.Spec.EgressIPs {
+			for _, egressIP := range getEgressIPs(newEIP) {
 				if staleEgressIPs.Has(egressIP) {
 					staleEgressIPs.Delete(egressIP)
 				}
@@ -964,13 +978,29 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 		}
 	} else {
 		eIPC.deallocMark(name)
+		if poolIPs, err := util.ParseEgressIPPoolIPs(old.Annotations); err != nil {
+			klog.Errorf("Failed to release the egress IPs allocated to EgressIP %s from an EgressIPPool: %v", name, err)
+		} else if poolIPs != nil {
+			eIPC.releaseEgressIPPoolIPs(name, poolIPs)
+		}
 	}
 
 	// Validate the spec and use only the valid egress IPs when performing any
 	// successive operations, theoretically: the user could specify invalid IP
 	// addresses, which would break us.
-	validSpecIPs, err := eIPC.validateEgressIPSpec(name, newEIP.Spec.EgressIPs)
+	validSpecIPs, err := eIPC.validateEgressIPSpec(name, getEgressIPs(newEIP))
+	if err != nil {
+		return fmt.Errorf("invalid EgressIP spec, err: %v", err)
+	}
+	// The node selector and the node preferences restrict and rank the nodes
+	// the egress IPs can be assigned to.
+	affinity, err := newEgressIPNodeAffinity(&newEIP.Spec)
 	if err != nil {
+		eIPRef := corev1.ObjectReference{
+			Kind: "EgressIP",
+			Name: name,
+		}
+		eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "InvalidEgressIP", "EgressIP: %s has an invalid node selector: %v", name, err)
 		return fmt.Errorf("invalid EgressIP spec, err: %v", err)
 	}
 
@@ -979,7 +1009,7 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 	// anymore (specifically if ovnkube-master has been crashing for a while).
 	// Any invalid status at this point in time needs to be removed and assigned
 	// to a valid node.
-	validStatus, invalidStatus := eIPC.validateEgressIPStatus(name, status)
+	validStatus, invalidStatus := eIPC.validateEgressIPStatus(name, affinity, status)
 	for status := range validStatus {
 		// If the spec has changed and an egress IP has been removed by the
 		// user: we need to un-assign that egress IP
@@ -1041,6 +1071,16 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 		ipsToAssign = ipsToAssign.Intersection(ipsToRemove)
 	}
 
+	// The egress IPs which could not be assigned, the reasons of the egress
+	// IPs not attempted to be assigned in this round are kept.
+	unassigned := make([]egressipv1.EgressIPUnassignedItem, 0, len(newEIP.Status.UnassignedItems))
+	for _, item := range newEIP.Status.UnassignedItems {
+		if !ipsToAssign.Has(item.EgressIP) {
+			unassigned = append(unassigned, item)
+		}
+	}
+
+	var patchedStatus *egressipv1.EgressIPStatus
 	if !util.PlatformTypeIsEgressIPCloudProvider() {
 		if len(statusToRemove) > 0 {
 			// Delete the statusToRemove from the allocator cache. If we don't
@@ -1049,8 +1089,10 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 			eIPC.deleteAllocatorEgressIPAssignments(statusToRemove)
 		}
 		if len(ipsToAssign) > 0 {
-			statusToAdd = eIPC.assignEgressIPs(name, ipsToAssign.UnsortedList())
+			var ipsUnassigned []egressipv1.EgressIPUnassignedItem
+			statusToAdd, ipsUnassigned = eIPC.assignEgressIPs(name, affinity, ipsToAssign.UnsortedList())
 			statusToKeep = append(statusToKeep, statusToAdd...)
+			unassigned = append(unassigned, ipsUnassigned...)
 		}
 		// Add all assignments which are to be kept to the allocator cache,
 		// allowing us to track all assignments which have been performed and
@@ -1058,10 +1100,16 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 		eIPC.addAllocatorEgressIPAssignments(name, statusToKeep)
 		// Update the object only on an ADD/UPDATE. If we are processing a
 		// DELETE, new will be nil and we should not update the object.
-		if len(statusToAdd) > 0 || (len(statusToRemove) > 0 && new != nil) {
-			if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, statusToKeep)...); err != nil {
+		if new != nil {
+			status, err := eIPC.newEgressIPStatusForZones(new, statusToKeep, unassigned)
+			if err != nil {
 				return err
 			}
+			if len(statusToAdd) > 0 || len(statusToRemove) > 0 || egressIPStatusChanged(new.Status, status) {
+				if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, status)...); err != nil {
+					return err
+				}
+			}
 		}
 	} else {
 		// Even when running on a public cloud, we must make sure that we unwire EgressIP
@@ -1087,9 +1135,14 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 			// Update the object only on an ADD/UPDATE. If we are processing a
 			// DELETE, new will be nil and we should not update the object.
 			if new != nil {
-				if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, statusToKeep)...); err != nil {
+				status, err := eIPC.newEgressIPStatusForZones(new, statusToKeep, unassigned)
+				if err != nil {
+					return err
+				}
+				if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, status)...); err != nil {
 					return err
 				}
+				patchedStatus = &status
 			}
 		}
 		// When egress IP is not fully assigned to a node, then statusToRemove may not
@@ -1112,9 +1165,12 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 		// it can assign the IPs. reconcileCloudPrivateIPConfig will take care of
 		// processing the answer from the requests we make here, and update OVN
 		// accordingly when we know what the outcome is.
+		statusItems := slices.Clone(statusToKeep)
 		if len(ipsToAssign) > 0 {
-			statusToAdd = eIPC.assignEgressIPs(name, ipsToAssign.UnsortedList())
+			var ipsUnassigned []egressipv1.EgressIPUnassignedItem
+			statusToAdd, ipsUnassigned = eIPC.assignEgressIPs(name, affinity, ipsToAssign.UnsortedList())
 			statusToKeep = append(statusToKeep, statusToAdd...)
+			unassigned = append(unassigned, ipsUnassigned...)
 		}
 		// Same as above: Add all assignments which are to be kept to the
 		// allocator cache, allowing us to track all assignments which have been
@@ -1128,6 +1184,23 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 		if err := eIPC.executeCloudPrivateIPConfigChange(name, statusToAdd, statusToRemove); err != nil {
 			return err
 		}
+
+		// The assignments are only added to the status once the cloud confirms
+		// them, only update the unassigned egress IPs and the conditions.
+		if new != nil {
+			if patchedStatus == nil {
+				patchedStatus = &new.Status
+			}
+			status, err := eIPC.newEgressIPStatusForZones(new, statusItems, unassigned)
+			if err != nil {
+				return err
+			}
+			if egressIPStatusChanged(*patchedStatus, status) {
+				if err := eIPC.patchEgressIP(name, eIPC.generateEgressIPPatches(name, new.Annotations, status)...); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	// Record the egress IP allocator count
@@ -1171,7 +1244,11 @@ func (eIPC *egressIPClusterController) syncCloudPrivateIPConfigs(objs []interfac
 		if cloudPrivateIPNotFound {
 			// There could be one or more stale entry found in egress ip object, remove it by patching egressip
 			// object with updated status.
-			err = eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, updatedStatus)...)
+			status, err := eIPC.newEgressIPStatusForZones(egressIP, updatedStatus, egressIP.Status.UnassignedItems)
+			if err != nil {
+				return fmt.Errorf("syncCloudPrivateIPConfigs unable to build EgressIP status: %w", err)
+			}
+			err = eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, status)...)
 			if err != nil {
 				return fmt.Errorf("syncCloudPrivateIPConfigs unable to update EgressIP status: %w", err)
 			}
@@ -1208,12 +1285,19 @@ func (eIPC *egressIPClusterController) getCloudPrivateIPConfigMap(objs []interfa
 // ascending order following their existing amount of allocations, and trying to
 // assign the egress IP to the node with the lowest amount of allocations every
 // time, this does not guarantee complete balance, but mostly complete.
+// The node selector of the EgressIP restricts the assignable nodes, and its
+// node preferences rank them before their amount of allocations is considered.
 // For Egress IPs that are hosted by secondary host networks, there must be at least
 // one node that hosts the network and exposed via the nodes host-cidrs annotation.
-func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []string) []egressipv1.EgressIPStatusItem {
+// The egress IPs which could not be assigned are returned along with the reason why.
+func (eIPC *egressIPClusterController) assignEgressIPs(name string, affinity *egressIPNodeAffinity, egressIPs []string) ([]egressipv1.EgressIPStatusItem, []egressipv1.EgressIPUnassignedItem) {
 	eIPC.nodeAllocator.Lock()
 	defer eIPC.nodeAllocator.Unlock()
 	assignments := []egressipv1.EgressIPStatusItem{}
+	unassigned := []egressipv1.EgressIPUnassignedItem{}
+	addUnassigned := func(egressIP, reason, message string) {
+		unassigned = append(unassigned, egressipv1.EgressIPUnassignedItem{EgressIP: egressIP, Reason: reason, Message: message})
+	}
 	assignableNodes, existingAllocations := eIPC.getSortedEgressData()
 	if len(assignableNodes) == 0 {
 		eIPRef := corev1.ObjectReference{
@@ -1222,10 +1306,27 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		}
 		eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "NoMatchingNodeFound", "no assignable nodes for EgressIP: %s, please tag at least one node with label: %s", name, util.GetNodeEgressLabel())
 		klog.Errorf("No assignable nodes found for EgressIP: %s and requested IPs: %v", name, egressIPs)
-		return assignments
+		for _, egressIP := range egressIPs {
+			addUnassigned(egressIP, egressipv1.EgressIPReasonNoAssignableNodes,
+				fmt.Sprintf("no node is labeled with %s", util.GetNodeEgressLabel()))
+		}
+		return assignments, unassigned
+	}
+	if assignableNodes = eIPC.filterAndRankEgressNodes(name, affinity, assignableNodes); len(assignableNodes) == 0 {
+		eIPRef := corev1.ObjectReference{
+			Kind: "EgressIP",
+			Name: name,
+		}
+		eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "NoMatchingNodeFound", "no assignable nodes match the node selector of EgressIP: %s", name)
+		klog.Errorf("No assignable nodes matching the node selector found for EgressIP: %s and requested IPs: %v", name, egressIPs)
+		for _, egressIP := range egressIPs {
+			addUnassigned(egressIP, egressipv1.EgressIPReasonNoSelectedNodes,
+				"no assignable node matches the node selector of the EgressIP")
+		}
+		return assignments, unassigned
 	}
 	klog.V(5).Infof("Current assignments are: %+v", existingAllocations)
-	for _, egressIP := range egressIPs {
+	for i, egressIP := range egressIPs {
 		klog.V(5).Infof("Will attempt assignment for egress IP: %s", egressIP)
 		eIP := net.ParseIP(egressIP)
 
@@ -1233,7 +1334,11 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		// cluster, therefore there maybe still conflicts when we attempt to assign an egress IP with a different scope.
 		if isIPConflict, conflictedHost, err := eIPC.isEgressIPAddrConflict(eIP); err != nil {
 			klog.Errorf("Egress IP: %v failed to check if EgressIP already is assigned on any interface throughout the cluster: %v", eIP, err)
-			return assignments
+			for _, egressIP := range egressIPs[i:] {
+				addUnassigned(egressIP, egressipv1.EgressIPReasonAssignmentError,
+					fmt.Sprintf("failed to check for conflicts with node IPs: %v", err))
+			}
+			return assignments, unassigned
 		} else if isIPConflict {
 			eIPRef := corev1.ObjectReference{
 				Kind: "EgressIP",
@@ -1242,6 +1347,8 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "EgressIPConflict", "Egress IP %s with IP "+
 				"%v is conflicting with a host (%s) IP address and will not be assigned", name, eIP, conflictedHost)
 			klog.Errorf("Egress IP: %v address is already assigned on an interface on node %s", eIP, conflictedHost)
+			addUnassigned(egressIP, egressipv1.EgressIPReasonHostIPConflict,
+				fmt.Sprintf("IP is an address of node %s", conflictedHost))
 			continue
 		}
 		if status, exists := existingAllocations[eIP.String()]; exists {
@@ -1260,21 +1367,29 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 				if err != nil {
 					klog.Errorf("Failed to process existing egress IP %s allocation because node %s doesn't exist: %v",
 						egressIP, status.Node, err)
+					addUnassigned(egressIP, egressipv1.EgressIPReasonAssignmentError,
+						fmt.Sprintf("IP is allocated to node %s which can't be retrieved: %v", status.Node, err))
 					continue
 				}
 				eNode, exists := eIPC.nodeAllocator.cache[status.Node] // allocator lock was previously acquired
 				if !exists {
 					klog.Errorf("Failed to find entry in allocator cache for EgressIP %s and IP %s,", name, eIP.String())
+					addUnassigned(egressIP, egressipv1.EgressIPReasonAssignmentError,
+						fmt.Sprintf("IP is allocated to node %s which is not an egress node", status.Node))
 					continue
 				}
 				eIPNetwork, err := util.GetEgressIPNetwork(node, eNode.egressIPConfig, eIP)
 				if err != nil {
 					klog.Errorf("Failed to determine EgressIP %s network using IP %s for node %s: %v", name, eIP.String(), node.Name, err)
+					addUnassigned(egressIP, egressipv1.EgressIPReasonAssignmentError,
+						fmt.Sprintf("failed to find a network of node %s to host the IP: %v", node.Name, err))
 					continue
 				}
 				if eIPNetwork == "" {
 					klog.Errorf("EgressIP %s IP %s is allocated to node %s but node does not contain a network "+
 						"that can host it", name, eIP.String(), node.Name)
+					addUnassigned(egressIP, egressipv1.EgressIPReasonNoMatchingNetwork,
+						fmt.Sprintf("IP is allocated to node %s which has no network that can host it", node.Name))
 					continue
 				}
 				// IP is already assigned for this EgressIP object
@@ -1294,6 +1409,8 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 					"IP: %q for EgressIP: %s is already allocated for EgressIP: %s on %s", egressIP, name, status.Name, status.Node,
 				)
 				klog.Errorf("IP: %q for EgressIP: %s is already allocated for EgressIP: %s on %s", egressIP, name, status.Name, status.Node)
+				addUnassigned(egressIP, egressipv1.EgressIPReasonEgressIPConflict,
+					fmt.Sprintf("IP is already allocated for EgressIP %s on node %s", status.Name, status.Node))
 				continue
 			}
 		}
@@ -1331,12 +1448,14 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			}
 		}
 
-		var assignmentSuccessful bool
+		// track why the nodes could not host the egress IP, to report why it is left unassigned
+		var assignmentSuccessful, nodesInUse, capacityExhausted bool
 		for i := 0; i < len(assignableNodes) && !assignmentSuccessful; i++ {
 			eNode := assignableNodes[i]
 			klog.V(5).Infof("Attempting assignment on egress node: %+v", eNode)
 			if eNode.getAllocationCountForEgressIP(name) > 0 {
 				klog.V(5).Infof("Node: %s is already in use by another egress IP for this EgressIP: %s, trying another node", eNode.name, name)
+				nodesInUse = true
 				continue
 			}
 			node, err := eIPC.watchFactory.GetNode(eNode.name)
@@ -1356,18 +1475,21 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			if eNode.egressIPConfig.Capacity.IP != nil && *eNode.egressIPConfig.Capacity.IP < util.UnlimitedNodeCapacity {
 				if *eNode.egressIPConfig.Capacity.IP-len(eNode.allocations) <= 0 {
 					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IP capacity, trying another node", eNode.name)
+					capacityExhausted = true
 					continue
 				}
 			}
 			if eNode.egressIPConfig.Capacity.IPv4 != nil && *eNode.egressIPConfig.Capacity.IPv4 < util.UnlimitedNodeCapacity && utilnet.IsIPv4(eIP) {
 				if *eNode.egressIPConfig.Capacity.IPv4-getIPFamilyAllocationCount(eNode.allocations, false) <= 0 {
 					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv4 capacity, trying another node", eNode.name)
+					capacityExhausted = true
 					continue
 				}
 			}
 			if eNode.egressIPConfig.Capacity.IPv6 != nil && *eNode.egressIPConfig.Capacity.IPv6 < util.UnlimitedNodeCapacity && utilnet.IsIPv6(eIP) {
 				if *eNode.egressIPConfig.Capacity.IPv6-getIPFamilyAllocationCount(eNode.allocations, true) <= 0 {
 					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv6 capacity, trying another node", eNode.name)
+					capacityExhausted = true
 					continue
 				}
 			}
@@ -1380,6 +1502,18 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			klog.Infof("Successful assignment of egress IP: %s to network %s on node: %+v", egressIP, egressIPNetwork, eNode)
 			break
 		}
+		switch {
+		case assignmentSuccessful:
+		case capacityExhausted:
+			addUnassigned(egressIP, egressipv1.EgressIPReasonCapacityExhausted,
+				"all the nodes that can host the IP reached their egress IP capacity")
+		case nodesInUse:
+			addUnassigned(egressIP, egressipv1.EgressIPReasonNodesInUse,
+				"all the nodes that can host the IP already host another egress IP of this EgressIP")
+		default:
+			addUnassigned(egressIP, egressipv1.EgressIPReasonNoMatchingNetwork,
+				"no assignable node has a network that can host the IP")
+		}
 	}
 	if len(assignments) == 0 {
 		eIPRef := corev1.ObjectReference{
@@ -1388,7 +1522,7 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		}
 		eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "NoMatchingNodeFound", "No matching nodes found, which can host any of the egress IPs: %v for object EgressIP: %s", egressIPs, name)
 		klog.Errorf("No matching host found for EgressIP: %s", name)
-		return assignments
+		return assignments, unassigned
 	}
 	if len(assignments) < len(egressIPs) {
 		eIPRef := corev1.ObjectReference{
@@ -1397,7 +1531,7 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		}
 		eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "UnassignedRequest", "Not all egress IPs for EgressIP: %s could be assigned, please tag more nodes", name)
 	}
-	return assignments
+	return assignments, unassigned
 }
 
 func getIPFamilyAllocationCount(allocations map[string]string, isIPv6 bool) (count int) {
@@ -1458,7 +1592,7 @@ func (eIPC *egressIPClusterController) isEgressIPAddrConflict(egressIP net.IP) (
 // cache knows about all egress nodes. WatchEgressNodes is initialized before
 // any other egress IP handler, so the cache should be warm and correct once we
 // start going this.
-func (eIPC *egressIPClusterController) validateEgressIPStatus(name string, items []egressipv1.EgressIPStatusItem) (map[egressipv1.EgressIPStatusItem]string, map[egressipv1.EgressIPStatusItem]string) {
+func (eIPC *egressIPClusterController) validateEgressIPStatus(name string, affinity *egressIPNodeAffinity, items []egressipv1.EgressIPStatusItem) (map[egressipv1.EgressIPStatusItem]string, map[egressipv1.EgressIPStatusItem]string) {
 	eIPC.nodeAllocator.Lock()
 	defer eIPC.nodeAllocator.Unlock()
 	valid, invalid := make(map[egressipv1.EgressIPStatusItem]string), make(map[egressipv1.EgressIPStatusItem]string)
@@ -1497,6 +1631,9 @@ func (eIPC *egressIPClusterController) validateEgressIPStatus(name string, items
 			if err != nil {
 				klog.Errorf("Allocator error: failed to validate and will not consider node %s for egress IP %s: %v",
 					eNode.name, name, err)
+			} else if !affinity.matches(node) {
+				klog.Errorf("Allocator error: EgressIP: %s assigned to node: %s which does not match its node selector, will attempt rebalancing", name, eIPStatus.Node)
+				validAssignment = false
 			}
 			isOVNNetwork := util.IsOVNNetwork(eNode.egressIPConfig, ip)
 			isSecondaryHostNetwork, err := util.IsSecondaryHostNetworkContainingIP(node, ip)
@@ -1600,7 +1737,11 @@ func (eIPC *egressIPClusterController) reconcileCloudPrivateIPConfig(old, new *o
 					updatedStatus = append(updatedStatus, status)
 				}
 			}
-			if err := eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, updatedStatus)...); err != nil {
+			status, err := eIPC.newEgressIPStatusForZones(egressIP, updatedStatus, egressIP.Status.UnassignedItems)
+			if err != nil {
+				return err
+			}
+			if err := eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, status)...); err != nil {
 				return err
 			}
 		}
@@ -1647,7 +1788,11 @@ func (eIPC *egressIPClusterController) reconcileCloudPrivateIPConfig(old, new *o
 		}
 		if !hasStatus {
 			statusToKeep := append(egressIP.Status.Items, statusItem)
-			if err := eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, statusToKeep)...); err != nil {
+			status, err := eIPC.newEgressIPStatusForZones(egressIP, statusToKeep, egressIP.Status.UnassignedItems)
+			if err != nil {
+				return err
+			}
+			if err := eIPC.patchEgressIP(egressIP.Name, eIPC.generateEgressIPPatches(egressIP.Name, egressIP.Annotations, status)...); err != nil {
 				return err
 			}
 		}
@@ -1744,7 +1889,7 @@ func (eIPC *egressIPClusterController) removePendingOpsAndGetResyncs(egressIPNam
 	for _, egressIP := range egressIPs {
 		egressIP := *egressIP
 		if egressIP.Name == egressIPName {
-			for _, specIP := range egressIP.Spec.EgressIPs {
+			for _, specIP := range getEgressIPs(&egressIP) {
 				// Do not process the egress IP object which owns the
 				// CloudPrivateIPConfig for which we are currently processing the
 				// deletion for unless it still has the IP in it's spec
@@ -1755,7 +1900,7 @@ func (eIPC *egressIPClusterController) removePendingOpsAndGetResyncs(egressIPNam
 			}
 			continue
 		}
-		unassigned := len(egressIP.Spec.EgressIPs) - len(egressIP.Status.Items)
+		unassigned := len(getEgressIPs(&egressIP)) - len(egressIP.Status.Items)
 		ops, pending := eIPC.pendingCloudPrivateIPConfigsOps[egressIP.Name]
 		// If the EgressIP was never added to the pending cache to begin
 		// with, but has un-assigned egress IPs, try it.
@@ -1805,38 +1950,217 @@ func (eIPC *egressIPClusterController) patchEgressIP(name string, patches ...jso
 // mark range exhaustion. Primary default network egress IP currently does not utilize marks to config EgressIP.
 // Generating the status patch is mandatory
 func (eIPC *egressIPClusterController) generateEgressIPPatches(name string, annotations map[string]string,
-	statusItems []egressipv1.EgressIPStatusItem) []jsonPatchOperation {
+	status egressipv1.EgressIPStatus) []jsonPatchOperation {
 	patches := make([]jsonPatchOperation, 0, 1)
 	if !util.IsEgressIPMarkSet(annotations) {
 		if mark, _, err := eIPC.getOrAllocMark(name); err != nil {
 			klog.Errorf("Failed to get mark for EgressIP %s: %v", name, err)
 		} else {
-			patches = append(patches, generateMarkPatchOp(mark))
+			patches = append(patches, generateMarkPatchOp(annotations, mark))
 		}
 	}
-	return append(patches, generateStatusPatchOp(statusItems))
+	return append(patches, generateStatusPatchOp(status))
 }
 
-func generateMarkPatchOp(mark int) jsonPatchOperation {
-	return jsonPatchOperation{
-		Operation: "add",
-		Path:      "/metadata/annotations",
-		Value:     createAnnotWithMark(mark),
-	}
+func generateMarkPatchOp(annotations map[string]string, mark int) jsonPatchOperation {
+	return generateAnnotationPatchOp(annotations, util.EgressIPMarkAnnotation, fmt.Sprintf("%d", mark))
 }
 
 func createAnnotWithMark(mark int) map[string]string {
 	return map[string]string{util.EgressIPMarkAnnotation: fmt.Sprintf("%d", mark)}
 }
 
-func generateStatusPatchOp(statusItems []egressipv1.EgressIPStatusItem) jsonPatchOperation {
+func generateStatusPatchOp(status egressipv1.EgressIPStatus) jsonPatchOperation {
 	return jsonPatchOperation{
 		Operation: "replace",
 		Path:      "/status",
-		Value: egressipv1.EgressIPStatus{
-			Items: statusItems,
-		},
+		Value:     status,
+	}
+}
+
+const (
+	egressIPReasonAllAssigned     = "AllEgressIPsAssigned"
+	egressIPReasonNotAllAssigned  = "NotAllEgressIPsAssigned"
+	egressIPReasonAllProgrammed   = "AllEgressIPsProgrammed"
+	egressIPReasonPendingCloud    = "CloudAssignmentPending"
+	egressIPReasonPendingZones    = "ZoneProgrammingPending"
+	egressIPReasonFailedZones     = "ZoneProgrammingFailed"
+	egressIPReasonNoConflict      = "NoConflict"
+	egressIPConditionMessageLimit = 5
+)
+
+// getZones returns the zones of the nodes the egress IPs can be programmed on,
+// every zone reports in the EgressIP status messages whether it programmed them.
+func (eIPC *egressIPClusterController) getZones() (sets.Set[string], error) {
+	nodes, err := eIPC.watchFactory.GetNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %v", err)
+	}
+	zones := sets.New[string]()
+	for _, node := range nodes {
+		// EgressIP is not supported on hybrid overlay nodes
+		if util.NoHostSubnet(node) {
+			continue
+		}
+		zones.Insert(util.GetNodeZone(node))
+	}
+	return zones, nil
+}
+
+// newEgressIPStatusForZones returns the status of the EgressIP as newEgressIPStatus
+// does, with the zones of the cluster nodes.
+func (eIPC *egressIPClusterController) newEgressIPStatusForZones(egressIP *egressipv1.EgressIP, items []egressipv1.EgressIPStatusItem,
+	unassigned []egressipv1.EgressIPUnassignedItem) (egressipv1.EgressIPStatus, error) {
+	zones, err := eIPC.getZones()
+	if err != nil {
+		return egressipv1.EgressIPStatus{}, err
 	}
+	return newEgressIPStatus(egressIP, items, unassigned, zones), nil
+}
+
+// newEgressIPStatus returns the status of the EgressIP with the given assignments
+// and unassigned egress IPs, with its conditions set accordingly. Unassigned egress
+// IPs that are assigned or not requested anymore are dropped. The status messages of
+// the zones are kept as long as the assignments don't change, the egress IPs are
+// programmed once they are all hosted by their node and every one of the given zones
+// reported them programmed without error.
+func newEgressIPStatus(egressIP *egressipv1.EgressIP, items []egressipv1.EgressIPStatusItem,
+	unassigned []egressipv1.EgressIPUnassignedItem, zones sets.Set[string]) egressipv1.EgressIPStatus {
+	status := egressipv1.EgressIPStatus{
+		Items:      items,
+		Conditions: slices.Clone(egressIP.Status.Conditions),
+	}
+	if sets.New(items...).Equal(sets.New(egressIP.Status.Items...)) {
+		// the zones programmed the current assignments, their messages are still valid
+		status.Messages = slices.Clone(egressIP.Status.Messages)
+	}
+	requested := sets.New[string]()
+	for _, egressIP := range getEgressIPs(egressIP) {
+		if ip := net.ParseIP(egressIP); ip != nil {
+			requested.Insert(ip.String())
+		}
+	}
+	assigned := sets.New[string]()
+	for _, item := range items {
+		assigned.Insert(item.EgressIP)
+	}
+	unassignedIPs := sets.New[string]()
+	for _, item := range unassigned {
+		if !requested.Has(item.EgressIP) || assigned.Has(item.EgressIP) || unassignedIPs.Has(item.EgressIP) {
+			continue
+		}
+		unassignedIPs.Insert(item.EgressIP)
+		status.UnassignedItems = append(status.UnassignedItems, item)
+	}
+	slices.SortFunc(status.UnassignedItems, func(a, b egressipv1.EgressIPUnassignedItem) int {
+		return strings.Compare(a.EgressIP, b.EgressIP)
+	})
+	programmedZones := sets.New[string]()
+	failedZones := sets.New[string]()
+	for _, message := range status.Messages {
+		zone := types.GetZoneFromStatus(message)
+		if !zones.Has(zone) {
+			// the zone has no node anymore
+			continue
+		}
+		if strings.Contains(message, types.EgressIPErrorMsg) {
+			failedZones.Insert(zone)
+		} else {
+			programmedZones.Insert(zone)
+		}
+	}
+
+	assignedCondition := metav1.Condition{
+		Type:               egressipv1.EgressIPConditionAssigned,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: egressIP.Generation,
+		Reason:             egressIPReasonAllAssigned,
+		Message:            fmt.Sprintf("All %d egress IPs are assigned to a node", requested.Len()),
+	}
+	programmedCondition := metav1.Condition{
+		Type:               egressipv1.EgressIPConditionProgrammed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: egressIP.Generation,
+		Reason:             egressIPReasonAllProgrammed,
+		Message:            fmt.Sprintf("All %d egress IPs are hosted by their node and programmed by the %d zones", requested.Len(), zones.Len()),
+	}
+	conflictCondition := metav1.Condition{
+		Type:               egressipv1.EgressIPConditionConflict,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: egressIP.Generation,
+		Reason:             egressIPReasonNoConflict,
+		Message:            "None of the egress IPs conflicts with a node IP or another EgressIP",
+	}
+	if len(status.UnassignedItems) > 0 {
+		assignedCondition.Status = metav1.ConditionFalse
+		assignedCondition.Reason = egressIPReasonNotAllAssigned
+		assignedCondition.Message = fmt.Sprintf("%d of %d egress IPs could not be assigned: %s",
+			len(status.UnassignedItems), requested.Len(), formatUnassignedEgressIPs(status.UnassignedItems))
+	}
+	if hosted := requested.Intersection(assigned).Len(); hosted < requested.Len() {
+		programmedCondition.Status = metav1.ConditionFalse
+		programmedCondition.Reason = egressIPReasonNotAllAssigned
+		if len(status.UnassignedItems) == 0 {
+			// all the egress IPs are assigned, but the cloud didn't confirm all the assignments yet
+			programmedCondition.Reason = egressIPReasonPendingCloud
+		}
+		programmedCondition.Message = fmt.Sprintf("%d of %d egress IPs are hosted by their node", hosted, requested.Len())
+	} else if failedZones.Len() > 0 {
+		programmedCondition.Status = metav1.ConditionFalse
+		programmedCondition.Reason = egressIPReasonFailedZones
+		programmedCondition.Message = fmt.Sprintf("%d of %d zones failed to program the egress IPs: %s",
+			failedZones.Len(), zones.Len(), formatZones(sets.List(failedZones)))
+	} else if pending := zones.Difference(programmedZones); pending.Len() > 0 {
+		programmedCondition.Status = metav1.ConditionFalse
+		programmedCondition.Reason = egressIPReasonPendingZones
+		programmedCondition.Message = fmt.Sprintf("%d of %d zones did not program the egress IPs yet: %s",
+			pending.Len(), zones.Len(), formatZones(sets.List(pending)))
+	}
+	var conflicts []egressipv1.EgressIPUnassignedItem
+	for _, item := range status.UnassignedItems {
+		if item.Reason == egressipv1.EgressIPReasonHostIPConflict || item.Reason == egressipv1.EgressIPReasonEgressIPConflict {
+			conflicts = append(conflicts, item)
+		}
+	}
+	if len(conflicts) > 0 {
+		conflictCondition.Status = metav1.ConditionTrue
+		conflictCondition.Reason = conflicts[0].Reason
+		conflictCondition.Message = fmt.Sprintf("%d egress IPs conflict: %s", len(conflicts), formatUnassignedEgressIPs(conflicts))
+	}
+	meta.SetStatusCondition(&status.Conditions, assignedCondition)
+	meta.SetStatusCondition(&status.Conditions, programmedCondition)
+	meta.SetStatusCondition(&status.Conditions, conflictCondition)
+	return status
+}
+
+// formatZones formats the first zones for a condition message
+func formatZones(zones []string) string {
+	if len(zones) > egressIPConditionMessageLimit {
+		zones = append(zones[:egressIPConditionMessageLimit:egressIPConditionMessageLimit],
+			fmt.Sprintf("and %d more", len(zones)-egressIPConditionMessageLimit))
+	}
+	return strings.Join(zones, ", ")
+}
+
+// formatUnassignedEgressIPs formats the first unassigned egress IPs for a condition message
+func formatUnassignedEgressIPs(items []egressipv1.EgressIPUnassignedItem) string {
+	formatted := make([]string, 0, egressIPConditionMessageLimit+1)
+	for i, item := range items {
+		if i == egressIPConditionMessageLimit {
+			formatted = append(formatted, fmt.Sprintf("and %d more", len(items)-i))
+			break
+		}
+		formatted = append(formatted, fmt.Sprintf("%s (%s)", item.EgressIP, item.Reason))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// egressIPStatusChanged returns true if the unassigned egress IPs, the messages or the
+// conditions of the EgressIP status changed. The assignments are compared by the callers.
+func egressIPStatusChanged(old, new egressipv1.EgressIPStatus) bool {
+	return !reflect.DeepEqual(old.UnassignedItems, new.UnassignedItems) ||
+		!slices.Equal(old.Messages, new.Messages) ||
+		!equality.Semantic.DeepEqual(old.Conditions, new.Conditions)
 }
 
 // ensureAllocatorEgressIPAssignments adds EgressIP assignments to the allocator cache
@@ -1848,6 +2172,14 @@ func (eIPC *egressIPClusterController) ensureAllocatorEgressIPAssignments(egress
 	}
 }
 
+// syncEgressIPs builds the EgressIPPool and mark allocator caches from the existing EgressIPs.
+func (eIPC *egressIPClusterController) syncEgressIPs(egressIPs []interface{}) error {
+	if err := eIPC.syncEgressIPPoolAllocator(egressIPs); err != nil {
+		return err
+	}
+	return eIPC.syncEgressIPMarkAllocator(egressIPs)
+}
+
 // syncEgressIPMarkAllocator iterates over all existing EgressIPs. It builds a mark cache of existing marks stored on each
 // EgressIP annotation or allocates and adds a new mark to an EgressIP if it doesn't exist.
 func (eIPC *egressIPClusterController) syncEgressIPMarkAllocator(egressIPs []interface{}) error {
@@ -1887,7 +2219,7 @@ func (eIPC *egressIPClusterController) syncEgressIPMarkAllocator(egressIPs []int
 			// Mark range is limited so do not return an error in-order not to block pods attached to the CDN
 			klog.Errorf("Failed to sync mark allocator: unable to allocate for EgressIP %s: %v", egressIP.Name, err)
 		} else {
-			if err = eIPC.patchEgressIP(egressIP.Name, generateMarkPatchOp(mark)); err != nil {
+			if err = eIPC.patchEgressIP(egressIP.Name, generateMarkPatchOp(egressIP.Annotations, mark)); err != nil {
 				releaseMarkFn()
 				return fmt.Errorf("failed to patch EgressIP %s: %v", egressIP.Name, err)
 			}
@@ -19,6 +19,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -26,7 +27,9 @@ import (
 
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
 	egressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressipapply "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/applyconfiguration/egressip/v1"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/ovn/healthcheck"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
 )
 
@@ -259,6 +262,30 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 		return egressIPs, nodes
 	}
 
+	getEgressIPConditions := func(egressIPName string) func() map[string]metav1.ConditionStatus {
+		return func() map[string]metav1.ConditionStatus {
+			tmp, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), egressIPName, metav1.GetOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			conditions := map[string]metav1.ConditionStatus{}
+			for _, condition := range tmp.Status.Conditions {
+				conditions[condition.Type] = condition.Status
+			}
+			return conditions
+		}
+	}
+
+	getEgressIPUnassignedReasons := func(egressIPName string) func() map[string]string {
+		return func() map[string]string {
+			tmp, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), egressIPName, metav1.GetOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			reasons := map[string]string{}
+			for _, item := range tmp.Status.UnassignedItems {
+				reasons[item.EgressIP] = item.Reason
+			}
+			return reasons
+		}
+	}
+
 	getEgressIPAnnotationValue := func(egressIPName string) func() (string, error) {
 		return func() (string, error) {
 			tmp, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), egressIPName, metav1.GetOptions{})
@@ -1384,6 +1411,15 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				}
 				gomega.Expect(conflictCount).To(gomega.Equal(4))
 				gomega.Expect(noMatchingCount).To(gomega.Equal(4))
+
+				gomega.Eventually(getEgressIPUnassignedReasons(egressIPName)).Should(gomega.Equal(map[string]string{
+					egressIP: egressipv1.EgressIPReasonHostIPConflict,
+				}))
+				gomega.Eventually(getEgressIPConditions(egressIPName)).Should(gomega.Equal(map[string]metav1.ConditionStatus{
+					egressipv1.EgressIPConditionAssigned:   metav1.ConditionFalse,
+					egressipv1.EgressIPConditionProgrammed: metav1.ConditionFalse,
+					egressipv1.EgressIPConditionConflict:   metav1.ConditionTrue,
+				}))
 				return nil
 			}
 
@@ -1876,7 +1912,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 						EgressIPs: []string{egressIP},
 					},
 				}
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP).String()))
@@ -1958,7 +1994,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP1).String()))
@@ -2045,7 +2081,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node2)).To(gomega.Succeed())
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP1).String()))
@@ -2139,7 +2175,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node2)).To(gomega.Succeed())
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP1SecondaryHost).String()))
@@ -2225,10 +2261,10 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node2)).To(gomega.Succeed())
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node2Name))
-				assignedStatuses = fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ = fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node2Name))
 				return nil
@@ -2310,7 +2346,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 
 				return nil
@@ -2393,7 +2429,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node1)).To(gomega.Succeed())
 				gomega.Expect(fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&node2)).To(gomega.Succeed())
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 
 				return nil
@@ -2472,7 +2508,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 				return nil
 			}
@@ -2551,7 +2587,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 				return nil
 			}
@@ -2630,7 +2666,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP).String()))
@@ -2711,7 +2747,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
 				return nil
 			}
@@ -2867,7 +2903,7 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
 				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
 
-				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				assignedStatuses, _ := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, nil, eIP.Spec.EgressIPs)
 				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
 				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(egressIP).String()))
@@ -3045,6 +3081,24 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 				gomega.Expect(nodes[0]).To(gomega.Equal(egressNode2.name))
 				gomega.Expect(egressIPs[0]).To(gomega.Equal(egressIP))
 				gomega.Expect(egressIPs[0]).To(gomega.Equal(egressIP))
+				gomega.Expect(getEgressIPUnassignedReasons(egressIPName)()).To(gomega.BeEmpty())
+				gomega.Expect(getEgressIPConditions(egressIPName)()).To(gomega.Equal(map[string]metav1.ConditionStatus{
+					egressipv1.EgressIPConditionAssigned:   metav1.ConditionTrue,
+					egressipv1.EgressIPConditionProgrammed: metav1.ConditionFalse,
+					egressipv1.EgressIPConditionConflict:   metav1.ConditionFalse,
+				}))
+
+				ginkgo.By("reporting the egress IPs programmed once the zone programmed them")
+				_, err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Apply(context.TODO(),
+					egressipapply.EgressIP(egressIPName).WithStatus(egressipapply.EgressIPStatus().
+						WithMessages(types.GetZoneStatus(types.OvnDefaultZone, "EgressIP programmed"))),
+					metav1.ApplyOptions{Force: true, FieldManager: types.OvnDefaultZone})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPConditions(egressIPName)).Should(gomega.Equal(map[string]metav1.ConditionStatus{
+					egressipv1.EgressIPConditionAssigned:   metav1.ConditionTrue,
+					egressipv1.EgressIPConditionProgrammed: metav1.ConditionTrue,
+					egressipv1.EgressIPConditionConflict:   metav1.ConditionFalse,
+				}))
 				return nil
 			}
 
@@ -3316,6 +3370,525 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 		})
 	})
 
+	ginkgo.Context("EgressIP status", func() {
+
+		ginkgo.It("should set the conditions from the assigned and unassigned egress IPs", func() {
+			eIP := &egressipv1.EgressIP{
+				ObjectMeta: newEgressIPMeta(egressIPName),
+				Spec: egressipv1.EgressIPSpec{
+					EgressIPs: []string{"192.168.126.10", "192.168.126.11", "192.168.126.12"},
+				},
+			}
+			items := []egressipv1.EgressIPStatusItem{{Node: node1Name, EgressIP: "192.168.126.10"}}
+			zones := sets.New(types.OvnDefaultZone)
+
+			ginkgo.By("dropping the unassigned egress IPs that are assigned or not requested")
+			status := newEgressIPStatus(eIP, items, []egressipv1.EgressIPUnassignedItem{
+				{EgressIP: "192.168.126.10", Reason: egressipv1.EgressIPReasonCapacityExhausted},
+				{EgressIP: "192.168.126.12", Reason: egressipv1.EgressIPReasonNodesInUse},
+				{EgressIP: "192.168.126.13", Reason: egressipv1.EgressIPReasonHostIPConflict},
+			}, zones)
+			gomega.Expect(status.Items).To(gomega.Equal(items))
+			gomega.Expect(status.UnassignedItems).To(gomega.Equal([]egressipv1.EgressIPUnassignedItem{
+				{EgressIP: "192.168.126.12", Reason: egressipv1.EgressIPReasonNodesInUse},
+			}))
+			gomega.Expect(meta.IsStatusConditionFalse(status.Conditions, egressipv1.EgressIPConditionAssigned)).To(gomega.BeTrue())
+			gomega.Expect(meta.IsStatusConditionFalse(status.Conditions, egressipv1.EgressIPConditionProgrammed)).To(gomega.BeTrue())
+			gomega.Expect(meta.IsStatusConditionFalse(status.Conditions, egressipv1.EgressIPConditionConflict)).To(gomega.BeTrue())
+
+			ginkgo.By("reporting the egress IPs assigned but not confirmed by the cloud yet")
+			eIP.Status = status
+			status = newEgressIPStatus(eIP, items, nil, zones)
+			gomega.Expect(status.UnassignedItems).To(gomega.BeEmpty())
+			gomega.Expect(meta.IsStatusConditionTrue(status.Conditions, egressipv1.EgressIPConditionAssigned)).To(gomega.BeTrue())
+			programmed := meta.FindStatusCondition(status.Conditions, egressipv1.EgressIPConditionProgrammed)
+			gomega.Expect(programmed.Status).To(gomega.Equal(metav1.ConditionFalse))
+			gomega.Expect(programmed.Reason).To(gomega.Equal(egressIPReasonPendingCloud))
+			gomega.Expect(egressIPStatusChanged(eIP.Status, status)).To(gomega.BeTrue())
+
+			ginkgo.By("not changing the status when nothing changed")
+			eIP.Status = status
+			gomega.Expect(egressIPStatusChanged(eIP.Status, newEgressIPStatus(eIP, items, nil, zones))).To(gomega.BeFalse())
+
+			ginkgo.By("reporting conflicting egress IPs")
+			status = newEgressIPStatus(eIP, items, []egressipv1.EgressIPUnassignedItem{
+				{EgressIP: "192.168.126.11", Reason: egressipv1.EgressIPReasonEgressIPConflict},
+			}, zones)
+			conflict := meta.FindStatusCondition(status.Conditions, egressipv1.EgressIPConditionConflict)
+			gomega.Expect(conflict.Status).To(gomega.Equal(metav1.ConditionTrue))
+			gomega.Expect(conflict.Reason).To(gomega.Equal(egressipv1.EgressIPReasonEgressIPConflict))
+			gomega.Expect(conflict.Message).To(gomega.ContainSubstring("192.168.126.11"))
+		})
+
+		ginkgo.It("should set the programmed condition from the status messages of the zones", func() {
+			eIP := &egressipv1.EgressIP{
+				ObjectMeta: newEgressIPMeta(egressIPName),
+				Spec: egressipv1.EgressIPSpec{
+					EgressIPs: []string{"192.168.126.10", "192.168.126.11"},
+				},
+			}
+			items := []egressipv1.EgressIPStatusItem{
+				{Node: node1Name, EgressIP: "192.168.126.10"},
+				{Node: node2Name, EgressIP: "192.168.126.11"},
+			}
+			zones := sets.New("zone1", "zone2")
+			programmed := func(status egressipv1.EgressIPStatus) *metav1.Condition {
+				return meta.FindStatusCondition(status.Conditions, egressipv1.EgressIPConditionProgrammed)
+			}
+
+			ginkgo.By("waiting for all the zones to program the egress IPs")
+			status := newEgressIPStatus(eIP, items, nil, zones)
+			gomega.Expect(programmed(status).Status).To(gomega.Equal(metav1.ConditionFalse))
+			gomega.Expect(programmed(status).Reason).To(gomega.Equal(egressIPReasonPendingZones))
+			gomega.Expect(programmed(status).Message).To(gomega.ContainSubstring("zone1, zone2"))
+
+			eIP.Status = status
+			eIP.Status.Messages = []string{types.GetZoneStatus("zone1", "EgressIP programmed")}
+			status = newEgressIPStatus(eIP, items, nil, zones)
+			gomega.Expect(status.Messages).To(gomega.Equal(eIP.Status.Messages))
+			gomega.Expect(programmed(status).Reason).To(gomega.Equal(egressIPReasonPendingZones))
+			gomega.Expect(programmed(status).Message).To(gomega.HaveSuffix("zone2"))
+
+			ginkgo.By("reporting the zones that failed to program the egress IPs")
+			eIP.Status.Messages = append(eIP.Status.Messages,
+				types.GetZoneStatus("zone2", types.EgressIPErrorMsg+": failed to add pod"))
+			status = newEgressIPStatus(eIP, items, nil, zones)
+			gomega.Expect(programmed(status).Status).To(gomega.Equal(metav1.ConditionFalse))
+			gomega.Expect(programmed(status).Reason).To(gomega.Equal(egressIPReasonFailedZones))
+			gomega.Expect(programmed(status).Message).To(gomega.HaveSuffix("zone2"))
+
+			ginkgo.By("reporting the egress IPs programmed by all the zones, ignoring the zones without nodes")
+			eIP.Status.Messages = []string{
+				types.GetZoneStatus("zone1", "EgressIP programmed"),
+				types.GetZoneStatus("zone2", "EgressIP programmed"),
+				types.GetZoneStatus("zone3", types.EgressIPErrorMsg+": failed to add pod"),
+			}
+			status = newEgressIPStatus(eIP, items, nil, zones)
+			gomega.Expect(programmed(status).Status).To(gomega.Equal(metav1.ConditionTrue))
+			gomega.Expect(programmed(status).Reason).To(gomega.Equal(egressIPReasonAllProgrammed))
+
+			ginkgo.By("resetting the messages when the assignments change")
+			eIP.Status = status
+			status = newEgressIPStatus(eIP, items[:1], nil, zones)
+			gomega.Expect(status.Messages).To(gomega.BeEmpty())
+			gomega.Expect(programmed(status).Status).To(gomega.Equal(metav1.ConditionFalse))
+			gomega.Expect(programmed(status).Reason).To(gomega.Equal(egressIPReasonPendingCloud))
+			gomega.Expect(egressIPStatusChanged(eIP.Status, status)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("should set the programmed condition on cloud platforms", func() {
+			config.Kubernetes.PlatformType = string(ocpconfigapi.AWSPlatformType)
+			eIP := &egressipv1.EgressIP{
+				ObjectMeta: newEgressIPMeta(egressIPName),
+				Spec: egressipv1.EgressIPSpec{
+					EgressIPs: []string{"192.168.126.10", "192.168.126.11"},
+				},
+			}
+			zones := sets.New(types.OvnDefaultZone)
+
+			ginkgo.By("reporting the egress IPs assigned but not confirmed by the cloud yet")
+			status := newEgressIPStatus(eIP, []egressipv1.EgressIPStatusItem{{Node: node1Name, EgressIP: "192.168.126.10"}}, nil, zones)
+			gomega.Expect(meta.IsStatusConditionTrue(status.Conditions, egressipv1.EgressIPConditionAssigned)).To(gomega.BeTrue())
+			programmed := meta.FindStatusCondition(status.Conditions, egressipv1.EgressIPConditionProgrammed)
+			gomega.Expect(programmed).NotTo(gomega.BeNil())
+			gomega.Expect(programmed.Status).To(gomega.Equal(metav1.ConditionFalse))
+			gomega.Expect(programmed.Reason).To(gomega.Equal(egressIPReasonPendingCloud))
+
+			ginkgo.By("reporting the egress IPs confirmed by the cloud and programmed by the zones")
+			eIP.Status = status
+			items := []egressipv1.EgressIPStatusItem{
+				{Node: node1Name, EgressIP: "192.168.126.10"},
+				{Node: node2Name, EgressIP: "192.168.126.11"},
+			}
+			status = newEgressIPStatus(eIP, items, nil, zones)
+			gomega.Expect(meta.FindStatusCondition(status.Conditions, egressipv1.EgressIPConditionProgrammed).Reason).To(gomega.Equal(egressIPReasonPendingZones))
+			eIP.Status = status
+			eIP.Status.Messages = []string{types.GetZoneStatus(types.OvnDefaultZone, "EgressIP programmed")}
+			status = newEgressIPStatus(eIP, items, nil, zones)
+			gomega.Expect(meta.IsStatusConditionTrue(status.Conditions, egressipv1.EgressIPConditionProgrammed)).To(gomega.BeTrue())
+		})
+	})
+
+	ginkgo.Context("EgressIPPool", func() {
+
+		getEgressIPPoolIPs := func(egressIPName string) func() []string {
+			return func() []string {
+				tmp, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), egressIPName, metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				poolIPs, err := util.ParseEgressIPPoolIPs(tmp.Annotations)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				if poolIPs == nil {
+					return nil
+				}
+				return poolIPs.IPs
+			}
+		}
+
+		newEgressNode := func(name, nodeIPv4 string) corev1.Node {
+			return corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Annotations: map[string]string{
+						"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv4\": \"%s\", \"ipv6\": \"%s\"}", nodeIPv4, ""),
+						"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\":[\"%s\", \"%s\"]}", v4NodeSubnet, v6NodeSubnet),
+						util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\"]", nodeIPv4),
+					},
+					Labels: map[string]string{
+						"k8s.ovn.org/egress-assignable": "",
+					},
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{
+							Type:   corev1.NodeReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			}
+		}
+
+		newEgressIPPool := func(name string, cidrs, excludeIPs []string) egressipv1.EgressIPPool {
+			return egressipv1.EgressIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: egressipv1.EgressIPPoolSpec{
+					CIDRs:      cidrs,
+					ExcludeIPs: excludeIPs,
+				},
+			}
+		}
+
+		newPoolEgressIP := func(name, pool string, count int32) egressipv1.EgressIP {
+			return egressipv1.EgressIP{
+				ObjectMeta: newEgressIPMeta(name),
+				Spec: egressipv1.EgressIPSpec{
+					EgressIPPool: &egressipv1.EgressIPPoolRequest{Name: pool, Count: count},
+				},
+			}
+		}
+
+		ginkgo.It("should allocate egress IPs from the pool, skipping the excluded and node IPs, and release them on delete", func() {
+			app.Action = func(*cli.Context) error {
+				pool := newEgressIPPool("pool", []string{"192.168.126.8/29"}, []string{"192.168.126.9", "192.168.126.10"})
+				eIP := newPoolEgressIP(egressIPName, pool.Name, 1)
+				egressNode1 := setupNode(node1Name, []string{"192.168.126.12/24"}, map[string]string{})
+				egressNode2 := setupNode(node2Name, []string{"192.168.126.51/24"}, map[string]string{})
+
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{
+						newEgressNode(node1Name, "192.168.126.12/24"),
+						newEgressNode(node2Name, "192.168.126.51/24"),
+					}},
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+					&egressipv1.EgressIPPoolList{Items: []egressipv1.EgressIPPool{pool}},
+				)
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
+
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Eventually(getEgressIPPoolIPs(eIP.Name)).Should(gomega.Equal([]string{"192.168.126.11"}))
+				gomega.Eventually(getEgressIPStatusLen(eIP.Name)).Should(gomega.Equal(1))
+				egressIPs, _ := getEgressIPStatus(eIP.Name)
+				gomega.Expect(egressIPs).To(gomega.ConsistOf("192.168.126.11"))
+
+				ginkgo.By("allocating more egress IPs when the count is increased, skipping the IP of the node")
+				eIPUpdate, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), eIP.Name, metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				eIPUpdate.Spec.EgressIPPool.Count = 2
+				_, err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Update(context.TODO(), eIPUpdate, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPPoolIPs(eIP.Name)).Should(gomega.Equal([]string{"192.168.126.11", "192.168.126.13"}))
+				gomega.Eventually(getEgressIPStatusLen(eIP.Name)).Should(gomega.Equal(2))
+
+				ginkgo.By("releasing the egress IPs when the EgressIP is deleted")
+				err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Delete(context.TODO(), eIP.Name, metav1.DeleteOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				eIP2 := newPoolEgressIP(egressIPName2, pool.Name, 1)
+				_, err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Create(context.TODO(), &eIP2, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPPoolIPs(eIP2.Name)).Should(gomega.Equal([]string{"192.168.126.11"}))
+				gomega.Eventually(getEgressIPStatusLen(eIP2.Name)).Should(gomega.Equal(1))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should keep the allocated egress IPs on restart and allocate the free ones when the pool is exhausted", func() {
+			app.Action = func(*cli.Context) error {
+				pool := newEgressIPPool("pool", []string{"192.168.126.8/30"}, nil)
+				eIP1 := newPoolEgressIP(egressIPName, pool.Name, 1)
+				eIP1.Annotations = map[string]string{util.EgressIPPoolAnnotation: `{"pool":"pool","ips":["192.168.126.10"]}`}
+				eIP2 := newPoolEgressIP(egressIPName2, pool.Name, 2)
+				egressNode1 := setupNode(node1Name, []string{"192.168.126.12/24"}, map[string]string{})
+				egressNode2 := setupNode(node2Name, []string{"192.168.126.51/24"}, map[string]string{})
+
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{
+						newEgressNode(node1Name, "192.168.126.12/24"),
+						newEgressNode(node2Name, "192.168.126.51/24"),
+					}},
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP1, eIP2}},
+					&egressipv1.EgressIPPoolList{Items: []egressipv1.EgressIPPool{pool}},
+				)
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
+
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Eventually(getEgressIPPoolIPs(eIP1.Name)).Should(gomega.Equal([]string{"192.168.126.10"}))
+				gomega.Eventually(getEgressIPPoolIPs(eIP2.Name)).Should(gomega.Equal([]string{"192.168.126.9"}))
+				gomega.Eventually(getEgressIPStatusLen(eIP1.Name)).Should(gomega.Equal(1))
+				gomega.Eventually(getEgressIPStatusLen(eIP2.Name)).Should(gomega.Equal(1))
+
+				ginkgo.By("allocating the released egress IP to the EgressIP waiting for it")
+				err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Delete(context.TODO(), eIP1.Name, metav1.DeleteOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPPoolIPs(eIP2.Name)).Should(gomega.Equal([]string{"192.168.126.9", "192.168.126.10"}))
+				gomega.Eventually(getEgressIPStatusLen(eIP2.Name)).Should(gomega.Equal(2))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should not allocate the same egress IP from overlapping pools", func() {
+			app.Action = func(*cli.Context) error {
+				pool1 := newEgressIPPool("pool1", []string{"192.168.126.8/30"}, nil)
+				pool2 := newEgressIPPool("pool2", []string{"192.168.126.8/29"}, []string{"192.168.126.11", "192.168.126.13", "192.168.126.14"})
+				eIP1 := newPoolEgressIP(egressIPName, pool1.Name, 1)
+				eIP2 := newPoolEgressIP(egressIPName2, pool2.Name, 2)
+				egressNode1 := setupNode(node1Name, []string{"192.168.126.12/24"}, map[string]string{})
+				egressNode2 := setupNode(node2Name, []string{"192.168.126.51/24"}, map[string]string{})
+
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{
+						newEgressNode(node1Name, "192.168.126.12/24"),
+						newEgressNode(node2Name, "192.168.126.51/24"),
+					}},
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP1, eIP2}},
+					&egressipv1.EgressIPPoolList{Items: []egressipv1.EgressIPPool{pool1, pool2}},
+				)
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
+
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				// both pools only have 192.168.126.9 and 192.168.126.10 to
+				// allocate, which EgressIP gets them depends on the order they
+				// are reconciled in
+				gomega.Eventually(func() []string {
+					return append(getEgressIPPoolIPs(eIP1.Name)(), getEgressIPPoolIPs(eIP2.Name)()...)
+				}).Should(gomega.ConsistOf("192.168.126.9", "192.168.126.10"))
+
+				ginkgo.By("allocating the egress IP released from one pool to the EgressIP waiting on the other pool")
+				err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Delete(context.TODO(), eIP1.Name, metav1.DeleteOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPPoolIPs(eIP2.Name)).Should(gomega.ConsistOf("192.168.126.9", "192.168.126.10"))
+				gomega.Eventually(getEgressIPStatusLen(eIP2.Name)).Should(gomega.Equal(2))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should reallocate the egress IPs which are not part of the pool anymore", func() {
+			app.Action = func(*cli.Context) error {
+				pool := newEgressIPPool("pool", []string{"192.168.126.8/30"}, nil)
+				eIP := newPoolEgressIP(egressIPName, pool.Name, 1)
+				egressNode1 := setupNode(node1Name, []string{"192.168.126.12/24"}, map[string]string{})
+				egressNode2 := setupNode(node2Name, []string{"192.168.126.51/24"}, map[string]string{})
+
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{
+						newEgressNode(node1Name, "192.168.126.12/24"),
+						newEgressNode(node2Name, "192.168.126.51/24"),
+					}},
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+					&egressipv1.EgressIPPoolList{Items: []egressipv1.EgressIPPool{pool}},
+				)
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode1.name] = &egressNode1
+				fakeClusterManagerOVN.eIPC.nodeAllocator.cache[egressNode2.name] = &egressNode2
+
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = fakeClusterManagerOVN.eIPC.WatchEgressIPPools()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Eventually(getEgressIPPoolIPs(eIP.Name)).Should(gomega.Equal([]string{"192.168.126.9"}))
+
+				pool.Spec.CIDRs = []string{"192.168.126.32/30"}
+				_, err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPPools().Update(context.TODO(), &pool, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPPoolIPs(eIP.Name)).Should(gomega.Equal([]string{"192.168.126.33"}))
+				gomega.Eventually(func() []string {
+					egressIPs, _ := getEgressIPStatus(eIP.Name)
+					return egressIPs
+				}).Should(gomega.Equal([]string{"192.168.126.33"}))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+
+	ginkgo.Context("EgressIP node selector and preferences", func() {
+
+		const node3Name = "node3"
+
+		getEgressIPNodes := func(egressIPName string) func() []string {
+			return func() []string {
+				_, nodes := getEgressIPStatus(egressIPName)
+				return nodes
+			}
+		}
+
+		newLabeledEgressNode := func(name, nodeIPv4 string, labels map[string]string) corev1.Node {
+			labels["k8s.ovn.org/egress-assignable"] = ""
+			return corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Annotations: map[string]string{
+						"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv4\": \"%s\"}", nodeIPv4),
+						"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\":\"%s\"}", v4NodeSubnet),
+						util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\"]", nodeIPv4),
+					},
+					Labels: labels,
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{
+							Type:   corev1.NodeReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			}
+		}
+
+		newAffinityEgressIP := func(nodeSelector *metav1.LabelSelector, preferences []egressipv1.EgressIPNodePreference) egressipv1.EgressIP {
+			return egressipv1.EgressIP{
+				ObjectMeta: newEgressIPMeta(egressIPName),
+				Spec: egressipv1.EgressIPSpec{
+					EgressIPs: []string{"192.168.126.101"},
+					NamespaceSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"name": namespace,
+						},
+					},
+					NodeSelector:    nodeSelector,
+					NodePreferences: preferences,
+				},
+			}
+		}
+
+		ginkgo.It("should only assign the egress IPs to the nodes matching the node selector", func() {
+			app.Action = func(*cli.Context) error {
+				node1 := newLabeledEgressNode(node1Name, "192.168.126.12/24", map[string]string{"rack": "a"})
+				node2 := newLabeledEgressNode(node2Name, "192.168.126.51/24", map[string]string{"rack": "b"})
+				eIP := newAffinityEgressIP(&metav1.LabelSelector{MatchLabels: map[string]string{"rack": "b"}}, nil)
+
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{node1, node2}},
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+				)
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Eventually(getEgressIPNodes(eIP.Name)).Should(gomega.Equal([]string{node2.Name}))
+
+				ginkgo.By("moving the egress IP when its node does not match the node selector anymore")
+				node1.Labels["rack"] = "b"
+				_, err = fakeClusterManagerOVN.fakeClient.KubeClient.CoreV1().Nodes().Update(context.TODO(), &node1, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Consistently(getEgressIPNodes(eIP.Name)).Should(gomega.Equal([]string{node2.Name}))
+				node2.Labels["rack"] = "a"
+				_, err = fakeClusterManagerOVN.fakeClient.KubeClient.CoreV1().Nodes().Update(context.TODO(), &node2, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPNodes(eIP.Name)).Should(gomega.Equal([]string{node1.Name}))
+
+				ginkgo.By("unassigning the egress IP when no node matches the node selector")
+				eIPUpdate, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), eIP.Name, metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				eIPUpdate.Spec.NodeSelector.MatchLabels["rack"] = "c"
+				_, err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Update(context.TODO(), eIPUpdate, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPStatusLen(eIP.Name)).Should(gomega.Equal(0))
+				gomega.Eventually(getEgressIPUnassignedReasons(eIP.Name)).Should(gomega.Equal(map[string]string{
+					"192.168.126.101": egressipv1.EgressIPReasonNoSelectedNodes,
+				}))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should assign the egress IPs following the node preferences, also when their node fails", func() {
+			app.Action = func(*cli.Context) error {
+				node1 := newLabeledEgressNode(node1Name, "192.168.126.12/24", map[string]string{"zone": "z1"})
+				node2 := newLabeledEgressNode(node2Name, "192.168.126.51/24", map[string]string{"zone": "z2"})
+				node3 := newLabeledEgressNode(node3Name, "192.168.126.52/24", map[string]string{"zone": "z3"})
+				eIP := newAffinityEgressIP(nil, []egressipv1.EgressIPNodePreference{
+					{
+						NodeSelector: metav1.LabelSelector{MatchLabels: map[string]string{"zone": "z2"}},
+						Type:         egressipv1.EgressIPNodePreferred,
+					},
+					{
+						NodeSelector: metav1.LabelSelector{MatchLabels: map[string]string{"zone": "z1"}},
+						Type:         egressipv1.EgressIPNodeAvoided,
+					},
+				})
+
+				fakeClusterManagerOVN.start(
+					&corev1.NodeList{Items: []corev1.Node{node1, node2, node3}},
+				)
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPAllocatorSizeSafely).Should(gomega.Equal(3))
+				_, err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Create(context.TODO(), &eIP, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Eventually(getEgressIPNodes(eIP.Name)).Should(gomega.Equal([]string{node2.Name}))
+
+				ginkgo.By("moving the egress IP to a node matching no preference when the preferred node fails")
+				node2.Status.Conditions[0].Status = corev1.ConditionFalse
+				_, err = fakeClusterManagerOVN.fakeClient.KubeClient.CoreV1().Nodes().Update(context.TODO(), &node2, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPNodes(eIP.Name)).Should(gomega.Equal([]string{node3.Name}))
+
+				ginkgo.By("moving the egress IP to the avoided node when no other node can host it")
+				node3.Status.Conditions[0].Status = corev1.ConditionFalse
+				_, err = fakeClusterManagerOVN.fakeClient.KubeClient.CoreV1().Nodes().Update(context.TODO(), &node3, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPNodes(eIP.Name)).Should(gomega.Equal([]string{node1.Name}))
+
+				ginkgo.By("keeping the egress IP on its node when the preferred node recovers")
+				node2.Status.Conditions[0].Status = corev1.ConditionTrue
+				_, err = fakeClusterManagerOVN.fakeClient.KubeClient.CoreV1().Nodes().Update(context.TODO(), &node2, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(isEgressAssignableNode(node2.Name)).Should(gomega.BeTrue())
+				gomega.Consistently(getEgressIPNodes(eIP.Name)).Should(gomega.Equal([]string{node1.Name}))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+
 	ginkgo.Context("syncEgressIP for dual-stack", func() {
 
 		// This test validates that if the allocator cache contains valid entries that match
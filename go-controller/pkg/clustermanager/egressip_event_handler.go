@@ -7,6 +7,7 @@ import (
 	ocpcloudnetworkapi "github.com/openshift/api/cloudnetwork/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	cache "k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
@@ -125,6 +126,7 @@ func (h *egressIPClusterControllerEventHandler) UpdateResource(oldObj, newObj in
 		isNewReady := h.eIPC.isEgressNodeReady(newNode)
 		isNewReachable := h.eIPC.isEgressNodeReachable(newNode)
 		isHostCIDRsAltered := util.NodeHostCIDRsAnnotationChanged(oldNode, newNode)
+		isLabelsAltered := !labels.Equals(oldLabels, newLabels)
 		h.eIPC.setNodeEgressReady(newNode.Name, isNewReady)
 		if !oldHadEgressLabel && newHasEgressLabel {
 			klog.Infof("Node: %s has been labeled, adding it for egress assignment", newNode.Name)
@@ -139,7 +141,7 @@ func (h *egressIPClusterControllerEventHandler) UpdateResource(oldObj, newObj in
 			}
 			return nil
 		}
-		if isOldReady == isNewReady && !isHostCIDRsAltered {
+		if isOldReady == isNewReady && !isHostCIDRsAltered && !isLabelsAltered {
 			return nil
 		}
 		if !isNewReady {
@@ -160,6 +162,13 @@ func (h *egressIPClusterControllerEventHandler) UpdateResource(oldObj, newObj in
 				return fmt.Errorf("failed to reconsider egress IPs that are secondary host networks: %v", err)
 			}
 		}
+		if isLabelsAltered && isNewReady {
+			// move the egress IPs of the EgressIPs whose node selector does not
+			// match the node anymore
+			if err := h.eIPC.reconcileNodeSelectorEIPs(newNode); err != nil {
+				return fmt.Errorf("failed to reconsider egress IPs with a node selector: %v", err)
+			}
+		}
 		return nil
 	case factory.CloudPrivateIPConfigType:
 		oldCloudPrivateIPConfig := oldObj.(*ocpcloudnetworkapi.CloudPrivateIPConfig)
@@ -210,7 +219,7 @@ func (h *egressIPClusterControllerEventHandler) SyncFunc(objs []interface{}) err
 	} else {
 		switch h.objType {
 		case factory.EgressIPType:
-			syncFunc = h.eIPC.syncEgressIPMarkAllocator
+			syncFunc = h.eIPC.syncEgressIPs
 		case factory.EgressNodeType:
 			syncFunc = h.eIPC.initEgressNodeReachability
 		case factory.CloudPrivateIPConfigType:
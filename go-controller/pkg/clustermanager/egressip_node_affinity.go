@@ -0,0 +1,136 @@
+package clustermanager
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	egressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	utilerrors "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// egressIPNodeAffinity restricts and ranks the egress nodes an EgressIP's
+// egress IPs are assigned to, following its node selector and node preferences.
+type egressIPNodeAffinity struct {
+	// nodeSelector the nodes must match, nil if any node can be used
+	nodeSelector labels.Selector
+	// preferences in the order of the spec
+	preferences []labels.Selector
+	avoided     []bool
+}
+
+func newEgressIPNodeAffinity(spec *egressipv1.EgressIPSpec) (*egressIPNodeAffinity, error) {
+	affinity := &egressIPNodeAffinity{}
+	if spec.NodeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.NodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node selector: %v", err)
+		}
+		affinity.nodeSelector = selector
+	}
+	for i, preference := range spec.NodePreferences {
+		selector, err := metav1.LabelSelectorAsSelector(&preference.NodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node selector of node preference %d: %v", i, err)
+		}
+		affinity.preferences = append(affinity.preferences, selector)
+		affinity.avoided = append(affinity.avoided, preference.Type == egressipv1.EgressIPNodeAvoided)
+	}
+	return affinity, nil
+}
+
+// matches returns true if the egress IPs can be assigned to the node
+func (a *egressIPNodeAffinity) matches(node *corev1.Node) bool {
+	return a == nil || a.nodeSelector == nil || a.nodeSelector.Matches(labels.Set(node.Labels))
+}
+
+// rank returns the rank of the node, the nodes with the lowest rank are picked
+// first: the preferred nodes in the order of the preferences, then the nodes
+// matching no preference, then the avoided nodes.
+func (a *egressIPNodeAffinity) rank(node *corev1.Node) int {
+	if a == nil {
+		return 0
+	}
+	for i, selector := range a.preferences {
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		if a.avoided[i] {
+			return len(a.preferences) + 1
+		}
+		return i
+	}
+	return len(a.preferences)
+}
+
+// filterAndRankEgressNodes returns the egress nodes matching the node selector
+// of the EgressIP, sorted by their rank. The order of the nodes of the same rank
+// is kept, so these remain sorted by their amount of allocations.
+func (eIPC *egressIPClusterController) filterAndRankEgressNodes(name string, affinity *egressIPNodeAffinity, eNodes []*egressNode) []*egressNode {
+	if affinity == nil || (affinity.nodeSelector == nil && len(affinity.preferences) == 0) {
+		return eNodes
+	}
+	filtered := make([]*egressNode, 0, len(eNodes))
+	ranks := make(map[string]int, len(eNodes))
+	for _, eNode := range eNodes {
+		node, err := eIPC.watchFactory.GetNode(eNode.name)
+		if err != nil {
+			klog.Warningf("Failed to determine if node %s may host EgressIP %s because unable to get node obj: %v",
+				eNode.name, name, err)
+			continue
+		}
+		if !affinity.matches(node) {
+			continue
+		}
+		filtered = append(filtered, eNode)
+		ranks[eNode.name] = affinity.rank(node)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return ranks[filtered[i].name] < ranks[filtered[j].name]
+	})
+	return filtered
+}
+
+// reconcileNodeSelectorEIPs sends a 'synthetic' reconcile for the EgressIPs
+// with an egress IP assigned to the node which does not match their node
+// selector anymore, moving the egress IP to another node.
+func (eIPC *egressIPClusterController) reconcileNodeSelectorEIPs(node *corev1.Node) error {
+	var errorAggregate []error
+	egressIPs, err := eIPC.kube.GetEgressIPs()
+	if err != nil {
+		return fmt.Errorf("unable to list EgressIPs, err: %v", err)
+	}
+	for _, egressIP := range egressIPs {
+		if egressIP.Spec.NodeSelector == nil {
+			continue
+		}
+		affinity, err := newEgressIPNodeAffinity(&egressIP.Spec)
+		if err != nil {
+			klog.Errorf("Failed to process the node selector of EgressIP %s: %v", egressIP.Name, err)
+			continue
+		}
+		if affinity.matches(node) {
+			continue
+		}
+		for _, status := range egressIP.Status.Items {
+			if status.Node != node.Name {
+				continue
+			}
+			klog.Infof("Node %s does not match the node selector of EgressIP %s anymore, moving its egress IP %s",
+				node.Name, egressIP.Name, status.EgressIP)
+			if err := eIPC.reconcileEgressIP(nil, egressIP.DeepCopy()); err != nil {
+				errorAggregate = append(errorAggregate, fmt.Errorf("re-assignment for EgressIP %s not matching node %s "+
+					"failed, unable to update object, err: %v", egressIP.Name, node.Name, err))
+			}
+			break
+		}
+	}
+	if len(errorAggregate) > 0 {
+		return utilerrors.Join(errorAggregate...)
+	}
+	return nil
+}
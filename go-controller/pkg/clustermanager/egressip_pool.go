@@ -0,0 +1,503 @@
+package clustermanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	bitmapallocator "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/allocator/bitmap"
+	ipallocator "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/allocator/ip"
+	egressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// egressIPPool allocates the egress IPs of an EgressIPPool to the EgressIPs
+// requesting egress IPs from it.
+type egressIPPool struct {
+	// spec the allocator was built from
+	spec egressipv1.EgressIPPoolSpec
+	// ranges of the CIDRs of the pool, with the excluded IPs allocated
+	ranges []*ipallocator.Range
+	// owners holds the name of the EgressIP each egress IP is allocated to.
+	// IPs allocated on the ranges without an owner are either excluded or
+	// conflicting with an IP of the cluster, and are never allocated.
+	owners map[string]string
+}
+
+func newEgressIPPool(spec egressipv1.EgressIPPoolSpec) (*egressIPPool, error) {
+	pool := &egressIPPool{
+		spec:   *spec.DeepCopy(),
+		ranges: make([]*ipallocator.Range, 0, len(spec.CIDRs)),
+		owners: map[string]string{},
+	}
+	for _, cidr := range spec.CIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %s: %v", cidr, err)
+		}
+		// allocate the egress IPs in order, starting from the first IP of the CIDR
+		r, err := ipallocator.NewAllocatorCIDRRange(ipNet, func(max int, rangeSpec string) (bitmapallocator.Interface, error) {
+			return bitmapallocator.NewContiguousAllocationMap(max, rangeSpec), nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create allocator for CIDR %s: %v", cidr, err)
+		}
+		pool.ranges = append(pool.ranges, r)
+	}
+	for _, excludeIP := range spec.ExcludeIPs {
+		ip := net.ParseIP(excludeIP)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid excluded IP %s", excludeIP)
+		}
+		pool.exclude(ip)
+	}
+	return pool, nil
+}
+
+// reserve allocates ip to the EgressIP name, it returns false if ip is not
+// part of the pool or is allocated to another EgressIP.
+func (p *egressIPPool) reserve(name, ip string) bool {
+	if owner, allocated := p.owners[ip]; allocated {
+		return owner == name
+	}
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil || parsedIP.String() != ip {
+		return false
+	}
+	for _, r := range p.ranges {
+		if err := r.Allocate(parsedIP); err == nil {
+			p.owners[ip] = name
+			return true
+		}
+	}
+	return false
+}
+
+// allocateNext allocates the next free IP of the pool to the EgressIP name.
+func (p *egressIPPool) allocateNext(name string) (net.IP, error) {
+	for _, r := range p.ranges {
+		ip, err := r.AllocateNext()
+		if ipallocator.IsErrFull(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.owners[ip.String()] = name
+		return ip, nil
+	}
+	return nil, ipallocator.ErrFull
+}
+
+// exclude makes sure ip is never allocated again, until the pool is rebuilt.
+func (p *egressIPPool) exclude(ip net.IP) {
+	delete(p.owners, ip.String())
+	for _, r := range p.ranges {
+		if err := r.Allocate(ip); err == nil || ipallocator.IsErrAllocated(err) {
+			return
+		}
+	}
+}
+
+// release releases ip if it is allocated to the EgressIP name.
+func (p *egressIPPool) release(name, ip string) {
+	if owner, allocated := p.owners[ip]; !allocated || owner != name {
+		return
+	}
+	delete(p.owners, ip)
+	parsedIP := net.ParseIP(ip)
+	for _, r := range p.ranges {
+		r.Release(parsedIP)
+	}
+}
+
+// allocated returns the IPs of the pool allocated to the EgressIP name.
+func (p *egressIPPool) allocated(name string) []string {
+	var ips []string
+	for ip, owner := range p.owners {
+		if owner == name {
+			ips = append(ips, ip)
+		}
+	}
+	slices.Sort(ips)
+	return ips
+}
+
+// getEgressIPs returns the egress IPs requested by the EgressIP: the egress IPs
+// listed in its spec and the ones allocated to it from an EgressIPPool.
+func getEgressIPs(egressIP *egressipv1.EgressIP) []string {
+	poolIPs, err := util.ParseEgressIPPoolIPs(egressIP.Annotations)
+	if err != nil {
+		klog.Errorf("Ignoring the egress IPs allocated to EgressIP %s from an EgressIPPool: %v", egressIP.Name, err)
+	}
+	if poolIPs == nil {
+		return egressIP.Spec.EgressIPs
+	}
+	return append(slices.Clone(egressIP.Spec.EgressIPs), poolIPs.IPs...)
+}
+
+// getEgressIPPool returns the allocator of the EgressIPPool name, or nil if
+// the EgressIPPool doesn't exist. The allocator is rebuilt when the spec of
+// the EgressIPPool changed, keeping the allocations that are still part of
+// it. Must be called with egressIPAssignmentMutex held.
+func (eIPC *egressIPClusterController) getEgressIPPool(name string) (*egressIPPool, error) {
+	pool := eIPC.egressIPPools[name]
+	eIPPool, err := eIPC.watchFactory.EgressIPPoolInformer().Lister().Get(name)
+	if apierrors.IsNotFound(err) {
+		// keep the allocations until they are released, in case the
+		// EgressIPPool is recreated
+		if pool != nil && len(pool.owners) == 0 {
+			delete(eIPC.egressIPPools, name)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EgressIPPool %s: %v", name, err)
+	}
+	if pool != nil && reflect.DeepEqual(pool.spec, eIPPool.Spec) {
+		return pool, nil
+	}
+	newPool, err := newEgressIPPool(eIPPool.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EgressIPPool %s: %v", name, err)
+	}
+	if pool != nil {
+		for ip, owner := range pool.owners {
+			if !newPool.reserve(owner, ip) {
+				klog.Infof("Egress IP %s allocated to EgressIP %s is not part of EgressIPPool %s anymore", ip, owner, name)
+			}
+		}
+	}
+	eIPC.egressIPPools[name] = newPool
+	return newPool, nil
+}
+
+// reconcileEgressIPPoolIPs allocates and releases the egress IPs of the
+// EgressIPPool requested by the EgressIP so that it is allocated as many egress
+// IPs as requested, and persists them in the EgressIP annotations. It returns
+// the EgressIP with the allocated egress IPs annotated. Must be called with
+// egressIPAssignmentMutex held.
+func (eIPC *egressIPClusterController) reconcileEgressIPPoolIPs(egressIP *egressipv1.EgressIP) (*egressipv1.EgressIP, error) {
+	poolIPs, err := util.ParseEgressIPPoolIPs(egressIP.Annotations)
+	if err != nil {
+		// the annotation is only set by us, start over
+		klog.Errorf("Reallocating the egress IPs of EgressIP %s from an EgressIPPool: %v", egressIP.Name, err)
+		poolIPs = nil
+	}
+	request := egressIP.Spec.EgressIPPool
+	if poolIPs != nil && (request == nil || request.Name != poolIPs.Pool) {
+		eIPC.releaseEgressIPPoolIPs(egressIP.Name, poolIPs)
+		poolIPs = nil
+	}
+	if request != nil {
+		if poolIPs, err = eIPC.allocateEgressIPPoolIPs(egressIP.Name, request, poolIPs); err != nil {
+			return nil, err
+		}
+	}
+
+	annotation := ""
+	if poolIPs != nil {
+		bytes, err := json.Marshal(poolIPs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the egress IPs allocated to EgressIP %s: %v", egressIP.Name, err)
+		}
+		annotation = string(bytes)
+	}
+	if annotation == egressIP.Annotations[util.EgressIPPoolAnnotation] {
+		return egressIP, nil
+	}
+	if err := eIPC.patchEgressIP(egressIP.Name, generateAnnotationPatchOp(egressIP.Annotations, util.EgressIPPoolAnnotation, annotation)); err != nil {
+		return nil, fmt.Errorf("failed to patch the egress IPs allocated to EgressIP %s: %v", egressIP.Name, err)
+	}
+	egressIP = egressIP.DeepCopy()
+	if annotation == "" {
+		delete(egressIP.Annotations, util.EgressIPPoolAnnotation)
+	} else {
+		if egressIP.Annotations == nil {
+			egressIP.Annotations = map[string]string{}
+		}
+		egressIP.Annotations[util.EgressIPPoolAnnotation] = annotation
+	}
+	return egressIP, nil
+}
+
+// allocateEgressIPPoolIPs returns the egress IPs of the EgressIPPool requested
+// allocated to the EgressIP name. The egress IPs already allocated are kept if
+// they are still part of the EgressIPPool, and new ones are allocated if needed.
+func (eIPC *egressIPClusterController) allocateEgressIPPoolIPs(name string, request *egressipv1.EgressIPPoolRequest,
+	poolIPs *util.EgressIPPoolIPs) (*util.EgressIPPoolIPs, error) {
+	pool, err := eIPC.getEgressIPPool(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	eIPRef := corev1.ObjectReference{
+		Kind: "EgressIP",
+		Name: name,
+	}
+	if pool == nil {
+		// keep using the egress IPs already allocated, they are reserved
+		// again if the EgressIPPool is recreated
+		eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "EgressIPPoolNotFound",
+			"EgressIPPool %s requested by EgressIP %s does not exist", request.Name, name)
+		return poolIPs, nil
+	}
+
+	count := int(request.Count)
+	ips := make([]string, 0, count)
+	if poolIPs != nil {
+		for _, ip := range poolIPs.IPs {
+			if len(ips) < count && !eIPC.isAllocatedFromOtherEgressIPPool(request.Name, name, ip) && pool.reserve(name, ip) {
+				ips = append(ips, ip)
+			}
+		}
+	}
+	// egress IPs allocated to the EgressIP which failed to be persisted
+	for _, ip := range pool.allocated(name) {
+		if len(ips) < count && !slices.Contains(ips, ip) {
+			ips = append(ips, ip)
+		}
+	}
+	var specIPs sets.Set[string]
+	// egress IPs allocated from an overlapping EgressIPPool, released once
+	// done so that they can be allocated when freed by the other EgressIPPool
+	var otherPoolIPs []string
+	for len(ips) < count {
+		ip, err := pool.allocateNext(name)
+		if ipallocator.IsErrFull(err) {
+			eIPC.recorder.Eventf(&eIPRef, corev1.EventTypeWarning, "EgressIPPoolExhausted",
+				"EgressIPPool %s has no free egress IP left, EgressIP %s is allocated %d egress IPs out of %d requested",
+				request.Name, name, len(ips), count)
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate egress IP for EgressIP %s from EgressIPPool %s: %v", name, request.Name, err)
+		}
+		if specIPs == nil {
+			if specIPs, err = eIPC.getEgressIPSpecIPs(); err != nil {
+				pool.release(name, ip.String())
+				return nil, err
+			}
+		}
+		// never hand out an IP of a node or an egress IP requested explicitly
+		conflict, _, err := eIPC.isEgressIPAddrConflict(ip)
+		if err != nil {
+			pool.release(name, ip.String())
+			return nil, err
+		}
+		if conflict || specIPs.Has(ip.String()) {
+			klog.Infof("Skipping egress IP %s of EgressIPPool %s, it is already in use", ip, request.Name)
+			pool.exclude(ip)
+			continue
+		}
+		if eIPC.isAllocatedFromOtherEgressIPPool(request.Name, name, ip.String()) {
+			klog.Infof("Skipping egress IP %s of EgressIPPool %s, it is allocated from another EgressIPPool", ip, request.Name)
+			otherPoolIPs = append(otherPoolIPs, ip.String())
+			continue
+		}
+		ips = append(ips, ip.String())
+	}
+	for _, ip := range otherPoolIPs {
+		pool.release(name, ip)
+	}
+	// release the egress IPs not needed anymore
+	for _, ip := range pool.allocated(name) {
+		if !slices.Contains(ips, ip) {
+			pool.release(name, ip)
+		}
+	}
+	return &util.EgressIPPoolIPs{Pool: request.Name, IPs: ips}, nil
+}
+
+// isAllocatedFromOtherEgressIPPool returns whether ip is allocated to another
+// EgressIP than name from an EgressIPPool other than pool, as the CIDRs of
+// EgressIPPools may overlap. Must be called with egressIPAssignmentMutex held.
+func (eIPC *egressIPClusterController) isAllocatedFromOtherEgressIPPool(pool, name, ip string) bool {
+	for poolName, otherPool := range eIPC.egressIPPools {
+		if poolName == pool {
+			continue
+		}
+		if owner, allocated := otherPool.owners[ip]; allocated && owner != name {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseEgressIPPoolIPs releases the egress IPs allocated to the EgressIP name,
+// and resyncs the EgressIPs waiting for free egress IPs, from any EgressIPPool
+// as they may overlap.
+// Must be called with egressIPAssignmentMutex held.
+func (eIPC *egressIPClusterController) releaseEgressIPPoolIPs(name string, poolIPs *util.EgressIPPoolIPs) {
+	pool := eIPC.egressIPPools[poolIPs.Pool]
+	if pool == nil {
+		return
+	}
+	for _, ip := range poolIPs.IPs {
+		pool.release(name, ip)
+	}
+	for _, ip := range pool.allocated(name) {
+		pool.release(name, ip)
+	}
+	waiting := func(egressIP *egressipv1.EgressIP) bool {
+		if egressIP.Name == name {
+			return false
+		}
+		allocated, err := util.ParseEgressIPPoolIPs(egressIP.Annotations)
+		return err != nil || allocated == nil || len(allocated.IPs) < int(egressIP.Spec.EgressIPPool.Count)
+	}
+	for poolName := range eIPC.egressIPPools {
+		eIPC.resyncEgressIPPool(poolName, waiting)
+	}
+}
+
+// getEgressIPSpecIPs returns the egress IPs listed in the spec of all EgressIPs
+func (eIPC *egressIPClusterController) getEgressIPSpecIPs() (sets.Set[string], error) {
+	egressIPs, err := eIPC.watchFactory.GetEgressIPs()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list EgressIPs, err: %v", err)
+	}
+	specIPs := sets.New[string]()
+	for _, egressIP := range egressIPs {
+		for _, specIP := range egressIP.Spec.EgressIPs {
+			if ip := net.ParseIP(specIP); ip != nil {
+				specIPs.Insert(ip.String())
+			}
+		}
+	}
+	return specIPs, nil
+}
+
+// resyncEgressIPPool queues for reconciliation the EgressIPs requesting egress
+// IPs from the EgressIPPool name and matching filter.
+func (eIPC *egressIPClusterController) resyncEgressIPPool(name string, filter func(*egressipv1.EgressIP) bool) {
+	if eIPC.retryEgressIPs == nil {
+		return
+	}
+	egressIPs, err := eIPC.watchFactory.GetEgressIPs()
+	if err != nil {
+		klog.Errorf("Failed to resync the EgressIPs of EgressIPPool %s: unable to list EgressIPs: %v", name, err)
+		return
+	}
+	resync := false
+	for _, egressIP := range egressIPs {
+		if egressIP.Spec.EgressIPPool == nil || egressIP.Spec.EgressIPPool.Name != name || !filter(egressIP) {
+			continue
+		}
+		klog.V(5).Infof("Adding EgressIP %s for immediate retry due to EgressIPPool %s change", egressIP.Name, name)
+		if err := eIPC.retryEgressIPs.AddRetryObjWithAddNoBackoff(egressIP); err != nil {
+			klog.Warningf("Failed to add EgressIP %s to retry queue: %v", egressIP.Name, err)
+			continue
+		}
+		resync = true
+	}
+	if resync {
+		eIPC.retryEgressIPs.RequestRetryObjs()
+	}
+}
+
+// WatchEgressIPPools resyncs the EgressIPs requesting egress IPs from an
+// EgressIPPool whenever the EgressIPPool changes.
+func (eIPC *egressIPClusterController) WatchEgressIPPools() error {
+	all := func(*egressipv1.EgressIP) bool { return true }
+	_, err := eIPC.watchFactory.EgressIPPoolInformer().Informer().AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			// the EgressIPs are reconciled with the existing EgressIPPools on startup
+			if isInInitialList {
+				return
+			}
+			eIPC.resyncEgressIPPool(obj.(*egressipv1.EgressIPPool).Name, all)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPool := oldObj.(*egressipv1.EgressIPPool)
+			newPool := newObj.(*egressipv1.EgressIPPool)
+			if reflect.DeepEqual(oldPool.Spec, newPool.Spec) {
+				return
+			}
+			eIPC.resyncEgressIPPool(newPool.Name, all)
+		},
+		DeleteFunc: func(obj interface{}) {
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				klog.Errorf("Failed to get key of deleted EgressIPPool %+v: %v", obj, err)
+				return
+			}
+			eIPC.resyncEgressIPPool(key, all)
+		},
+	})
+	return err
+}
+
+// syncEgressIPPoolAllocator reserves the egress IPs allocated to each EgressIP
+// from an EgressIPPool, before any EgressIP is reconciled and can be allocated
+// new egress IPs.
+func (eIPC *egressIPClusterController) syncEgressIPPoolAllocator(egressIPs []interface{}) error {
+	eIPC.egressIPAssignmentMutex.Lock()
+	defer eIPC.egressIPAssignmentMutex.Unlock()
+	for _, object := range egressIPs {
+		egressIP, ok := object.(*egressipv1.EgressIP)
+		if !ok {
+			return fmt.Errorf("failed to cast %T to *egressipv1.EgressIP", object)
+		}
+		poolIPs, err := util.ParseEgressIPPoolIPs(egressIP.Annotations)
+		if err != nil {
+			klog.Errorf("Failed to sync EgressIPPool allocator for EgressIP %s: %v", egressIP.Name, err)
+			continue
+		}
+		if poolIPs == nil {
+			continue
+		}
+		pool, err := eIPC.getEgressIPPool(poolIPs.Pool)
+		if err != nil {
+			klog.Errorf("Failed to sync EgressIPPool allocator for EgressIP %s: %v", egressIP.Name, err)
+			continue
+		}
+		if pool == nil {
+			continue
+		}
+		var notReserved []string
+		for _, ip := range poolIPs.IPs {
+			if eIPC.isAllocatedFromOtherEgressIPPool(poolIPs.Pool, egressIP.Name, ip) || !pool.reserve(egressIP.Name, ip) {
+				notReserved = append(notReserved, ip)
+			}
+		}
+		if len(notReserved) > 0 {
+			klog.Warningf("Egress IPs %s allocated to EgressIP %s can't be reserved on EgressIPPool %s, they will be reallocated",
+				strings.Join(notReserved, ", "), egressIP.Name, poolIPs.Pool)
+		}
+	}
+	return nil
+}
+
+// generateAnnotationPatchOp returns the patch setting the annotation key of the
+// EgressIP with the given annotations to value, or removing it if value is empty,
+// preserving the other annotations.
+func generateAnnotationPatchOp(annotations map[string]string, key, value string) jsonPatchOperation {
+	path := "/metadata/annotations/" + strings.ReplaceAll(key, "/", "~1")
+	if value == "" {
+		return jsonPatchOperation{
+			Operation: "remove",
+			Path:      path,
+		}
+	}
+	if annotations == nil {
+		return jsonPatchOperation{
+			Operation: "add",
+			Path:      "/metadata/annotations",
+			Value:     map[string]string{key: value},
+		}
+	}
+	return jsonPatchOperation{
+		Operation: "add",
+		Path:      path,
+		Value:     value,
+	}
+}
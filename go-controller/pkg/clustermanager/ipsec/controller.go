@@ -0,0 +1,204 @@
+package ipsec
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	certificateslisters "k8s.io/client-go/listers/certificates/v1"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/certificate/csr"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
+	controllerutil "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/controller"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/csrapprover"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/factory"
+)
+
+const (
+	controllerName = "ipsec-certificate-signer"
+	// certificateBackdate tolerates clock skew between the cluster manager and the nodes
+	certificateBackdate = 5 * time.Minute
+)
+
+// Controller signs the IPsec certificates requested by the nodes once they have been
+// approved by the ovnkube CSR approver. Nodes use these certificates to authenticate
+// the IPsec tunnels between each other.
+type Controller struct {
+	client    kubernetes.Interface
+	csrLister certificateslisters.CertificateSigningRequestLister
+
+	csrController controllerutil.Controller
+
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+}
+
+// NewController creates a new IPsec certificate signer, loading the CA from the
+// configured IPsec CA certificate and key.
+func NewController(client kubernetes.Interface, wf *factory.WatchFactory) (*Controller, error) {
+	caCert, caKey, err := loadCA(config.IPsec.CACert, config.IPsec.CAKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Controller{
+		client:    client,
+		csrLister: wf.CertificateSigningRequestInformer().Lister(),
+		caCert:    caCert,
+		caKey:     caKey,
+	}
+
+	csrConfig := &controllerutil.ControllerConfig[certificatesv1.CertificateSigningRequest]{
+		RateLimiter:    workqueue.DefaultTypedControllerRateLimiter[string](),
+		Reconcile:      c.reconcile,
+		Threadiness:    1,
+		Informer:       wf.CertificateSigningRequestInformer().Informer(),
+		Lister:         wf.CertificateSigningRequestInformer().Lister().List,
+		ObjNeedsUpdate: csrNeedsSigning,
+	}
+	c.csrController = controllerutil.NewController(controllerName, csrConfig)
+
+	return c, nil
+}
+
+// Start starts the IPsec certificate signer
+func (c *Controller) Start() error {
+	klog.Infof("Starting %s controller", controllerName)
+	return controllerutil.Start(c.csrController)
+}
+
+// Stop stops the IPsec certificate signer
+func (c *Controller) Stop() {
+	klog.Infof("Stopping %s controller", controllerName)
+	controllerutil.Stop(c.csrController)
+}
+
+func loadCA(certFile, keyFile string) (*x509.Certificate, crypto.Signer, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, nil, fmt.Errorf("signing IPsec certificates requires both the IPsec CA certificate and key")
+	}
+	certs, err := cert.CertsFromFile(certFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load IPsec CA certificate: %w", err)
+	}
+	key, err := keyutil.PrivateKeyFromFile(keyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load IPsec CA key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("IPsec CA key %s cannot be used for signing", keyFile)
+	}
+	return certs[0], signer, nil
+}
+
+func isApproved(req *certificatesv1.CertificateSigningRequest) bool {
+	approved := false
+	for _, c := range req.Status.Conditions {
+		switch c.Type {
+		case certificatesv1.CertificateApproved:
+			approved = true
+		case certificatesv1.CertificateDenied, certificatesv1.CertificateFailed:
+			return false
+		}
+	}
+	return approved
+}
+
+// csrNeedsSigning tells if a CSR is an approved IPsec CSR that has not been signed yet
+func csrNeedsSigning(_, req *certificatesv1.CertificateSigningRequest) bool {
+	return req != nil && req.Spec.SignerName == csrapprover.IPsecSignerName &&
+		len(req.Status.Certificate) == 0 && isApproved(req)
+}
+
+func (c *Controller) reconcile(key string) error {
+	startTime := time.Now()
+	req, err := c.csrLister.Get(key)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !csrNeedsSigning(nil, req) {
+		return nil
+	}
+
+	certificate, err := c.sign(req)
+	if err != nil {
+		// the request can't be signed, there is no point in retrying
+		klog.Errorf("Failed to sign IPsec CSR %s: %v", req.Name, err)
+		return nil
+	}
+
+	req = req.DeepCopy()
+	req.Status.Certificate = certificate
+	_, err = c.client.CertificatesV1().CertificateSigningRequests().UpdateStatus(context.TODO(), req, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update the certificate of IPsec CSR %s: %w", req.Name, err)
+	}
+	klog.Infof("Signed IPsec CSR %s for %s in %v", req.Name, req.Spec.Username, time.Since(startTime))
+	return nil
+}
+
+// sign issues a certificate for the CSR, valid for the requested duration but
+// never beyond the validity of the CA. The certificate only identifies the chassis
+// ID the CSR approver checked the common name against, any other subject field or
+// alternative name of the request is ignored.
+func (c *Controller) sign(req *certificatesv1.CertificateSigningRequest) ([]byte, error) {
+	block, _ := pem.Decode(req.Spec.Request)
+	if block == nil {
+		return nil, fmt.Errorf("no certificate request found in .spec.request")
+	}
+	x509CSR, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate request: %w", err)
+	}
+	if err = x509CSR.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid certificate request signature: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	now := time.Now()
+	duration := config.IPsec.CertDuration
+	if req.Spec.ExpirationSeconds != nil {
+		duration = csr.ExpirationSecondsToDuration(*req.Spec.ExpirationSeconds)
+	}
+	notAfter := now.Add(duration)
+	if notAfter.After(c.caCert.NotAfter) {
+		notAfter = c.caCert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: x509CSR.Subject.CommonName},
+		DNSNames:              []string{x509CSR.Subject.CommonName},
+		NotBefore:             now.Add(-certificateBackdate),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, c.caCert, x509CSR.PublicKey, c.caKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: der}), nil
+}
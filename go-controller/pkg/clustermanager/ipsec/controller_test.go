@@ -0,0 +1,145 @@
+package ipsec
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	certificateslisters "k8s.io/client-go/listers/certificates/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/certificate/csr"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/csrapprover"
+)
+
+var _ = Describe("IPsec certificate signer", func() {
+	const chassisID = "5a3f8d1e-3c52-4b4a-9b7b-2f3e1b0c9d41"
+
+	var (
+		caCert *x509.Certificate
+		caKey  *ecdsa.PrivateKey
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		var err error
+		caKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		caCert, err = cert.NewSelfSignedCACert(cert.Config{CommonName: "ipsec-ca"}, caKey)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	newCSRWithSANs := func(signerName string, dnsNames []string, ipAddresses []net.IP,
+		conditions ...certificatesv1.CertificateSigningRequestCondition) *certificatesv1.CertificateSigningRequest {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		request, err := cert.MakeCSR(key, &pkix.Name{CommonName: chassisID, Organization: []string{"system:masters"}}, dnsNames, ipAddresses)
+		Expect(err).NotTo(HaveOccurred())
+		return &certificatesv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "ipsec-test-node"},
+			Spec: certificatesv1.CertificateSigningRequestSpec{
+				Request:           request,
+				SignerName:        signerName,
+				Usages:            []certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel},
+				Username:          "system:ovn-node:test-node",
+				ExpirationSeconds: csr.DurationToExpirationSeconds(time.Hour),
+			},
+			Status: certificatesv1.CertificateSigningRequestStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+
+	newCSR := func(signerName string, conditions ...certificatesv1.CertificateSigningRequestCondition) *certificatesv1.CertificateSigningRequest {
+		return newCSRWithSANs(signerName, []string{chassisID}, nil, conditions...)
+	}
+
+	approved := certificatesv1.CertificateSigningRequestCondition{
+		Type:   certificatesv1.CertificateApproved,
+		Status: corev1.ConditionTrue,
+	}
+
+	newController := func(req *certificatesv1.CertificateSigningRequest) *Controller {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		Expect(indexer.Add(req)).To(Succeed())
+		return &Controller{
+			client:    fake.NewSimpleClientset(req),
+			csrLister: certificateslisters.NewCertificateSigningRequestLister(indexer),
+			caCert:    caCert,
+			caKey:     caKey,
+		}
+	}
+
+	getCertificate := func(c *Controller, name string) []byte {
+		req, err := c.client.CertificatesV1().CertificateSigningRequests().Get(context.TODO(), name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return req.Status.Certificate
+	}
+
+	It("signs approved IPsec certificate requests with the IPsec CA", func() {
+		req := newCSR(csrapprover.IPsecSignerName, approved)
+		c := newController(req)
+		Expect(c.reconcile(req.Name)).To(Succeed())
+
+		certs, err := cert.ParseCertsPEM(getCertificate(c, req.Name))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(certs).To(HaveLen(1))
+		Expect(certs[0].Subject.CommonName).To(Equal(chassisID))
+		Expect(certs[0].DNSNames).To(ConsistOf(chassisID))
+		Expect(certs[0].NotAfter).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+		Expect(certs[0].CheckSignatureFrom(caCert)).To(Succeed())
+	})
+
+	It("only certifies the chassis ID of the request", func() {
+		req := newCSRWithSANs(csrapprover.IPsecSignerName, []string{chassisID, "kubernetes.default.svc"},
+			[]net.IP{net.ParseIP("10.0.0.1")}, approved)
+		c := newController(req)
+		Expect(c.reconcile(req.Name)).To(Succeed())
+
+		certs, err := cert.ParseCertsPEM(getCertificate(c, req.Name))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(certs).To(HaveLen(1))
+		Expect(certs[0].Subject.CommonName).To(Equal(chassisID))
+		Expect(certs[0].Subject.Organization).To(BeEmpty())
+		Expect(certs[0].DNSNames).To(ConsistOf(chassisID))
+		Expect(certs[0].IPAddresses).To(BeEmpty())
+	})
+
+	It("does not sign requests that are not approved", func() {
+		req := newCSR(csrapprover.IPsecSignerName)
+		c := newController(req)
+		Expect(c.reconcile(req.Name)).To(Succeed())
+		Expect(getCertificate(c, req.Name)).To(BeEmpty())
+	})
+
+	It("does not sign denied requests", func() {
+		req := newCSR(csrapprover.IPsecSignerName, approved, certificatesv1.CertificateSigningRequestCondition{
+			Type:   certificatesv1.CertificateDenied,
+			Status: corev1.ConditionTrue,
+		})
+		c := newController(req)
+		Expect(c.reconcile(req.Name)).To(Succeed())
+		Expect(getCertificate(c, req.Name)).To(BeEmpty())
+	})
+
+	It("does not sign requests for other signers", func() {
+		req := newCSR(certificatesv1.KubeAPIServerClientSignerName, approved)
+		c := newController(req)
+		Expect(c.reconcile(req.Name)).To(Succeed())
+		Expect(getCertificate(c, req.Name)).To(BeEmpty())
+	})
+})
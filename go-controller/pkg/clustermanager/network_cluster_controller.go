@@ -31,6 +31,7 @@ import (
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/networkmanager"
+	lsm "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/persistentips"
 	objretry "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/retry"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
@@ -558,6 +559,10 @@ func (ncc *networkClusterController) Cleanup() error {
 		}
 	}
 
+	if ncc.hasPodAllocation() {
+		metrics.DeleteNetworkIPAMMetrics(ncc.GetNetworkName())
+	}
+
 	return nil
 }
 
@@ -881,7 +886,11 @@ func (h *networkClusterControllerEventHandler) clearInitialNodeNetworkUnavailabl
 // newIPAllocatorForNetwork returns an initialized subnet allocator for the
 // subnets / excluded subnets provided in `netInfo`
 func newIPAllocatorForNetwork(netInfo util.NetInfo) (subnet.Allocator, error) {
-	ipAllocator := subnet.NewAllocator()
+	var ipAllocator subnet.Allocator = subnet.NewAllocator()
+	if isLayer2UserDefinedPrimaryNetwork(netInfo) {
+		// the switch of the network is the source of the service health checks
+		ipAllocator = lsm.NewServiceMonitorIPAllocator()
+	}
 
 	subnets := netInfo.Subnets()
 	ipNets := make([]*net.IPNet, 0, len(subnets))
@@ -895,6 +904,18 @@ func newIPAllocatorForNetwork(netInfo util.NetInfo) (subnet.Allocator, error) {
 		excludeSubnets = append(excludeSubnets, infrastructureExcludeCIDRs(netInfo)...)
 	}
 
+	if isLayer2UserDefinedPrimaryNetwork(netInfo) && config.OVNKubernetesFeature.EnableServiceHealthChecks {
+		for _, subnet := range ipNets {
+			excludeIP := util.GetNodeServiceMonitorExcludeIP(subnet)
+			if excludeIP == nil {
+				continue
+			}
+			if !util.IsContainedInAnyCIDR(excludeIP, excludeSubnets...) {
+				excludeSubnets = append(excludeSubnets, excludeIP)
+			}
+		}
+	}
+
 	if err := ipAllocator.AddOrUpdateSubnet(subnet.SubnetConfig{
 		Name:            netInfo.GetNetworkName(),
 		Subnets:         ipNets,
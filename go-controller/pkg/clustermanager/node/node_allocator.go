@@ -135,7 +135,7 @@ func (na *NodeAllocator) recordSubnetCount() {
 	// only for L3 networks
 	if na.hasNodeSubnetAllocation() {
 		v4count, v6count := na.clusterSubnetAllocator.Count()
-		metrics.RecordSubnetCount(float64(v4count), float64(v6count), na.netInfo.GetNetworkName())
+		metrics.RecordSubnetCount(float64(v4count), float64(v6count), na.netInfo.GetNetworkName(), na.netInfo.TopologyType())
 	}
 }
 
@@ -143,7 +143,7 @@ func (na *NodeAllocator) recordSubnetUsage() {
 	// only for L3 networks
 	if na.hasNodeSubnetAllocation() {
 		v4used, v6used := na.clusterSubnetAllocator.Usage()
-		metrics.RecordSubnetUsage(float64(v4used), float64(v6used), na.netInfo.GetNetworkName())
+		metrics.RecordSubnetUsage(float64(v4used), float64(v6used), na.netInfo.GetNetworkName(), na.netInfo.TopologyType())
 	}
 }
 
@@ -480,6 +480,10 @@ func (na *NodeAllocator) Cleanup() error {
 		na.clusterSubnetAllocator.ReleaseAllNetworks(node.Name)
 	}
 
+	if na.hasNodeSubnetAllocation() {
+		metrics.DeleteSubnetMetrics(networkName, na.netInfo.TopologyType())
+	}
+
 	return nil
 }
 
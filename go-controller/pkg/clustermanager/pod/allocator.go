@@ -15,6 +15,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	ref "k8s.io/client-go/tools/reference"
 	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
 
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/allocator/id"
 	ipallocator "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/allocator/ip"
@@ -22,12 +23,20 @@ import (
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/allocator/mac"
 	podallocator "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/allocator/pod"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/networkmanager"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/persistentips"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
 )
 
+const (
+	ipFamilyIPv4   = "ipv4"
+	ipFamilyIPv6   = "ipv6"
+	ipPoolDynamic  = "dynamic"
+	ipPoolReserved = "reserved"
+)
+
 // PodAllocator acts on pods events handed off by the cluster network controller
 // and allocates or releases resources (IPs and tunnel IDs at the time of this
 // writing) to pods on behalf of cluster manager.
@@ -172,6 +181,8 @@ func (a *PodAllocator) Sync(objs []interface{}) error {
 		}
 	}
 
+	a.recordIPAMUsage()
+
 	return nil
 }
 
@@ -265,6 +276,8 @@ func (a *PodAllocator) reconcileForNAD(old, new *corev1.Pod, nadKey string, netw
 	podDeleted := new == nil
 	podCompleted := util.PodCompleted(pod)
 
+	defer a.recordIPAMUsage()
+
 	if podCompleted || podDeleted {
 		return a.releasePodOnNAD(pod, nadKey, network, podDeleted, releaseIPsFromAllocator)
 	}
@@ -464,6 +477,54 @@ func (a *PodAllocator) recordPodErrorEvent(pod *corev1.Pod, podErr error) {
 	}
 }
 
+// recordIPAMUsage records the pod IP and MAC address allocation metrics of
+// the network
+func (a *PodAllocator) recordIPAMUsage() {
+	networkName := a.netInfo.GetNetworkName()
+	topology := a.netInfo.TopologyType()
+
+	if count, tracked := a.podAnnotationAllocator.ReservedMACCount(); tracked {
+		metrics.RecordNetworkReservedMACs(networkName, topology, float64(count))
+	}
+
+	if a.ipAllocator == nil {
+		return
+	}
+	usage, err := a.ipAllocator.Usage(networkName)
+	if err != nil {
+		klog.Warningf("Failed to get IP usage for network %s: %v", networkName, err)
+		return
+	}
+
+	type poolKey struct {
+		ipFamily string
+		pool     string
+	}
+	size := map[poolKey]int{}
+	allocated := map[poolKey]int{}
+	excluded := map[string]int{}
+	for _, subnetUsage := range usage {
+		ipFamily := ipFamilyIPv4
+		if utilnet.IsIPv6CIDR(subnetUsage.Subnet) {
+			ipFamily = ipFamilyIPv6
+		}
+		key := poolKey{ipFamily: ipFamily, pool: ipPoolDynamic}
+		if subnetUsage.Reserved {
+			key.pool = ipPoolReserved
+		} else {
+			excluded[ipFamily] += subnetUsage.Excluded
+		}
+		size[key] += subnetUsage.Size
+		allocated[key] += subnetUsage.Allocated
+	}
+	for key := range size {
+		metrics.RecordNetworkIPUsage(networkName, topology, key.ipFamily, key.pool, float64(size[key]), float64(allocated[key]))
+	}
+	for ipFamily, count := range excluded {
+		metrics.RecordNetworkExcludedIPs(networkName, topology, ipFamily, float64(count))
+	}
+}
+
 func podIdAllocationName(nadKey, uid string) string {
 	return fmt.Sprintf("%s/%s", nadKey, uid)
 }
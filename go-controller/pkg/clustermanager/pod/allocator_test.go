@@ -131,6 +131,10 @@ func (a *ipAllocatorStub) GetSubnetName([]*net.IPNet) (string, bool) {
 	panic("not implemented") // TODO: Implement
 }
 
+func (a *ipAllocatorStub) Usage(string) ([]subnet.SubnetUsage, error) {
+	return nil, nil
+}
+
 type idAllocatorStub struct {
 	released bool
 }
@@ -195,6 +199,9 @@ func (m *macRegistryStub) Release(owner string, mac net.HardwareAddr) error {
 	m.releasedMAC = mac
 	return m.releaseErr
 }
+func (m *macRegistryStub) Count() int {
+	return 0
+}
 
 func TestPodAllocator_reconcileForNAD(t *testing.T) {
 	type args struct {
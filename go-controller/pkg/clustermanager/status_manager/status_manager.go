@@ -282,6 +282,14 @@ func NewStatusManager(wf *factory.WatchFactory, ovnClient *util.OVNClusterManage
 			sm.withZonesRLock,
 		)
 		sm.typedManagers["egressfirewalls"] = egressFirewallManager
+		clusterEgressFirewallManager := newStatusManager[egressfirewallapi.ClusterEgressFirewall](
+			"clusteregressfirewalls_statusmanager",
+			wf.ClusterEgressFirewallInformer().Informer(),
+			wf.ClusterEgressFirewallInformer().Lister().List,
+			newClusterEgressFirewallManager(wf.ClusterEgressFirewallInformer().Lister(), ovnClient.EgressFirewallClient),
+			sm.withZonesRLock,
+		)
+		sm.typedManagers["clusteregressfirewalls"] = clusterEgressFirewallManager
 	}
 	if config.OVNKubernetesFeature.EnableEgressQoS {
 		egressQoSManager := newStatusManager[egressqosapi.EgressQoS](
@@ -117,6 +117,51 @@ func checkEmptyEFStatusConsistently(egressFirewall *egressfirewallapi.EgressFire
 	}).Should(BeTrue(), "expected Status to be consistently empty")
 }
 
+func newClusterEgressFirewall(name string) *egressfirewallapi.ClusterEgressFirewall {
+	return &egressfirewallapi.ClusterEgressFirewall{
+		ObjectMeta: util.NewObjectMeta(name, ""),
+		Spec: egressfirewallapi.ClusterEgressFirewallSpec{
+			Egress: []egressfirewallapi.EgressFirewallRule{
+				{
+					Type: "Deny",
+					To: egressfirewallapi.EgressFirewallDestination{
+						CIDRSelector: "1.2.3.4/23",
+					},
+				},
+			},
+		},
+	}
+}
+
+func updateClusterEgressFirewallStatus(cef *egressfirewallapi.ClusterEgressFirewall, status *egressfirewallapi.EgressFirewallStatus,
+	fakeClient *util.OVNClusterManagerClientset) {
+	cef.Status = *status
+	_, err := fakeClient.EgressFirewallClient.K8sV1().ClusterEgressFirewalls().
+		Update(context.TODO(), cef, metav1.UpdateOptions{})
+	Expect(err).ToNot(HaveOccurred())
+}
+
+func checkCEFStatusEventually(cef *egressfirewallapi.ClusterEgressFirewall, expectFailure bool, fakeClient *util.OVNClusterManagerClientset) {
+	Eventually(func() bool {
+		cef, err := fakeClient.EgressFirewallClient.K8sV1().ClusterEgressFirewalls().
+			Get(context.TODO(), cef.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		if expectFailure {
+			return strings.Contains(cef.Status.Status, types.ClusterEgressFirewallErrorMsg)
+		}
+		return strings.Contains(cef.Status.Status, "applied")
+	}).Should(BeTrue(), fmt.Sprintf("expected cluster egress firewall status with expectFailure=%v", expectFailure))
+}
+
+func checkEmptyCEFStatusConsistently(cef *egressfirewallapi.ClusterEgressFirewall, fakeClient *util.OVNClusterManagerClientset) {
+	Consistently(func() bool {
+		cef, err := fakeClient.EgressFirewallClient.K8sV1().ClusterEgressFirewalls().
+			Get(context.TODO(), cef.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return cef.Status.Status == ""
+	}).Should(BeTrue(), "expected Status to be consistently empty")
+}
+
 func newAPBRoute(name string) *adminpolicybasedrouteapi.AdminPolicyBasedExternalRoute {
 	return &adminpolicybasedrouteapi.AdminPolicyBasedExternalRoute{
 		ObjectMeta: util.NewObjectMeta(name, ""),
@@ -355,6 +400,29 @@ var _ = Describe("Cluster Manager Status Manager", func() {
 
 	})
 
+	It("updates ClusterEgressFirewall status with 2 zones", func() {
+		config.OVNKubernetesFeature.EnableEgressFirewall = true
+		zones := sets.New("zone1", "zone2")
+		cef := newClusterEgressFirewall("cef1")
+		start(zones, cef)
+
+		updateClusterEgressFirewallStatus(cef, &egressfirewallapi.EgressFirewallStatus{
+			Messages: []string{types.GetZoneStatus("zone1", "OK")},
+		}, fakeClient)
+		checkEmptyCEFStatusConsistently(cef, fakeClient)
+
+		updateClusterEgressFirewallStatus(cef, &egressfirewallapi.EgressFirewallStatus{
+			Messages: []string{types.GetZoneStatus("zone1", "OK"), types.GetZoneStatus("zone2", "OK")},
+		}, fakeClient)
+		checkCEFStatusEventually(cef, false, fakeClient)
+
+		updateClusterEgressFirewallStatus(cef, &egressfirewallapi.EgressFirewallStatus{
+			Messages: []string{types.GetZoneStatus("zone1", "OK"),
+				types.GetZoneStatus("zone2", types.ClusterEgressFirewallErrorMsg+": failed")},
+		}, fakeClient)
+		checkCEFStatusEventually(cef, true, fakeClient)
+	})
+
 	It("updates EgressFirewall status with UnknownZone", func() {
 		config.OVNKubernetesFeature.EnableEgressFirewall = true
 		zones := sets.New("zone1", zone_tracker.UnknownZone)
@@ -153,6 +153,7 @@ func renderCNINetworkConfig(networkName, nadName string, spec SpecGetter, opts *
 		netConfSpec.MTU = int(cfg.MTU)
 		netConfSpec.Subnets = layer3SubnetsString(cfg.Subnets)
 		netConfSpec.JoinSubnet = cidrString(renderJoinSubnets(cfg.Role, cfg.JoinSubnets))
+		netConfSpec.Multicast = strings.ToLower(string(cfg.Multicast))
 	case userdefinednetworkv1.NetworkTopologyLayer2:
 		cfg := spec.GetLayer2()
 		if err := validateIPAM(cfg.IPAM); err != nil {
@@ -175,6 +176,7 @@ func renderCNINetworkConfig(networkName, nadName string, spec SpecGetter, opts *
 			netConfSpec.DefaultGatewayIPs = ipString(cfg.DefaultGatewayIPs)
 		}
 		netConfSpec.JoinSubnet = cidrString(renderJoinSubnets(cfg.Role, cfg.JoinSubnets))
+		netConfSpec.Multicast = strings.ToLower(string(cfg.Multicast))
 		// now generate transit subnet for layer2 topology
 		if cfg.Role == userdefinednetworkv1.NetworkRolePrimary {
 			err := util.SetTransitSubnets(netConfSpec)
@@ -207,6 +209,10 @@ func renderCNINetworkConfig(networkName, nadName string, spec SpecGetter, opts *
 		return nil, fmt.Errorf("allowPersistentIPs is set but persistentIPs is Disabled")
 	}
 
+	if netConfSpec.Multicast == types.NetworkMulticastEnabled && !config.EnableMulticast {
+		return nil, fmt.Errorf("multicast is Enabled but multicast support is disabled")
+	}
+
 	if err := util.ValidateNetConf(nadName, netConfSpec); err != nil {
 		return nil, err
 	}
@@ -244,6 +250,9 @@ func renderCNINetworkConfig(networkName, nadName string, spec SpecGetter, opts *
 	if netConfSpec.AllowPersistentIPs {
 		cniNetConf["allowPersistentIPs"] = netConfSpec.AllowPersistentIPs
 	}
+	if netConfSpec.Multicast != "" {
+		cniNetConf["multicast"] = netConfSpec.Multicast
+	}
 	if netConfSpec.PhysicalNetworkName != "" {
 		cniNetConf["physicalNetworkName"] = netConfSpec.PhysicalNetworkName
 	}
@@ -391,6 +391,30 @@ var _ = Describe("NetAttachDefTemplate", func() {
 			  "allowPersistentIPs": true
         	}`,
 		),
+		Entry("primary network, layer3 with multicast disabled",
+			udnv1.UserDefinedNetworkSpec{
+				Topology: udnv1.NetworkTopologyLayer3,
+				Layer3: &udnv1.Layer3Config{
+					Role: udnv1.NetworkRolePrimary,
+					Subnets: []udnv1.Layer3Subnet{
+						{CIDR: "192.168.100.0/16"},
+						{CIDR: "2001:dbb::/60"},
+					},
+					Multicast: udnv1.MulticastDisabled,
+				},
+			},
+			`{
+				"cniVersion": "1.1.0",
+				"type": "ovn-k8s-cni-overlay",
+				"name": "mynamespace_test-net",
+				"netAttachDefName": "mynamespace/test-net",
+				"role": "primary",
+				"topology": "layer3",
+				"joinSubnet": "100.65.0.0/16,fd99::/64",
+				"subnets": "192.168.100.0/16,2001:dbb::/60",
+				"multicast": "disabled"
+			}`,
+		),
 		Entry("primary network, should override join-subnets when specified",
 			udnv1.UserDefinedNetworkSpec{
 				Topology: udnv1.NetworkTopologyLayer2,
@@ -975,6 +999,32 @@ var _ = Describe("NetAttachDefTemplate", func() {
 		})
 	})
 
+	It("should render multicast for layer2 primary network when multicast is enabled", func() {
+		config.IPv4Mode = true
+		config.EnableMulticast = true
+		udn := &udnv1.UserDefinedNetwork{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "mynamespace", Name: "test-net", UID: "1"},
+			Spec: udnv1.UserDefinedNetworkSpec{
+				Topology: udnv1.NetworkTopologyLayer2,
+				Layer2: &udnv1.Layer2Config{
+					Role:      udnv1.NetworkRolePrimary,
+					Subnets:   udnv1.DualStackCIDRs{"192.168.100.0/24"},
+					Multicast: udnv1.MulticastEnabled,
+				},
+			},
+		}
+		nad, err := RenderNetAttachDefManifest(udn, "mynamespace")
+		Expect(err).NotTo(HaveOccurred())
+		netConf := &ovncnitypes.NetConf{}
+		Expect(json.Unmarshal([]byte(nad.Spec.Config), netConf)).To(Succeed())
+		Expect(netConf.Multicast).To(Equal("enabled"))
+
+		// enabling multicast on a network requires multicast support
+		config.EnableMulticast = false
+		_, err = RenderNetAttachDefManifest(udn, "mynamespace")
+		Expect(err).To(MatchError(ContainSubstring("multicast is Enabled but multicast support is disabled")))
+	})
+
 	It("should correctly assign transit Subnets", func() {
 		// check no overlap, use default values
 		netConf := &ovncnitypes.NetConf{
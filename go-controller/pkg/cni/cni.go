@@ -24,6 +24,7 @@ import (
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/kubevirt"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops/ovs"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/networkmanager"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
@@ -428,11 +429,102 @@ func (pr *PodRequest) cmdDel(clientset *ClientSet) (*Response, error) {
 	return response, nil
 }
 
-func (pr *PodRequest) cmdCheck() error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
-	return nil
+// cmdCheck verifies that the pod interfaces are still plumbed as described by
+// the pod annotation.
+func (pr *PodRequest) cmdCheck(clientset *ClientSet, networkManager networkmanager.Interface) error {
+	// noop unless enabled...CMD check has a considerable performance impact to pod bring up
+	// times with CRIO. This is due to the fact that CRIO currently calls check after CNI ADD
+	// before it finishes bringing the container up
+	if !config.CNI.EnableCheck {
+		return nil
+	}
+	// pod interfaces are not plumbed by ovnkube-node in unprivileged or DPU host modes
+	if config.UnprivilegedMode || config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		return nil
+	}
+
+	namespace := pr.PodNamespace
+	podName := pr.PodName
+	if namespace == "" || podName == "" {
+		return fmt.Errorf("required CNI variable missing")
+	}
+
+	pod, err := clientset.getPod(namespace, podName)
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s/%s: %w", namespace, podName, err)
+	}
+	if err = pr.checkOrUpdatePodUID(pod); err != nil {
+		return err
+	}
+
+	if pr.netName != types.DefaultNetworkName {
+		nadKey, err := GetCNINADKey(pod, pr.IfName, pr.nadName)
+		if err != nil {
+			return fmt.Errorf("failed to get NAD key for CNI Check request %v: %v", pr, err)
+		}
+		pr.nadKey = nadKey
+	} else {
+		pr.nadKey = pr.nadName
+	}
+
+	annotCondFn := isOvnReady
+	primaryUDN := udn.NewPrimaryNetwork(networkManager, clientset.nadLister)
+	if util.IsNetworkSegmentationSupportEnabled() {
+		annotCondFn = primaryUDN.WaitForPrimaryAnnotationFn(annotCondFn)
+	}
+	podNADAnnotation, ready, err := annotCondFn(pod, pr.nadKey)
+	if err != nil {
+		return fmt.Errorf("failed to get pod annotation: %w", err)
+	}
+	if !ready {
+		return fmt.Errorf("pod %s/%s annotation for NAD key %s is not ready", namespace, podName, pr.nadKey)
+	}
+
+	podInterfaceInfo, err := pr.buildPodInterfaceInfo(pod.Annotations, podNADAnnotation, "")
+	if err != nil {
+		return err
+	}
+	podInterfaceInfo.SkipIPConfig = kubevirt.IsPodLiveMigratable(pod)
+	if err = checkPodInterface(pr, podInterfaceInfo); err != nil {
+		return err
+	}
+
+	primaryUDNPodRequest := pr.buildPrimaryUDNPodRequest(primaryUDN)
+	if primaryUDNPodRequest == nil {
+		return nil
+	}
+	primaryUDNPodInfo, err := primaryUDNPodRequest.buildPodInterfaceInfo(pod.Annotations, primaryUDN.Annotation(), primaryUDN.NetworkDevice())
+	if err != nil {
+		return err
+	}
+	return checkPodInterface(primaryUDNPodRequest, primaryUDNPodInfo)
+}
+
+// cmdGC reclaims the pod OVS ports and host-side interfaces left behind by
+// sandboxes that are not in the runtime's list of valid attachments.
+func (pr *PodRequest) cmdGC() error {
+	// pod ports are not plumbed by ovnkube-node in unprivileged or DPU modes
+	if config.UnprivilegedMode || config.OvnKubeNode.Mode != types.NodeModeFull {
+		klog.V(5).Infof("Skipping CNI GC, unprivileged mode: %t, node mode: %s",
+			config.UnprivilegedMode, config.OvnKubeNode.Mode)
+		return nil
+	}
+
+	// without the list of valid attachments every pod port would be considered stale
+	if pr.CNIConf.ValidAttachments == nil {
+		klog.Warningf("Skipping CNI GC, the runtime did not provide the list of valid attachments")
+		return nil
+	}
+
+	// the runtime only lists the valid attachments of the network it collects
+	netName := pr.CNIConf.Name
+	nadName := types.DefaultNetworkName
+	if netName != types.DefaultNetworkName {
+		nadName = pr.CNIConf.NADName
+	}
+	reclaimed, err := reclaimStalePodPorts(netName, nadName, pr.CNIConf.ValidAttachments, config.CNI.GCDryRun)
+	metrics.MetricCNIGCReclaimedPorts.Add(float64(reclaimed))
+	return err
 }
 
 // HandlePodRequest is the callback for all the requests
@@ -458,11 +550,13 @@ func HandlePodRequest(
 	case CNIDel:
 		response, err = request.cmdDel(clientset)
 	case CNICheck:
-		err = request.cmdCheck()
+		err = request.cmdCheck(clientset, networkManager)
 	case CNIUpdate:
 		// No-op update path today
 	case CNIStatus:
 		// handled by DPU health check gating before reaching here
+	case CNIGC:
+		err = request.cmdGC()
 	default:
 		err = fmt.Errorf("unsupported CNI command %s", request.Command)
 	}
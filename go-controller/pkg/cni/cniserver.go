@@ -167,8 +167,8 @@ func cniRequestToPodRequest(cr *Request) (*PodRequest, error) {
 	req.CNIConf = conf
 	req.deviceInfo = cr.DeviceInfo
 
-	// STATUS requests do not carry pod-specific context. Return early after validating config.
-	if req.Command == CNIStatus {
+	// STATUS and GC requests do not carry pod-specific context. Return early after validating config.
+	if req.Command == CNIStatus || req.Command == CNIGC {
 		// Match the Kubelet default CRI operation timeout of 2m.
 		req.ctx, req.cancel = context.WithTimeout(context.Background(), kubeletDefaultCRIOperationTimeout)
 		return req, nil
@@ -58,7 +58,8 @@ var expectedResult cnitypes.Result
 func serverHandleCNI(request *PodRequest, _ *ClientSet, _ *KubeAPIAuth, _ networkmanager.Interface, _ client.Client) ([]byte, error) {
 	if request.Command == CNIAdd {
 		return json.Marshal(&expectedResult)
-	} else if request.Command == CNIDel || request.Command == CNIUpdate || request.Command == CNICheck || request.Command == CNIStatus {
+	} else if request.Command == CNIDel || request.Command == CNIUpdate || request.Command == CNICheck || request.Command == CNIStatus ||
+		request.Command == CNIGC {
 		return nil, nil
 	}
 	return nil, fmt.Errorf("unhandled CNI command %v", request.Command)
@@ -230,6 +231,18 @@ func TestCNIServer(t *testing.T) {
 			},
 			result: nil,
 		},
+		// GC request
+		{
+			name: "GC",
+			request: &Request{
+				Env: map[string]string{
+					"CNI_COMMAND": string(CNIGC),
+				},
+				Config: []byte("{\"cniVersion\": \"1.1.0\",\"name\": \"ovnkube\",\"type\": \"ovn-k8s-cni-overlay\"," +
+					"\"cni.dev/valid-attachments\": [{\"containerID\": \"" + sandboxID + "\",\"ifname\": \"eth0\"}]}"),
+			},
+			result: nil,
+		},
 		// Missing CNI_ARGS
 		{
 			name: "ARGS1",
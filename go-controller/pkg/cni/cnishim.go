@@ -384,14 +384,38 @@ func (p *Plugin) CmdGC(args *skel.CmdArgs) error {
 	}
 	setupLogging(conf)
 
-	// OVN-Kubernetes does not maintain independent local plugin state that needs GC.
-	return nil
+	req := newCNIRequest(args, nadapi.DeviceInfo{})
+	_, err = p.doCNIFunc("http://dummy/", req)
+	return err
 }
 
 // CmdCheck is the callback for 'checking' container's networking is as expected.
-func (p *Plugin) CmdCheck(_ *skel.CmdArgs) error {
-	// noop...CMD check is not considered useful, and has a considerable performance impact
-	// to pod bring up times with CRIO. This is due to the fact that CRIO currently calls check
-	// after CNI ADD before it finishes bringing the container up
-	return nil
+// The server only verifies the pod interface when the check is enabled in its
+// config, which ovnkube-node also writes to the CNI config of the default network.
+func (p *Plugin) CmdCheck(args *skel.CmdArgs) error {
+	var err error
+
+	startTime := time.Now()
+	defer func() {
+		p.postMetrics(startTime, CNICheck, err)
+		if err != nil {
+			klog.Errorf("Error on CmdCheck: %v", err)
+		}
+	}()
+
+	conf, err := config.ReadCNIConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+	setupLogging(conf)
+
+	// noop unless enabled, spare the round trip to the server. The CNI configs
+	// of the other networks don't tell, the server skips their requests itself.
+	if conf.Topology == "" && !conf.EnableCheck {
+		return nil
+	}
+
+	req := newCNIRequest(args, nadapi.DeviceInfo{})
+	_, err = p.doCNIFunc("http://dummy/", req)
+	return err
 }
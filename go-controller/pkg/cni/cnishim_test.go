@@ -3,6 +3,7 @@ package cni
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
 	"reflect"
 	"testing"
@@ -293,14 +294,73 @@ func TestCmdDel_UnprivilegedMode(t *testing.T) {
 
 func TestCmdGC(t *testing.T) {
 	p := &Plugin{}
-	args := &skel.CmdArgs{
-		StdinData: []byte(`{"cniVersion":"1.1.0","name":"mynet","type":"ovn-k8s-cni-overlay"}`),
+
+	stdinData := []byte(`{"cniVersion":"1.1.0","name":"mynet","type":"ovn-k8s-cni-overlay",` +
+		`"cni.dev/valid-attachments":[{"containerID":"cid","ifname":"eth0"}]}`)
+	// Mock a doCNI that checks the valid attachments are forwarded to the server
+	p.doCNIFunc = func(_ string, req interface{}) ([]byte, error) {
+		cniReq, ok := req.(*Request)
+		require.True(t, ok)
+		require.Equal(t, stdinData, cniReq.Config)
+		return nil, nil
 	}
 
+	args := &skel.CmdArgs{
+		StdinData: stdinData,
+	}
 	err := p.CmdGC(args)
 	require.NoError(t, err)
 }
 
+func TestCmdCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		stdinData  string
+		expectSent bool
+	}{
+		{
+			name:      "default network with CHECK disabled",
+			stdinData: `{"cniVersion":"1.1.0","name":"ovn-kubernetes","type":"ovn-k8s-cni-overlay"}`,
+		},
+		{
+			name:       "default network with CHECK enabled",
+			stdinData:  `{"cniVersion":"1.1.0","name":"ovn-kubernetes","type":"ovn-k8s-cni-overlay","enableCheck":true}`,
+			expectSent: true,
+		},
+		{
+			name:       "secondary network",
+			stdinData:  `{"cniVersion":"1.1.0","name":"mynet","type":"ovn-k8s-cni-overlay","topology":"layer2","netAttachDefName":"ns/mynet"}`,
+			expectSent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Plugin{}
+
+			// Mock a doCNI that fails the check on the server side
+			sent := false
+			p.doCNIFunc = func(_ string, _ interface{}) ([]byte, error) {
+				sent = true
+				return nil, fmt.Errorf("interface eth0 is missing IP 10.0.0.2/24")
+			}
+
+			args := &skel.CmdArgs{
+				StdinData:   []byte(tt.stdinData),
+				ContainerID: "cid",
+				Netns:       "/var/run/netns/test",
+				IfName:      "eth0",
+			}
+			err := p.CmdCheck(args)
+			require.Equal(t, tt.expectSent, sent)
+			if tt.expectSent {
+				require.ErrorContains(t, err, "missing IP")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func withCNIEnv(t *testing.T, fn func()) {
 	t.Helper()
 
@@ -13,17 +13,21 @@ import (
 	"strings"
 	"time"
 
+	cnitypes "github.com/containernetworking/cni/pkg/types"
 	current "github.com/containernetworking/cni/pkg/types/100"
 	"github.com/containernetworking/plugins/pkg/ip"
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/safchain/ethtool"
 	"github.com/vishvananda/netlink"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/knftables"
 
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/cni/udn"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util/errors"
 )
 
 type CNIPluginLibOps interface {
@@ -890,6 +894,135 @@ func (*defaultPodRequestInterfaceOps) UnconfigureInterface(pr *PodRequest, ifInf
 	return nil
 }
 
+// checkPodInterface verifies that the pod interface plumbed by ConfigureInterface
+// still matches ifInfo: the OVS port iface-id, the MAC, IPs and MTU of the
+// container interface and the pod gateways and routes.
+func checkPodInterface(pr *PodRequest, ifInfo *PodInterfaceInfo) error {
+	ifaceID := util.GetIfaceId(pr.PodNamespace, pr.PodName)
+	if ifInfo.NetName != types.DefaultNetworkName {
+		ifaceID = util.GetUDNIfaceId(pr.PodNamespace, pr.PodName, ifInfo.NADKey)
+	}
+	ovsIfNames, err := ovsFind("Interface", "name", "external-ids:iface-id="+ifaceID)
+	if err != nil {
+		return fmt.Errorf("failed to find the OVS interface with iface-id %s: %w", ifaceID, err)
+	}
+	if len(ovsIfNames) != 1 {
+		return fmt.Errorf("expected one OVS interface with iface-id %s, found %d", ifaceID, len(ovsIfNames))
+	}
+	sandboxID, err := ovsGet("Interface", ovsIfNames[0], "external_ids", "sandbox")
+	if err != nil {
+		return fmt.Errorf("failed to get the sandbox of OVS interface %s: %w", ovsIfNames[0], err)
+	}
+	if sandboxID != pr.SandboxID {
+		return fmt.Errorf("OVS interface %s with iface-id %s belongs to sandbox %q, expected %q",
+			ovsIfNames[0], ifaceID, sandboxID, pr.SandboxID)
+	}
+
+	// nothing to check in the container namespace for the VFIO case
+	if pr.IsVFIO {
+		return nil
+	}
+	netns, err := ns.GetNS(pr.Netns)
+	if err != nil {
+		return fmt.Errorf("failed to open netns %q: %v", pr.Netns, err)
+	}
+	defer netns.Close()
+
+	return netns.Do(func(_ ns.NetNS) error {
+		link, err := util.GetNetLinkOps().LinkByName(pr.IfName)
+		if err != nil {
+			return fmt.Errorf("failed to get container interface %s: %v", pr.IfName, err)
+		}
+		return checkPodLink(link, ifInfo)
+	})
+}
+
+// checkPodLink verifies the container side of the pod interface, it must be
+// called in the container network namespace.
+func checkPodLink(link netlink.Link, ifInfo *PodInterfaceInfo) error {
+	linkName := link.Attrs().Name
+	if ifInfo.MAC != nil && link.Attrs().HardwareAddr.String() != ifInfo.MAC.String() {
+		return fmt.Errorf("interface %s has MAC %s, expected %s", linkName, link.Attrs().HardwareAddr, ifInfo.MAC)
+	}
+	if ifInfo.MTU != 0 && link.Attrs().MTU != ifInfo.MTU {
+		return fmt.Errorf("interface %s has MTU %d, expected %d", linkName, link.Attrs().MTU, ifInfo.MTU)
+	}
+	if ifInfo.SkipIPConfig {
+		return nil
+	}
+
+	addrs, err := util.GetNetLinkOps().AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list addresses of interface %s: %v", linkName, err)
+	}
+	for _, ip := range ifInfo.IPs {
+		found := false
+		for _, addr := range addrs {
+			if addr.IPNet != nil && addr.IPNet.String() == ip.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("interface %s is missing IP %s", linkName, ip)
+		}
+	}
+
+	routes, err := util.GetNetLinkOps().RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list routes: %v", err)
+	}
+	for _, gw := range ifInfo.Gateways {
+		if err := checkPodRoute(routes, nil, gw, ifInfo.RoutableMTU); err != nil {
+			return fmt.Errorf("interface %s: %w", linkName, err)
+		}
+	}
+	for _, route := range ifInfo.Routes {
+		if err := checkPodRoute(routes, route.Dest, route.NextHop, ifInfo.RoutableMTU); err != nil {
+			return fmt.Errorf("interface %s: %w", linkName, err)
+		}
+	}
+	return nil
+}
+
+// checkPodRoute verifies that routes contain a route to dst via gw, either
+// directly or as one of the ECMP next hops. A nil dst stands for the default route.
+func checkPodRoute(routes []netlink.Route, dst *net.IPNet, gw net.IP, mtu int) error {
+	isDefault := func(ipNet *net.IPNet) bool {
+		if ipNet == nil {
+			return true
+		}
+		ones, _ := ipNet.Mask.Size()
+		return ones == 0 && ipNet.IP.IsUnspecified()
+	}
+	desc := fmt.Sprintf("default route via %s", gw)
+	if dst != nil {
+		desc = fmt.Sprintf("route to %s via %s", dst, gw)
+	}
+
+	for _, route := range routes {
+		if dst == nil {
+			if !isDefault(route.Dst) {
+				continue
+			}
+		} else if route.Dst == nil || route.Dst.String() != dst.String() {
+			continue
+		}
+		viaGW := route.Gw.Equal(gw)
+		for _, nh := range route.MultiPath {
+			viaGW = viaGW || nh.Gw.Equal(gw)
+		}
+		if !viaGW {
+			continue
+		}
+		if mtu != 0 && route.MTU != mtu {
+			return fmt.Errorf("%s has MTU %d, expected %d", desc, route.MTU, mtu)
+		}
+		return nil
+	}
+	return fmt.Errorf("missing %s", desc)
+}
+
 func (pr *PodRequest) deletePodConntrack() {
 	if pr.CNIConf.PrevResult == nil {
 		return
@@ -939,6 +1072,117 @@ func (pr *PodRequest) deletePorts(ifaces []string, podNamespace, podName string)
 	}
 }
 
+// reclaimStalePodPorts removes the pod ports on br-int of the given network and
+// NAD whose sandbox and pod interface are not in validAttachments, together with
+// their host-side veth. The ports of the default network are the ones without a
+// network, along with the primary UDN ports that are plumbed with them and are
+// valid as long as their sandbox is. Ports added before the pod interface name
+// was recorded are matched by sandbox only. VF representors are only detached
+// from br-int since they belong to the host. In dry-run mode the stale ports are
+// only logged. It returns the number of reclaimed ports.
+func reclaimStalePodPorts(netName, nadName string, validAttachments []cnitypes.GCAttachment, dryRun bool) (int, error) {
+	// pod interfaces are the interfaces with a sandbox
+	lines, err := ovsFind("Interface", "name,external_ids", "external_ids:sandbox!=\"\"")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pod interfaces: %w", err)
+	}
+
+	validSandboxes := sets.New[string]()
+	for _, attachment := range validAttachments {
+		validSandboxes.Insert(attachment.ContainerID)
+	}
+	validIfaces := sets.New(validAttachments...)
+
+	var errs []error
+	reclaimed := 0
+	staleSandboxes := sets.New[string]()
+	for _, line := range lines {
+		// each line is "<name>,<space separated key=value pairs of external_ids>"
+		ifaceName, externalIDs, found := strings.Cut(line, ",")
+		if !found {
+			errs = append(errs, fmt.Errorf("unexpected interface output %q", line))
+			continue
+		}
+		var sandboxID, netdevName, podIfName, ifaceNetName, ifaceNADKey string
+		for _, attr := range strings.Fields(externalIDs) {
+			key, value, _ := strings.Cut(attr, "=")
+			switch key {
+			case "sandbox":
+				sandboxID = value
+			case "vf-netdev-name":
+				netdevName = value
+			case "pod-if-name":
+				podIfName = value
+			case types.NetworkExternalID:
+				ifaceNetName = value
+			case types.NADExternalID:
+				ifaceNADKey = value
+			}
+		}
+		if sandboxID == "" {
+			continue
+		}
+		// only consider the interfaces attached by the network being collected
+		matchSandbox := podIfName == ""
+		if netName == types.DefaultNetworkName {
+			if ifaceNetName != "" {
+				// the primary UDN interface goes with the default network interface
+				if podIfName != udn.InterfaceName {
+					continue
+				}
+				matchSandbox = true
+			}
+		} else {
+			if ifaceNetName != netName || podIfName == udn.InterfaceName {
+				continue
+			}
+			ifaceNADName, _, err := util.GetNadFromIndexedNADKey(ifaceNADKey)
+			if err != nil || (nadName != "" && ifaceNADName != nadName) {
+				continue
+			}
+		}
+		if matchSandbox && validSandboxes.Has(sandboxID) ||
+			validIfaces.Has(cnitypes.GCAttachment{ContainerID: sandboxID, IfName: podIfName}) {
+			continue
+		}
+		if dryRun {
+			klog.Infof("CNI GC dry-run: would remove stale interface %s of sandbox %s", ifaceName, sandboxID)
+			continue
+		}
+
+		klog.Infof("CNI GC: removing stale interface %s of sandbox %s", ifaceName, sandboxID)
+		if _, err = ovsExec("--if-exists", "del-port", "br-int", ifaceName); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete OVS port %s: %w", ifaceName, err))
+			continue
+		}
+		staleSandboxes.Insert(sandboxID)
+		reclaimed++
+
+		// skip deleting representor ports
+		if netdevName != "" {
+			continue
+		}
+		// the veth is usually gone already together with the sandbox network namespace
+		link, err := util.GetNetLinkOps().LinkByName(ifaceName)
+		if err != nil {
+			if !util.GetNetLinkOps().IsLinkNotFoundError(err) {
+				errs = append(errs, fmt.Errorf("failed to lookup link %s: %w", ifaceName, err))
+			}
+			continue
+		}
+		if err = util.GetNetLinkOps().LinkDelete(link); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete link %s: %w", ifaceName, err))
+		}
+	}
+
+	for _, sandboxID := range sets.List(staleSandboxes) {
+		if err = clearPodBandwidthForPorts(nil, sandboxID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to clear bandwidth of sandbox %s: %w", sandboxID, err))
+		}
+	}
+	return reclaimed, utilerrors.Join(errs...)
+}
+
 // setupIngressFilter sets up an ingress filter using nftables to block
 // unwanted ICMPv6 Router Advertisement (RA) packets on a specific device.
 // It creates a new nftables table, chain, and rule to drop RA packets
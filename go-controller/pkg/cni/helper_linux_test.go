@@ -25,6 +25,7 @@ import (
 
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/cni/mocks"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
 	ovntest "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing"
 	cni_type_mocks "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/containernetworking/cni/pkg/types"
 	cni_ns_mocks "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/containernetworking/plugins/pkg/ns"
@@ -1287,6 +1288,291 @@ func TestPodRequest_deletePodConntrack(t *testing.T) {
 	}
 }
 
+func TestCheckPodLink(t *testing.T) {
+	mockNetLinkOps := new(util_mocks.NetLinkOps)
+	// below sets the `netLinkOps` in util/net_linux.go to a mock instance for purpose of unit tests execution
+	util.SetNetLinkOpMockInst(mockNetLinkOps)
+	defer util.ResetNetLinkOpMockInst()
+
+	mac := ovntest.MustParseMAC("0a:58:0a:80:00:05")
+	podIP := ovntest.MustParseIPNet("10.128.0.5/24")
+	gwIP := ovntest.MustParseIP("10.128.0.1")
+	serviceCIDR := ovntest.MustParseIPNet("172.30.0.0/16")
+	ifInfo := &PodInterfaceInfo{
+		PodAnnotation: util.PodAnnotation{
+			IPs:      []*net.IPNet{podIP},
+			MAC:      mac,
+			Gateways: []net.IP{gwIP},
+			Routes:   []util.PodRoute{{Dest: serviceCIDR, NextHop: gwIP}},
+		},
+		MTU:         1400,
+		RoutableMTU: 1400,
+	}
+	link := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "eth0", MTU: 1400, HardwareAddr: mac}}
+	addrs := []netlink.Addr{{IPNet: podIP}}
+	routes := []netlink.Route{
+		{Dst: nil, Gw: gwIP, MTU: 1400},
+		{Dst: serviceCIDR, Gw: gwIP, MTU: 1400},
+	}
+	addrListMock := ovntest.TestifyMockHelper{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{addrs, nil}}
+
+	tests := []struct {
+		desc                 string
+		link                 *netlink.Dummy
+		errMatch             string
+		netLinkOpsMockHelper []ovntest.TestifyMockHelper
+	}{
+		{
+			desc: "pod interface matches the pod annotation",
+			link: link,
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				addrListMock,
+				{OnCallMethodName: "RouteList", OnCallMethodArgs: []interface{}{nil, netlink.FAMILY_ALL}, RetArgList: []interface{}{routes, nil}},
+			},
+		},
+		{
+			desc: "pod route via ECMP next hops matches the pod annotation",
+			link: link,
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				addrListMock,
+				{OnCallMethodName: "RouteList", OnCallMethodArgs: []interface{}{nil, netlink.FAMILY_ALL}, RetArgList: []interface{}{
+					[]netlink.Route{routes[0], {Dst: serviceCIDR, MTU: 1400, MultiPath: []*netlink.NexthopInfo{{Gw: gwIP}, {Gw: gwIP}}}}, nil}},
+			},
+		},
+		{
+			desc:     "MAC mismatch is reported",
+			link:     &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "eth0", MTU: 1400, HardwareAddr: ovntest.MustParseMAC("0a:58:0a:80:00:06")}},
+			errMatch: "interface eth0 has MAC 0a:58:0a:80:00:06, expected 0a:58:0a:80:00:05",
+		},
+		{
+			desc:     "MTU mismatch is reported",
+			link:     &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "eth0", MTU: 1500, HardwareAddr: mac}},
+			errMatch: "interface eth0 has MTU 1500, expected 1400",
+		},
+		{
+			desc:     "missing IP is reported",
+			link:     link,
+			errMatch: "interface eth0 is missing IP 10.128.0.5/24",
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*netlink.Dummy", "int"}, RetArgList: []interface{}{[]netlink.Addr{}, nil}},
+			},
+		},
+		{
+			desc:     "missing default route is reported",
+			link:     link,
+			errMatch: "interface eth0: missing default route via 10.128.0.1",
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				addrListMock,
+				{OnCallMethodName: "RouteList", OnCallMethodArgs: []interface{}{nil, netlink.FAMILY_ALL}, RetArgList: []interface{}{routes[1:], nil}},
+			},
+		},
+		{
+			desc:     "route MTU mismatch is reported",
+			link:     link,
+			errMatch: "interface eth0: route to 172.30.0.0/16 via 10.128.0.1 has MTU 1500, expected 1400",
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				addrListMock,
+				{OnCallMethodName: "RouteList", OnCallMethodArgs: []interface{}{nil, netlink.FAMILY_ALL}, RetArgList: []interface{}{
+					[]netlink.Route{routes[0], {Dst: serviceCIDR, Gw: gwIP, MTU: 1500}}, nil}},
+			},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.netLinkOpsMockHelper)
+
+			err := checkPodLink(tc.link, ifInfo)
+			if tc.errMatch != "" {
+				assert.EqualError(t, err, tc.errMatch)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockNetLinkOps.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReclaimStalePodPorts(t *testing.T) {
+	mockNetLinkOps := new(util_mocks.NetLinkOps)
+	mockLink := new(netlink_mocks.Link)
+	// below sets the `netLinkOps` in util/net_linux.go to a mock instance for purpose of unit tests execution
+	util.SetNetLinkOpMockInst(mockNetLinkOps)
+	defer util.ResetNetLinkOpMockInst()
+
+	const (
+		validSandbox = "validsandbox"
+		staleSandbox = "stalesandbox"
+		validIface   = "validsandbox12"
+		staleIface   = "stalesandbox12"
+		blueNetwork  = "blue"
+		blueNAD      = "testns/blue"
+	)
+	findIfacesCmd := genOVSFindCmd("30", "Interface", "name,external_ids", `external_ids:sandbox!=""`)
+	validIfaceLine := validIface + ",iface-id=testns_validpod sandbox=" + validSandbox
+	staleIfaceLine := staleIface + ",iface-id=testns_stalepod sandbox=" + staleSandbox
+	delPortCmd := "ovs-vsctl --timeout=30 --if-exists del-port br-int " + staleIface
+	qosFindCmd := genOVSFindCmd("30", "qos", "_uuid", "external-ids:sandbox="+staleSandbox)
+	validQoSFindCmd := genOVSFindCmd("30", "qos", "_uuid", "external-ids:sandbox="+validSandbox)
+	// the interfaces of a valid and of a stale sandbox attached to the default network and to the blue network
+	blueExternalIDs := fmt.Sprintf(" %s=%s %s=", ovntypes.NetworkExternalID, blueNetwork, ovntypes.NADExternalID)
+	defaultIfaceLines := []string{
+		"validsandbox_eth0,iface-id=testns_validpod sandbox=" + validSandbox + " pod-if-name=eth0",
+		"stalesandbox_eth0,iface-id=testns_stalepod sandbox=" + staleSandbox + " pod-if-name=eth0",
+	}
+	blueIfaceLines := []string{
+		"validsandbox_net1,iface-id=testns_validpod sandbox=" + validSandbox + " pod-if-name=net1" + blueExternalIDs + blueNAD,
+		"validsandbox_net2,iface-id=testns_validpod sandbox=" + validSandbox + " pod-if-name=net2" + blueExternalIDs + blueNAD + "/1",
+		"stalesandbox_net1,iface-id=testns_stalepod sandbox=" + staleSandbox + " pod-if-name=net1" + blueExternalIDs + blueNAD,
+	}
+	primaryUDNIfaceLines := []string{
+		"validsandbox_udn,iface-id=testns_validpod sandbox=" + validSandbox + " pod-if-name=ovn-udn1" + blueExternalIDs + blueNAD,
+		"stalesandbox_udn,iface-id=testns_stalepod sandbox=" + staleSandbox + " pod-if-name=ovn-udn1" + blueExternalIDs + blueNAD,
+	}
+	delPortCmdFor := func(iface string) ovntest.ExpectedCmd {
+		return ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=30 --if-exists del-port br-int " + iface}
+	}
+	linkGoneMockHelper := []ovntest.TestifyMockHelper{
+		{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
+		{OnCallMethodName: "IsLinkNotFoundError", OnCallMethodArgType: []string{"*errors.errorString"}, RetArgList: []interface{}{true}},
+	}
+
+	tests := []struct {
+		desc                 string
+		netName              string
+		nadName              string
+		validAttachments     []cnitypes.GCAttachment
+		dryRun               bool
+		expectedReclaimed    int
+		errMatch             error
+		ovsCmds              []ovntest.ExpectedCmd
+		netLinkOpsMockHelper []ovntest.TestifyMockHelper
+	}{
+		{
+			desc:              "stale veth port and interface are removed",
+			expectedReclaimed: 1,
+			ovsCmds: []ovntest.ExpectedCmd{
+				{Cmd: findIfacesCmd, Output: strings.Join([]string{validIfaceLine, staleIfaceLine}, "\n")},
+				{Cmd: delPortCmd},
+				{Cmd: qosFindCmd},
+			},
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+				{OnCallMethodName: "LinkDelete", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
+			},
+		},
+		{
+			desc:              "stale port is removed when its veth is already gone",
+			expectedReclaimed: 1,
+			ovsCmds: []ovntest.ExpectedCmd{
+				{Cmd: findIfacesCmd, Output: staleIfaceLine},
+				{Cmd: delPortCmd},
+				{Cmd: qosFindCmd},
+			},
+			netLinkOpsMockHelper: linkGoneMockHelper,
+		},
+		{
+			desc:              "stale VF representor is only removed from br-int",
+			expectedReclaimed: 1,
+			ovsCmds: []ovntest.ExpectedCmd{
+				{Cmd: findIfacesCmd, Output: staleIfaceLine + " vf-netdev-name=enp1s0f0v1"},
+				{Cmd: delPortCmd},
+				{Cmd: qosFindCmd},
+			},
+		},
+		{
+			desc:   "stale port is only logged in dry-run mode",
+			dryRun: true,
+			ovsCmds: []ovntest.ExpectedCmd{
+				{Cmd: findIfacesCmd, Output: staleIfaceLine},
+			},
+		},
+		{
+			desc:     "failure to delete a stale port is reported",
+			errMatch: fmt.Errorf("failed to delete OVS port %s", staleIface),
+			ovsCmds: []ovntest.ExpectedCmd{
+				{Cmd: findIfacesCmd, Output: staleIfaceLine},
+				{Cmd: delPortCmd, Err: fmt.Errorf("mock error")},
+			},
+		},
+		{
+			desc:              "default network collection only removes the stale default network and primary UDN ports",
+			expectedReclaimed: 2,
+			ovsCmds: []ovntest.ExpectedCmd{
+				{Cmd: findIfacesCmd, Output: strings.Join(append(append(defaultIfaceLines, blueIfaceLines...), primaryUDNIfaceLines...), "\n")},
+				delPortCmdFor("stalesandbox_eth0"),
+				delPortCmdFor("stalesandbox_udn"),
+				{Cmd: qosFindCmd},
+			},
+			netLinkOpsMockHelper: append(linkGoneMockHelper, linkGoneMockHelper...),
+		},
+		{
+			desc:              "network collection only removes the stale ports of its NAD",
+			netName:           blueNetwork,
+			nadName:           blueNAD,
+			validAttachments:  []cnitypes.GCAttachment{{ContainerID: validSandbox, IfName: "net1"}},
+			expectedReclaimed: 2,
+			ovsCmds: []ovntest.ExpectedCmd{
+				{Cmd: findIfacesCmd, Output: strings.Join(append(append(defaultIfaceLines, blueIfaceLines...), primaryUDNIfaceLines...), "\n")},
+				delPortCmdFor("validsandbox_net2"),
+				delPortCmdFor("stalesandbox_net1"),
+				{Cmd: qosFindCmd},
+				{Cmd: validQoSFindCmd},
+			},
+			netLinkOpsMockHelper: append(linkGoneMockHelper, linkGoneMockHelper...),
+		},
+		{
+			desc:             "network collection ignores the ports of other NADs of the network",
+			netName:          blueNetwork,
+			nadName:          "testns/other",
+			validAttachments: []cnitypes.GCAttachment{},
+			ovsCmds: []ovntest.ExpectedCmd{
+				{Cmd: findIfacesCmd, Output: strings.Join(blueIfaceLines, "\n")},
+			},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.netLinkOpsMockHelper)
+			execMock := ovntest.NewFakeExec()
+			for i := range tc.ovsCmds {
+				execMock.AddFakeCmd(&tc.ovsCmds[i])
+			}
+			err := SetExec(execMock)
+			require.NoError(t, err)
+
+			netName, nadName := tc.netName, tc.nadName
+			if netName == "" {
+				netName, nadName = ovntypes.DefaultNetworkName, ovntypes.DefaultNetworkName
+			}
+			validAttachments := tc.validAttachments
+			if validAttachments == nil {
+				validAttachments = []cnitypes.GCAttachment{{ContainerID: validSandbox, IfName: "eth0"}}
+			}
+			reclaimed, err := reclaimStalePodPorts(netName, nadName, validAttachments, tc.dryRun)
+			if tc.errMatch != nil {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMatch.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedReclaimed, reclaimed)
+			assert.True(t, execMock.CalledMatchesExpected(), execMock.ErrorDesc)
+			mockNetLinkOps.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCmdGCWithoutValidAttachments(t *testing.T) {
+	require.NoError(t, config.PrepareTestConfig())
+	// no OVS command is expected, all pod ports would be considered stale
+	execMock := ovntest.NewFakeExec()
+	require.NoError(t, SetExec(execMock))
+
+	pr := &PodRequest{CNIConf: &types.NetConf{}}
+	require.NoError(t, pr.cmdGC())
+	assert.True(t, execMock.CalledMatchesExpected(), execMock.ErrorDesc)
+}
+
 func TestConfigureOVS(t *testing.T) {
 	mockLink := new(netlink_mocks.Link)
 	mockNetLinkOps := new(util_mocks.NetLinkOps)
@@ -85,6 +85,12 @@ type NetConf struct {
 	// When omitted, the default OVN overlay transport is used.
 	Transport string `json:"transport,omitempty"`
 
+	// Multicast enables or disables IP multicast for the network.
+	// Valid values are "enabled" and "disabled", only for primary layer2 and
+	// layer3 topologies.
+	// When omitted, multicast follows the global configuration.
+	Multicast string `json:"multicast,omitempty"`
+
 	// EVPNConfig contains configuration for EVPN mode.
 	// Only valid when Transport is "evpn".
 	EVPN *EVPNConfig `json:"evpn,omitempty"`
@@ -104,6 +110,9 @@ type NetConf struct {
 	// LogFileMaxAge represents the maximum number
 	// of days to retain old log files
 	LogFileMaxAge int `json:"logfile-maxage"`
+	// EnableCheck is set in the CNI config of the default network when CNI
+	// CHECK verifies the pod interfaces, the shim skips CHECK otherwise
+	EnableCheck bool `json:"enableCheck,omitempty"`
 	// Runtime arguments passed by the NPWG implementation (e.g. multus)
 	RuntimeConfig struct {
 		// see https://github.com/k8snetworkplumbingwg/device-info-spec
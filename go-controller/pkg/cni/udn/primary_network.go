@@ -51,8 +51,12 @@ func NewPrimaryNetwork(networkManager networkmanager.Interface, nadLister nadv1L
 	}
 }
 
+// InterfaceName is the name of the primary UDN interface of the pods, plumbed
+// together with their default network interface
+const InterfaceName = "ovn-udn1"
+
 func (p *UserDefinedPrimaryNetwork) InterfaceName() string {
-	return "ovn-udn1"
+	return InterfaceName
 }
 
 func (p *UserDefinedPrimaryNetwork) NetworkDevice() string {
@@ -37,6 +37,7 @@ func WriteCNIConfig() error {
 		LogFileMaxSize:    Logging.LogFileMaxSize,
 		LogFileMaxBackups: Logging.LogFileMaxBackups,
 		LogFileMaxAge:     Logging.LogFileMaxAge,
+		EnableCheck:       CNI.EnableCheck,
 	}
 
 	newBytes, err := json.Marshal(netConf)
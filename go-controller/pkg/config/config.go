@@ -154,7 +154,9 @@ var (
 	}
 
 	// Metrics holds Prometheus metrics-related parameters.
-	Metrics MetricsConfig
+	Metrics = MetricsConfig{
+		ACLStatsMaxSeries: 1000,
+	}
 
 	// OVNKubernetesFeature config holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
 	OVNKubernetesFeature = OVNKubernetesFeatureConfig{
@@ -222,6 +224,12 @@ var (
 		VXLANPort: DefaultVXLANPort,
 	}
 
+	// IPsec holds IPsec lifecycle management config options.
+	IPsec = IPsecConfig{
+		CertDir:      "/etc/openvswitch/keys",
+		CertDuration: 24 * time.Hour * 180,
+	}
+
 	// UnprivilegedMode allows ovnkube-node to run without SYS_ADMIN capability, by performing interface setup in the CNI plugin
 	UnprivilegedMode bool
 
@@ -276,6 +284,16 @@ const (
 	ManagedBGPTopologyFullMesh string = "full-mesh"
 )
 
+// IPsec encryption configuration option constants
+const (
+	// IPsecEncryptionEnabled indicates OVN-Kubernetes enables the IPsec encryption of the tunnels
+	IPsecEncryptionEnabled string = "enabled"
+	// IPsecEncryptionDisabled indicates OVN-Kubernetes disables the IPsec encryption of the tunnels
+	IPsecEncryptionDisabled string = "disabled"
+	// IPsecEncryptionUnmanaged indicates OVN-Kubernetes leaves the IPsec encryption of the tunnels as it is
+	IPsecEncryptionUnmanaged string = "unmanaged"
+)
+
 // DefaultConfig holds parsed config file parameters and command-line overrides
 type DefaultConfig struct {
 	// MTU value used for the overlay networks.
@@ -426,6 +444,10 @@ type CNIConfig struct {
 	ConfDir string `gcfg:"conf-dir"`
 	// Plugin specifies the name of the CNI plugin
 	Plugin string `gcfg:"plugin"`
+	// GCDryRun makes CNI GC only log the stale pod ports it would reclaim
+	GCDryRun bool `gcfg:"gc-dry-run"`
+	// EnableCheck makes CNI CHECK verify the pod interfaces against the pod annotation
+	EnableCheck bool `gcfg:"enable-check"`
 }
 
 // KubernetesConfig holds Kubernetes-related parsed config file parameters and command-line overrides
@@ -475,6 +497,14 @@ type MetricsConfig struct {
 	// configuration duration and optionally, its application to all nodes
 	EnableConfigDuration bool `gcfg:"enable-config-duration"`
 	EnableScaleMetrics   bool `gcfg:"enable-scale-metrics"`
+	// EnableACLStats labels network policy, admin network policy and egress firewall ACLs
+	// and exports per-rule allowed connection counters from the OVN metrics server of ovnkube-node
+	EnableACLStats bool `gcfg:"enable-acl-stats"`
+	// ACLStatsMaxSeries is the maximum number of per-rule allowed connection counter series exported
+	ACLStatsMaxSeries int `gcfg:"acl-stats-max-series"`
+	// EnableNodeDebug serves the gateway flows, routes, IP rules and nftables of ovnkube-node,
+	// along with their drift from the live state of the node, on the metrics server
+	EnableNodeDebug bool `gcfg:"enable-node-debug"`
 }
 
 // OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
@@ -502,6 +532,7 @@ type OVNKubernetesFeatureConfig struct {
 	EnablePersistentIPs             bool `gcfg:"enable-persistent-ips"`
 	EnableDNSNameResolver           bool `gcfg:"enable-dns-name-resolver"`
 	EnableServiceTemplateSupport    bool `gcfg:"enable-svc-template-support"`
+	EnableServiceHealthChecks       bool `gcfg:"enable-svc-health-checks"`
 	EnableObservability             bool `gcfg:"enable-observability"`
 	EnableNetworkQoS                bool `gcfg:"enable-network-qos"`
 	AllowICMPNetworkPolicy          bool `gcfg:"allow-icmp-network-policy"`
@@ -512,6 +543,8 @@ type OVNKubernetesFeatureConfig struct {
 	// UDNDeletionGracePeriod specified in number of seconds to wait before garbage collecting a UDN. Applies
 	// only when Dynamic UDN Allocation is enabled.
 	UDNDeletionGracePeriod time.Duration `gcfg:"udn-deletion-grace-period"`
+	// EnableIPsec enables east-west IPsec encryption and the management of the IPsec certificates of the nodes
+	EnableIPsec bool `gcfg:"enable-ipsec"`
 }
 
 // GatewayMode holds the node gateway mode
@@ -635,6 +668,24 @@ type HybridOverlayConfig struct {
 	VXLANPort uint `gcfg:"hybrid-overlay-vxlan-port"`
 }
 
+// IPsecConfig holds configuration for the IPsec certificates managed by ovnkube
+// when east-west IPsec is enabled.
+type IPsecConfig struct {
+	// CACert is the path of the CA certificate node IPsec certificates are signed with.
+	// Nodes use it to authenticate their peers.
+	CACert string `gcfg:"ca-cert"`
+	// CAKey is the path of the private key of CACert, only needed by ovnkube-cluster-manager.
+	CAKey string `gcfg:"ca-key"`
+	// CertDir is the directory ovnkube-node stores its IPsec private key and certificate in.
+	CertDir string `gcfg:"cert-dir"`
+	// CertDuration is the requested lifetime of node IPsec certificates.
+	CertDuration time.Duration `gcfg:"cert-duration"`
+	// Encryption is whether the tunnels between the chassis are encrypted: enabled, disabled,
+	// or unmanaged to leave it as it is, when IPsec is managed outside of ovnkube. Defaults to
+	// enabled when IPsec is enabled, unmanaged otherwise.
+	Encryption string `gcfg:"encryption"`
+}
+
 // OvnKubeNodeConfig holds ovnkube-node configurations
 type OvnKubeNodeConfig struct {
 	Mode                      string `gcfg:"mode"`
@@ -706,6 +757,7 @@ type config struct {
 	MasterHA             HAConfig
 	ClusterMgrHA         HAConfig
 	HybridOverlay        HybridOverlayConfig
+	IPsec                IPsecConfig
 	OvnKubeNode          OvnKubeNodeConfig
 	ClusterManager       ClusterManagerConfig
 	OvsPaths             OvsPathConfig
@@ -728,6 +780,7 @@ var (
 	savedMasterHA             HAConfig
 	savedClusterMgrHA         HAConfig
 	savedHybridOverlay        HybridOverlayConfig
+	savedIPsec                IPsecConfig
 	savedOvnKubeNode          OvnKubeNodeConfig
 	savedClusterManager       ClusterManagerConfig
 	savedOvsPaths             OvsPathConfig
@@ -760,6 +813,7 @@ func init() {
 	savedMasterHA = MasterHA
 	savedClusterMgrHA = ClusterMgrHA
 	savedHybridOverlay = HybridOverlay
+	savedIPsec = IPsec
 	savedOvnKubeNode = OvnKubeNode
 	savedClusterManager = ClusterManager
 	savedOvsPaths = OvsPaths
@@ -793,6 +847,7 @@ func PrepareTestConfig() error {
 	Gateway = savedGateway
 	MasterHA = savedMasterHA
 	HybridOverlay = savedHybridOverlay
+	IPsec = savedIPsec
 	OvnKubeNode = savedOvnKubeNode
 	ClusterManager = savedClusterManager
 	OvsPaths = savedOvsPaths
@@ -1174,6 +1229,16 @@ var CNIFlags = []cli.Flag{
 		Destination: &cliConfig.CNI.Plugin,
 		Value:       CNI.Plugin,
 	},
+	&cli.BoolFlag{
+		Name:        "cni-gc-dry-run",
+		Usage:       "only log the stale pod OVS ports and interfaces that CNI GC would remove",
+		Destination: &cliConfig.CNI.GCDryRun,
+	},
+	&cli.BoolFlag{
+		Name:        "cni-enable-check",
+		Usage:       "verify on CNI CHECK that the pod OVS ports and interfaces match the pod annotation",
+		Destination: &cliConfig.CNI.EnableCheck,
+	},
 }
 
 // OVNK8sFeatureFlags capture OVN-Kubernetes feature related options
@@ -1309,6 +1374,13 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableServiceTemplateSupport,
 		Value:       OVNKubernetesFeature.EnableServiceTemplateSupport,
 	},
+	&cli.BoolFlag{
+		Name: "enable-svc-health-checks",
+		Usage: "Configure OVN load balancer health checks for services annotated with k8s.ovn.org/health-check. " +
+			"The last usable address of each node subnet is reserved as source of the health checks.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableServiceHealthChecks,
+		Value:       OVNKubernetesFeature.EnableServiceHealthChecks,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-observability",
 		Usage:       "Use OVN sampling with ovn-kubernetes.",
@@ -1334,6 +1406,12 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.UDNDeletionGracePeriod,
 		Value:       OVNKubernetesFeature.UDNDeletionGracePeriod,
 	},
+	&cli.BoolFlag{
+		Name:        "enable-ipsec",
+		Usage:       "Enables east-west IPsec encryption and the management of the IPsec certificates of the nodes.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableIPsec,
+		Value:       OVNKubernetesFeature.EnableIPsec,
+	},
 }
 
 // K8sFlags capture Kubernetes-related options
@@ -1508,6 +1586,24 @@ var MetricsFlags = []cli.Flag{
 		Usage:       "Enables metrics related to scaling",
 		Destination: &cliConfig.Metrics.EnableScaleMetrics,
 	},
+	&cli.BoolFlag{
+		Name:        "metrics-enable-acl-stats",
+		Usage:       "Enables per-rule allowed connection counters for network policy, admin network policy and egress firewall ACLs",
+		Destination: &cliConfig.Metrics.EnableACLStats,
+	},
+	&cli.IntFlag{
+		Name:        "metrics-acl-stats-max-series",
+		Usage:       "Maximum number of per-rule allowed connection counter series exported by the OVN metrics server",
+		Destination: &cliConfig.Metrics.ACLStatsMaxSeries,
+		Value:       Metrics.ACLStatsMaxSeries,
+	},
+	&cli.BoolFlag{
+		Name: "metrics-enable-node-debug",
+		Usage: "Enables an authenticated endpoint on the ovnkube-node metrics server that dumps the gateway flows, " +
+			"routes, IP rules and nftables managed by ovnkube-node, and their drift from the live state of the node. " +
+			"Requires the metrics server to serve TLS.",
+		Destination: &cliConfig.Metrics.EnableNodeDebug,
+	},
 }
 
 // OvnNBFlags capture OVN northbound database options
@@ -1815,6 +1911,42 @@ var HybridOverlayFlags = []cli.Flag{
 	},
 }
 
+// IPsecFlags capture IPsec certificate management options
+var IPsecFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:        "ipsec-ca-cert",
+		Usage:       "The absolute path to the CA certificate used to sign and verify node IPsec certificates",
+		Destination: &cliConfig.IPsec.CACert,
+		Value:       IPsec.CACert,
+	},
+	&cli.StringFlag{
+		Name:        "ipsec-ca-key",
+		Usage:       "The absolute path to the private key of the IPsec CA certificate, used by ovnkube-cluster-manager to sign node IPsec certificates",
+		Destination: &cliConfig.IPsec.CAKey,
+		Value:       IPsec.CAKey,
+	},
+	&cli.StringFlag{
+		Name:        "ipsec-cert-dir",
+		Usage:       "The directory ovnkube-node stores its IPsec private key and certificate in",
+		Destination: &cliConfig.IPsec.CertDir,
+		Value:       IPsec.CertDir,
+	},
+	&cli.DurationFlag{
+		Name:        "ipsec-cert-duration",
+		Usage:       "The requested lifetime of node IPsec certificates",
+		Destination: &cliConfig.IPsec.CertDuration,
+		Value:       IPsec.CertDuration,
+	},
+	&cli.StringFlag{
+		Name: "ipsec-encryption",
+		Usage: "Whether the tunnels between the chassis are encrypted with IPsec: enabled, disabled, or " +
+			"unmanaged to leave it as it is, when IPsec is managed outside of ovnkube. Defaults to " +
+			"enabled when IPsec is enabled, unmanaged otherwise.",
+		Destination: &cliConfig.IPsec.Encryption,
+		Value:       IPsec.Encryption,
+	},
+}
+
 // OvnKubeNodeFlags captures ovnkube-node specific configurations
 var OvnKubeNodeFlags = []cli.Flag{
 	&cli.StringFlag{
@@ -1896,6 +2028,7 @@ func GetFlags(customFlags []cli.Flag) []cli.Flag {
 	flags = append(flags, MasterHAFlags...)
 	flags = append(flags, ClusterMgrHAFlags...)
 	flags = append(flags, HybridOverlayFlags...)
+	flags = append(flags, IPsecFlags...)
 	flags = append(flags, MonitoringFlags...)
 	flags = append(flags, IPFIXFlags...)
 	flags = append(flags, OvnKubeNodeFlags...)
@@ -2408,6 +2541,45 @@ func buildHybridOverlayConfig(cli, file *config) error {
 	return nil
 }
 
+func buildIPsecConfig(cli, file *config) error {
+	// Copy config file values over default values
+	if err := overrideFields(&IPsec, &file.IPsec, &savedIPsec); err != nil {
+		return err
+	}
+
+	// And CLI overrides over config file and default values
+	if err := overrideFields(&IPsec, &cli.IPsec, &savedIPsec); err != nil {
+		return err
+	}
+
+	if OVNKubernetesFeature.EnableIPsec {
+		if IPsec.CACert == "" {
+			return fmt.Errorf("IPsec requires the IPsec CA certificate to be configured")
+		}
+		if IPsec.CertDuration < time.Hour {
+			return fmt.Errorf("invalid IPsec certificate duration %s: must be at least 1h", IPsec.CertDuration)
+		}
+	}
+
+	switch IPsec.Encryption {
+	case "":
+		IPsec.Encryption = IPsecEncryptionUnmanaged
+		if OVNKubernetesFeature.EnableIPsec {
+			IPsec.Encryption = IPsecEncryptionEnabled
+		}
+	case IPsecEncryptionDisabled:
+		if OVNKubernetesFeature.EnableIPsec {
+			return fmt.Errorf("IPsec encryption can't be disabled when IPsec is enabled")
+		}
+	case IPsecEncryptionEnabled, IPsecEncryptionUnmanaged:
+	default:
+		return fmt.Errorf("invalid IPsec encryption %q: must be %s, %s or %s", IPsec.Encryption,
+			IPsecEncryptionEnabled, IPsecEncryptionDisabled, IPsecEncryptionUnmanaged)
+	}
+
+	return nil
+}
+
 // completeHybridOverlayConfig completes the HybridOverlay config by parsing raw values
 // into their final form.
 func completeHybridOverlayConfig(allSubnets *ConfigSubnets) error {
@@ -2711,6 +2883,7 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		MasterHA:             savedMasterHA,
 		ClusterMgrHA:         savedClusterMgrHA,
 		HybridOverlay:        savedHybridOverlay,
+		IPsec:                savedIPsec,
 		OvnKubeNode:          savedOvnKubeNode,
 		ClusterManager:       savedClusterManager,
 		OvsPaths:             savedOvsPaths,
@@ -2829,6 +3002,10 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		return "", err
 	}
 
+	if err = buildIPsecConfig(&cliConfig, &cfg); err != nil {
+		return "", err
+	}
+
 	if err = buildOvnKubeNodeConfig(&cliConfig, &cfg); err != nil {
 		return "", err
 	}
@@ -2348,6 +2348,52 @@ udn-allowed-default-services= ns/svc, ns1/svc1
 		})
 	})
 
+	Describe("IPsec Configuration", func() {
+		var cliConfig, fileConfig config
+
+		BeforeEach(func() {
+			err := PrepareTestConfig()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cliConfig = config{IPsec: IPsec}
+			fileConfig = config{IPsec: IPsec}
+		})
+
+		It("leaves the encryption unmanaged by default", func() {
+			err := buildIPsecConfig(&cliConfig, &fileConfig)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(IPsec.Encryption).To(gomega.Equal(IPsecEncryptionUnmanaged))
+		})
+
+		It("enables the encryption by default when IPsec is enabled", func() {
+			OVNKubernetesFeature.EnableIPsec = true
+			cliConfig.IPsec.CACert = "/ca.crt"
+			err := buildIPsecConfig(&cliConfig, &fileConfig)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(IPsec.Encryption).To(gomega.Equal(IPsecEncryptionEnabled))
+		})
+
+		It("disables the encryption when configured", func() {
+			cliConfig.IPsec.Encryption = IPsecEncryptionDisabled
+			err := buildIPsecConfig(&cliConfig, &fileConfig)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(IPsec.Encryption).To(gomega.Equal(IPsecEncryptionDisabled))
+		})
+
+		It("rejects disabling the encryption when IPsec is enabled", func() {
+			OVNKubernetesFeature.EnableIPsec = true
+			cliConfig.IPsec.CACert = "/ca.crt"
+			cliConfig.IPsec.Encryption = IPsecEncryptionDisabled
+			err := buildIPsecConfig(&cliConfig, &fileConfig)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+
+		It("rejects an invalid encryption", func() {
+			cliConfig.IPsec.Encryption = "invalid"
+			err := buildIPsecConfig(&cliConfig, &fileConfig)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+	})
+
 	Describe("BGP Configuration", func() {
 		BeforeEach(func() {
 			err := PrepareTestConfig()
@@ -72,6 +72,9 @@ type ControllerManager struct {
 	eIPController *ovn.EgressIPController
 
 	addressSetManager *addresssetmanager.AddressSetManager
+
+	// observManager configures sampling based on NetworkObservabilities
+	observManager *observability.Manager
 }
 
 func (cm *ControllerManager) NewNetworkController(nInfo util.NetInfo) (networkmanager.NetworkController, error) {
@@ -488,10 +491,9 @@ func (cm *ControllerManager) Start(ctx context.Context) error {
 		}
 	}
 
-	var observabilityManager *observability.Manager
 	if config.OVNKubernetesFeature.EnableObservability {
-		observabilityManager = observability.NewManager(cm.nbClient)
-		if err = observabilityManager.Init(); err != nil {
+		cm.observManager = observability.NewManager(cm.nbClient, cm.watchFactory.NetworkObservabilityInformer())
+		if err = cm.observManager.Init(); err != nil {
 			return fmt.Errorf("failed to init observability manager: %w", err)
 		}
 	} else {
@@ -511,7 +513,7 @@ func (cm *ControllerManager) Start(ctx context.Context) error {
 		}()
 	}
 
-	err = cm.initDefaultNetworkController(observabilityManager)
+	err = cm.initDefaultNetworkController(cm.observManager)
 	if err != nil {
 		return fmt.Errorf("failed to init default network controller: %v", err)
 	}
@@ -565,6 +567,10 @@ func (cm *ControllerManager) Stop() {
 	if cm.addressSetManager != nil {
 		cm.addressSetManager.Stop()
 	}
+
+	if cm.observManager != nil {
+		cm.observManager.Stop()
+	}
 }
 
 func (cm *ControllerManager) Reconcile(_ string, _, _ util.NetInfo) error {
@@ -0,0 +1,75 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	egressfirewallv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ClusterEgressFirewallSpecApplyConfiguration represents a declarative configuration of the ClusterEgressFirewallSpec type for use
+// with apply.
+type ClusterEgressFirewallSpecApplyConfiguration struct {
+	NamespaceSelector *metav1.LabelSelectorApplyConfiguration          `json:"namespaceSelector,omitempty"`
+	Priority          *int32                                           `json:"priority,omitempty"`
+	Placement         *egressfirewallv1.ClusterEgressFirewallPlacement `json:"placement,omitempty"`
+	Egress            []EgressFirewallRuleApplyConfiguration           `json:"egress,omitempty"`
+}
+
+// ClusterEgressFirewallSpecApplyConfiguration constructs a declarative configuration of the ClusterEgressFirewallSpec type for use with
+// apply.
+func ClusterEgressFirewallSpec() *ClusterEgressFirewallSpecApplyConfiguration {
+	return &ClusterEgressFirewallSpecApplyConfiguration{}
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *ClusterEgressFirewallSpecApplyConfiguration) WithNamespaceSelector(value *metav1.LabelSelectorApplyConfiguration) *ClusterEgressFirewallSpecApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithPriority sets the Priority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Priority field is set to the value of the last call.
+func (b *ClusterEgressFirewallSpecApplyConfiguration) WithPriority(value int32) *ClusterEgressFirewallSpecApplyConfiguration {
+	b.Priority = &value
+	return b
+}
+
+// WithPlacement sets the Placement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Placement field is set to the value of the last call.
+func (b *ClusterEgressFirewallSpecApplyConfiguration) WithPlacement(value egressfirewallv1.ClusterEgressFirewallPlacement) *ClusterEgressFirewallSpecApplyConfiguration {
+	b.Placement = &value
+	return b
+}
+
+// WithEgress adds the given value to the Egress field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Egress field.
+func (b *ClusterEgressFirewallSpecApplyConfiguration) WithEgress(values ...*EgressFirewallRuleApplyConfiguration) *ClusterEgressFirewallSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithEgress")
+		}
+		b.Egress = append(b.Egress, *values[i])
+	}
+	return b
+}
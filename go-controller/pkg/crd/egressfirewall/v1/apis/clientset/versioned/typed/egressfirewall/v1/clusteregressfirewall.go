@@ -0,0 +1,75 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	context "context"
+
+	egressfirewallv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	applyconfigurationegressfirewallv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/applyconfiguration/egressfirewall/v1"
+	scheme "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterEgressFirewallsGetter has a method to return a ClusterEgressFirewallInterface.
+// A group's client should implement this interface.
+type ClusterEgressFirewallsGetter interface {
+	ClusterEgressFirewalls() ClusterEgressFirewallInterface
+}
+
+// ClusterEgressFirewallInterface has methods to work with ClusterEgressFirewall resources.
+type ClusterEgressFirewallInterface interface {
+	Create(ctx context.Context, clusterEgressFirewall *egressfirewallv1.ClusterEgressFirewall, opts metav1.CreateOptions) (*egressfirewallv1.ClusterEgressFirewall, error)
+	Update(ctx context.Context, clusterEgressFirewall *egressfirewallv1.ClusterEgressFirewall, opts metav1.UpdateOptions) (*egressfirewallv1.ClusterEgressFirewall, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterEgressFirewall *egressfirewallv1.ClusterEgressFirewall, opts metav1.UpdateOptions) (*egressfirewallv1.ClusterEgressFirewall, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*egressfirewallv1.ClusterEgressFirewall, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*egressfirewallv1.ClusterEgressFirewallList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *egressfirewallv1.ClusterEgressFirewall, err error)
+	Apply(ctx context.Context, clusterEgressFirewall *applyconfigurationegressfirewallv1.ClusterEgressFirewallApplyConfiguration, opts metav1.ApplyOptions) (result *egressfirewallv1.ClusterEgressFirewall, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, clusterEgressFirewall *applyconfigurationegressfirewallv1.ClusterEgressFirewallApplyConfiguration, opts metav1.ApplyOptions) (result *egressfirewallv1.ClusterEgressFirewall, err error)
+	ClusterEgressFirewallExpansion
+}
+
+// clusterEgressFirewalls implements ClusterEgressFirewallInterface
+type clusterEgressFirewalls struct {
+	*gentype.ClientWithListAndApply[*egressfirewallv1.ClusterEgressFirewall, *egressfirewallv1.ClusterEgressFirewallList, *applyconfigurationegressfirewallv1.ClusterEgressFirewallApplyConfiguration]
+}
+
+// newClusterEgressFirewalls returns a ClusterEgressFirewalls
+func newClusterEgressFirewalls(c *K8sV1Client) *clusterEgressFirewalls {
+	return &clusterEgressFirewalls{
+		gentype.NewClientWithListAndApply[*egressfirewallv1.ClusterEgressFirewall, *egressfirewallv1.ClusterEgressFirewallList, *applyconfigurationegressfirewallv1.ClusterEgressFirewallApplyConfiguration](
+			"clusteregressfirewalls",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *egressfirewallv1.ClusterEgressFirewall { return &egressfirewallv1.ClusterEgressFirewall{} },
+			func() *egressfirewallv1.ClusterEgressFirewallList {
+				return &egressfirewallv1.ClusterEgressFirewallList{}
+			},
+		),
+	}
+}
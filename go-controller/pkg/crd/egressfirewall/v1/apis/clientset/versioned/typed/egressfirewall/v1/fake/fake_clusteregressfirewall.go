@@ -0,0 +1,52 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	egressfirewallv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/applyconfiguration/egressfirewall/v1"
+	typedegressfirewallv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned/typed/egressfirewall/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterEgressFirewalls implements ClusterEgressFirewallInterface
+type fakeClusterEgressFirewalls struct {
+	*gentype.FakeClientWithListAndApply[*v1.ClusterEgressFirewall, *v1.ClusterEgressFirewallList, *egressfirewallv1.ClusterEgressFirewallApplyConfiguration]
+	Fake *FakeK8sV1
+}
+
+func newFakeClusterEgressFirewalls(fake *FakeK8sV1) typedegressfirewallv1.ClusterEgressFirewallInterface {
+	return &fakeClusterEgressFirewalls{
+		gentype.NewFakeClientWithListAndApply[*v1.ClusterEgressFirewall, *v1.ClusterEgressFirewallList, *egressfirewallv1.ClusterEgressFirewallApplyConfiguration](
+			fake.Fake,
+			"",
+			v1.SchemeGroupVersion.WithResource("clusteregressfirewalls"),
+			v1.SchemeGroupVersion.WithKind("ClusterEgressFirewall"),
+			func() *v1.ClusterEgressFirewall { return &v1.ClusterEgressFirewall{} },
+			func() *v1.ClusterEgressFirewallList { return &v1.ClusterEgressFirewallList{} },
+			func(dst, src *v1.ClusterEgressFirewallList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.ClusterEgressFirewallList) []*v1.ClusterEgressFirewall {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1.ClusterEgressFirewallList, items []*v1.ClusterEgressFirewall) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,47 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	egressfirewallv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterEgressFirewallLister helps list ClusterEgressFirewalls.
+// All objects returned here must be treated as read-only.
+type ClusterEgressFirewallLister interface {
+	// List lists all ClusterEgressFirewalls in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*egressfirewallv1.ClusterEgressFirewall, err error)
+	// Get retrieves the ClusterEgressFirewall from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*egressfirewallv1.ClusterEgressFirewall, error)
+	ClusterEgressFirewallListerExpansion
+}
+
+// clusterEgressFirewallLister implements the ClusterEgressFirewallLister interface.
+type clusterEgressFirewallLister struct {
+	listers.ResourceIndexer[*egressfirewallv1.ClusterEgressFirewall]
+}
+
+// NewClusterEgressFirewallLister returns a new ClusterEgressFirewallLister.
+func NewClusterEgressFirewallLister(indexer cache.Indexer) ClusterEgressFirewallLister {
+	return &clusterEgressFirewallLister{listers.New[*egressfirewallv1.ClusterEgressFirewall](indexer, egressfirewallv1.Resource("clusteregressfirewall"))}
+}
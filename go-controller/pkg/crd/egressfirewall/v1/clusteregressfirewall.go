@@ -0,0 +1,76 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterEgressFirewallPlacement defines when ClusterEgressFirewall rules are evaluated
+// relative to the EgressFirewall of a selected namespace.
+// +kubebuilder:validation:Enum=BeforeEgressFirewall;AfterEgressFirewall
+type ClusterEgressFirewallPlacement string
+
+const (
+	// ClusterEgressFirewallBeforeEgressFirewall rules are evaluated after AdminNetworkPolicy rules and before
+	// namespaced EgressFirewall and NetworkPolicy rules, they can't be overridden by namespace owners.
+	ClusterEgressFirewallBeforeEgressFirewall ClusterEgressFirewallPlacement = "BeforeEgressFirewall"
+	// ClusterEgressFirewallAfterEgressFirewall rules are only evaluated for traffic that didn't match any
+	// AdminNetworkPolicy, namespaced EgressFirewall or NetworkPolicy rule, before BaselineAdminNetworkPolicy
+	// rules. They work as cluster-wide defaults.
+	ClusterEgressFirewallAfterEgressFirewall ClusterEgressFirewallPlacement = "AfterEgressFirewall"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +resource:path=clusteregressfirewall
+// +kubebuilder:resource:shortName=cef,scope=Cluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=".spec.priority"
+// +kubebuilder:printcolumn:name="Placement",type=string,JSONPath=".spec.placement"
+// +kubebuilder:printcolumn:name="ClusterEgressFirewall Status",type=string,JSONPath=".status.status"
+// +kubebuilder:subresource:status
+// ClusterEgressFirewall describes an egress firewall applied to all Namespaces selected by its
+// namespaceSelector. Traffic from a pod to an IP address outside the cluster will be checked against
+// ClusterEgressFirewalls selecting the pod's namespace in priority order, and against the EgressFirewall
+// of the pod's namespace, based on the ClusterEgressFirewall placement.
+type ClusterEgressFirewall struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of ClusterEgressFirewall.
+	Spec ClusterEgressFirewallSpec `json:"spec"`
+	// Observed status of ClusterEgressFirewall
+	// +optional
+	Status EgressFirewallStatus `json:"status,omitempty"`
+}
+
+// ClusterEgressFirewallSpec is a desired state description of ClusterEgressFirewall.
+type ClusterEgressFirewallSpec struct {
+	// namespaceSelector selects the namespaces the egress rules apply to.
+	// An empty selector selects all namespaces.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// priority orders ClusterEgressFirewalls with the same placement, lower values are evaluated first.
+	// Two ClusterEgressFirewalls with the same placement and priority selecting the same namespace
+	// result in undefined behavior.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=99
+	Priority int32 `json:"priority"`
+	// placement defines if the egress rules are evaluated before or after the EgressFirewall
+	// of a selected namespace. Defaults to BeforeEgressFirewall.
+	// +kubebuilder:default=BeforeEgressFirewall
+	// +optional
+	Placement ClusterEgressFirewallPlacement `json:"placement,omitempty"`
+	// a collection of egress firewall rule objects, evaluated in order.
+	// +kubebuilder:validation:MaxItems=80
+	Egress []EgressFirewallRule `json:"egress"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=clusteregressfirewall
+// ClusterEgressFirewallList is the list of ClusterEgressFirewalls.
+type ClusterEgressFirewallList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of ClusterEgressFirewalls.
+	Items []ClusterEgressFirewall `json:"items"`
+}
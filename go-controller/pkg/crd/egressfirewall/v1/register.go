@@ -23,6 +23,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&EgressFirewall{},
 		&EgressFirewallList{},
+		&ClusterEgressFirewall{},
+		&ClusterEgressFirewallList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
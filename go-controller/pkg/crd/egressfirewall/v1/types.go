@@ -60,7 +60,8 @@ type EgressFirewallRule struct {
 	To EgressFirewallDestination `json:"to"`
 }
 
-// EgressFirewallPort specifies the port to allow or deny traffic to
+// EgressFirewallPort specifies the port or the range of ports to allow or deny traffic to
+// +kubebuilder:validation:XValidation:rule="!has(self.endPort) || self.endPort >= self.port",message="endPort must be greater than or equal to port"
 type EgressFirewallPort struct {
 	// protocol (tcp, udp, sctp) that the traffic must match.
 	// +kubebuilder:validation:Pattern=^TCP|UDP|SCTP$
@@ -69,6 +70,12 @@ type EgressFirewallPort struct {
 	// +kubebuilder:validation:Minimum:=1
 	// +kubebuilder:validation:Maximum:=65535
 	Port int32 `json:"port"`
+	// endPort indicates that the range of ports from port to endPort, inclusive, should be matched.
+	// It must be greater than or equal to port.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	EndPort *int32 `json:"endPort,omitempty"`
 }
 
 // +kubebuilder:validation:MinProperties:=1
@@ -80,7 +87,8 @@ type EgressFirewallDestination struct {
 	// dnsName is the domain name to allow/deny traffic to. If this is set, cidrSelector and nodeSelector must be unset.
 	// For a wildcard DNS name, the '*' will match only one label. Additionally, only a single '*' can be
 	// used at the beginning of the wildcard DNS name. For example, '*.example.com' will match 'sub1.example.com'
-	// but won't match 'sub2.sub1.example.com'.
+	// but won't match 'sub2.sub1.example.com'. Wildcard DNS names are only supported when DNSNameResolver
+	// is enabled.
 	// +kubebuilder:validation:Pattern=`^(\*\.)?([A-Za-z0-9-]+\.)*[A-Za-z0-9-]+\.?$`
 	DNSName string `json:"dnsName,omitempty"`
 	// nodeSelector will allow/deny traffic to the Kubernetes node IP of selected nodes. If this is set,
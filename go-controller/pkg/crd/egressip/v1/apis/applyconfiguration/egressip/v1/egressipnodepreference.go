@@ -0,0 +1,52 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	egressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// EgressIPNodePreferenceApplyConfiguration represents a declarative configuration of the EgressIPNodePreference type for use
+// with apply.
+type EgressIPNodePreferenceApplyConfiguration struct {
+	NodeSelector *metav1.LabelSelectorApplyConfiguration `json:"nodeSelector,omitempty"`
+	Type         *egressipv1.EgressIPNodePreferenceType  `json:"type,omitempty"`
+}
+
+// EgressIPNodePreferenceApplyConfiguration constructs a declarative configuration of the EgressIPNodePreference type for use with
+// apply.
+func EgressIPNodePreference() *EgressIPNodePreferenceApplyConfiguration {
+	return &EgressIPNodePreferenceApplyConfiguration{}
+}
+
+// WithNodeSelector sets the NodeSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeSelector field is set to the value of the last call.
+func (b *EgressIPNodePreferenceApplyConfiguration) WithNodeSelector(value *metav1.LabelSelectorApplyConfiguration) *EgressIPNodePreferenceApplyConfiguration {
+	b.NodeSelector = value
+	return b
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *EgressIPNodePreferenceApplyConfiguration) WithType(value egressipv1.EgressIPNodePreferenceType) *EgressIPNodePreferenceApplyConfiguration {
+	b.Type = &value
+	return b
+}
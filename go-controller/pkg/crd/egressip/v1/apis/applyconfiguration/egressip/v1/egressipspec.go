@@ -24,9 +24,12 @@ import (
 // EgressIPSpecApplyConfiguration represents a declarative configuration of the EgressIPSpec type for use
 // with apply.
 type EgressIPSpecApplyConfiguration struct {
-	EgressIPs         []string                                `json:"egressIPs,omitempty"`
-	NamespaceSelector *metav1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
-	PodSelector       *metav1.LabelSelectorApplyConfiguration `json:"podSelector,omitempty"`
+	EgressIPs         []string                                   `json:"egressIPs,omitempty"`
+	EgressIPPool      *EgressIPPoolRequestApplyConfiguration     `json:"egressIPPool,omitempty"`
+	NamespaceSelector *metav1.LabelSelectorApplyConfiguration    `json:"namespaceSelector,omitempty"`
+	PodSelector       *metav1.LabelSelectorApplyConfiguration    `json:"podSelector,omitempty"`
+	NodeSelector      *metav1.LabelSelectorApplyConfiguration    `json:"nodeSelector,omitempty"`
+	NodePreferences   []EgressIPNodePreferenceApplyConfiguration `json:"nodePreferences,omitempty"`
 }
 
 // EgressIPSpecApplyConfiguration constructs a declarative configuration of the EgressIPSpec type for use with
@@ -45,6 +48,14 @@ func (b *EgressIPSpecApplyConfiguration) WithEgressIPs(values ...string) *Egress
 	return b
 }
 
+// WithEgressIPPool sets the EgressIPPool field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EgressIPPool field is set to the value of the last call.
+func (b *EgressIPSpecApplyConfiguration) WithEgressIPPool(value *EgressIPPoolRequestApplyConfiguration) *EgressIPSpecApplyConfiguration {
+	b.EgressIPPool = value
+	return b
+}
+
 // WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the NamespaceSelector field is set to the value of the last call.
@@ -60,3 +71,24 @@ func (b *EgressIPSpecApplyConfiguration) WithPodSelector(value *metav1.LabelSele
 	b.PodSelector = value
 	return b
 }
+
+// WithNodeSelector sets the NodeSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeSelector field is set to the value of the last call.
+func (b *EgressIPSpecApplyConfiguration) WithNodeSelector(value *metav1.LabelSelectorApplyConfiguration) *EgressIPSpecApplyConfiguration {
+	b.NodeSelector = value
+	return b
+}
+
+// WithNodePreferences adds the given value to the NodePreferences field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NodePreferences field.
+func (b *EgressIPSpecApplyConfiguration) WithNodePreferences(values ...*EgressIPNodePreferenceApplyConfiguration) *EgressIPSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNodePreferences")
+		}
+		b.NodePreferences = append(b.NodePreferences, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,69 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	context "context"
+
+	egressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	applyconfigurationegressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/applyconfiguration/egressip/v1"
+	scheme "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// EgressIPPoolsGetter has a method to return a EgressIPPoolInterface.
+// A group's client should implement this interface.
+type EgressIPPoolsGetter interface {
+	EgressIPPools() EgressIPPoolInterface
+}
+
+// EgressIPPoolInterface has methods to work with EgressIPPool resources.
+type EgressIPPoolInterface interface {
+	Create(ctx context.Context, egressIPPool *egressipv1.EgressIPPool, opts metav1.CreateOptions) (*egressipv1.EgressIPPool, error)
+	Update(ctx context.Context, egressIPPool *egressipv1.EgressIPPool, opts metav1.UpdateOptions) (*egressipv1.EgressIPPool, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*egressipv1.EgressIPPool, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*egressipv1.EgressIPPoolList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *egressipv1.EgressIPPool, err error)
+	Apply(ctx context.Context, egressIPPool *applyconfigurationegressipv1.EgressIPPoolApplyConfiguration, opts metav1.ApplyOptions) (result *egressipv1.EgressIPPool, err error)
+	EgressIPPoolExpansion
+}
+
+// egressIPPools implements EgressIPPoolInterface
+type egressIPPools struct {
+	*gentype.ClientWithListAndApply[*egressipv1.EgressIPPool, *egressipv1.EgressIPPoolList, *applyconfigurationegressipv1.EgressIPPoolApplyConfiguration]
+}
+
+// newEgressIPPools returns a EgressIPPools
+func newEgressIPPools(c *K8sV1Client) *egressIPPools {
+	return &egressIPPools{
+		gentype.NewClientWithListAndApply[*egressipv1.EgressIPPool, *egressipv1.EgressIPPoolList, *applyconfigurationegressipv1.EgressIPPoolApplyConfiguration](
+			"egressippools",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *egressipv1.EgressIPPool { return &egressipv1.EgressIPPool{} },
+			func() *egressipv1.EgressIPPoolList { return &egressipv1.EgressIPPoolList{} },
+		),
+	}
+}
@@ -0,0 +1,50 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/applyconfiguration/egressip/v1"
+	typedegressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/typed/egressip/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeEgressIPPools implements EgressIPPoolInterface
+type fakeEgressIPPools struct {
+	*gentype.FakeClientWithListAndApply[*v1.EgressIPPool, *v1.EgressIPPoolList, *egressipv1.EgressIPPoolApplyConfiguration]
+	Fake *FakeK8sV1
+}
+
+func newFakeEgressIPPools(fake *FakeK8sV1) typedegressipv1.EgressIPPoolInterface {
+	return &fakeEgressIPPools{
+		gentype.NewFakeClientWithListAndApply[*v1.EgressIPPool, *v1.EgressIPPoolList, *egressipv1.EgressIPPoolApplyConfiguration](
+			fake.Fake,
+			"",
+			v1.SchemeGroupVersion.WithResource("egressippools"),
+			v1.SchemeGroupVersion.WithKind("EgressIPPool"),
+			func() *v1.EgressIPPool { return &v1.EgressIPPool{} },
+			func() *v1.EgressIPPoolList { return &v1.EgressIPPoolList{} },
+			func(dst, src *v1.EgressIPPoolList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.EgressIPPoolList) []*v1.EgressIPPool { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1.EgressIPPoolList, items []*v1.EgressIPPool) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,47 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	egressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// EgressIPPoolLister helps list EgressIPPools.
+// All objects returned here must be treated as read-only.
+type EgressIPPoolLister interface {
+	// List lists all EgressIPPools in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*egressipv1.EgressIPPool, err error)
+	// Get retrieves the EgressIPPool from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*egressipv1.EgressIPPool, error)
+	EgressIPPoolListerExpansion
+}
+
+// egressIPPoolLister implements the EgressIPPoolLister interface.
+type egressIPPoolLister struct {
+	listers.ResourceIndexer[*egressipv1.EgressIPPool]
+}
+
+// NewEgressIPPoolLister returns a new EgressIPPoolLister.
+func NewEgressIPPoolLister(indexer cache.Indexer) EgressIPPoolLister {
+	return &egressIPPoolLister{listers.New[*egressipv1.EgressIPPool](indexer, egressipv1.Resource("egressippool"))}
+}
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +resource:path=egressippool
+// +kubebuilder:resource:shortName=eippool,scope=Cluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="CIDRs",type=string,JSONPath=".spec.cidrs[*]"
+// EgressIPPool is a CRD holding the CIDRs egress IPs are allocated from for
+// the EgressIPs requesting a number of egress IPs from the pool, instead of
+// listing the egress IPs.
+type EgressIPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of EgressIPPool.
+	Spec EgressIPPoolSpec `json:"spec"`
+}
+
+// EgressIPPoolSpec is a desired state description of EgressIPPool.
+type EgressIPPoolSpec struct {
+	// CIDRs is the list of IPv4 and/or IPv6 CIDRs egress IPs are allocated from.
+	// The egress IPs allocated can only be assigned to the nodes with a network
+	// containing them: the CIDRs should be part of the node subnet or of a
+	// secondary host network of the egress nodes. The network and the IPv4
+	// broadcast addresses of the CIDRs are never allocated.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:items:XValidation:rule="isCIDR(self)",message="CIDR is invalid"
+	CIDRs []string `json:"cidrs"`
+	// ExcludeIPs is the list of IPs of the CIDRs that are never allocated, like
+	// the gateway of the network. The IPs of the nodes are never allocated and
+	// don't need to be listed.
+	// +optional
+	// +kubebuilder:validation:items:XValidation:rule="isIP(self)",message="IP is invalid"
+	ExcludeIPs []string `json:"excludeIPs,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=egressippool
+// EgressIPPoolList is the list of EgressIPPool.
+type EgressIPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of EgressIPPool.
+	Items []EgressIPPool `json:"items"`
+}
@@ -33,6 +33,27 @@ type EgressIP struct {
 type EgressIPStatus struct {
 	// The list of assigned egress IPs and their corresponding node assignment.
 	Items []EgressIPStatusItem `json:"items"`
+	// The list of requested egress IPs which could not be assigned to any node,
+	// and the reason why.
+	// +optional
+	// +listType=map
+	// +listMapKey=egressIP
+	UnassignedItems []EgressIPUnassignedItem `json:"unassignedItems,omitempty"`
+	// messages are the status messages of the zones programming the egress IPs,
+	// one per zone in the form "<zone>: <message>". They are reset when the
+	// assignments change.
+	// +patchStrategy=merge
+	// +listType=set
+	// +optional
+	Messages []string `json:"messages,omitempty"`
+	// conditions is an array of condition objects indicating details about
+	// status of EgressIP object: Assigned, Programmed and Conflict.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // The per node status, for those egress IPs who have been assigned.
@@ -43,11 +64,63 @@ type EgressIPStatusItem struct {
 	EgressIP string `json:"egressIP"`
 }
 
+// The per IP status, for those egress IPs who could not be assigned.
+type EgressIPUnassignedItem struct {
+	// Unassigned egress IP
+	EgressIP string `json:"egressIP"`
+	// Reason is a CamelCase reason why the egress IP could not be assigned
+	Reason string `json:"reason"`
+	// Message is a human readable message with details about the failed assignment
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	// EgressIPConditionAssigned is true when all the egress IPs requested are assigned to a node
+	EgressIPConditionAssigned = "Assigned"
+	// EgressIPConditionProgrammed is true when all the egress IPs requested are hosted by the node
+	// they are assigned to and all the zones reported their assignments programmed. On cloud
+	// platforms an egress IP is only hosted once the cloud confirmed its assignment to the node.
+	EgressIPConditionProgrammed = "Programmed"
+	// EgressIPConditionConflict is true when any of the egress IPs requested conflicts with an IP
+	// of a node, or with an egress IP of another EgressIP
+	EgressIPConditionConflict = "Conflict"
+)
+
+// Reasons of the unassigned egress IPs
+const (
+	// EgressIPReasonNoAssignableNodes is set when no node is labeled to host egress IPs
+	EgressIPReasonNoAssignableNodes = "NoAssignableNodes"
+	// EgressIPReasonNoSelectedNodes is set when no assignable node matches the node selector of the EgressIP
+	EgressIPReasonNoSelectedNodes = "NoSelectedNodes"
+	// EgressIPReasonNoMatchingNetwork is set when no assignable node has a network that can host the egress IP
+	EgressIPReasonNoMatchingNetwork = "NoMatchingNetwork"
+	// EgressIPReasonNodesInUse is set when all the nodes that can host the egress IP already host
+	// another egress IP of the same EgressIP
+	EgressIPReasonNodesInUse = "NodesInUse"
+	// EgressIPReasonCapacityExhausted is set when all the nodes that can host the egress IP reached
+	// their egress IP capacity
+	EgressIPReasonCapacityExhausted = "CapacityExhausted"
+	// EgressIPReasonHostIPConflict is set when the egress IP is an IP of a node
+	EgressIPReasonHostIPConflict = "HostIPConflict"
+	// EgressIPReasonEgressIPConflict is set when the egress IP is already assigned to another EgressIP
+	EgressIPReasonEgressIPConflict = "EgressIPConflict"
+	// EgressIPReasonAssignmentError is set when the assignment of the egress IP failed unexpectedly
+	EgressIPReasonAssignmentError = "AssignmentError"
+)
+
 // EgressIPSpec is a desired state description of EgressIP.
+// +kubebuilder:validation:XValidation:rule="(has(self.egressIPs) && size(self.egressIPs) > 0) || has(self.egressIPPool)",message="egressIPs or egressIPPool must be set"
 type EgressIPSpec struct {
 	// EgressIPs is the list of egress IP addresses requested. Can be IPv4 and/or IPv6.
-	// This field is mandatory.
-	EgressIPs []string `json:"egressIPs"`
+	// This field is mandatory unless EgressIPPool is set.
+	// +optional
+	EgressIPs []string `json:"egressIPs,omitempty"`
+	// EgressIPPool requests egress IPs allocated from an EgressIPPool, in addition
+	// to the egress IPs listed in EgressIPs. The allocated egress IPs are kept for
+	// the lifetime of the EgressIP and released when it is deleted.
+	// +optional
+	EgressIPPool *EgressIPPoolRequest `json:"egressIPPool,omitempty"`
 	// NamespaceSelector applies the egress IP only to the namespace(s) whose label
 	// matches this definition. This field is mandatory.
 	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
@@ -60,6 +133,58 @@ type EgressIPSpec struct {
 	// match this pod selector.
 	// +optional
 	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+	// NodeSelector restricts the nodes the egress IPs can be assigned to, among
+	// the nodes labeled to host egress IPs, to the nodes whose label matches this
+	// definition. This field is optional, and in case it is not set: the egress
+	// IPs can be assigned to any egress node. Egress IPs assigned to a node which
+	// stops matching the selector are moved to another node.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// NodePreferences is an ordered list of preferences used to pick the node an
+	// egress IP is assigned to, among the nodes the egress IP can be assigned to.
+	// Preferred nodes are picked first, following the order of the list, then
+	// the nodes matching no preference, and Avoided nodes last. Nodes of the same
+	// rank are picked by their amount of egress IPs assigned. The preferences
+	// apply when an egress IP is assigned, including when it is moved after the
+	// failure of its node, but an egress IP assigned is not moved back to a more
+	// preferred node.
+	// +optional
+	// +kubebuilder:validation:MaxItems=8
+	NodePreferences []EgressIPNodePreference `json:"nodePreferences,omitempty"`
+}
+
+// EgressIPNodePreferenceType is the type of an EgressIPNodePreference.
+// +kubebuilder:validation:Enum=Preferred;Avoided
+type EgressIPNodePreferenceType string
+
+const (
+	// EgressIPNodePreferred nodes are picked before the other nodes.
+	EgressIPNodePreferred EgressIPNodePreferenceType = "Preferred"
+	// EgressIPNodeAvoided nodes are only picked when no other node can host the egress IP.
+	EgressIPNodeAvoided EgressIPNodePreferenceType = "Avoided"
+)
+
+// EgressIPNodePreference ranks the nodes whose label matches its node selector.
+// A node matching the node selector of several preferences is ranked by the
+// first of them.
+type EgressIPNodePreference struct {
+	// NodeSelector selects the nodes the preference applies to.
+	NodeSelector metav1.LabelSelector `json:"nodeSelector"`
+	// Type of the preference: Preferred or Avoided. Defaults to Preferred.
+	// +kubebuilder:default=Preferred
+	// +optional
+	Type EgressIPNodePreferenceType `json:"type,omitempty"`
+}
+
+// EgressIPPoolRequest requests a number of egress IPs from an EgressIPPool.
+type EgressIPPoolRequest struct {
+	// Name of the EgressIPPool the egress IPs are allocated from.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Count is the number of egress IPs allocated from the EgressIPPool.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	Count int32 `json:"count"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
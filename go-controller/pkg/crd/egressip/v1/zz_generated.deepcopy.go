@@ -21,6 +21,7 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -85,6 +86,125 @@ func (in *EgressIPList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPNodePreference) DeepCopyInto(out *EgressIPNodePreference) {
+	*out = *in
+	in.NodeSelector.DeepCopyInto(&out.NodeSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPNodePreference.
+func (in *EgressIPNodePreference) DeepCopy() *EgressIPNodePreference {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPNodePreference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPPool) DeepCopyInto(out *EgressIPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPPool.
+func (in *EgressIPPool) DeepCopy() *EgressIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EgressIPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPPoolList) DeepCopyInto(out *EgressIPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EgressIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPPoolList.
+func (in *EgressIPPoolList) DeepCopy() *EgressIPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EgressIPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPPoolRequest) DeepCopyInto(out *EgressIPPoolRequest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPPoolRequest.
+func (in *EgressIPPoolRequest) DeepCopy() *EgressIPPoolRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPPoolRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPPoolSpec) DeepCopyInto(out *EgressIPPoolSpec) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeIPs != nil {
+		in, out := &in.ExcludeIPs, &out.ExcludeIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPPoolSpec.
+func (in *EgressIPPoolSpec) DeepCopy() *EgressIPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressIPSpec) DeepCopyInto(out *EgressIPSpec) {
 	*out = *in
@@ -93,8 +213,25 @@ func (in *EgressIPSpec) DeepCopyInto(out *EgressIPSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EgressIPPool != nil {
+		in, out := &in.EgressIPPool, &out.EgressIPPool
+		*out = new(EgressIPPoolRequest)
+		**out = **in
+	}
 	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
 	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodePreferences != nil {
+		in, out := &in.NodePreferences, &out.NodePreferences
+		*out = make([]EgressIPNodePreference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -116,6 +253,23 @@ func (in *EgressIPStatus) DeepCopyInto(out *EgressIPStatus) {
 		*out = make([]EgressIPStatusItem, len(*in))
 		copy(*out, *in)
 	}
+	if in.UnassignedItems != nil {
+		in, out := &in.UnassignedItems, &out.UnassignedItems
+		*out = make([]EgressIPUnassignedItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -144,3 +298,19 @@ func (in *EgressIPStatusItem) DeepCopy() *EgressIPStatusItem {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPUnassignedItem) DeepCopyInto(out *EgressIPUnassignedItem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPUnassignedItem.
+func (in *EgressIPUnassignedItem) DeepCopy() *EgressIPUnassignedItem {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPUnassignedItem)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,51 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	networkobservabilityv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1"
+)
+
+// SamplingFeatureApplyConfiguration represents a declarative configuration of the SamplingFeature type for use
+// with apply.
+type SamplingFeatureApplyConfiguration struct {
+	Name        *networkobservabilityv1.SamplingFeatureName `json:"name,omitempty"`
+	Probability *int32                                      `json:"probability,omitempty"`
+}
+
+// SamplingFeatureApplyConfiguration constructs a declarative configuration of the SamplingFeature type for use with
+// apply.
+func SamplingFeature() *SamplingFeatureApplyConfiguration {
+	return &SamplingFeatureApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *SamplingFeatureApplyConfiguration) WithName(value networkobservabilityv1.SamplingFeatureName) *SamplingFeatureApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithProbability sets the Probability field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Probability field is set to the value of the last call.
+func (b *SamplingFeatureApplyConfiguration) WithProbability(value int32) *SamplingFeatureApplyConfiguration {
+	b.Probability = &value
+	return b
+}
@@ -0,0 +1,47 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1"
+	internal "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/applyconfiguration/internal"
+	networkobservabilityv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/applyconfiguration/networkobservability/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=k8s.ovn.org, Version=v1
+	case v1.SchemeGroupVersion.WithKind("NetworkObservability"):
+		return &networkobservabilityv1.NetworkObservabilityApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("NetworkObservabilitySpec"):
+		return &networkobservabilityv1.NetworkObservabilitySpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SamplingFeature"):
+		return &networkobservabilityv1.SamplingFeatureApplyConfiguration{}
+
+	}
+	return nil
+}
+
+func NewTypeConverter(scheme *runtime.Scheme) managedfields.TypeConverter {
+	return managedfields.NewSchemeTypeConverter(scheme, internal.Parser())
+}
@@ -0,0 +1,52 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1"
+	networkobservabilityv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/applyconfiguration/networkobservability/v1"
+	typednetworkobservabilityv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/clientset/versioned/typed/networkobservability/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeNetworkObservabilities implements NetworkObservabilityInterface
+type fakeNetworkObservabilities struct {
+	*gentype.FakeClientWithListAndApply[*v1.NetworkObservability, *v1.NetworkObservabilityList, *networkobservabilityv1.NetworkObservabilityApplyConfiguration]
+	Fake *FakeK8sV1
+}
+
+func newFakeNetworkObservabilities(fake *FakeK8sV1) typednetworkobservabilityv1.NetworkObservabilityInterface {
+	return &fakeNetworkObservabilities{
+		gentype.NewFakeClientWithListAndApply[*v1.NetworkObservability, *v1.NetworkObservabilityList, *networkobservabilityv1.NetworkObservabilityApplyConfiguration](
+			fake.Fake,
+			"",
+			v1.SchemeGroupVersion.WithResource("networkobservabilities"),
+			v1.SchemeGroupVersion.WithKind("NetworkObservability"),
+			func() *v1.NetworkObservability { return &v1.NetworkObservability{} },
+			func() *v1.NetworkObservabilityList { return &v1.NetworkObservabilityList{} },
+			func(dst, src *v1.NetworkObservabilityList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.NetworkObservabilityList) []*v1.NetworkObservability {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1.NetworkObservabilityList, items []*v1.NetworkObservability) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
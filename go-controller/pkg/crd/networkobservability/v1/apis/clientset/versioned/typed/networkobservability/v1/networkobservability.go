@@ -0,0 +1,73 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	context "context"
+
+	networkobservabilityv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1"
+	applyconfigurationnetworkobservabilityv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/applyconfiguration/networkobservability/v1"
+	scheme "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// NetworkObservabilitiesGetter has a method to return a NetworkObservabilityInterface.
+// A group's client should implement this interface.
+type NetworkObservabilitiesGetter interface {
+	NetworkObservabilities() NetworkObservabilityInterface
+}
+
+// NetworkObservabilityInterface has methods to work with NetworkObservability resources.
+type NetworkObservabilityInterface interface {
+	Create(ctx context.Context, networkObservability *networkobservabilityv1.NetworkObservability, opts metav1.CreateOptions) (*networkobservabilityv1.NetworkObservability, error)
+	Update(ctx context.Context, networkObservability *networkobservabilityv1.NetworkObservability, opts metav1.UpdateOptions) (*networkobservabilityv1.NetworkObservability, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*networkobservabilityv1.NetworkObservability, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*networkobservabilityv1.NetworkObservabilityList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *networkobservabilityv1.NetworkObservability, err error)
+	Apply(ctx context.Context, networkObservability *applyconfigurationnetworkobservabilityv1.NetworkObservabilityApplyConfiguration, opts metav1.ApplyOptions) (result *networkobservabilityv1.NetworkObservability, err error)
+	NetworkObservabilityExpansion
+}
+
+// networkObservabilities implements NetworkObservabilityInterface
+type networkObservabilities struct {
+	*gentype.ClientWithListAndApply[*networkobservabilityv1.NetworkObservability, *networkobservabilityv1.NetworkObservabilityList, *applyconfigurationnetworkobservabilityv1.NetworkObservabilityApplyConfiguration]
+}
+
+// newNetworkObservabilities returns a NetworkObservabilities
+func newNetworkObservabilities(c *K8sV1Client) *networkObservabilities {
+	return &networkObservabilities{
+		gentype.NewClientWithListAndApply[*networkobservabilityv1.NetworkObservability, *networkobservabilityv1.NetworkObservabilityList, *applyconfigurationnetworkobservabilityv1.NetworkObservabilityApplyConfiguration](
+			"networkobservabilities",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *networkobservabilityv1.NetworkObservability {
+				return &networkobservabilityv1.NetworkObservability{}
+			},
+			func() *networkobservabilityv1.NetworkObservabilityList {
+				return &networkobservabilityv1.NetworkObservabilityList{}
+			},
+		),
+	}
+}
@@ -0,0 +1,47 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	networkobservabilityv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NetworkObservabilityLister helps list NetworkObservabilities.
+// All objects returned here must be treated as read-only.
+type NetworkObservabilityLister interface {
+	// List lists all NetworkObservabilities in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*networkobservabilityv1.NetworkObservability, err error)
+	// Get retrieves the NetworkObservability from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*networkobservabilityv1.NetworkObservability, error)
+	NetworkObservabilityListerExpansion
+}
+
+// networkObservabilityLister implements the NetworkObservabilityLister interface.
+type networkObservabilityLister struct {
+	listers.ResourceIndexer[*networkobservabilityv1.NetworkObservability]
+}
+
+// NewNetworkObservabilityLister returns a new NetworkObservabilityLister.
+func NewNetworkObservabilityLister(indexer cache.Indexer) NetworkObservabilityLister {
+	return &networkObservabilityLister{listers.New[*networkobservabilityv1.NetworkObservability](indexer, networkobservabilityv1.Resource("networkobservability"))}
+}
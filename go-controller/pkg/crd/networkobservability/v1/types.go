@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkObservability configures the sampling of network events, such as connections allowed or denied by
+// network policies, to an OVS collector set. Multiple NetworkObservabilities may be used to send samples of
+// different features, with different probabilities or scopes, to different collector sets.
+//
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=networkobservabilities,scope=Cluster,singular=networkobservability
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Collector Set ID",type=integer,JSONPath=".spec.collectorSetID"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type NetworkObservability struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +required
+	Spec NetworkObservabilitySpec `json:"spec"`
+}
+
+// NetworkObservabilitySpec defines the desired state of NetworkObservability.
+type NetworkObservabilitySpec struct {
+	// collectorSetID is the ID of the OVS collector set that samples are sent to.
+	// It must match the collector set ID configured by the samples consumer, e.g. ovnkube-observ.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	// +required
+	CollectorSetID int64 `json:"collectorSetID"`
+
+	// features specifies which features are sampled and with which probability.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=5
+	// +listType=map
+	// +listMapKey=name
+	// +required
+	Features []SamplingFeature `json:"features"`
+
+	// namespaces limits sampling to the namespaced objects of the given namespaces, such as NetworkPolicies,
+	// EgressFirewalls and namespace multicast policies. Cluster-scoped objects, such as AdminNetworkPolicies,
+	// are not sampled when namespaces is set.
+	// When omitted, all namespaces are sampled.
+	//
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=set
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// networks limits sampling to the objects of the given networks, identified by their network name.
+	// The cluster default network is named "default".
+	// When omitted, all networks are sampled.
+	//
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=set
+	// +optional
+	Networks []string `json:"networks,omitempty"`
+}
+
+// SamplingFeature configures the sampling of a feature.
+type SamplingFeature struct {
+	// name of the sampled feature.
+	//
+	// +required
+	Name SamplingFeatureName `json:"name"`
+
+	// probability is the percentage of packets that are sampled.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=100
+	// +optional
+	Probability int32 `json:"probability,omitempty"`
+}
+
+// SamplingFeatureName represents the features that can be sampled.
+// +kubebuilder:validation:Enum=EgressFirewall;NetworkPolicy;AdminNetworkPolicy;Multicast;UDNIsolation
+type SamplingFeatureName string
+
+const (
+	// EgressFirewallFeature samples the traffic allowed or denied by EgressFirewalls.
+	EgressFirewallFeature SamplingFeatureName = "EgressFirewall"
+
+	// NetworkPolicyFeature samples the traffic allowed or denied by NetworkPolicies.
+	NetworkPolicyFeature SamplingFeatureName = "NetworkPolicy"
+
+	// AdminNetworkPolicyFeature samples the traffic allowed, passed or denied by AdminNetworkPolicies and
+	// BaselineAdminNetworkPolicies.
+	AdminNetworkPolicyFeature SamplingFeatureName = "AdminNetworkPolicy"
+
+	// MulticastFeature samples the multicast traffic allowed or denied by multicast policies.
+	MulticastFeature SamplingFeatureName = "Multicast"
+
+	// UDNIsolationFeature samples the traffic denied by the isolation of User Defined Networks.
+	UDNIsolationFeature SamplingFeatureName = "UDNIsolation"
+)
+
+// NetworkObservabilityList contains a list of NetworkObservability.
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NetworkObservabilityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkObservability `json:"items"`
+}
@@ -0,0 +1,61 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	networkqosv1alpha1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1"
+)
+
+// IngressClassifierApplyConfiguration represents a declarative configuration of the IngressClassifier type for use
+// with apply.
+type IngressClassifierApplyConfiguration struct {
+	From  []SourceApplyConfiguration `json:"from,omitempty"`
+	Ports []*networkqosv1alpha1.Port `json:"ports,omitempty"`
+}
+
+// IngressClassifierApplyConfiguration constructs a declarative configuration of the IngressClassifier type for use with
+// apply.
+func IngressClassifier() *IngressClassifierApplyConfiguration {
+	return &IngressClassifierApplyConfiguration{}
+}
+
+// WithFrom adds the given value to the From field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the From field.
+func (b *IngressClassifierApplyConfiguration) WithFrom(values ...*SourceApplyConfiguration) *IngressClassifierApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFrom")
+		}
+		b.From = append(b.From, *values[i])
+	}
+	return b
+}
+
+// WithPorts adds the given value to the Ports field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Ports field.
+func (b *IngressClassifierApplyConfiguration) WithPorts(values ...**networkqosv1alpha1.Port) *IngressClassifierApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPorts")
+		}
+		b.Ports = append(b.Ports, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,56 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// IngressRuleApplyConfiguration represents a declarative configuration of the IngressRule type for use
+// with apply.
+type IngressRuleApplyConfiguration struct {
+	DSCP       *int                                 `json:"dscp,omitempty"`
+	Classifier *IngressClassifierApplyConfiguration `json:"classifier,omitempty"`
+	Bandwidth  *BandwidthApplyConfiguration         `json:"bandwidth,omitempty"`
+}
+
+// IngressRuleApplyConfiguration constructs a declarative configuration of the IngressRule type for use with
+// apply.
+func IngressRule() *IngressRuleApplyConfiguration {
+	return &IngressRuleApplyConfiguration{}
+}
+
+// WithDSCP sets the DSCP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DSCP field is set to the value of the last call.
+func (b *IngressRuleApplyConfiguration) WithDSCP(value int) *IngressRuleApplyConfiguration {
+	b.DSCP = &value
+	return b
+}
+
+// WithClassifier sets the Classifier field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Classifier field is set to the value of the last call.
+func (b *IngressRuleApplyConfiguration) WithClassifier(value *IngressClassifierApplyConfiguration) *IngressRuleApplyConfiguration {
+	b.Classifier = value
+	return b
+}
+
+// WithBandwidth sets the Bandwidth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Bandwidth field is set to the value of the last call.
+func (b *IngressRuleApplyConfiguration) WithBandwidth(value *BandwidthApplyConfiguration) *IngressRuleApplyConfiguration {
+	b.Bandwidth = value
+	return b
+}
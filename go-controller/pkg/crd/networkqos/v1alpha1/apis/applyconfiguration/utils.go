@@ -37,12 +37,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &networkqosv1alpha1.ClassifierApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Destination"):
 		return &networkqosv1alpha1.DestinationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("IngressClassifier"):
+		return &networkqosv1alpha1.IngressClassifierApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("IngressRule"):
+		return &networkqosv1alpha1.IngressRuleApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("NetworkQoS"):
 		return &networkqosv1alpha1.NetworkQoSApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Port"):
 		return &networkqosv1alpha1.PortApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Rule"):
 		return &networkqosv1alpha1.RuleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Source"):
+		return &networkqosv1alpha1.SourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Spec"):
 		return &networkqosv1alpha1.SpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Status"):
@@ -71,7 +71,18 @@ type Spec struct {
 	// determined by the order in which the rule is written. Thus, a rule that appears
 	// first in the list of egress rules would take the lower precedence.
 	// +kubebuilder:validation:MaxItems=20
-	Egress []Rule `json:"egress"`
+	// +optional
+	Egress []Rule `json:"egress,omitempty"`
+
+	// ingress a collection of Ingress NetworkQoS rule objects, applied to the traffic
+	// sent to the pods selected by podSelector. A total of 20 rules will be allowed in
+	// each NetworkQoS instance. The relative precedence of ingress rules within a single
+	// NetworkQos object follows the same ordering as egress rules. Ingress rules have a
+	// lower precedence than the egress rules of any NetworkQoS: when a packet between
+	// two pods matches both an egress and an ingress rule, the egress rule applies.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	Ingress []IngressRule `json:"ingress,omitempty"`
 }
 
 type Rule struct {
@@ -99,6 +110,33 @@ type Classifier struct {
 	Ports []*Port `json:"ports"`
 }
 
+type IngressRule struct {
+	// dscp marking value for the traffic sent to matching pods.
+	// +kubebuilder:validation:Maximum:=63
+	// +kubebuilder:validation:Minimum:=0
+	DSCP int `json:"dscp"`
+
+	// classifier The classifier on which packets should match
+	// to apply the NetworkQoS Rule.
+	// This field is optional, and in case it is not set the rule is applied
+	// to all ingress traffic regardless of the source.
+	// +optional
+	Classifier IngressClassifier `json:"classifier"`
+
+	// +optional
+	Bandwidth Bandwidth `json:"bandwidth"`
+}
+
+type IngressClassifier struct {
+	// +optional
+	From []Source `json:"from"`
+
+	// ports are matched against the destination port of the traffic
+	// sent to the pods selected by the NetworkQoS.
+	// +optional
+	Ports []*Port `json:"ports"`
+}
+
 // Bandwidth controls the maximum of rate traffic that can be sent
 // or received on the matching packets.
 type Bandwidth struct {
@@ -160,6 +198,34 @@ type Destination struct {
 	IPBlock *networkingv1.IPBlock `json:"ipBlock,omitempty" protobuf:"bytes,3,rep,name=ipBlock"`
 }
 
+// Source describes a peer to apply NetworkQoS configuration for the incoming traffic.
+// Only certain combinations of fields are allowed.
+// +kubebuilder:validation:XValidation:rule="!(has(self.ipBlock) && (has(self.podSelector) || has(self.namespaceSelector)))",message="Can't specify both podSelector/namespaceSelector and ipBlock"
+type Source struct {
+	// podSelector is a label selector which selects pods. This field follows standard label
+	// selector semantics; if present but empty, it selects all pods.
+	//
+	// If namespaceSelector is also set, then the NetworkQoS as a whole selects
+	// the pods matching podSelector in the Namespaces selected by NamespaceSelector.
+	// Otherwise it selects the pods matching podSelector in the NetworkQoS's own namespace.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty" protobuf:"bytes,1,opt,name=podSelector"`
+
+	// namespaceSelector selects namespaces using cluster-scoped labels. This field follows
+	// standard label selector semantics; if present but empty, it selects all namespaces.
+	//
+	// If podSelector is also set, then the NetworkQoS as a whole selects
+	// the pods matching podSelector in the namespaces selected by namespaceSelector.
+	// Otherwise it selects all pods in the namespaces selected by namespaceSelector.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" protobuf:"bytes,2,opt,name=namespaceSelector"`
+
+	// ipBlock defines policy on a particular IPBlock. If this field is set then
+	// neither of the other fields can be.
+	// +optional
+	IPBlock *networkingv1.IPBlock `json:"ipBlock,omitempty" protobuf:"bytes,3,rep,name=ipBlock"`
+}
+
 // Status defines the observed state of NetworkQoS
 type Status struct {
 	// A concise indication of whether the NetworkQoS resource is applied with success.
@@ -32,6 +32,7 @@ type Layer2ConfigApplyConfiguration struct {
 	DefaultGatewayIPs     *userdefinednetworkv1.DualStackIPs   `json:"defaultGatewayIPs,omitempty"`
 	JoinSubnets           *userdefinednetworkv1.DualStackCIDRs `json:"joinSubnets,omitempty"`
 	IPAM                  *IPAMConfigApplyConfiguration        `json:"ipam,omitempty"`
+	Multicast             *userdefinednetworkv1.MulticastMode  `json:"multicast,omitempty"`
 }
 
 // Layer2ConfigApplyConfiguration constructs a declarative configuration of the Layer2Config type for use with
@@ -107,3 +108,11 @@ func (b *Layer2ConfigApplyConfiguration) WithIPAM(value *IPAMConfigApplyConfigur
 	b.IPAM = value
 	return b
 }
+
+// WithMulticast sets the Multicast field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Multicast field is set to the value of the last call.
+func (b *Layer2ConfigApplyConfiguration) WithMulticast(value userdefinednetworkv1.MulticastMode) *Layer2ConfigApplyConfiguration {
+	b.Multicast = &value
+	return b
+}
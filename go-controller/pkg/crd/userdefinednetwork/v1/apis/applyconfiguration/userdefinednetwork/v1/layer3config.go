@@ -28,6 +28,7 @@ type Layer3ConfigApplyConfiguration struct {
 	MTU         *int32                               `json:"mtu,omitempty"`
 	Subnets     []Layer3SubnetApplyConfiguration     `json:"subnets,omitempty"`
 	JoinSubnets *userdefinednetworkv1.DualStackCIDRs `json:"joinSubnets,omitempty"`
+	Multicast   *userdefinednetworkv1.MulticastMode  `json:"multicast,omitempty"`
 }
 
 // Layer3ConfigApplyConfiguration constructs a declarative configuration of the Layer3Config type for use with
@@ -72,3 +73,11 @@ func (b *Layer3ConfigApplyConfiguration) WithJoinSubnets(value userdefinednetwor
 	b.JoinSubnets = &value
 	return b
 }
+
+// WithMulticast sets the Multicast field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Multicast field is set to the value of the last call.
+func (b *Layer3ConfigApplyConfiguration) WithMulticast(value userdefinednetworkv1.MulticastMode) *Layer3ConfigApplyConfiguration {
+	b.Multicast = &value
+	return b
+}
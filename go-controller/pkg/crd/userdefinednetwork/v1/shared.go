@@ -25,6 +25,7 @@ const (
 
 // +kubebuilder:validation:XValidation:rule="!has(self.joinSubnets) || has(self.role) && self.role == 'Primary'", message="JoinSubnets is only supported for Primary network"
 // +kubebuilder:validation:XValidation:rule="!has(self.subnets) || !has(self.mtu) || !self.subnets.exists_one(i, isCIDR(i.cidr) && cidr(i.cidr).ip().family() == 6) || self.mtu >= 1280", message="MTU should be greater than or equal to 1280 when IPv6 subnet is used"
+// +kubebuilder:validation:XValidation:rule="!has(self.multicast) || has(self.role) && self.role == 'Primary'", message="multicast is only supported for Primary network"
 type Layer3Config struct {
 	// Role describes the network role in the pod.
 	//
@@ -66,6 +67,19 @@ type Layer3Config struct {
 	//
 	// +optional
 	JoinSubnets DualStackCIDRs `json:"joinSubnets,omitempty"`
+
+	// Multicast controls IP multicast support for the network.
+	//
+	// `Enabled` configures IGMP/MLD snooping on the network logical switches and multicast relay on its
+	// logical router, and requires multicast to be enabled in OVN-Kubernetes.
+	// Pods still have to be in a namespace annotated with `k8s.ovn.org/multicast-enabled` to send and
+	// receive multicast traffic.
+	// `Disabled` turns multicast off for the network.
+	// This field is only allowed for "Primary" network.
+	// When omitted, multicast follows the global OVN-Kubernetes configuration.
+	//
+	// +optional
+	Multicast MulticastMode `json:"multicast,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="!has(self.hostSubnet) || !isCIDR(self.cidr) || self.hostSubnet > cidr(self.cidr).prefixLength()", message="HostSubnet must be smaller than CIDR subnet"
@@ -104,6 +118,7 @@ type Layer3Subnet struct {
 // +kubebuilder:validation:XValidation:rule="!has(self.infrastructureSubnets) || !has(self.reservedSubnets) || self.infrastructureSubnets.all(infra, !self.reservedSubnets.exists(reserved, cidr(infra).containsCIDR(reserved) || cidr(reserved).containsCIDR(infra)))", message="infrastructureSubnets and reservedSubnets must not overlap"
 // +kubebuilder:validation:XValidation:rule="!has(self.infrastructureSubnets) || self.infrastructureSubnets.all(s, isCIDR(s) && cidr(s) == cidr(s).masked())", message="infrastructureSubnets must be a masked network address (no host bits set)"
 // +kubebuilder:validation:XValidation:rule="!has(self.reservedSubnets) || self.reservedSubnets.all(s, isCIDR(s) && cidr(s) == cidr(s).masked())", message="reservedSubnets must be a masked network address (no host bits set)"
+// +kubebuilder:validation:XValidation:rule="!has(self.multicast) || has(self.role) && self.role == 'Primary'", message="multicast is only supported for Primary network"
 type Layer2Config struct {
 	// Role describes the network role in the pod.
 	//
@@ -182,6 +197,19 @@ type Layer2Config struct {
 	// IPAM section contains IPAM-related configuration for the network.
 	// +optional
 	IPAM *IPAMConfig `json:"ipam,omitempty"`
+
+	// Multicast controls IP multicast support for the network.
+	//
+	// `Enabled` configures IGMP/MLD snooping on the network logical switches and multicast relay on its
+	// logical router, and requires multicast to be enabled in OVN-Kubernetes.
+	// Pods still have to be in a namespace annotated with `k8s.ovn.org/multicast-enabled` to send and
+	// receive multicast traffic.
+	// `Disabled` turns multicast off for the network.
+	// This field is only allowed for "Primary" network.
+	// When omitted, multicast follows the global OVN-Kubernetes configuration.
+	//
+	// +optional
+	Multicast MulticastMode `json:"multicast,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="!has(self.lifecycle) || self.lifecycle != 'Persistent' || !has(self.mode) || self.mode == 'Enabled'", message="lifecycle Persistent is only supported when ipam.mode is Enabled"
@@ -217,6 +245,14 @@ const (
 	IPAMDisabled IPAMMode = "Disabled"
 )
 
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type MulticastMode string
+
+const (
+	MulticastEnabled  MulticastMode = "Enabled"
+	MulticastDisabled MulticastMode = "Disabled"
+)
+
 type NetworkRole string
 
 const (
@@ -23,12 +23,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
 )
 
 const (
 	ControllerName = "ovnkube-csr-approver-controller"
 	NamePrefix     = "system:ovn-node"
 	MaxDuration    = time.Hour * 24 * 365
+	// IPsecSignerName is the signer of the certificates nodes authenticate their IPsec tunnels with.
+	// The certificates are signed by ovnkube-cluster-manager once approved.
+	IPsecSignerName = "k8s.ovn.org/ipsec-signer"
 )
 
 // CSRAcceptanceCondition specifies conditions which CSRs are approved by csrapprover.
@@ -95,6 +100,8 @@ var (
 	Usages = sets.New[certificatesv1.KeyUsage](
 		certificatesv1.UsageDigitalSignature,
 		certificatesv1.UsageClientAuth)
+	IPsecUsages = sets.New[certificatesv1.KeyUsage](
+		certificatesv1.UsageIPsecTunnel)
 )
 
 // OVNKubeCSRController approves certificate signing requests (CSRs) by applying the conditions, which is defined
@@ -149,6 +156,9 @@ func NewController(client crclient.Client,
 }
 
 func (c *OVNKubeCSRController) filterCSR(csr *certificatesv1.CertificateSigningRequest, x509CSR *x509.CertificateRequest) bool {
+	if csr.Spec.SignerName == IPsecSignerName {
+		return true
+	}
 	for _, v := range c.commonNamePrefixes {
 		if strings.HasPrefix(x509CSR.Subject.CommonName, v) {
 			return csr.Spec.SignerName == certificatesv1.KubeAPIServerClientSignerName
@@ -240,6 +250,10 @@ func (c *OVNKubeCSRController) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, nil
 	}
 
+	if req.Spec.SignerName == IPsecSignerName {
+		return c.reconcileIPsecCSR(ctx, req, x509CSR, &nodeName)
+	}
+
 	// expected common name format: userPrefix:nodeName
 	// example: system:ovn-node:ovn-worker2
 	i := strings.LastIndex(x509CSR.Subject.CommonName, ":")
@@ -274,6 +288,58 @@ func (c *OVNKubeCSRController) Reconcile(ctx context.Context, request reconcile.
 	return reconcile.Result{}, c.approveCSR(ctx, req)
 }
 
+// reconcileIPsecCSR approves IPsec certificate requests by the following rules:
+// - .Spec.Username has a format of <prefix>:<nodeName> where <prefix> is one of the default UserPrefixes
+// - The .Spec.Usages in the CSR is exactly "ipsec tunnel"
+// - The .Spec.ExpirationSeconds is set and is not higher than "maxDuration"
+// - The parsed CSR in .Spec.Request has a .Subject.CommonName equal to the chassis ID of the node, as OVN
+// identifies IPsec peers by their chassis ID
+// - The parsed CSR in .Spec.Request has no subject alternative names other than the chassis ID as DNS name
+func (c *OVNKubeCSRController) reconcileIPsecCSR(ctx context.Context, req *certificatesv1.CertificateSigningRequest,
+	x509CSR *x509.CertificateRequest, nodeName *string) (reconcile.Result, error) {
+	i := strings.LastIndex(req.Spec.Username, ":")
+	if i == -1 || i == len(req.Spec.Username)-1 || !sets.New[string](DefaultCSRAcceptanceCondition.UserPrefixes...).Has(req.Spec.Username[:i]) {
+		return reconcile.Result{}, c.denyCSR(ctx, req, fmt.Errorf("CSR %q was created by an unexpected user: %q", req.Name, req.Spec.Username))
+	}
+	*nodeName = req.Spec.Username[i+1:]
+
+	if usages := sets.New[certificatesv1.KeyUsage](req.Spec.Usages...); !usages.Equal(IPsecUsages) {
+		return reconcile.Result{}, c.denyCSR(ctx, req, fmt.Errorf("CSR %q was created with unexpected usages: %v", req.Name, usages.UnsortedList()))
+	}
+
+	if req.Spec.ExpirationSeconds == nil {
+		return reconcile.Result{}, c.denyCSR(ctx, req, fmt.Errorf("CSR %q was created without specyfying the expirationSeconds", req.Name))
+	}
+
+	if csr.ExpirationSecondsToDuration(*req.Spec.ExpirationSeconds) > c.maxDuration {
+		return reconcile.Result{}, c.denyCSR(ctx, req, fmt.Errorf("CSR %q was created with invalid expirationSeconds value: %d", req.Name, *req.Spec.ExpirationSeconds))
+	}
+
+	node := &corev1.Node{}
+	if err := c.client.Get(ctx, crclient.ObjectKey{Name: *nodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, c.denyCSR(ctx, req, fmt.Errorf("CSR %q was created for an unknown node %q", req.Name, *nodeName))
+		}
+		return reconcile.Result{}, err
+	}
+	chassisID, err := util.ParseNodeChassisIDAnnotation(node)
+	if err != nil {
+		// the node may not have been annotated yet, retry
+		return reconcile.Result{}, err
+	}
+	if x509CSR.Subject.CommonName != chassisID {
+		return reconcile.Result{}, c.denyCSR(ctx, req, fmt.Errorf("expected the CSR's commonName to be the chassis ID %q of node %q, but it is %q",
+			chassisID, *nodeName, x509CSR.Subject.CommonName))
+	}
+	if len(x509CSR.IPAddresses) > 0 || len(x509CSR.EmailAddresses) > 0 || len(x509CSR.URIs) > 0 ||
+		len(x509CSR.DNSNames) > 1 || (len(x509CSR.DNSNames) == 1 && x509CSR.DNSNames[0] != chassisID) {
+		return reconcile.Result{}, c.denyCSR(ctx, req, fmt.Errorf("CSR %q was created with unexpected subject alternative names, only the chassis ID %q is allowed",
+			req.Name, chassisID))
+	}
+
+	return reconcile.Result{}, c.approveCSR(ctx, req)
+}
+
 func (c *CSRAcceptanceCondition) validateCSR(req *certificatesv1.CertificateSigningRequest, x509CSR *x509.CertificateRequest, acceptUsages sets.Set[certificatesv1.KeyUsage]) error {
 
 	// expected username format: userPrefix:nodeName
@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"crypto/x509/pkix"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -391,3 +392,172 @@ func TestOVNKubeCSRController(t *testing.T) {
 		})
 	}
 }
+
+func TestOVNKubeCSRControllerIPsec(t *testing.T) {
+	const chassisID = "5a3f8d1e-3c52-4b4a-9b7b-2f3e1b0c9d41"
+	tests := []struct {
+		name              string
+		expectedCondition certificatesv1.CertificateSigningRequestCondition
+		csrUserName       string
+		commonName        string
+		dnsNames          []string
+		ipAddresses       []net.IP
+		usages            []certificatesv1.KeyUsage
+		duration          time.Duration
+	}{
+		{
+			name: "CSR for the chassis ID of the requesting node is approved",
+			expectedCondition: certificatesv1.CertificateSigningRequestCondition{
+				Type:    certificatesv1.CertificateApproved,
+				Status:  corev1.ConditionTrue,
+				Reason:  "AutoApproved",
+				Message: fmt.Sprintf("Auto-approved CSR %q", csrName),
+			},
+			csrUserName: "system:ovn-node:test.node",
+			commonName:  chassisID,
+			dnsNames:    []string{chassisID},
+			usages:      []certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel},
+			duration:    time.Hour,
+		},
+		{
+			name: "CSR with other DNS names than the chassis ID is denied",
+			expectedCondition: certificatesv1.CertificateSigningRequestCondition{
+				Type:   certificatesv1.CertificateDenied,
+				Status: corev1.ConditionTrue,
+				Reason: "CSRDenied",
+				Message: fmt.Sprintf("CSR %q was created with unexpected subject alternative names, only the chassis ID %q is allowed",
+					csrName, chassisID),
+			},
+			csrUserName: "system:ovn-node:test.node",
+			commonName:  chassisID,
+			dnsNames:    []string{chassisID, "kubernetes.default.svc"},
+			usages:      []certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel},
+			duration:    time.Hour,
+		},
+		{
+			name: "CSR with IP addresses is denied",
+			expectedCondition: certificatesv1.CertificateSigningRequestCondition{
+				Type:   certificatesv1.CertificateDenied,
+				Status: corev1.ConditionTrue,
+				Reason: "CSRDenied",
+				Message: fmt.Sprintf("CSR %q was created with unexpected subject alternative names, only the chassis ID %q is allowed",
+					csrName, chassisID),
+			},
+			csrUserName: "system:ovn-node:test.node",
+			commonName:  chassisID,
+			ipAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			usages:      []certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel},
+			duration:    time.Hour,
+		},
+		{
+			name: "CSR created by unexpected user is denied",
+			expectedCondition: certificatesv1.CertificateSigningRequestCondition{
+				Type:    certificatesv1.CertificateDenied,
+				Status:  corev1.ConditionTrue,
+				Reason:  "CSRDenied",
+				Message: fmt.Sprintf("CSR %q was created by an unexpected user: %q", csrName, "system:serviceaccount:ovn-kubernetes:ovnkube-node"),
+			},
+			csrUserName: "system:serviceaccount:ovn-kubernetes:ovnkube-node",
+			commonName:  chassisID,
+			usages:      []certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel},
+			duration:    time.Hour,
+		},
+		{
+			name: "CSR with unexpected usages is denied",
+			expectedCondition: certificatesv1.CertificateSigningRequestCondition{
+				Type:    certificatesv1.CertificateDenied,
+				Status:  corev1.ConditionTrue,
+				Reason:  "CSRDenied",
+				Message: fmt.Sprintf("CSR %q was created with unexpected usages: %v", csrName, []certificatesv1.KeyUsage{certificatesv1.UsageClientAuth}),
+			},
+			csrUserName: "system:ovn-node:test.node",
+			commonName:  chassisID,
+			usages:      []certificatesv1.KeyUsage{certificatesv1.UsageClientAuth},
+			duration:    time.Hour,
+		},
+		{
+			name: "CSR for a different chassis ID is denied",
+			expectedCondition: certificatesv1.CertificateSigningRequestCondition{
+				Type:   certificatesv1.CertificateDenied,
+				Status: corev1.ConditionTrue,
+				Reason: "CSRDenied",
+				Message: fmt.Sprintf("expected the CSR's commonName to be the chassis ID %q of node %q, but it is %q",
+					chassisID, "test.node", "other-chassis"),
+			},
+			csrUserName: "system:ovn-node:test.node",
+			commonName:  "other-chassis",
+			usages:      []certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel},
+			duration:    time.Hour,
+		},
+		{
+			name: "CSR for an unknown node is denied",
+			expectedCondition: certificatesv1.CertificateSigningRequestCondition{
+				Type:    certificatesv1.CertificateDenied,
+				Status:  corev1.ConditionTrue,
+				Reason:  "CSRDenied",
+				Message: fmt.Sprintf("CSR %q was created for an unknown node %q", csrName, "unknown.node"),
+			},
+			csrUserName: "system:ovn-node:unknown.node",
+			commonName:  chassisID,
+			usages:      []certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel},
+			duration:    time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			csrPEM, err := cert.MakeCSR(privateKey, &pkix.Name{CommonName: tt.commonName}, tt.dnsNames, tt.ipAddresses)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			csrObj := &certificatesv1.CertificateSigningRequest{
+				TypeMeta: metav1.TypeMeta{Kind: "CertificateSigningRequest"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: csrName,
+				},
+				Spec: certificatesv1.CertificateSigningRequestSpec{
+					Request:           csrPEM,
+					Usages:            tt.usages,
+					SignerName:        IPsecSignerName,
+					Username:          tt.csrUserName,
+					ExpirationSeconds: csr.DurationToExpirationSeconds(tt.duration),
+				},
+			}
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test.node",
+					Annotations: map[string]string{"k8s.ovn.org/node-chassis-id": chassisID},
+				},
+			}
+
+			client := fake.NewClientBuilder().WithRuntimeObjects(csrObj, node).Build()
+			recorder := record.NewFakeRecorder(10)
+			csrCtrl := NewController(client, []CSRAcceptanceCondition{DefaultCSRAcceptanceCondition}, Usages, time.Hour*24, recorder)
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: csrName,
+				},
+			}
+			if _, err = csrCtrl.Reconcile(context.Background(), req); err != nil {
+				t.Fatal(err)
+			}
+
+			csrObj = &certificatesv1.CertificateSigningRequest{}
+			if err = client.Get(context.TODO(), req.NamespacedName, csrObj); err != nil {
+				t.Fatal(err)
+			}
+			if len(csrObj.Status.Conditions) != 1 {
+				t.Fatal(fmt.Errorf("invalid conditions: %v", csrObj.Status.Conditions))
+			}
+			if csrObj.Status.Conditions[0] != tt.expectedCondition {
+				t.Fatal(fmt.Errorf("expected:\n%v\ngot:\n%v", tt.expectedCondition, csrObj.Status.Conditions[0]))
+			}
+		})
+	}
+}
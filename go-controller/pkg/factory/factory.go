@@ -85,6 +85,10 @@ import (
 	egressservicescheme "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned/scheme"
 	egressserviceinformerfactory "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/informers/externalversions"
 	egressserviceinformer "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/informers/externalversions/egressservice/v1"
+	networkobservabilityapi "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1"
+	networkobservabilityscheme "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/clientset/versioned/scheme"
+	networkobservabilityinformerfactory "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/informers/externalversions"
+	networkobservabilityinformer "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/informers/externalversions/networkobservability/v1"
 	networkqosapi "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1"
 	networkqosscheme "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1/apis/clientset/versioned/scheme"
 	networkqosinformerfactory "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1/apis/informers/externalversions"
@@ -132,6 +136,7 @@ type WatchFactory struct {
 	frrFactory           frrinformerfactory.SharedInformerFactory
 	networkQoSFactory    networkqosinformerfactory.SharedInformerFactory
 	vtepFactory          vtepinformerfactory.SharedInformerFactory
+	observFactory        networkobservabilityinformerfactory.SharedInformerFactory
 	informers            map[reflect.Type]*informer
 
 	stopChan chan struct{}
@@ -162,6 +167,7 @@ func (wf *WatchFactory) ShallowClone() *WatchFactory {
 		frrFactory:           wf.frrFactory,
 		networkQoSFactory:    wf.networkQoSFactory,
 		vtepFactory:          wf.vtepFactory,
+		observFactory:        wf.observFactory,
 		informers:            wf.informers,
 		stopChan:             wf.stopChan,
 
@@ -388,6 +394,10 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 		return nil, err
 	}
 
+	if err := networkobservabilityapi.AddToScheme(networkobservabilityscheme.Scheme); err != nil {
+		return nil, err
+	}
+
 	// For Services and Endpoints, pre-populate the shared Informer with one that
 	// has a label selector excluding headless services.
 	wf.iFactory.InformerFor(&corev1.Service{}, func(c kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
@@ -465,6 +475,8 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 		if err != nil {
 			return nil, err
 		}
+		// make sure shared informer is created for a factory, so on wf.efFactory.Start() it is initialized and caches are synced.
+		wf.efFactory.K8s().V1().ClusterEgressFirewalls().Informer()
 
 		if config.OVNKubernetesFeature.EnableDNSNameResolver {
 			// make sure shared informer is created for a factory, so on wf.dnsFactory.Start() it is initialized and caches are synced.
@@ -555,6 +567,12 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableObservability {
+		wf.observFactory = networkobservabilityinformerfactory.NewSharedInformerFactory(ovnClientset.NetworkObservabilityClient, resyncInterval)
+		// make sure shared informer is created for a factory, so on wf.observFactory.Start() it is initialized and caches are synced.
+		wf.observFactory.K8s().V1().NetworkObservabilities().Informer()
+	}
+
 	return wf, nil
 }
 
@@ -667,6 +685,13 @@ func (wf *WatchFactory) Start() error {
 		}
 	}
 
+	if wf.observFactory != nil {
+		wf.observFactory.Start(wf.stopChan)
+		if err := waitForCacheSyncWithTimeout(wf.observFactory, wf.stopChan); err != nil {
+			return err
+		}
+	}
+
 	if wf.raFactory != nil {
 		wf.raFactory.Start(wf.stopChan)
 		if err := waitForCacheSyncWithTimeout(wf.raFactory, wf.stopChan); err != nil {
@@ -731,6 +756,10 @@ func (wf *WatchFactory) Stop() {
 		wf.vtepFactory.Shutdown()
 	}
 
+	if wf.observFactory != nil {
+		wf.observFactory.Shutdown()
+	}
+
 	if wf.raFactory != nil {
 		wf.raFactory.Shutdown()
 	}
@@ -1015,6 +1044,8 @@ func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset
 		if err != nil {
 			return nil, err
 		}
+		// make sure shared informer is created for a factory, so on wf.eipFactory.Start() it is initialized and caches are synced.
+		wf.eipFactory.K8s().V1().EgressIPPools().Informer()
 	}
 	if util.PlatformTypeIsEgressIPCloudProvider() {
 		wf.informers[CloudPrivateIPConfigType], err = newQueuedInformer(eventQueueSize,
@@ -1075,6 +1106,7 @@ func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset
 	if config.OVNKubernetesFeature.EnableEgressFirewall {
 		// make sure shared informer is created for a factory, so on wf.efFactory.Start() it is initialized and caches are synced.
 		wf.efFactory.K8s().V1().EgressFirewalls().Informer()
+		wf.efFactory.K8s().V1().ClusterEgressFirewalls().Informer()
 
 		if config.OVNKubernetesFeature.EnableDNSNameResolver {
 			// make sure shared informer is created for a factory, so on wf.dnsFactory.Start() it is initialized and caches are synced.
@@ -1825,10 +1857,18 @@ func (wf *WatchFactory) EgressIPInformer() egressipinformer.EgressIPInformer {
 	return wf.eipFactory.K8s().V1().EgressIPs()
 }
 
+func (wf *WatchFactory) EgressIPPoolInformer() egressipinformer.EgressIPPoolInformer {
+	return wf.eipFactory.K8s().V1().EgressIPPools()
+}
+
 func (wf *WatchFactory) EgressFirewallInformer() egressfirewallinformer.EgressFirewallInformer {
 	return wf.efFactory.K8s().V1().EgressFirewalls()
 }
 
+func (wf *WatchFactory) ClusterEgressFirewallInformer() egressfirewallinformer.ClusterEgressFirewallInformer {
+	return wf.efFactory.K8s().V1().ClusterEgressFirewalls()
+}
+
 func (wf *WatchFactory) IPAMClaimsInformer() ipamclaimsinformer.IPAMClaimInformer {
 	return wf.ipamClaimsFactory.K8s().V1alpha1().IPAMClaims()
 }
@@ -1853,6 +1893,10 @@ func (wf *WatchFactory) VTEPInformer() vtepinformer.VTEPInformer {
 	return wf.vtepFactory.K8s().V1().VTEPs()
 }
 
+func (wf *WatchFactory) NetworkObservabilityInformer() networkobservabilityinformer.NetworkObservabilityInformer {
+	return wf.observFactory.K8s().V1().NetworkObservabilities()
+}
+
 func (wf *WatchFactory) DNSNameResolverInformer() ocpnetworkinformerv1alpha1.DNSNameResolverInformer {
 	return wf.dnsFactory.Network().V1alpha1().DNSNameResolvers()
 }
@@ -229,6 +229,38 @@ func NewNBClientWithConfig(cfg config.OvnAuthConfig, promRegistry prometheus.Reg
 	return c, nil
 }
 
+// NewNBACLClient creates a new OVN Northbound Database client that only monitors
+// the ACL table, for read-only consumers like the ACL stats metrics
+func NewNBACLClient(stopCh <-chan struct{}) (client.Client, error) {
+	dbModel, err := nbdb.FullDatabaseModel()
+	if err != nil {
+		return nil, err
+	}
+	c, err := newClient(config.OvnNorth, dbModel, stopCh)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), config.Default.OVSDBTxnTimeout*2)
+	go func() {
+		<-stopCh
+		cancel()
+		c.Close()
+	}()
+
+	_, err = c.Monitor(ctx,
+		c.NewMonitor(
+			client.WithTable(&nbdb.ACL{}),
+		),
+	)
+	if err != nil {
+		cancel()
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
 // NewOVSClient creates a new openvswitch Database client
 func NewOVSClient(stopCh <-chan struct{}) (client.Client, error) {
 	cfg := &config.OvnAuthConfig{
@@ -18,6 +18,7 @@ const (
 	// owner types
 	EgressFirewallDNSOwnerType          ownerType = "EgressFirewallDNS"
 	EgressFirewallOwnerType             ownerType = "EgressFirewall"
+	ClusterEgressFirewallOwnerType      ownerType = "ClusterEgressFirewall"
 	EgressQoSOwnerType                  ownerType = "EgressQoS"
 	AdminNetworkPolicyOwnerType         ownerType = "AdminNetworkPolicy"
 	BaselineAdminNetworkPolicyOwnerType ownerType = "BaselineAdminNetworkPolicy"
@@ -65,6 +66,7 @@ const (
 	CIDRKey                 ExternalIDKey = types.OvnK8sPrefix + "/cidr"
 	PortPolicyProtocolKey   ExternalIDKey = "port-policy-protocol"
 	RouterNameKey           ExternalIDKey = "router-name"
+	NamespaceKey            ExternalIDKey = "namespace"
 )
 
 // ObjectIDsTypes should only be created here
@@ -299,6 +301,15 @@ var ACLEgressFirewall = newObjectIDsType(acl, EgressFirewallOwnerType, []Externa
 	RuleIndex,
 })
 
+var ACLClusterEgressFirewall = newObjectIDsType(acl, ClusterEgressFirewallOwnerType, []ExternalIDKey{
+	// cluster egress firewall name
+	ObjectNameKey,
+	// rules are rendered separately for every selected namespace
+	NamespaceKey,
+	// the index of the ClusterEgressFirewall.Spec.Egress rule
+	RuleIndex,
+})
+
 var ACLUDN = newObjectIDsType(acl, UDNIsolationOwnerType, []ExternalIDKey{
 	// name of a UDN-related ACL
 	ObjectNameKey,
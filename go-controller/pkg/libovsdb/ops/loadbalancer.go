@@ -58,6 +58,38 @@ func BuildLoadBalancer(name string, protocol nbdb.LoadBalancerProtocol, selectio
 	}
 }
 
+// BuildLoadBalancerHealthCheck builds a load balancer health check for the
+// provided vip
+func BuildLoadBalancerHealthCheck(vip string, options, externalIDs map[string]string) *nbdb.LoadBalancerHealthCheck {
+	return &nbdb.LoadBalancerHealthCheck{
+		Vip:         vip,
+		Options:     options,
+		ExternalIDs: externalIDs,
+	}
+}
+
+// CreateOrUpdateLoadBalancerHealthChecksOps creates or updates the provided
+// load balancer health checks returning the corresponding ops. Health checks
+// have no index, so they are only updated if their UUID is set, and created
+// otherwise. They are not root objects and are garbage collected once no load
+// balancer references them anymore.
+func CreateOrUpdateLoadBalancerHealthChecksOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation, hcs ...*nbdb.LoadBalancerHealthCheck) ([]ovsdb.Operation, error) {
+	opModels := make([]operationModel, 0, len(hcs))
+	for i := range hcs {
+		hc := hcs[i]
+		opModel := operationModel{
+			Model:          hc,
+			OnModelUpdates: []interface{}{&hc.Vip, &hc.Options, &hc.ExternalIDs},
+			ErrNotFound:    false,
+			BulkOp:         false,
+		}
+		opModels = append(opModels, opModel)
+	}
+
+	modelClient := newModelClient(nbClient)
+	return modelClient.CreateOrUpdateOps(ops, opModels...)
+}
+
 // CreateOrUpdateLoadBalancersOps creates or updates the provided load balancers
 // returning the corresponding ops
 func CreateOrUpdateLoadBalancersOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation, lbs ...*nbdb.LoadBalancer) ([]ovsdb.Operation, error) {
@@ -138,6 +170,46 @@ func ListLoadBalancers(nbClient libovsdbclient.Client) ([]*nbdb.LoadBalancer, er
 	return lbs, err
 }
 
+// GetLoadBalancer looks up a load balancer from the cache by UUID or name
+func GetLoadBalancer(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) (*nbdb.LoadBalancer, error) {
+	found := []*nbdb.LoadBalancer{}
+	opModel := operationModel{
+		Model:          lb,
+		ExistingResult: &found,
+		ErrNotFound:    true,
+		BulkOp:         false,
+	}
+
+	modelClient := newModelClient(nbClient)
+	err := modelClient.Lookup(opModel)
+	if err != nil {
+		return nil, err
+	}
+
+	return found[0], nil
+}
+
+// GetLoadBalancerHealthChecks looks up the health checks referenced by the
+// provided load balancer from the cache
+func GetLoadBalancerHealthChecks(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) ([]*nbdb.LoadBalancerHealthCheck, error) {
+	hcs := make([]*nbdb.LoadBalancerHealthCheck, 0, len(lb.HealthCheck))
+	for _, uuid := range lb.HealthCheck {
+		found := []*nbdb.LoadBalancerHealthCheck{}
+		opModel := operationModel{
+			Model:          &nbdb.LoadBalancerHealthCheck{UUID: uuid},
+			ExistingResult: &found,
+			ErrNotFound:    true,
+			BulkOp:         false,
+		}
+		modelClient := newModelClient(nbClient)
+		if err := modelClient.Lookup(opModel); err != nil {
+			return nil, err
+		}
+		hcs = append(hcs, found[0])
+	}
+	return hcs, nil
+}
+
 type loadBalancerPredicate func(*nbdb.LoadBalancer) bool
 
 // FindLoadBalancersWithPredicate looks up loadbalancers from the cache
@@ -1,6 +1,8 @@
 package ops
 
 import (
+	"errors"
+
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
 
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
@@ -62,3 +64,33 @@ func UpdateNBGlobalSetOptions(nbClient libovsdbclient.Client, nbGlobal *nbdb.NBG
 	_, err = m.CreateOrUpdate(opModel)
 	return err
 }
+
+// UpdateNBGlobalIPsec enables or disables IPsec encryption of the tunnels
+// between the chassis of the NB Global entry
+func UpdateNBGlobalIPsec(nbClient libovsdbclient.Client, enabled bool) error {
+	nbGlobal, err := GetNBGlobal(nbClient, &nbdb.NBGlobal{})
+	if errors.Is(err, libovsdbclient.ErrNotFound) && !enabled {
+		// without an NB Global entry there is nothing to disable
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if nbGlobal.Ipsec == enabled {
+		return nil
+	}
+	nbGlobal.Ipsec = enabled
+
+	opModel := operationModel{
+		Model: nbGlobal,
+		OnModelUpdates: []interface{}{
+			&nbGlobal.Ipsec,
+		},
+		ErrNotFound: true,
+		BulkOp:      false,
+	}
+
+	m := newModelClient(nbClient)
+	_, err = m.CreateOrUpdate(opModel)
+	return err
+}
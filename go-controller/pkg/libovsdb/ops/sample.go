@@ -2,9 +2,12 @@ package ops
 
 import (
 	"hash/fnv"
+	"strings"
 
 	"golang.org/x/net/context"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
 	"github.com/ovn-kubernetes/libovsdb/model"
 	"github.com/ovn-kubernetes/libovsdb/ovsdb"
@@ -138,15 +141,82 @@ const (
 	UDNIsolationSample       SampleFeature = "UDNIsolation"
 )
 
+// networkControllerSuffix is used by network controllers to build the OwnerControllerKey from the network name.
+const networkControllerSuffix = "-network-controller"
+
+// GetOwnerControllerNetwork returns the network of the network controller owning a db object, given the
+// object external IDs.
+func GetOwnerControllerNetwork(externalIDs map[string]string) string {
+	return strings.TrimSuffix(externalIDs[OwnerControllerKey.String()], networkControllerSuffix)
+}
+
+// SampleCollectorScope limits the db objects sampled by a collector.
+// Empty sets don't limit anything.
+type SampleCollectorScope struct {
+	Namespaces sets.Set[string]
+	Networks   sets.Set[string]
+}
+
+func (s *SampleCollectorScope) matches(namespace, network string) bool {
+	if s == nil {
+		return true
+	}
+	if s.Namespaces.Len() > 0 && !s.Namespaces.Has(namespace) {
+		return false
+	}
+	if s.Networks.Len() > 0 && !s.Networks.Has(network) {
+		return false
+	}
+	return true
+}
+
 // SamplingConfig is used to configure sampling for different db objects.
 type SamplingConfig struct {
 	featureCollectors map[SampleFeature][]string
+	// collector UUID => scope, collectors without a scope sample every object of a given feature.
+	collectorScopes map[string]*SampleCollectorScope
+	// network is used to match collector scopes instead of the db object owner controller, see ForNetwork.
+	network string
 }
 
-func NewSamplingConfig(featureCollectors map[SampleFeature][]string) *SamplingConfig {
+func NewSamplingConfig(featureCollectors map[SampleFeature][]string, collectorScopes map[string]*SampleCollectorScope) *SamplingConfig {
 	return &SamplingConfig{
 		featureCollectors: featureCollectors,
+		collectorScopes:   collectorScopes,
+	}
+}
+
+// ForNetwork returns a copy of the SamplingConfig that matches collector scopes against the given network.
+// It should be used when the db object owner controller doesn't reflect the network the object
+// applies to, e.g. EgressFirewall ACLs are always owned by the default network controller.
+func (c *SamplingConfig) ForNetwork(network string) *SamplingConfig {
+	if c == nil {
+		return nil
+	}
+	return &SamplingConfig{
+		featureCollectors: c.featureCollectors,
+		collectorScopes:   c.collectorScopes,
+		network:           network,
+	}
+}
+
+func (c *SamplingConfig) getACLCollectors(acl *nbdb.ACL) []string {
+	collectors := c.featureCollectors[GetACLSampleFeature(acl)]
+	if len(c.collectorScopes) == 0 || len(collectors) == 0 {
+		return collectors
 	}
+	namespace := getACLSampleNamespace(acl)
+	network := c.network
+	if network == "" {
+		network = GetOwnerControllerNetwork(acl.ExternalIDs)
+	}
+	scopedCollectors := make([]string, 0, len(collectors))
+	for _, collector := range collectors {
+		if c.collectorScopes[collector].matches(namespace, network) {
+			scopedCollectors = append(scopedCollectors, collector)
+		}
+	}
+	return scopedCollectors
 }
 
 func addSample(c *SamplingConfig, opModels []operationModel, model model.Model) []operationModel {
@@ -164,7 +234,7 @@ func createOrUpdateSampleForACL(opModels []operationModel, c *SamplingConfig, ac
 		acl.SampleNew = nil
 		return opModels
 	}
-	collectors := c.featureCollectors[getACLSampleFeature(acl)]
+	collectors := c.getACLCollectors(acl)
 	if len(collectors) == 0 {
 		acl.SampleEst = nil
 		acl.SampleNew = nil
@@ -190,6 +260,27 @@ func createOrUpdateSampleForACL(opModels []operationModel, c *SamplingConfig, ac
 	return opModels
 }
 
+// UpdateACLsSamplesOps updates only the samples of the provided ACLs based on the given samplingConfig
+// and returns the corresponding ops.
+func UpdateACLsSamplesOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation, samplingConfig *SamplingConfig, acls ...*nbdb.ACL) ([]ovsdb.Operation, error) {
+	opModels := make([]operationModel, 0, 2*len(acls))
+	for i := range acls {
+		// can't use i in the predicate, for loop replaces it in-memory
+		acl := acls[i]
+		opModels = createOrUpdateSampleForACL(opModels, samplingConfig, acl)
+		opModel := operationModel{
+			Model:          acl,
+			OnModelUpdates: []interface{}{&acl.SampleNew, &acl.SampleEst},
+			ErrNotFound:    true,
+			BulkOp:         false,
+		}
+		opModels = append(opModels, opModel)
+	}
+
+	modelClient := newModelClient(nbClient)
+	return modelClient.CreateOrUpdateOps(ops, opModels...)
+}
+
 func GetACLSampleID(acl *nbdb.ACL) uint32 {
 	// primaryID is unique for each ACL, but established connections will keep sampleID that is set on
 	// connection creation. Here is the situation we want to avoid:
@@ -206,7 +297,7 @@ func GetACLSampleID(acl *nbdb.ACL) uint32 {
 	return h.Sum32()
 }
 
-func getACLSampleFeature(acl *nbdb.ACL) SampleFeature {
+func GetACLSampleFeature(acl *nbdb.ACL) SampleFeature {
 	switch acl.ExternalIDs[OwnerTypeKey.String()] {
 	case AdminNetworkPolicyOwnerType, BaselineAdminNetworkPolicyOwnerType:
 		return AdminNetworkPolicySample
@@ -214,10 +305,28 @@ func getACLSampleFeature(acl *nbdb.ACL) SampleFeature {
 		return MulticastSample
 	case NetpolNodeOwnerType, NetworkPolicyOwnerType, NetpolNamespaceOwnerType:
 		return NetworkPolicySample
-	case EgressFirewallOwnerType:
+	case EgressFirewallOwnerType, ClusterEgressFirewallOwnerType:
 		return EgressFirewallSample
 	case UDNIsolationOwnerType:
 		return UDNIsolationSample
 	}
 	return ""
 }
+
+// getACLSampleNamespace returns the namespace a given ACL applies to, or an empty string for
+// cluster-scoped ACLs.
+func getACLSampleNamespace(acl *nbdb.ACL) string {
+	switch acl.ExternalIDs[OwnerTypeKey.String()] {
+	case NetworkPolicyOwnerType:
+		namespace, _, err := ParseNamespaceNameKey(acl.ExternalIDs[ObjectNameKey.String()])
+		if err != nil {
+			return ""
+		}
+		return namespace
+	case NetpolNamespaceOwnerType, MulticastNamespaceOwnerType, EgressFirewallOwnerType:
+		return acl.ExternalIDs[ObjectNameKey.String()]
+	case ClusterEgressFirewallOwnerType:
+		return acl.ExternalIDs[NamespaceKey.String()]
+	}
+	return ""
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"hash/fnv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -9,6 +10,7 @@ import (
 
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
 
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
@@ -78,6 +80,9 @@ func GetACLName(dbIDs *libovsdbops.DbObjectIDs) string {
 		aclName = "NP:" + dbIDs.GetObjectID(libovsdbops.ObjectNameKey) + ":" + dbIDs.GetObjectID(libovsdbops.PolicyDirectionKey)
 	case t.IsSameType(libovsdbops.ACLEgressFirewall):
 		aclName = "EF:" + dbIDs.GetObjectID(libovsdbops.ObjectNameKey) + ":" + dbIDs.GetObjectID(libovsdbops.RuleIndex)
+	case t.IsSameType(libovsdbops.ACLClusterEgressFirewall):
+		aclName = "CEF:" + dbIDs.GetObjectID(libovsdbops.NamespaceKey) + ":" + dbIDs.GetObjectID(libovsdbops.ObjectNameKey) +
+			":" + dbIDs.GetObjectID(libovsdbops.RuleIndex)
 	case t.IsSameType(libovsdbops.ACLAdminNetworkPolicy):
 		aclName = "ANP:" + dbIDs.GetObjectID(libovsdbops.ObjectNameKey) + ":" + dbIDs.GetObjectID(libovsdbops.PolicyDirectionKey) +
 			":" + dbIDs.GetObjectID(libovsdbops.GressIdxKey)
@@ -88,6 +93,36 @@ func GetACLName(dbIDs *libovsdbops.DbObjectIDs) string {
 	return fmt.Sprintf("%.63s", aclName)
 }
 
+// GetACLLabel returns the label used to count connections allowed by ACLs implementing network policy, admin
+// network policy and egress firewall rules when ACL stats are enabled, and 0 otherwise.
+// OVN loads the label of allow ACLs into a register of the OpenFlow flows derived from them, which allows
+// ovnkube-node to map flow statistics back to the ACL. The label is a 32-bit hash of the ACL IDs, so that every
+// zone derives the same label without coordination; ovnkube-node detects labels shared by different rules and
+// does not export their stats.
+func GetACLLabel(dbIDs *libovsdbops.DbObjectIDs) int {
+	if !config.Metrics.EnableACLStats {
+		return 0
+	}
+	t := dbIDs.GetIDsType()
+	switch {
+	case t.IsSameType(libovsdbops.ACLNetworkPolicy),
+		t.IsSameType(libovsdbops.ACLAdminNetworkPolicy),
+		t.IsSameType(libovsdbops.ACLBaselineAdminNetworkPolicy),
+		t.IsSameType(libovsdbops.ACLEgressFirewall),
+		t.IsSameType(libovsdbops.ACLClusterEgressFirewall):
+	default:
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(dbIDs.String()))
+	label := int(h.Sum32())
+	if label == 0 {
+		// 0 means no label
+		label = 1
+	}
+	return label
+}
+
 // BuildACLWithDefaultTier is used for the most ACL-related features with the default ACL tier.
 // That includes egress firewall, network policy, multicast.
 func BuildACLWithDefaultTier(dbIDs *libovsdbops.DbObjectIDs, priority int, match, action string, logLevels *ACLLoggingLevels,
@@ -131,6 +166,7 @@ func BuildACL(dbIDs *libovsdbops.DbObjectIDs, priority int, match, action string
 		options,
 		tier,
 	)
+	ACL.Label = GetACLLabel(dbIDs)
 	return ACL
 }
 
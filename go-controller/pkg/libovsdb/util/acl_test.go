@@ -6,6 +6,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 )
 
 func TestConvertK8sProtocolToOVNProtocol(t *testing.T) {
@@ -299,3 +302,34 @@ func TestGetL3L4MatchesFromNamedPorts(t *testing.T) {
 		}
 	}
 }
+
+func TestGetACLLabel(t *testing.T) {
+	npIDs := func(gressIdx string) *libovsdbops.DbObjectIDs {
+		return libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetworkPolicy, "default-network-controller",
+			map[libovsdbops.ExternalIDKey]string{
+				libovsdbops.ObjectNameKey:         "ns1:allow-web",
+				libovsdbops.PolicyDirectionKey:    "Ingress",
+				libovsdbops.GressIdxKey:           gressIdx,
+				libovsdbops.PortPolicyProtocolKey: "tcp",
+				libovsdbops.IpBlockIndexKey:       "-1",
+			})
+	}
+	multicastIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLMulticastCluster, "default-network-controller",
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.TypeKey:            "DefaultDeny",
+			libovsdbops.PolicyDirectionKey: "Ingress",
+		})
+
+	defer func() {
+		config.Metrics.EnableACLStats = false
+	}()
+
+	config.Metrics.EnableACLStats = false
+	assert.Zero(t, GetACLLabel(npIDs("0")), "ACLs must not be labelled with ACL stats disabled")
+
+	config.Metrics.EnableACLStats = true
+	assert.NotZero(t, GetACLLabel(npIDs("0")))
+	assert.Equal(t, GetACLLabel(npIDs("0")), GetACLLabel(npIDs("0")), "label must be stable")
+	assert.NotEqual(t, GetACLLabel(npIDs("0")), GetACLLabel(npIDs("1")), "rules must have different labels")
+	assert.Zero(t, GetACLLabel(multicastIDs), "only policy ACLs are labelled")
+}
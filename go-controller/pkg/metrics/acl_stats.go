@@ -0,0 +1,278 @@
+//go:build linux
+// +build linux
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+
+	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
+)
+
+var aclStatsLabels = []string{
+	"type",      // NetworkPolicy, AdminNetworkPolicy, BaselineAdminNetworkPolicy, EgressFirewall or ClusterEgressFirewall
+	"namespace", // namespace of the policy, or of the selected namespace for a ClusterEgressFirewall
+	"name",      // name of the policy, empty for an EgressFirewall
+	"direction", // Ingress or Egress
+	"rule",      // index of the rule in the policy
+}
+
+// aclStatsCacheInterval is the minimum interval between two dumps of the br-int flows, scrapes
+// in between are served the statistics of the latest dump
+const aclStatsCacheInterval = 30 * time.Second
+
+// Descriptors used by the aclStatsCollector below.
+var (
+	aclAllowedConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(types.MetricOvnkubeNamespace, types.MetricOvnkubeSubsystemNode, "acl_allowed_connections_total"),
+		"The total number of connections allowed by a network policy, admin network policy or egress firewall rule on the node. "+
+			"Connections dropped or rejected by a rule are not counted",
+		aclStatsLabels, nil,
+	)
+	aclStatsDroppedSeriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(types.MetricOvnkubeNamespace, types.MetricOvnkubeSubsystemNode, "acl_stats_dropped_series"),
+		"The number of rules whose allowed connections are not exported because of the configured series limit",
+		nil, nil,
+	)
+	aclStatsLabelCollisionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(types.MetricOvnkubeNamespace, types.MetricOvnkubeSubsystemNode, "acl_stats_label_collisions"),
+		"The number of rules whose allowed connections are not exported because their ACL label is shared with another rule",
+		nil, nil,
+	)
+)
+
+// aclLabelRegex matches the OpenFlow action of an ACL flow that loads the ACL label into the
+// register OVN commits to the conntrack label. Depending on the OVS version it is dumped either
+// as a load or as a set_field action.
+var aclLabelRegex = regexp.MustCompile(`(?:load:|set_field:)(0x[0-9a-f]+)->(?:NXM_NX_REG3\[\]|reg3)(?:,|$)`)
+
+// aclRule identifies the policy rule an ACL was created for
+type aclRule struct {
+	ownerType string
+	namespace string
+	name      string
+	direction string
+	rule      string
+}
+
+func (r aclRule) labelValues() []string {
+	return []string{r.ownerType, r.namespace, r.name, r.direction, r.rule}
+}
+
+func (r aclRule) String() string {
+	return strings.Join(r.labelValues(), "/")
+}
+
+// aclStats holds the connections allowed by every policy rule, and the number of rules
+// left out because their ACL label collides with the label of another rule
+type aclStats struct {
+	connections map[aclRule]float64
+	collisions  int
+}
+
+// aclStatsCollector exports per-rule allowed connection counters by aggregating the packet
+// statistics of the OpenFlow flows OVN derived from labelled ACLs. Only ACLs that allow traffic
+// carry their label into OpenFlow, and only the first packet of every connection is evaluated
+// against stateful ACLs, so every packet matching such a flow is a new allowed connection.
+type aclStatsCollector struct {
+	nbClient  libovsdbclient.Client
+	ovsOfctl  ovsClient
+	maxSeries int
+
+	// lock protects the fields below, scrapes may run concurrently
+	lock        sync.Mutex
+	lastStats   *aclStats
+	lastCollect time.Time
+}
+
+func newACLStatsCollector(nbClient libovsdbclient.Client, ovsOfctl ovsClient, maxSeries int) *aclStatsCollector {
+	return &aclStatsCollector{
+		nbClient:  nbClient,
+		ovsOfctl:  ovsOfctl,
+		maxSeries: maxSeries,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *aclStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- aclAllowedConnectionsDesc
+	ch <- aclStatsDroppedSeriesDesc
+	ch <- aclStatsLabelCollisionsDesc
+}
+
+// Collect implements prometheus.Collector. Dumping the flows of br-int is expensive on nodes
+// with many flows, so ACL stats are read from OVS at most once every aclStatsCacheInterval.
+func (c *aclStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.getCachedACLStats()
+	if err != nil {
+		klog.Errorf("Failed to collect ACL stats: %v", err)
+		return
+	}
+
+	rules := make([]aclRule, 0, len(stats.connections))
+	for rule := range stats.connections {
+		rules = append(rules, rule)
+	}
+	// sort the rules so the same subset is exported on every scrape when over the limit
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].String() < rules[j].String()
+	})
+	dropped := 0
+	if c.maxSeries >= 0 && len(rules) > c.maxSeries {
+		dropped = len(rules) - c.maxSeries
+		rules = rules[:c.maxSeries]
+	}
+
+	for _, rule := range rules {
+		ch <- prometheus.MustNewConstMetric(aclAllowedConnectionsDesc, prometheus.CounterValue, stats.connections[rule], rule.labelValues()...)
+	}
+	ch <- prometheus.MustNewConstMetric(aclStatsDroppedSeriesDesc, prometheus.GaugeValue, float64(dropped))
+	ch <- prometheus.MustNewConstMetric(aclStatsLabelCollisionsDesc, prometheus.GaugeValue, float64(stats.collisions))
+}
+
+// getCachedACLStats returns the ACL stats of the latest dump of the br-int flows, and dumps
+// them again once they are older than aclStatsCacheInterval
+func (c *aclStatsCollector) getCachedACLStats() (*aclStats, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.lastStats != nil && time.Since(c.lastCollect) < aclStatsCacheInterval {
+		return c.lastStats, nil
+	}
+	stats, err := c.getACLStats()
+	if err != nil {
+		return nil, err
+	}
+	c.lastStats = stats
+	c.lastCollect = time.Now()
+	return stats, nil
+}
+
+// getACLStats returns the connections allowed by the flows of every labelled ACL, aggregated
+// by the policy rule the ACL was created for. ACL labels are hashes of the ACL IDs, rules that
+// share a label with another rule cannot be told apart and are left out.
+func (c *aclStatsCollector) getACLStats() (*aclStats, error) {
+	acls, err := libovsdbops.FindACLsWithPredicate(c.nbClient, func(acl *nbdb.ACL) bool {
+		return acl.Label != 0
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find labelled ACLs: %w", err)
+	}
+
+	stats := &aclStats{connections: map[aclRule]float64{}}
+	labelToRule := map[int]aclRule{}
+	collidedRules := sets.New[aclRule]()
+	for _, acl := range acls {
+		rule, ok := aclRuleFromExternalIDs(acl.ExternalIDs)
+		if !ok {
+			continue
+		}
+		stats.connections[rule] = 0
+		// ACLs of the same rule may share a label, only different rules are ambiguous
+		if otherRule, ok := labelToRule[acl.Label]; ok && otherRule != rule {
+			klog.V(5).Infof("ACL rules %s and %s share label %d, not exporting their ACL stats", rule, otherRule, acl.Label)
+			collidedRules.Insert(rule, otherRule)
+			continue
+		}
+		labelToRule[acl.Label] = rule
+	}
+	for rule := range collidedRules {
+		delete(stats.connections, rule)
+	}
+	stats.collisions = collidedRules.Len()
+	if len(stats.connections) == 0 {
+		return stats, nil
+	}
+
+	stdout, stderr, err := c.ovsOfctl("-t", "5", "--no-names", "dump-flows", "br-int")
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump flows of br-int, stderr(%s): %w", stderr, err)
+	}
+	for _, flow := range strings.Split(stdout, "\n") {
+		match := aclLabelRegex.FindStringSubmatch(flow)
+		if match == nil {
+			continue
+		}
+		label, err := strconv.ParseUint(match[1], 0, 32)
+		if err != nil {
+			continue
+		}
+		rule, ok := labelToRule[int(label)]
+		if !ok || collidedRules.Has(rule) {
+			continue
+		}
+		packets, err := parseFlowPackets(flow)
+		if err != nil {
+			klog.V(5).Infof("Skipping flow of ACL rule %s: %v", rule, err)
+			continue
+		}
+		stats.connections[rule] += packets
+	}
+	return stats, nil
+}
+
+// parseFlowPackets returns the n_packets statistic of a flow dumped by ovs-ofctl
+func parseFlowPackets(flow string) (float64, error) {
+	for _, field := range strings.Split(flow, ", ") {
+		key, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok || key != "n_packets" {
+			continue
+		}
+		packets, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse n_packets of flow %q: %w", flow, err)
+		}
+		return packets, nil
+	}
+	return 0, fmt.Errorf("flow %q has no statistics", flow)
+}
+
+// aclRuleFromExternalIDs returns the policy rule of an ACL built for a network policy,
+// admin network policy or egress firewall
+func aclRuleFromExternalIDs(externalIDs map[string]string) (aclRule, bool) {
+	rule := aclRule{
+		ownerType: externalIDs[libovsdbops.OwnerTypeKey.String()],
+	}
+	objectName := externalIDs[libovsdbops.ObjectNameKey.String()]
+	switch rule.ownerType {
+	case libovsdbops.NetworkPolicyOwnerType:
+		namespace, name, err := libovsdbops.ParseNamespaceNameKey(objectName)
+		if err != nil {
+			return rule, false
+		}
+		rule.namespace = namespace
+		rule.name = name
+		rule.direction = externalIDs[libovsdbops.PolicyDirectionKey.String()]
+		rule.rule = externalIDs[libovsdbops.GressIdxKey.String()]
+	case libovsdbops.AdminNetworkPolicyOwnerType, libovsdbops.BaselineAdminNetworkPolicyOwnerType:
+		rule.name = objectName
+		rule.direction = externalIDs[libovsdbops.PolicyDirectionKey.String()]
+		rule.rule = externalIDs[libovsdbops.GressIdxKey.String()]
+	case libovsdbops.EgressFirewallOwnerType:
+		rule.namespace = objectName
+		rule.direction = "Egress"
+		rule.rule = externalIDs[libovsdbops.RuleIndex.String()]
+	case libovsdbops.ClusterEgressFirewallOwnerType:
+		rule.namespace = externalIDs[libovsdbops.NamespaceKey.String()]
+		rule.name = objectName
+		rule.direction = "Egress"
+		rule.rule = externalIDs[libovsdbops.RuleIndex.String()]
+	default:
+		return rule, false
+	}
+	return rule, true
+}
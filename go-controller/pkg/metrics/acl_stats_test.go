@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+
+	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+
+	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+)
+
+const aclStatsDumpFlowsSampleOutput = ` cookie=0x1a2b3c4d, duration=10.1s, table=44, n_packets=10, n_bytes=1000, idle_age=1, priority=2001,ct_state=+new-est+trk,ip,reg15=0x3,metadata=0x1 actions=load:0x1->NXM_NX_XXREG0[97],load:0x3039->NXM_NX_REG3[],resubmit(,45)
+ cookie=0x1a2b3c4e, duration=10.1s, table=44, n_packets=5, n_bytes=500, idle_age=1, priority=2001,ct_state=+new-est+trk,ipv6,reg15=0x3,metadata=0x1 actions=set_field:0x3039->reg3,resubmit(,45)
+ cookie=0x1a2b3c4f, duration=10.1s, table=44, n_packets=7, n_bytes=700, idle_age=1, priority=2001,ct_state=+new-est+trk,ip,reg14=0x4,metadata=0x1 actions=load:0x10932->NXM_NX_REG3[],resubmit(,45)
+ cookie=0x1a2b3c50, duration=10.1s, table=44, n_packets=3, n_bytes=300, idle_age=1, priority=2001,ip,reg14=0x4,metadata=0x1 actions=load:0x30390->NXM_NX_REG3[],resubmit(,45)
+ cookie=0x1a2b3c51, duration=10.1s, table=45, n_packets=100, n_bytes=10000, idle_age=1, priority=0,metadata=0x1 actions=resubmit(,46)`
+
+var _ = ginkgo.Describe("ACL stats metrics", func() {
+	var libovsdbCleanup *libovsdbtest.Context
+
+	ginkgo.AfterEach(func() {
+		if libovsdbCleanup != nil {
+			libovsdbCleanup.Cleanup()
+		}
+	})
+
+	newNBClient := func(acls ...*nbdb.ACL) libovsdbclient.Client {
+		// ACLs are not root objects, reference them from a port group so they are not garbage collected
+		pg := &nbdb.PortGroup{UUID: buildUUID(), Name: "pg"}
+		testData := make([]libovsdbtest.TestData, 0, len(acls)+1)
+		for _, acl := range acls {
+			pg.ACLs = append(pg.ACLs, acl.UUID)
+			testData = append(testData, acl)
+		}
+		testData = append(testData, pg)
+		nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{NBData: testData}, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		libovsdbCleanup = cleanup
+		return nbClient
+	}
+
+	npACL := &nbdb.ACL{
+		UUID:      buildUUID(),
+		Action:    nbdb.ACLActionAllowRelated,
+		Direction: nbdb.ACLDirectionToLport,
+		Label:     0x3039,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():       libovsdbops.NetworkPolicyOwnerType,
+			libovsdbops.ObjectNameKey.String():      "ns1:allow-web",
+			libovsdbops.PolicyDirectionKey.String(): "Ingress",
+			libovsdbops.GressIdxKey.String():        "0",
+		},
+	}
+	efACL := &nbdb.ACL{
+		UUID:      buildUUID(),
+		Action:    nbdb.ACLActionAllow,
+		Direction: nbdb.ACLDirectionToLport,
+		Label:     0x10932,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():  libovsdbops.EgressFirewallOwnerType,
+			libovsdbops.ObjectNameKey.String(): "ns2",
+			libovsdbops.RuleIndex.String():     "1",
+		},
+	}
+	collidingACL := &nbdb.ACL{
+		UUID:      buildUUID(),
+		Action:    nbdb.ACLActionAllow,
+		Direction: nbdb.ACLDirectionToLport,
+		Label:     0x3039,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():  libovsdbops.EgressFirewallOwnerType,
+			libovsdbops.ObjectNameKey.String(): "ns4",
+			libovsdbops.RuleIndex.String():     "0",
+		},
+	}
+	unlabelledACL := &nbdb.ACL{
+		UUID:      buildUUID(),
+		Action:    nbdb.ACLActionAllow,
+		Direction: nbdb.ACLDirectionToLport,
+		ExternalIDs: map[string]string{
+			libovsdbops.OwnerTypeKey.String():  libovsdbops.EgressFirewallOwnerType,
+			libovsdbops.ObjectNameKey.String(): "ns3",
+			libovsdbops.RuleIndex.String():     "0",
+		},
+	}
+
+	ginkgo.It("aggregates flow statistics by policy rule", func() {
+		nbClient := newNBClient(npACL, efACL, unlabelledACL)
+		ovsOfctl := NewFakeOVSClient([]clientOutput{{stdout: aclStatsDumpFlowsSampleOutput}})
+		collector := newACLStatsCollector(nbClient, ovsOfctl.FakeCall, 10)
+
+		stats, err := collector.getACLStats()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(stats.connections).To(gomega.Equal(map[aclRule]float64{
+			{ownerType: "NetworkPolicy", namespace: "ns1", name: "allow-web", direction: "Ingress", rule: "0"}: 15,
+			{ownerType: "EgressFirewall", namespace: "ns2", direction: "Egress", rule: "1"}:                    7,
+		}))
+		gomega.Expect(stats.collisions).To(gomega.BeZero())
+	})
+
+	ginkgo.It("does not export rules sharing a label with another rule", func() {
+		nbClient := newNBClient(npACL, efACL, collidingACL)
+		ovsOfctl := NewFakeOVSClient([]clientOutput{{stdout: aclStatsDumpFlowsSampleOutput}})
+		collector := newACLStatsCollector(nbClient, ovsOfctl.FakeCall, 10)
+
+		stats, err := collector.getACLStats()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(stats.connections).To(gomega.Equal(map[aclRule]float64{
+			{ownerType: "EgressFirewall", namespace: "ns2", direction: "Egress", rule: "1"}: 7,
+		}))
+		gomega.Expect(stats.collisions).To(gomega.Equal(2))
+	})
+
+	ginkgo.It("does not dump flows again before the cache interval", func() {
+		nbClient := newNBClient(npACL)
+		ovsOfctl := NewFakeOVSClient([]clientOutput{
+			{stdout: aclStatsDumpFlowsSampleOutput},
+			{err: fmt.Errorf("unexpected call")},
+		})
+		collector := newACLStatsCollector(nbClient, ovsOfctl.FakeCall, 10)
+
+		for i := 0; i < 2; i++ {
+			ch := make(chan prometheus.Metric, 10)
+			collector.Collect(ch)
+			close(ch)
+			// connections of a single rule, and the dropped series and label collisions gauges
+			gomega.Expect(ch).To(gomega.HaveLen(3))
+		}
+	})
+
+	ginkgo.It("limits the number of exported series", func() {
+		nbClient := newNBClient(npACL, efACL)
+		ovsOfctl := NewFakeOVSClient([]clientOutput{{stdout: aclStatsDumpFlowsSampleOutput}})
+		collector := newACLStatsCollector(nbClient, ovsOfctl.FakeCall, 1)
+
+		ch := make(chan prometheus.Metric, 10)
+		collector.Collect(ch)
+		close(ch)
+		// connections of a single rule, and the dropped series and label collisions gauges
+		gomega.Expect(ch).To(gomega.HaveLen(3))
+	})
+
+	ginkgo.It("does not dump flows without labelled ACLs", func() {
+		nbClient := newNBClient(unlabelledACL)
+		ovsOfctl := NewFakeOVSClient([]clientOutput{{err: fmt.Errorf("unexpected call")}})
+		collector := newACLStatsCollector(nbClient, ovsOfctl.FakeCall, 10)
+
+		stats, err := collector.getACLStats()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(stats.connections).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("returns an error when flows cannot be dumped", func() {
+		nbClient := newNBClient(npACL)
+		ovsOfctl := NewFakeOVSClient([]clientOutput{{stderr: "connection refused", err: fmt.Errorf("exit status 1")}})
+		collector := newACLStatsCollector(nbClient, ovsOfctl.FakeCall, 10)
+
+		_, err := collector.getACLStats()
+		gomega.Expect(err).To(gomega.HaveOccurred())
+	})
+})
@@ -36,6 +36,7 @@ var metricV4HostSubnetCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Help:      "The total number of v4 host subnets possible per network"},
 	[]string{
 		"network_name",
+		"topology",
 	},
 )
 
@@ -46,6 +47,7 @@ var metricV6HostSubnetCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Help:      "The total number of v6 host subnets possible per network"},
 	[]string{
 		"network_name",
+		"topology",
 	},
 )
 
@@ -56,6 +58,7 @@ var metricV4AllocatedHostSubnetCount = prometheus.NewGaugeVec(prometheus.GaugeOp
 	Help:      "The total number of v4 host subnets currently allocated per network"},
 	[]string{
 		"network_name",
+		"topology",
 	},
 )
 
@@ -66,6 +69,57 @@ var metricV6AllocatedHostSubnetCount = prometheus.NewGaugeVec(prometheus.GaugeOp
 	Help:      "The total number of v6 host subnets currently allocated per network"},
 	[]string{
 		"network_name",
+		"topology",
+	},
+)
+
+var metricNetworkIPCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemClusterManager,
+	Name:      "network_ips",
+	Help:      "The total number of pod IPs available for allocation per network, IP family and pool"},
+	[]string{
+		"network_name",
+		"topology",
+		"ip_family",
+		"pool",
+	},
+)
+
+var metricNetworkAllocatedIPCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemClusterManager,
+	Name:      "allocated_network_ips",
+	Help:      "The total number of pod IPs currently allocated per network, IP family and pool"},
+	[]string{
+		"network_name",
+		"topology",
+		"ip_family",
+		"pool",
+	},
+)
+
+var metricNetworkExcludedIPCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemClusterManager,
+	Name:      "excluded_network_ips",
+	Help: "The total number of pod IPs excluded from dynamic allocation per network and IP family, " +
+		"including reserved, infrastructure and excluded subnets"},
+	[]string{
+		"network_name",
+		"topology",
+		"ip_family",
+	},
+)
+
+var metricNetworkReservedMACCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemClusterManager,
+	Name:      "reserved_network_macs",
+	Help:      "The total number of MAC addresses currently reserved per network"},
+	[]string{
+		"network_name",
+		"topology",
 	},
 )
 
@@ -162,6 +216,10 @@ func RegisterClusterManagerFunctional() {
 		prometheus.MustRegister(metricV6HostSubnetCount)
 		prometheus.MustRegister(metricV4AllocatedHostSubnetCount)
 		prometheus.MustRegister(metricV6AllocatedHostSubnetCount)
+		prometheus.MustRegister(metricNetworkIPCount)
+		prometheus.MustRegister(metricNetworkAllocatedIPCount)
+		prometheus.MustRegister(metricNetworkExcludedIPCount)
+		prometheus.MustRegister(metricNetworkReservedMACCount)
 		if config.OVNKubernetesFeature.EnableEgressIP {
 			prometheus.MustRegister(metricEgressIPNodeUnreacheableCount)
 			prometheus.MustRegister(metricEgressIPRebalanceCount)
@@ -182,16 +240,52 @@ func RegisterClusterManagerFunctional() {
 }
 
 // RecordSubnetUsage records the number of subnets allocated for nodes
-func RecordSubnetUsage(v4SubnetsAllocated, v6SubnetsAllocated float64, networkName string) {
-	metricV4AllocatedHostSubnetCount.WithLabelValues(networkName).Set(v4SubnetsAllocated)
-	metricV6AllocatedHostSubnetCount.WithLabelValues(networkName).Set(v6SubnetsAllocated)
+func RecordSubnetUsage(v4SubnetsAllocated, v6SubnetsAllocated float64, networkName, topology string) {
+	metricV4AllocatedHostSubnetCount.WithLabelValues(networkName, topology).Set(v4SubnetsAllocated)
+	metricV6AllocatedHostSubnetCount.WithLabelValues(networkName, topology).Set(v6SubnetsAllocated)
 }
 
 // RecordSubnetCount records the number of available subnets per configuration
 // for ovn-kubernetes
-func RecordSubnetCount(v4SubnetCount, v6SubnetCount float64, networkName string) {
-	metricV4HostSubnetCount.WithLabelValues(networkName).Set(v4SubnetCount)
-	metricV6HostSubnetCount.WithLabelValues(networkName).Set(v6SubnetCount)
+func RecordSubnetCount(v4SubnetCount, v6SubnetCount float64, networkName, topology string) {
+	metricV4HostSubnetCount.WithLabelValues(networkName, topology).Set(v4SubnetCount)
+	metricV6HostSubnetCount.WithLabelValues(networkName, topology).Set(v6SubnetCount)
+}
+
+// DeleteSubnetMetrics removes the host subnet metrics of a network when it is deleted.
+func DeleteSubnetMetrics(networkName, topology string) {
+	metricV4HostSubnetCount.DeleteLabelValues(networkName, topology)
+	metricV6HostSubnetCount.DeleteLabelValues(networkName, topology)
+	metricV4AllocatedHostSubnetCount.DeleteLabelValues(networkName, topology)
+	metricV6AllocatedHostSubnetCount.DeleteLabelValues(networkName, topology)
+}
+
+// RecordNetworkIPUsage records the number of pod IPs available and allocated
+// in the given pool ("dynamic" or "reserved") of a network for an IP family.
+func RecordNetworkIPUsage(networkName, topology, ipFamily, pool string, size, allocated float64) {
+	metricNetworkIPCount.WithLabelValues(networkName, topology, ipFamily, pool).Set(size)
+	metricNetworkAllocatedIPCount.WithLabelValues(networkName, topology, ipFamily, pool).Set(allocated)
+}
+
+// RecordNetworkExcludedIPs records the number of pod IPs of a network and IP
+// family that are excluded from dynamic allocation.
+func RecordNetworkExcludedIPs(networkName, topology, ipFamily string, excluded float64) {
+	metricNetworkExcludedIPCount.WithLabelValues(networkName, topology, ipFamily).Set(excluded)
+}
+
+// RecordNetworkReservedMACs records the number of MAC addresses reserved in a network.
+func RecordNetworkReservedMACs(networkName, topology string, count float64) {
+	metricNetworkReservedMACCount.WithLabelValues(networkName, topology).Set(count)
+}
+
+// DeleteNetworkIPAMMetrics removes the pod IP and MAC allocation metrics of a
+// network when it is deleted.
+func DeleteNetworkIPAMMetrics(networkName string) {
+	labels := prometheus.Labels{"network_name": networkName}
+	metricNetworkIPCount.DeletePartialMatch(labels)
+	metricNetworkAllocatedIPCount.DeletePartialMatch(labels)
+	metricNetworkExcludedIPCount.DeletePartialMatch(labels)
+	metricNetworkReservedMACCount.DeletePartialMatch(labels)
 }
 
 // RecordEgressIPReachableNode records how many times EgressIP detected an unuseable node.
@@ -460,18 +460,19 @@ func writePlainText(statusCode int, text string, w http.ResponseWriter) {
 // StartMetricsServer runs the prometheus listener so that OVN K8s metrics can be collected.
 // It now reuses the unified MetricServer implementation so it can share plumbing with the
 // OVN/OVS metrics server. TLS and pprof behaviour remain unchanged.
-func StartMetricsServer(bindAddress string, enablePprof bool, certFile string, keyFile string,
-	stopChan <-chan struct{}, wg *sync.WaitGroup) {
+func StartMetricsServer(bindAddress string, enablePprof, enableNodeDebug bool, certFile string, keyFile string,
+	kubeClient kubernetes.Interface, stopChan <-chan struct{}, wg *sync.WaitGroup) {
 	opts := MetricServerOptions{
-		BindAddress: bindAddress,
-		CertFile:    certFile,
-		KeyFile:     keyFile,
-		EnablePprof: enablePprof,
+		BindAddress:     bindAddress,
+		CertFile:        certFile,
+		KeyFile:         keyFile,
+		EnablePprof:     enablePprof,
+		EnableNodeDebug: enableNodeDebug,
 		// Use default registry so existing metric registrations keep working.
 		Registerer: prometheus.DefaultRegisterer,
 	}
 
-	server := NewMetricServer(opts, nil, nil)
+	server := NewMetricServer(opts, nil, kubeClient)
 
 	wg.Add(1)
 	go func() {
@@ -22,6 +22,15 @@ var MetricCNIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOp
 	[]string{"command", "err"},
 )
 
+// MetricCNIGCReclaimedPorts is a prometheus metric that tracks the number of
+// stale pod OVS ports reclaimed by CNI GC
+var MetricCNIGCReclaimedPorts = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemNode,
+	Name:      "cni_gc_reclaimed_ports_total",
+	Help:      "The total number of stale pod OVS ports reclaimed by CNI GC.",
+})
+
 var MetricNodeReadyDuration = prometheus.NewGauge(prometheus.GaugeOpts{
 	Namespace: types.MetricOvnkubeNamespace,
 	Subsystem: types.MetricOvnkubeSubsystemNode,
@@ -47,14 +56,70 @@ var metricOvnKubeNodeLogFileSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	},
 )
 
+// MetricGatewayOpenFlowSyncDuration is a prometheus metric that tracks the duration
+// of the syncs of the gateway flows to the OVS bridges
+var MetricGatewayOpenFlowSyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemNode,
+	Name:      "gateway_openflow_sync_duration_seconds",
+	Help:      "The duration of the syncs of the gateway flows to an OVS bridge, either full or incremental.",
+	Buckets:   prometheus.ExponentialBuckets(.001, 2, 15)},
+	[]string{
+		"bridge",
+		"type",
+	},
+)
+
+// MetricGatewayOpenFlowFlows is a prometheus metric that tracks the number of gateway
+// flows programmed on the OVS bridges, per flow cache key
+var MetricGatewayOpenFlowFlows = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemNode,
+	Name:      "gateway_openflow_flows",
+	Help:      "The number of gateway flows programmed on an OVS bridge, per flow cache key."},
+	[]string{
+		"bridge",
+		"key",
+	},
+)
+
+// MetricIPsecTunnelEstablished is a prometheus metric that tracks if the IPsec security
+// associations of the tunnel to a remote chassis are established in both directions
+var MetricIPsecTunnelEstablished = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemNode,
+	Name:      "ipsec_tunnel_established",
+	Help:      "Specifies if the IPsec tunnel to a remote chassis is established(1) or not(0)."},
+	[]string{
+		"remote_chassis",
+		"remote_ip",
+	},
+)
+
+// MetricIPsecCertificateExpiration is a prometheus metric that tracks when the IPsec
+// certificate of the node expires
+var MetricIPsecCertificateExpiration = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: types.MetricOvnkubeNamespace,
+	Subsystem: types.MetricOvnkubeSubsystemNode,
+	Name:      "ipsec_certificate_expiration_timestamp_seconds",
+	Help:      "The time the IPsec certificate of the node expires, in seconds since the Unix epoch.",
+})
+
 var registerNodeMetricsOnce sync.Once
 
 func RegisterNodeMetrics(stopChan <-chan struct{}) {
 	registerNodeMetricsOnce.Do(func() {
 		// ovnkube-node metrics
 		prometheus.MustRegister(MetricCNIRequestDuration)
+		prometheus.MustRegister(MetricCNIGCReclaimedPorts)
 		prometheus.MustRegister(MetricNodeReadyDuration)
 		prometheus.MustRegister(metricOvnNodePortEnabled)
+		prometheus.MustRegister(MetricGatewayOpenFlowSyncDuration)
+		prometheus.MustRegister(MetricGatewayOpenFlowFlows)
+		if config.OVNKubernetesFeature.EnableIPsec {
+			prometheus.MustRegister(MetricIPsecTunnelEstablished)
+			prometheus.MustRegister(MetricIPsecCertificateExpiration)
+		}
 		prometheus.MustRegister(prometheus.NewGaugeFunc(
 			prometheus.GaugeOpts{
 				Namespace: types.MetricOvnkubeNamespace,
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// NodeDebugPath is the path the node debug endpoint is served under
+const NodeDebugPath = "/debug/node/"
+
+var (
+	nodeDebugHandlerLock sync.RWMutex
+	nodeDebugHandler     http.Handler
+)
+
+// RegisterNodeDebugHandler registers the handler serving the node debug endpoint. The metrics
+// server starts before the node controllers do, requests are answered with 503 until then.
+func RegisterNodeDebugHandler(handler http.Handler) {
+	nodeDebugHandlerLock.Lock()
+	defer nodeDebugHandlerLock.Unlock()
+	nodeDebugHandler = handler
+}
+
+func serveNodeDebug(w http.ResponseWriter, r *http.Request) {
+	nodeDebugHandlerLock.RLock()
+	handler := nodeDebugHandler
+	nodeDebugHandlerLock.RUnlock()
+	if handler == nil {
+		writePlainText(http.StatusServiceUnavailable, "node debug handler is not registered yet", w)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// withKubernetesAuth only lets through the requests with a bearer token of a user allowed to
+// access the requested non-resource URL, as the kubelet does for its own debug endpoints.
+func withKubernetesAuth(kubeClient kubernetes.Interface, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || token == "" {
+			writePlainText(http.StatusUnauthorized, "Unauthorized", w)
+			return
+		}
+
+		tokenReview, err := kubeClient.AuthenticationV1().TokenReviews().Create(r.Context(),
+			&authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}, metav1.CreateOptions{})
+		if err != nil {
+			klog.Errorf("Failed to authenticate request for %s from %s: %v", r.URL.Path, r.RemoteAddr, err)
+			writePlainText(http.StatusInternalServerError, "Internal Server Error", w)
+			return
+		}
+		if !tokenReview.Status.Authenticated {
+			writePlainText(http.StatusUnauthorized, "Unauthorized", w)
+			return
+		}
+
+		user := tokenReview.Status.User
+		extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for k, v := range user.Extra {
+			extra[k] = authorizationv1.ExtraValue(v)
+		}
+		sar, err := kubeClient.AuthorizationV1().SubjectAccessReviews().Create(r.Context(),
+			&authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					User:   user.Username,
+					UID:    user.UID,
+					Groups: user.Groups,
+					Extra:  extra,
+					NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+						Path: r.URL.Path,
+						Verb: strings.ToLower(r.Method),
+					},
+				},
+			}, metav1.CreateOptions{})
+		if err != nil {
+			klog.Errorf("Failed to authorize request for %s from %s: %v", r.URL.Path, r.RemoteAddr, err)
+			writePlainText(http.StatusInternalServerError, "Internal Server Error", w)
+			return
+		}
+		if !sar.Status.Allowed {
+			klog.V(5).Infof("User %q is not allowed to %s %s: %s", user.Username, r.Method, r.URL.Path, sar.Status.Reason)
+			writePlainText(http.StatusForbidden, "Forbidden", w)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
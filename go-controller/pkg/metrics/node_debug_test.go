@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestWithKubernetesAuth(t *testing.T) {
+	testCases := []struct {
+		name           string
+		authorization  string
+		authenticated  bool
+		tokenReviewErr error
+		allowed        bool
+		expectedStatus int
+	}{
+		{
+			name:           "request without token is unauthorized",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "request with invalid token is unauthorized",
+			authorization:  "Bearer invalid",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "failure to review the token is an internal error",
+			authorization:  "Bearer token",
+			tokenReviewErr: fmt.Errorf("apiserver unavailable"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "request of a user not allowed to access the path is forbidden",
+			authorization:  "Bearer token",
+			authenticated:  true,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "request of a user allowed to access the path is served",
+			authorization:  "Bearer token",
+			authenticated:  true,
+			allowed:        true,
+			expectedStatus: http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+				require.Equal(t, "Bearer "+review.Spec.Token, tc.authorization)
+				review.Status.Authenticated = tc.authenticated
+				review.Status.User = authenticationv1.UserInfo{Username: "admin", Groups: []string{"system:masters"}}
+				return true, review, tc.tokenReviewErr
+			})
+			kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				sar := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+				require.Equal(t, "admin", sar.Spec.User)
+				require.Equal(t, []string{"system:masters"}, sar.Spec.Groups)
+				require.Equal(t, &authorizationv1.NonResourceAttributes{Path: NodeDebugPath + "state", Verb: "get"},
+					sar.Spec.NonResourceAttributes)
+				sar.Status.Allowed = tc.allowed
+				return true, sar, nil
+			})
+
+			handler := withKubernetesAuth(kubeClient, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(http.MethodGet, NodeDebugPath+"state", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			require.Equal(t, tc.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestServeNodeDebug(t *testing.T) {
+	defer RegisterNodeDebugHandler(nil)
+
+	recorder := httptest.NewRecorder()
+	serveNodeDebug(recorder, httptest.NewRequest(http.MethodGet, NodeDebugPath+"state", nil))
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	RegisterNodeDebugHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	recorder = httptest.NewRecorder()
+	serveNodeDebug(recorder, httptest.NewRequest(http.MethodGet, NodeDebugPath+"state", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
@@ -36,7 +36,13 @@ type MetricServerOptions struct {
 	EnableOVNDBMetrics         bool
 	EnableOVNControllerMetrics bool
 	EnableOVNNorthdMetrics     bool
+	EnableACLStatsMetrics      bool
 	EnablePprof                bool
+	// EnableNodeDebug serves the node debug endpoint, only over TLS and to authorized users
+	EnableNodeDebug bool
+
+	// ACLStatsMaxSeries limits the number of per-rule allowed connection counter series
+	ACLStatsMaxSeries int
 
 	// OnFatalError is called when an unrecoverable error occurs (e.g., failed to bind to address).
 	// If set, it allows the caller to trigger a graceful shutdown.
@@ -49,6 +55,8 @@ type MetricServerOptions struct {
 	K8sClient   kubernetes.Interface
 	K8sNodeName string
 	OVSDBClient libovsdbclient.Client
+	// NBClient is an OVN northbound database client, required by the ACL stats metrics
+	NBClient libovsdbclient.Client
 
 	dbIsClustered  bool
 	dbFoundViaPath bool
@@ -110,6 +118,14 @@ func NewMetricServer(opts MetricServerOptions, ovsDBClient libovsdbclient.Client
 		server.mux.HandleFunc("/debug/flags/v", stringFlagPutHandler(klogSetter))
 	}
 
+	if opts.EnableNodeDebug {
+		if opts.CertFile == "" || opts.KeyFile == "" || kubeClient == nil {
+			klog.Warningf("Not serving the node debug endpoint, it requires TLS and a Kubernetes client")
+		} else {
+			server.mux.Handle(NodeDebugPath, withKubernetesAuth(kubeClient, http.HandlerFunc(serveNodeDebug)))
+		}
+	}
+
 	return server
 }
 
@@ -131,6 +147,10 @@ func (s *MetricServer) registerMetrics() {
 		klog.Infof("MetricServer registers OVN Northd metrics")
 		RegisterOvnNorthdMetrics(s.registerer)
 	}
+	if s.opts.EnableACLStatsMetrics {
+		klog.Infof("MetricServer registers ACL stats metrics")
+		s.registerer.MustRegister(newACLStatsCollector(s.opts.NBClient, util.RunOVSOfctl, s.opts.ACLStatsMaxSeries))
+	}
 }
 
 func (s *MetricServer) EnableOVNNorthdMetrics() {
@@ -39,6 +39,7 @@ import (
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/controllers/egressip"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/controllers/egressservice"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/dpulease"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/ipsec"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/linkmanager"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/managementport"
 	nodenft "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/nftables"
@@ -1124,6 +1125,12 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		klog.Infof("Egress IP for secondary host network is disabled")
 	}
 
+	if config.OVNKubernetesFeature.EnableIPsec && config.OvnKubeNode.Mode != types.NodeModeDPUHost {
+		if err = ipsec.NewManager(nc.client, nc.ovsClient).Run(nc.stopChan, nc.wg); err != nil {
+			return fmt.Errorf("failed to start IPsec manager: %w", err)
+		}
+	}
+
 	nc.linkManager.Run(nc.stopChan, nc.wg)
 
 	nc.wg.Add(1)
@@ -142,21 +142,61 @@ func (rm *Controller) OwnPriority(priority int) error {
 	return rm.reconcile()
 }
 
-func (rm *Controller) reconcile() error {
-	start := time.Now()
-	defer func() {
-		klog.V(5).Infof("Reconciling IP rules took %v", time.Since(start))
-	}()
-	var family int
+// RuleState is an IP rule managed by the IP rule manager
+type RuleState struct {
+	Rule netlink.Rule
+	// Metadata the rule was added with
+	Metadata string
+	// Installed tells if the rule is installed on the node, only set when requested
+	Installed *bool
+}
+
+// List returns the managed IP rules. If checkInstalled is set, every rule is
+// also checked against the rules installed on the node.
+func (rm *Controller) List(checkInstalled bool) ([]RuleState, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	var rulesFound []netlink.Rule
+	if checkInstalled {
+		var err error
+		rulesFound, err = netlink.RuleList(rm.family())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list IP rules: %w", err)
+		}
+	}
+	rules := make([]RuleState, 0, len(rm.rules))
+	for _, r := range rm.rules {
+		if r.delete {
+			continue
+		}
+		state := RuleState{Rule: *r.rule, Metadata: r.metadata}
+		if checkInstalled {
+			installed, _ := isNetlinkRuleInSlice(rulesFound, r.rule)
+			state.Installed = &installed
+		}
+		rules = append(rules, state)
+	}
+	return rules, nil
+}
+
+func (rm *Controller) family() int {
 	if rm.v4 && rm.v6 {
-		family = netlink.FAMILY_ALL
+		return netlink.FAMILY_ALL
 	} else if rm.v4 {
-		family = netlink.FAMILY_V4
+		return netlink.FAMILY_V4
 	} else if rm.v6 {
-		family = netlink.FAMILY_V6
+		return netlink.FAMILY_V6
 	}
+	return 0
+}
 
-	rulesFound, err := netlink.RuleList(family)
+func (rm *Controller) reconcile() error {
+	start := time.Now()
+	defer func() {
+		klog.V(5).Infof("Reconciling IP rules took %v", time.Since(start))
+	}()
+	rulesFound, err := netlink.RuleList(rm.family())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,13 @@
+package ipsec
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIPsec(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Node IPsec Suite")
+}
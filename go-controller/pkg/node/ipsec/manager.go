@@ -0,0 +1,293 @@
+package ipsec
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vishvananda/netlink"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/certificate"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/klog/v2"
+
+	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/csrapprover"
+	ovsops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops/ovs"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/vswitchd"
+)
+
+const (
+	certPairPrefix = "ipsec"
+	caCertFile     = "ipsec-cacert.pem"
+	certFilePrefix = "ipsec-cert-"
+	keyFilePrefix  = "ipsec-privkey-"
+
+	syncInterval = 10 * time.Second
+)
+
+// Manager maintains the IPsec certificate of the node and the Open_vSwitch
+// other_config that ovs-monitor-ipsec uses to set up the IPsec tunnels to the
+// other nodes. The certificate is requested with the chassis ID of the node as
+// common name, signed by ovnkube-cluster-manager, and rotated before it expires.
+type Manager struct {
+	client    clientset.Interface
+	ovsClient libovsdbclient.Client
+	certDir   string
+
+	certManager certificate.Manager
+	// serial of the certificate currently configured in OVS
+	configuredSerial string
+	// tunnels the health was last reported for, by interface name
+	tunnels map[string]tunnel
+
+	listXfrmStates func() ([]netlink.XfrmState, error)
+}
+
+// tunnel is an OVS tunnel interface to a remote chassis
+type tunnel struct {
+	remoteChassis string
+	remoteIP      net.IP
+}
+
+// NewManager creates an IPsec manager for the local node
+func NewManager(client clientset.Interface, ovsClient libovsdbclient.Client) *Manager {
+	return &Manager{
+		client:    client,
+		ovsClient: ovsClient,
+		certDir:   config.IPsec.CertDir,
+		tunnels:   map[string]tunnel{},
+		listXfrmStates: func() ([]netlink.XfrmState, error) {
+			return netlink.XfrmStateList(netlink.FAMILY_ALL)
+		},
+	}
+}
+
+// Run starts requesting and rotating the IPsec certificate of the node, and
+// periodically reports the health of the IPsec tunnels.
+func (m *Manager) Run(stopCh <-chan struct{}, wg *sync.WaitGroup) error {
+	chassisID, err := util.GetNodeChassisID()
+	if err != nil {
+		return fmt.Errorf("failed to get the chassis ID: %w", err)
+	}
+	if err := os.MkdirAll(m.certDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create IPsec certificate directory %s: %w", m.certDir, err)
+	}
+	if err := m.syncCACert(); err != nil {
+		return err
+	}
+
+	certificateStore, err := certificate.NewFileStore(certPairPrefix, m.certDir, m.certDir, "", "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize the IPsec certificate store: %w", err)
+	}
+	// OVN identifies IPsec peers by their chassis ID, request a new certificate if it changed
+	if current, err := certificateStore.Current(); err == nil && current.Leaf != nil && current.Leaf.Subject.CommonName != chassisID {
+		klog.Warningf("Unexpected common name found in the IPsec certificate, expected: %q, got: %q, removing %s",
+			chassisID, current.Leaf.Subject.CommonName, certificateStore.CurrentPath())
+		if err := os.Remove(certificateStore.CurrentPath()); err != nil {
+			return fmt.Errorf("failed to remove the current IPsec certificate file: %w", err)
+		}
+	}
+
+	m.certManager, err = certificate.NewManager(&certificate.Config{
+		ClientsetFn: func(_ *tls.Certificate) (clientset.Interface, error) {
+			return m.client, nil
+		},
+		Template: &x509.CertificateRequest{
+			Subject:  pkix.Name{CommonName: chassisID},
+			DNSNames: []string{chassisID},
+		},
+		RequestedCertificateLifetime: &config.IPsec.CertDuration,
+		SignerName:                   csrapprover.IPsecSignerName,
+		Usages:                       []certificatesv1.KeyUsage{certificatesv1.UsageIPsecTunnel},
+		CertificateStore:             certificateStore,
+		Name:                         "ipsec",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize the IPsec certificate manager: %w", err)
+	}
+	m.certManager.Start()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wait.Until(func() {
+			if err := m.syncCertificate(); err != nil {
+				klog.Errorf("Failed to configure the IPsec certificate: %v", err)
+			}
+			if err := m.syncTunnelHealth(); err != nil {
+				klog.Errorf("Failed to check the IPsec tunnels: %v", err)
+			}
+		}, syncInterval, stopCh)
+		m.certManager.Stop()
+	}()
+	return nil
+}
+
+// syncCACert copies the IPsec CA certificate to the certificate directory shared with ovs-monitor-ipsec
+func (m *Manager) syncCACert() error {
+	caCert, err := os.ReadFile(config.IPsec.CACert)
+	if err != nil {
+		return fmt.Errorf("failed to read IPsec CA certificate: %w", err)
+	}
+	if _, err = cert.ParseCertsPEM(caCert); err != nil {
+		return fmt.Errorf("invalid IPsec CA certificate %s: %w", config.IPsec.CACert, err)
+	}
+	return cert.WriteCert(filepath.Join(m.certDir, caCertFile), caCert)
+}
+
+// syncCertificate writes the current certificate and key of the node to files named after the serial
+// of the certificate, and points OVS to them. ovs-monitor-ipsec only reloads the certificate when
+// the configured paths change.
+func (m *Manager) syncCertificate() error {
+	current := m.certManager.Current()
+	if current == nil || current.Leaf == nil {
+		klog.V(5).Infof("Waiting for the IPsec certificate to be signed")
+		return nil
+	}
+	metrics.MetricIPsecCertificateExpiration.Set(float64(current.Leaf.NotAfter.Unix()))
+
+	serial := current.Leaf.SerialNumber.Text(16)
+	if serial == m.configuredSerial {
+		return nil
+	}
+
+	certPath := filepath.Join(m.certDir, certFilePrefix+serial+".pem")
+	keyPath := filepath.Join(m.certDir, keyFilePrefix+serial+".pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: current.Leaf.Raw})
+	if err := cert.WriteCert(certPath, certPEM); err != nil {
+		return fmt.Errorf("failed to write IPsec certificate: %w", err)
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(current.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to encode IPsec private key: %w", err)
+	}
+	if err := keyutil.WriteKey(keyPath, keyPEM); err != nil {
+		return fmt.Errorf("failed to write IPsec private key: %w", err)
+	}
+
+	_, stderr, err := util.RunOVSVsctl("set", "Open_vSwitch", ".",
+		fmt.Sprintf("other_config:certificate=%s", certPath),
+		fmt.Sprintf("other_config:private_key=%s", keyPath),
+		fmt.Sprintf("other_config:ca_cert=%s", filepath.Join(m.certDir, caCertFile)),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to configure IPsec certificate in OVS, stderr: %q: %w", stderr, err)
+	}
+	klog.Infof("Configured IPsec certificate %s, valid until %s", certPath, current.Leaf.NotAfter)
+	m.configuredSerial = serial
+
+	m.removeStaleCertificates(serial)
+	return nil
+}
+
+// removeStaleCertificates removes the certificates and keys that were rotated
+func (m *Manager) removeStaleCertificates(serial string) {
+	entries, err := os.ReadDir(m.certDir)
+	if err != nil {
+		klog.Warningf("Failed to list IPsec certificate directory %s: %v", m.certDir, err)
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, certFilePrefix) && !strings.HasPrefix(name, keyFilePrefix) {
+			continue
+		}
+		if strings.HasSuffix(name, "-"+serial+".pem") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(m.certDir, name)); err != nil {
+			klog.Warningf("Failed to remove stale IPsec file %s: %v", name, err)
+		}
+	}
+}
+
+// syncTunnelHealth reports for every tunnel to a remote chassis whether its IPsec
+// security associations are established in both directions
+func (m *Manager) syncTunnelHealth() error {
+	tunnels, err := m.getTunnels()
+	if err != nil {
+		return err
+	}
+	states, err := m.listXfrmStates()
+	if err != nil {
+		return fmt.Errorf("failed to list xfrm states: %w", err)
+	}
+
+	for name, t := range m.tunnels {
+		if _, ok := tunnels[name]; !ok {
+			metrics.MetricIPsecTunnelEstablished.DeleteLabelValues(t.remoteChassis, t.remoteIP.String())
+		}
+	}
+	for _, t := range tunnels {
+		established := isTunnelEstablished(t, states)
+		if !established {
+			klog.V(5).Infof("IPsec tunnel to chassis %s (%s) is not established", t.remoteChassis, t.remoteIP)
+		}
+		value := 0.0
+		if established {
+			value = 1
+		}
+		metrics.MetricIPsecTunnelEstablished.WithLabelValues(t.remoteChassis, t.remoteIP.String()).Set(value)
+	}
+	m.tunnels = tunnels
+	return nil
+}
+
+// getTunnels returns the geneve tunnel interfaces ovn-controller created to the remote chassis
+func (m *Manager) getTunnels() (map[string]tunnel, error) {
+	ifaces, err := ovsops.FindInterfacesWithPredicate(m.ovsClient, func(iface *vswitchd.Interface) bool {
+		return iface.Type == "geneve"
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tunnel interfaces: %w", err)
+	}
+	tunnels := map[string]tunnel{}
+	for _, iface := range ifaces {
+		remoteIP := net.ParseIP(iface.Options["remote_ip"])
+		if remoteIP == nil {
+			continue
+		}
+		remoteChassis := iface.Options["remote_name"]
+		if remoteChassis == "" {
+			remoteChassis = iface.ExternalIDs["ovn-chassis-id"]
+		}
+		tunnels[iface.Name] = tunnel{remoteChassis: remoteChassis, remoteIP: remoteIP}
+	}
+	return tunnels, nil
+}
+
+// isTunnelEstablished tells if there are ESP security associations to and from the
+// remote end of the tunnel
+func isTunnelEstablished(t tunnel, states []netlink.XfrmState) bool {
+	var outbound, inbound bool
+	for _, state := range states {
+		if state.Proto != netlink.XFRM_PROTO_ESP {
+			continue
+		}
+		if state.Dst.Equal(t.remoteIP) {
+			outbound = true
+		}
+		if state.Src.Equal(t.remoteIP) {
+			inbound = true
+		}
+	}
+	return outbound && inbound
+}
@@ -0,0 +1,98 @@
+package ipsec
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/metrics"
+	libovsdbtest "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/vswitchd"
+)
+
+var _ = Describe("IPsec manager", func() {
+	var libovsdbCleanup *libovsdbtest.Context
+
+	AfterEach(func() {
+		if libovsdbCleanup != nil {
+			libovsdbCleanup.Cleanup()
+		}
+	})
+
+	esp := func(src, dst string) netlink.XfrmState {
+		return netlink.XfrmState{
+			Src:   net.ParseIP(src),
+			Dst:   net.ParseIP(dst),
+			Proto: netlink.XFRM_PROTO_ESP,
+		}
+	}
+
+	tunnelEstablished := func(remoteChassis, remoteIP string) float64 {
+		m := &dto.Metric{}
+		Expect(metrics.MetricIPsecTunnelEstablished.WithLabelValues(remoteChassis, remoteIP).Write(m)).To(Succeed())
+		return m.GetGauge().GetValue()
+	}
+
+	Context("isTunnelEstablished", func() {
+		t := tunnel{remoteChassis: "chassis-2", remoteIP: net.ParseIP("172.18.0.3")}
+
+		It("requires security associations in both directions", func() {
+			Expect(isTunnelEstablished(t, []netlink.XfrmState{
+				esp("172.18.0.2", "172.18.0.3"),
+				esp("172.18.0.3", "172.18.0.2"),
+			})).To(BeTrue())
+			Expect(isTunnelEstablished(t, []netlink.XfrmState{
+				esp("172.18.0.2", "172.18.0.3"),
+			})).To(BeFalse())
+			Expect(isTunnelEstablished(t, []netlink.XfrmState{
+				esp("172.18.0.2", "172.18.0.4"),
+				esp("172.18.0.4", "172.18.0.2"),
+			})).To(BeFalse())
+		})
+
+		It("ignores non ESP security associations", func() {
+			ah := esp("172.18.0.3", "172.18.0.2")
+			ah.Proto = netlink.XFRM_PROTO_AH
+			Expect(isTunnelEstablished(t, []netlink.XfrmState{
+				esp("172.18.0.2", "172.18.0.3"),
+				ah,
+			})).To(BeFalse())
+		})
+	})
+
+	It("reports the health of the tunnels to the remote chassis", func() {
+		ovsClient, cleanup, err := libovsdbtest.NewOVSTestHarness(libovsdbtest.TestSetup{
+			OVSData: []libovsdbtest.TestData{
+				&vswitchd.Interface{UUID: "intf-1", Name: "ovn-chassis-2-0", Type: "geneve",
+					Options: map[string]string{"remote_ip": "172.18.0.3", "remote_name": "chassis-2"}},
+				&vswitchd.Interface{UUID: "intf-2", Name: "ovn-chassis-3-0", Type: "geneve",
+					Options: map[string]string{"remote_ip": "172.18.0.4", "remote_name": "chassis-3"}},
+				&vswitchd.Interface{UUID: "intf-3", Name: "ovn-k8s-mp0", Type: "internal"},
+				&vswitchd.Port{UUID: "port-1", Name: "ovn-chassis-2-0", Interfaces: []string{"intf-1"}},
+				&vswitchd.Port{UUID: "port-2", Name: "ovn-chassis-3-0", Interfaces: []string{"intf-2"}},
+				&vswitchd.Port{UUID: "port-3", Name: "ovn-k8s-mp0", Interfaces: []string{"intf-3"}},
+				&vswitchd.Bridge{UUID: "br-int", Name: "br-int", Ports: []string{"port-1", "port-2", "port-3"}},
+				&vswitchd.OpenvSwitch{UUID: "root-ovs", Bridges: []string{"br-int"}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		libovsdbCleanup = cleanup
+
+		m := NewManager(nil, ovsClient)
+		m.listXfrmStates = func() ([]netlink.XfrmState, error) {
+			return []netlink.XfrmState{
+				esp("172.18.0.2", "172.18.0.3"),
+				esp("172.18.0.3", "172.18.0.2"),
+				esp("172.18.0.2", "172.18.0.4"),
+			}, nil
+		}
+		Expect(m.syncTunnelHealth()).To(Succeed())
+		Expect(m.tunnels).To(HaveLen(2))
+		Expect(tunnelEstablished("chassis-2", "172.18.0.3")).To(Equal(1.0))
+		Expect(tunnelEstablished("chassis-3", "172.18.0.4")).To(Equal(0.0))
+	})
+})
@@ -0,0 +1,371 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/knftables"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/iprulemanager"
+	nodenft "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/nftables"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// flowDebugCookieBase is the first of the cookies the flows of a flow cache are tagged with
+// to compare them to the installed flows
+const flowDebugCookieBase uint64 = 0xdeb0000000000000
+
+var (
+	// flowCacheCookieRegex matches the cookie of a flow of the flow cache
+	flowCacheCookieRegex = regexp.MustCompile(`(^|[ ,])cookie=([^, ]*)`)
+	// diffFlowCookieRegex matches the cookie of a flow printed by ovs-ofctl diff-flows
+	diffFlowCookieRegex = regexp.MustCompile(` cookie=(0x[0-9a-f]+)`)
+)
+
+const (
+	nodeDebugStatePath = metrics.NodeDebugPath + "state"
+	nodeDebugDriftPath = metrics.NodeDebugPath + "drift"
+)
+
+// nodeDebugState is the node plumbing ovnkube-node manages, keyed by owner
+type nodeDebugState struct {
+	// Flows of the gateway bridges, by bridge and flow cache key
+	Flows map[string]map[string]*flowsDebugState `json:"flows,omitempty"`
+	// UnownedFlows are the flows installed on the gateway bridges whose table, priority and match
+	// no flow of the flow caches has
+	UnownedFlows map[string][]string `json:"unownedFlows,omitempty"`
+	// Routes managed by the route manager, by routing table
+	Routes map[string][]netlinkDebugState `json:"routes,omitempty"`
+	// IPRules managed by the IP rule manager, by the metadata they were added with
+	IPRules map[string][]netlinkDebugState `json:"ipRules,omitempty"`
+	// NFTables elements of the ovn-kubernetes table, by set or map. ovnkube-node doesn't keep
+	// the nftables elements it wants, this is always the live state of the node.
+	NFTables map[string][]string `json:"nftables,omitempty"`
+	// Errors that prevented dumping parts of the state
+	Errors []string `json:"errors,omitempty"`
+}
+
+type flowsDebugState struct {
+	Flows []string `json:"flows"`
+	// LiveFlows are the installed versions of the flows of the key that differ from Flows, flows
+	// that are not installed at all have none
+	LiveFlows []string `json:"liveFlows,omitempty"`
+	InSync    *bool    `json:"inSync,omitempty"`
+}
+
+type netlinkDebugState struct {
+	Object    string `json:"object"`
+	Installed *bool  `json:"installed,omitempty"`
+}
+
+// nodeDebugHandler serves the node plumbing ovnkube-node manages: the state it wants on
+// NodeDebugPath/state, and the same state compared to the live state of the node on
+// NodeDebugPath/drift
+type nodeDebugHandler struct {
+	openflowManager *openflowManager
+	routeManager    *routemanager.Controller
+	ruleManager     *iprulemanager.Controller
+}
+
+// NewNodeDebugHandler creates the handler of the node debug endpoint. The rule manager is
+// optional.
+func NewNodeDebugHandler(gw Gateway, routeManager *routemanager.Controller, ruleManager *iprulemanager.Controller) http.Handler {
+	h := &nodeDebugHandler{
+		routeManager: routeManager,
+		ruleManager:  ruleManager,
+	}
+	if g, ok := gw.(*gateway); ok {
+		h.openflowManager = g.openflowManager
+	}
+	return h
+}
+
+func (h *nodeDebugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "unsupported http method", http.StatusMethodNotAllowed)
+		return
+	}
+	var drift bool
+	switch r.URL.Path {
+	case nodeDebugStatePath:
+	case nodeDebugDriftPath:
+		drift = true
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	state := h.getState(r.Context(), drift)
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(state); err != nil {
+		klog.Errorf("Failed to write node debug state: %v", err)
+	}
+}
+
+func (h *nodeDebugHandler) getState(ctx context.Context, drift bool) *nodeDebugState {
+	state := &nodeDebugState{}
+	if h.openflowManager != nil {
+		if err := h.openflowManager.getDebugState(state, drift); err != nil {
+			state.Errors = append(state.Errors, err.Error())
+		}
+	}
+	if h.routeManager != nil {
+		if err := h.getRoutesDebugState(state, drift); err != nil {
+			state.Errors = append(state.Errors, err.Error())
+		}
+	}
+	if h.ruleManager != nil {
+		if err := h.getIPRulesDebugState(state, drift); err != nil {
+			state.Errors = append(state.Errors, err.Error())
+		}
+	}
+	if err := getNFTablesDebugState(ctx, state); err != nil {
+		state.Errors = append(state.Errors, err.Error())
+	}
+	return state
+}
+
+func (h *nodeDebugHandler) getRoutesDebugState(state *nodeDebugState, drift bool) error {
+	routes, err := h.routeManager.List(drift)
+	if err != nil {
+		return fmt.Errorf("failed to list routes: %w", err)
+	}
+	state.Routes = map[string][]netlinkDebugState{}
+	for _, r := range routes {
+		table := fmt.Sprintf("table %d", r.Route.Table)
+		state.Routes[table] = append(state.Routes[table], netlinkDebugState{Object: r.Route.String(), Installed: r.Installed})
+	}
+	for _, routes := range state.Routes {
+		sortNetlinkDebugState(routes)
+	}
+	return nil
+}
+
+func (h *nodeDebugHandler) getIPRulesDebugState(state *nodeDebugState, drift bool) error {
+	rules, err := h.ruleManager.List(drift)
+	if err != nil {
+		return fmt.Errorf("failed to list IP rules: %w", err)
+	}
+	state.IPRules = map[string][]netlinkDebugState{}
+	for _, r := range rules {
+		state.IPRules[r.Metadata] = append(state.IPRules[r.Metadata], netlinkDebugState{Object: r.Rule.String(), Installed: r.Installed})
+	}
+	for _, rules := range state.IPRules {
+		sortNetlinkDebugState(rules)
+	}
+	return nil
+}
+
+func sortNetlinkDebugState(states []netlinkDebugState) {
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Object < states[j].Object
+	})
+}
+
+func getNFTablesDebugState(ctx context.Context, state *nodeDebugState) error {
+	nft, err := nodenft.GetNFTablesHelper()
+	if err != nil {
+		return fmt.Errorf("failed to get nftables helper: %w", err)
+	}
+	state.NFTables = map[string][]string{}
+	for _, objectType := range []string{"set", "map"} {
+		names, err := nft.List(ctx, objectType)
+		if err != nil {
+			return fmt.Errorf("failed to list nftables %ss: %w", objectType, err)
+		}
+		for _, name := range names {
+			elements, err := nft.ListElements(ctx, objectType, name)
+			if err != nil {
+				return fmt.Errorf("failed to list elements of nftables %s %s: %w", objectType, name, err)
+			}
+			dump := make([]string, 0, len(elements))
+			for _, element := range elements {
+				dump = append(dump, formatNFTElement(element))
+			}
+			sort.Strings(dump)
+			state.NFTables[objectType+" "+name] = dump
+		}
+	}
+	return nil
+}
+
+func formatNFTElement(element *knftables.Element) string {
+	s := strings.Join(element.Key, " . ")
+	if len(element.Value) > 0 {
+		s += " : " + strings.Join(element.Value, " . ")
+	}
+	if element.Comment != nil {
+		s += fmt.Sprintf(" comment %q", *element.Comment)
+	}
+	return s
+}
+
+// getDebugState adds the flows of the flow caches to the debug state. With drift set, the flows
+// of every key are compared to the installed flows with the same table, priority and match, once
+// both are normalized by ovs-ofctl.
+func (c *openflowManager) getDebugState(state *nodeDebugState, drift bool) error {
+	state.Flows = map[string]map[string]*flowsDebugState{}
+	if drift {
+		state.UnownedFlows = map[string][]string{}
+	}
+
+	var errs []error
+	c.flowMutex.Lock()
+	flowCache := maps.Clone(c.flowCache)
+	c.flowMutex.Unlock()
+	if err := addBridgeFlowsDebugState(state, c.defaultBridge.GetBridgeName(), flowCache, drift); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.externalGatewayBridge != nil {
+		c.exGWFlowMutex.Lock()
+		exGWFlowCache := maps.Clone(c.exGWFlowCache)
+		c.exGWFlowMutex.Unlock()
+		if err := addBridgeFlowsDebugState(state, c.externalGatewayBridge.GetBridgeName(), exGWFlowCache, drift); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to get the flows debug state: %v", errs)
+	}
+	return nil
+}
+
+func addBridgeFlowsDebugState(state *nodeDebugState, bridgeName string, flowCache map[string][]string, drift bool) error {
+	bridgeState := map[string]*flowsDebugState{}
+	state.Flows[bridgeName] = bridgeState
+	keys := make([]string, 0, len(flowCache))
+	for key, flows := range flowCache {
+		bridgeState[key] = &flowsDebugState{Flows: flows}
+		keys = append(keys, key)
+	}
+	if !drift {
+		return nil
+	}
+
+	// distinct flows of the cache, in a stable order, and the keys having them
+	sort.Strings(keys)
+	var flows []string
+	keysByFlow := map[string]sets.Set[string]{}
+	for _, key := range keys {
+		for _, flow := range flowCache[key] {
+			flow = strings.TrimSpace(flow)
+			if _, ok := keysByFlow[flow]; !ok {
+				flows = append(flows, flow)
+				keysByFlow[flow] = sets.New[string]()
+			}
+			keysByFlow[flow].Insert(key)
+		}
+	}
+
+	// tag every flow with its own cookie, so that all of them are printed by ovs-ofctl
+	// diff-flows and can be mapped back to the flow cache once normalized
+	taggedFlows := make([]string, 0, len(flows))
+	for i, flow := range flows {
+		taggedFlows = append(taggedFlows, setFlowCacheCookie(flow, fmt.Sprintf("0x%x", flowDebugCookieBase+uint64(i))))
+	}
+	diff, err := util.DiffOFFlows(bridgeName, taggedFlows)
+	if err != nil {
+		return err
+	}
+	wantedFlows := map[uint64]*diffFlow{}
+	liveFlows := map[string]*diffFlow{}
+	for _, line := range diff {
+		flow := parseDiffFlow(line[1:])
+		if line[0] == '-' {
+			wantedFlows[flow.cookie] = flow
+			continue
+		}
+		liveFlows[flow.id] = flow
+	}
+
+	for _, key := range keys {
+		inSync := true
+		bridgeState[key].InSync = &inSync
+	}
+	ownedFlowIDs := sets.New[string]()
+	for i, flow := range flows {
+		// a flow that is not printed has the same table, priority and match as another
+		// flow of the cache, which replaced it
+		wanted, ok := wantedFlows[flowDebugCookieBase+uint64(i)]
+		var live *diffFlow
+		if ok {
+			live = liveFlows[wanted.id]
+			ownedFlowIDs.Insert(wanted.id)
+		}
+		if live != nil && live.actions == wanted.actions && live.cookie == getFlowCookie(flow) {
+			continue
+		}
+		for key := range keysByFlow[flow] {
+			inSync := false
+			bridgeState[key].InSync = &inSync
+			if live != nil {
+				bridgeState[key].LiveFlows = append(bridgeState[key].LiveFlows, live.flow)
+			}
+		}
+	}
+	for id, live := range liveFlows {
+		if !ownedFlowIDs.Has(id) {
+			state.UnownedFlows[bridgeName] = append(state.UnownedFlows[bridgeName], live.flow)
+		}
+	}
+	sort.Strings(state.UnownedFlows[bridgeName])
+	return nil
+}
+
+// diffFlow is a flow printed by ovs-ofctl diff-flows
+type diffFlow struct {
+	flow string
+	// id is the table, priority, match and other fields of the flow but the cookie and actions
+	id      string
+	cookie  uint64
+	actions string
+}
+
+func parseDiffFlow(flow string) *diffFlow {
+	f := &diffFlow{flow: flow}
+	if match := diffFlowCookieRegex.FindStringSubmatch(flow); match != nil {
+		f.cookie, _ = strconv.ParseUint(match[1], 0, 64)
+		flow = diffFlowCookieRegex.ReplaceAllString(flow, "")
+	}
+	f.id, f.actions, _ = strings.Cut(flow, " actions=")
+	return f
+}
+
+// getFlowCookie returns the cookie of a flow of the flow cache, 0 if it has none
+func getFlowCookie(flow string) uint64 {
+	match, _, _ := strings.Cut(flow, "actions=")
+	if m := flowCacheCookieRegex.FindStringSubmatch(match); m != nil {
+		cookie, _ := strconv.ParseUint(m[2], 0, 64)
+		return cookie
+	}
+	return 0
+}
+
+// setFlowCacheCookie sets the cookie of a flow of the flow cache, replacing the cookie it was built
+// with if any
+func setFlowCacheCookie(flow, cookie string) string {
+	match, actions, found := strings.Cut(flow, "actions=")
+	if flowCacheCookieRegex.MatchString(match) {
+		match = flowCacheCookieRegex.ReplaceAllString(match, "${1}cookie="+cookie)
+	} else {
+		match = "cookie=" + cookie + ", " + match
+	}
+	if !found {
+		return match
+	}
+	return match + "actions=" + actions
+}
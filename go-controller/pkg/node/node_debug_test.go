@@ -0,0 +1,131 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	kexec "k8s.io/utils/exec"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/knftables"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/bridgeconfig"
+	nodenft "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/node/nftables"
+	ovntest "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func TestNodeDebugHandler(t *testing.T) {
+	tag := func(i uint64) string {
+		return fmt.Sprintf("0x%x", flowDebugCookieBase+i)
+	}
+	fexec := ovntest.NewFakeExec()
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd: "ovs-ofctl --no-names -O OpenFlow13 diff-flows /dev/stdin breth0",
+		Output: strings.Join([]string{
+			"-priority=1 cookie=" + tag(0) + " actions=drop",
+			"+priority=1 cookie=0xdeff105 actions=drop",
+			"-priority=2 cookie=" + tag(1) + " actions=drop",
+			"+priority=2 cookie=0xdeff105 actions=drop",
+			"-priority=100,tcp,tp_dst=30001 cookie=" + tag(2) + " actions=output:1",
+			"+priority=100,tcp,tp_dst=30001 cookie=0x1 actions=output:2",
+			"-priority=100,tcp,tp_dst=30002 cookie=" + tag(3) + " actions=output:1",
+			"-table=6 priority=110 cookie=" + tag(4) + " actions=LOCAL",
+			"+table=6 priority=110 cookie=0xe745ecf105 actions=LOCAL",
+			"-priority=100,tcp,tp_dst=30003 cookie=" + tag(5) + " actions=output:1",
+			"+priority=100,tcp,tp_dst=30003 cookie=0x3 actions=output:1",
+			"+priority=110,arp cookie=0x305 actions=drop",
+		}, "\n"),
+		Err: kexec.CodeExitError{Err: fmt.Errorf("exit status 2"), Code: 2},
+	})
+	if err := util.SetExec(fexec); err != nil {
+		t.Fatalf("Failed to set fake exec: %v", err)
+	}
+
+	nft := nodenft.SetFakeNFTablesHelper()
+	tx := nft.NewTransaction()
+	tx.Add(&knftables.Set{Name: "mgmtport-no-snat-nodeports", Type: "inet_proto . inet_service"})
+	tx.Add(&knftables.Element{Set: "mgmtport-no-snat-nodeports", Key: []string{"tcp", "30001"}, Comment: ptr.To("ns/svc")})
+	if err := nft.Run(context.Background(), tx); err != nil {
+		t.Fatalf("Failed to add nftables set: %v", err)
+	}
+
+	ofm := &openflowManager{
+		defaultBridge: bridgeconfig.TestBridgeConfig("breth0"),
+		flowCache: map[string][]string{
+			"DEFAULT":   {"cookie=0xdeff105, priority=1, actions=drop", "cookie=0xdeff105, priority=2, actions=drop"},
+			"NodePort1": {"cookie=0x1, priority=100, tcp, tp_dst=30001, actions=output:1"},
+			"NodePort2": {"cookie=0x2, priority=100, tcp, tp_dst=30002, actions=output:1", "cookie=0xe745ecf105, priority=110, table=6, actions=LOCAL"},
+			"NodePort3": {"cookie=0x3, priority=100, tcp, tp_dst=30003, actions=output:1", "cookie=0xe745ecf105, priority=110, table=6, actions=LOCAL"},
+		},
+	}
+	handler := &nodeDebugHandler{openflowManager: ofm}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, nodeDebugDriftPath, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status %d got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	state := &nodeDebugState{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), state); err != nil {
+		t.Fatalf("Failed to decode node debug state: %v", err)
+	}
+
+	inSync, outOfSync := true, false
+	expectedFlows := map[string]map[string]*flowsDebugState{
+		"breth0": {
+			"DEFAULT": {
+				Flows:  []string{"cookie=0xdeff105, priority=1, actions=drop", "cookie=0xdeff105, priority=2, actions=drop"},
+				InSync: &inSync,
+			},
+			// installed with other actions
+			"NodePort1": {
+				Flows:     []string{"cookie=0x1, priority=100, tcp, tp_dst=30001, actions=output:1"},
+				LiveFlows: []string{"priority=100,tcp,tp_dst=30001 cookie=0x1 actions=output:2"},
+				InSync:    &outOfSync,
+			},
+			// not installed
+			"NodePort2": {
+				Flows:  []string{"cookie=0x2, priority=100, tcp, tp_dst=30002, actions=output:1", "cookie=0xe745ecf105, priority=110, table=6, actions=LOCAL"},
+				InSync: &outOfSync,
+			},
+			// sharing a flow with NodePort2
+			"NodePort3": {
+				Flows:  []string{"cookie=0x3, priority=100, tcp, tp_dst=30003, actions=output:1", "cookie=0xe745ecf105, priority=110, table=6, actions=LOCAL"},
+				InSync: &inSync,
+			},
+		},
+	}
+	if !reflect.DeepEqual(state.Flows, expectedFlows) {
+		t.Errorf("Expected flows %+v got %+v", expectedFlows, state.Flows)
+	}
+	expectedUnownedFlows := map[string][]string{
+		"breth0": {"priority=110,arp cookie=0x305 actions=drop"},
+	}
+	if !reflect.DeepEqual(state.UnownedFlows, expectedUnownedFlows) {
+		t.Errorf("Expected unowned flows %v got %v", expectedUnownedFlows, state.UnownedFlows)
+	}
+	expectedNFTables := map[string][]string{
+		"set mgmtport-no-snat-nodeports": {`tcp . 30001 comment "ns/svc"`},
+	}
+	if !reflect.DeepEqual(state.NFTables, expectedNFTables) {
+		t.Errorf("Expected nftables %v got %v", expectedNFTables, state.NFTables)
+	}
+	if len(state.Errors) > 0 {
+		t.Errorf("Unexpected errors: %v", state.Errors)
+	}
+	if !fexec.CalledMatchesExpected() {
+		t.Error(fexec.ErrorDesc())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/node/unknown", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d got %d", http.StatusNotFound, recorder.Code)
+	}
+}
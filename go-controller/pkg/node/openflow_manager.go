@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"maps"
 	"net"
 	"os"
 	"regexp"
@@ -29,6 +30,10 @@ type openflowManager struct {
 	exGWFlowMutex sync.Mutex
 	// channel to indicate we need to update flows immediately
 	flowChan chan struct{}
+	// syncers of the flow caches to the bridges, created on the first sync
+	flowSyncMutex    sync.Mutex
+	flowSyncer       *bridgeFlowSyncer
+	exGWBridgeSyncer *bridgeFlowSyncer
 }
 
 // UTILs Needed for UDN (also leveraged for default netInfo) in openflowmanager
@@ -117,40 +122,28 @@ func (c *openflowManager) requestFlowSync() {
 	}
 }
 
+// syncFlows pushes the flows of the flow caches that changed since the previous sync to the bridges
 func (c *openflowManager) syncFlows() {
-	c.flowMutex.Lock()
-	flows := flattenFlowCacheEntries(c.flowCache)
-	c.flowMutex.Unlock()
+	c.flowSyncMutex.Lock()
+	defer c.flowSyncMutex.Unlock()
 
-	_, stderr, err := util.ReplaceOFFlows(c.defaultBridge.GetBridgeName(), flows)
-	if err != nil {
-		klog.Errorf("Failed to add flows for bridge %s, error: %v, stderr, %s, flow count: %d",
-			c.defaultBridge.GetBridgeName(), err, stderr, len(flows))
+	if c.flowSyncer == nil {
+		c.flowSyncer = newBridgeFlowSyncer(c.defaultBridge.GetBridgeName())
 	}
+	c.flowMutex.Lock()
+	flowCache := maps.Clone(c.flowCache)
+	c.flowMutex.Unlock()
+	c.flowSyncer.sync(flowCache)
 
 	if c.externalGatewayBridge != nil {
+		if c.exGWBridgeSyncer == nil {
+			c.exGWBridgeSyncer = newBridgeFlowSyncer(c.externalGatewayBridge.GetBridgeName())
+		}
 		c.exGWFlowMutex.Lock()
-		exGWFlows := flattenFlowCacheEntries(c.exGWFlowCache)
+		exGWFlowCache := maps.Clone(c.exGWFlowCache)
 		c.exGWFlowMutex.Unlock()
-
-		_, stderr, err := util.ReplaceOFFlows(c.externalGatewayBridge.GetBridgeName(), exGWFlows)
-		if err != nil {
-			klog.Errorf("Failed to add flows for bridge %s, error: %v, stderr, %s, flow count: %d",
-				c.externalGatewayBridge.GetBridgeName(), err, stderr, len(exGWFlows))
-		}
-	}
-}
-
-func flattenFlowCacheEntries(flowCache map[string][]string) []string {
-	flowCount := 0
-	for _, entry := range flowCache {
-		flowCount += len(entry)
-	}
-	flows := make([]string, 0, flowCount)
-	for _, entry := range flowCache {
-		flows = append(flows, entry...)
+		c.exGWBridgeSyncer.sync(exGWFlowCache)
 	}
-	return flows
 }
 
 // since we share the host's k8s node IP, add OpenFlow flows
@@ -0,0 +1,227 @@
+package node
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// fullFlowSyncPeriod is how often all the flows of a bridge are replaced, as a safety net
+	// against flows that were modified or removed behind the back of the openflow manager
+	fullFlowSyncPeriod = time.Minute
+
+	flowSyncTypeFull        = "full"
+	flowSyncTypeIncremental = "incremental"
+)
+
+// flowModAttributes are the fields of a flow that are not part of its match
+var flowModAttributes = sets.New("cookie", "idle_timeout", "hard_timeout", "importance", "send_flow_rem",
+	"check_overlap", "reset_counts", "no_packet_counts", "no_byte_counts", "out_port", "out_group")
+
+// bridgeFlowSyncer pushes the flows of a flow cache to an OVS bridge. Like OVS, the syncer identifies
+// flows by their table, priority and match, as normalized by ovs-ofctl so that equivalent flows
+// are the same, and only pushes the flows that changed since the previous sync, in a single OpenFlow
+// bundle. Flows keep the cookie they were built with, and a flow shared by several flow cache keys
+// stays on the bridge as long as one of the keys has it.
+// All the flows of the bridge are replaced on the first sync, after a failed sync, and every
+// fullFlowSyncPeriod.
+type bridgeFlowSyncer struct {
+	bridgeName string
+	// flows of the flow cache on the bridge, by flow cache key, nil if a full sync is needed
+	synced map[string][]string
+	// flows on the bridge as normalized by ovs-ofctl, by table, priority and match, nil until
+	// needed after a full sync
+	syncedFlows map[string]string
+	// flow cache keys the flow count metric is reported for
+	keys         sets.Set[string]
+	lastFullSync time.Time
+}
+
+func newBridgeFlowSyncer(bridgeName string) *bridgeFlowSyncer {
+	return &bridgeFlowSyncer{
+		bridgeName: bridgeName,
+		keys:       sets.New[string](),
+	}
+}
+
+// sync pushes the flows of a snapshot of the flow cache to the bridge
+func (s *bridgeFlowSyncer) sync(flowCache map[string][]string) {
+	start := time.Now()
+	syncType := flowSyncTypeIncremental
+	if s.synced == nil || start.Sub(s.lastFullSync) >= fullFlowSyncPeriod {
+		syncType = flowSyncTypeFull
+	}
+
+	var changedKeys []string
+	for key, keyFlows := range flowCache {
+		if synced, ok := s.synced[key]; !ok || !slices.Equal(synced, keyFlows) {
+			changedKeys = append(changedKeys, key)
+		}
+	}
+	var deletedKeys []string
+	for key := range s.synced {
+		if _, ok := flowCache[key]; !ok {
+			deletedKeys = append(deletedKeys, key)
+		}
+	}
+	if syncType == flowSyncTypeIncremental && len(changedKeys) == 0 && len(deletedKeys) == 0 {
+		return
+	}
+
+	var syncedFlows map[string]string
+	var err error
+	if syncType == flowSyncTypeFull {
+		err = s.fullSync(getFlows(flowCache))
+	} else {
+		syncedFlows, err = s.incrementalSync(flowCache)
+	}
+	if err != nil {
+		klog.Errorf("Failed to sync flows for bridge %s: %v", s.bridgeName, err)
+		// don't trust what is on the bridge anymore
+		s.synced = nil
+		s.syncedFlows = nil
+		return
+	}
+	if syncType == flowSyncTypeFull {
+		s.lastFullSync = start
+	}
+	s.synced = flowCache
+	s.syncedFlows = syncedFlows
+
+	for key := range s.keys {
+		if _, ok := flowCache[key]; !ok {
+			s.keys.Delete(key)
+			metrics.MetricGatewayOpenFlowFlows.DeleteLabelValues(s.bridgeName, key)
+		}
+	}
+	for _, key := range changedKeys {
+		s.keys.Insert(key)
+		metrics.MetricGatewayOpenFlowFlows.WithLabelValues(s.bridgeName, key).Set(float64(len(flowCache[key])))
+	}
+	duration := time.Since(start)
+	metrics.MetricGatewayOpenFlowSyncDuration.WithLabelValues(s.bridgeName, syncType).Observe(duration.Seconds())
+	if len(changedKeys) > 0 || len(deletedKeys) > 0 {
+		klog.V(5).Infof("Synced flows for bridge %s (%s): %d keys changed, %d keys deleted, took %v",
+			s.bridgeName, syncType, len(changedKeys), len(deletedKeys), duration)
+	}
+}
+
+// normalizeFlows returns the flows normalized by ovs-ofctl, by table, priority and match
+func (s *bridgeFlowSyncer) normalizeFlows(flows []string) (map[string]string, error) {
+	normalized, err := util.NormalizeOFFlows(flows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize flows, flow count: %d: %w", len(flows), err)
+	}
+	flowsByMatch := make(map[string]string, len(normalized))
+	for _, flow := range normalized {
+		flowsByMatch[getFlowMatch(flow)] = flow
+	}
+	return flowsByMatch, nil
+}
+
+// fullSync replaces all the flows of the bridge with the flows of the flow cache
+func (s *bridgeFlowSyncer) fullSync(flows []string) error {
+	_, stderr, err := util.ReplaceOFFlows(s.bridgeName, flows)
+	if err != nil {
+		return fmt.Errorf("failed to replace flows, stderr: %s, flow count: %d: %w", stderr, len(flows), err)
+	}
+	return nil
+}
+
+// incrementalSync pushes the flows that changed since the previous sync, and returns the
+// normalized flows of the flow cache
+func (s *bridgeFlowSyncer) incrementalSync(flowCache map[string][]string) (map[string]string, error) {
+	if s.syncedFlows == nil {
+		// the flows are only normalized once needed, after a full sync
+		syncedFlows, err := s.normalizeFlows(getFlows(s.synced))
+		if err != nil {
+			return nil, err
+		}
+		s.syncedFlows = syncedFlows
+	}
+	flows, err := s.normalizeFlows(getFlows(flowCache))
+	if err != nil {
+		return nil, err
+	}
+	flowMods := s.diffFlows(flows)
+	if len(flowMods) == 0 {
+		return flows, nil
+	}
+	_, stderr, err := util.ModifyOFFlows(s.bridgeName, flowMods)
+	if err != nil {
+		return nil, fmt.Errorf("failed to modify flows, stderr: %s, flow mod count: %d: %w", stderr, len(flowMods), err)
+	}
+	return flows, nil
+}
+
+// diffFlows returns the flow mods that bring the bridge from the previously synced flows to the
+// given flows, both normalized and by table, priority and match. Flows that are not wanted anymore
+// are strictly deleted, new and modified flows are added, which replaces the flow with the same
+// table, priority and match if any.
+func (s *bridgeFlowSyncer) diffFlows(flows map[string]string) []string {
+	var deleted, added []string
+	for match := range s.syncedFlows {
+		if _, ok := flows[match]; !ok {
+			deleted = append(deleted, match)
+		}
+	}
+	for match, flow := range flows {
+		if synced, ok := s.syncedFlows[match]; !ok || synced != flow {
+			added = append(added, match)
+		}
+	}
+	// sort the flows for the flow mods to be predictable
+	sort.Strings(deleted)
+	sort.Strings(added)
+
+	flowMods := make([]string, 0, len(deleted)+len(added))
+	for _, match := range deleted {
+		flowMods = append(flowMods, "delete_strict "+match)
+	}
+	for _, match := range added {
+		flowMods = append(flowMods, "add "+flows[match])
+	}
+	return flowMods
+}
+
+// getFlows returns the flows of a flow cache, in the lexical order of the keys. When flows of
+// different keys have the same table, priority and match, the flow of the last key is installed.
+func getFlows(flowCache map[string][]string) []string {
+	keys := make([]string, 0, len(flowCache))
+	count := 0
+	for key, keyFlows := range flowCache {
+		keys = append(keys, key)
+		count += len(keyFlows)
+	}
+	sort.Strings(keys)
+
+	flows := make([]string, 0, count)
+	for _, key := range keys {
+		flows = append(flows, flowCache[key]...)
+	}
+	return flows
+}
+
+// getFlowMatch returns the table, priority and match of a flow normalized by ovs-ofctl, which
+// identify the flow on a bridge, in a form that can be used to strictly delete it
+func getFlowMatch(flow string) string {
+	match, _, _ := strings.Cut(flow, "actions=")
+	fields := slices.DeleteFunc(strings.Fields(match), func(field string) bool {
+		name, _, _ := strings.Cut(field, "=")
+		return flowModAttributes.Has(name)
+	})
+	if len(fields) == 0 {
+		// the catch-all flow of table 0 with the default priority
+		return "table=0"
+	}
+	return strings.Join(fields, ",")
+}
@@ -0,0 +1,213 @@
+package node
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	ovntest "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func TestGetFlowMatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		flow     string
+		expected string
+	}{
+		{
+			name:     "flow of table 0 with the default priority",
+			flow:     "in_port=2 actions=drop",
+			expected: "in_port=2",
+		},
+		{
+			name:     "cookie and flow mod attributes are not part of the match",
+			flow:     "table=6 priority=10,ip,in_port=2 cookie=0xdeff105 idle_timeout=10 actions=NORMAL",
+			expected: "table=6,priority=10,ip,in_port=2",
+		},
+		{
+			name:     "catch-all flow",
+			flow:     "cookie=0xdeff105 actions=NORMAL",
+			expected: "table=0",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if match := getFlowMatch(tc.flow); match != tc.expected {
+				t.Errorf("Expected flow match %q got %q", tc.expected, match)
+			}
+		})
+	}
+}
+
+// getNormalizedFlows returns flows normalized by ovs-ofctl by table, priority and match
+func getNormalizedFlows(flows ...string) map[string]string {
+	flowsByMatch := map[string]string{}
+	for _, flow := range flows {
+		flowsByMatch[getFlowMatch(flow)] = flow
+	}
+	return flowsByMatch
+}
+
+func TestBridgeFlowSyncerDiffFlows(t *testing.T) {
+	testCases := []struct {
+		name     string
+		synced   map[string]string
+		flows    map[string]string
+		expected []string
+	}{
+		{
+			name: "changed flows are pushed with their own cookie",
+			synced: getNormalizedFlows(
+				"priority=1 cookie=0xdeff105 actions=drop",
+				"priority=100,tcp,tp_dst=30001 cookie=0x1 actions=output:1",
+				"priority=100,tcp,tp_dst=30002 cookie=0x2 actions=output:1",
+				"priority=100,tcp,tp_dst=30003 cookie=0x3 actions=output:1",
+			),
+			flows: getNormalizedFlows(
+				// unchanged
+				"priority=1 cookie=0xdeff105 actions=drop",
+				"priority=100,tcp,tp_dst=30001 cookie=0x1 actions=output:1",
+				// flow added
+				"priority=100,udp,tp_dst=30001 cookie=0x1 actions=output:1",
+				// flow modified
+				"priority=100,tcp,tp_dst=30002 cookie=0x2 actions=output:2",
+			),
+			expected: []string{
+				"delete_strict priority=100,tcp,tp_dst=30003",
+				"add priority=100,tcp,tp_dst=30002 cookie=0x2 actions=output:2",
+				"add priority=100,udp,tp_dst=30001 cookie=0x1 actions=output:1",
+			},
+		},
+		{
+			name: "flows of other tables are deleted from their table",
+			synced: getNormalizedFlows(
+				"table=6 priority=110 cookie=0xe745ecf105 actions=LOCAL",
+			),
+			flows: getNormalizedFlows(),
+			expected: []string{
+				"delete_strict table=6,priority=110",
+			},
+		},
+		{
+			name: "unchanged flows are not pushed",
+			synced: getNormalizedFlows(
+				"table=6 priority=110 cookie=0xe745ecf105 actions=LOCAL",
+			),
+			flows: getNormalizedFlows(
+				"table=6 priority=110 cookie=0xe745ecf105 actions=LOCAL",
+			),
+			expected: []string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newBridgeFlowSyncer("breth0")
+			s.syncedFlows = tc.synced
+			if flowMods := s.diffFlows(tc.flows); !reflect.DeepEqual(flowMods, tc.expected) {
+				t.Errorf("Expected flow mods %v got %v", tc.expected, flowMods)
+			}
+		})
+	}
+}
+
+func TestBridgeFlowSyncerSync(t *testing.T) {
+	const (
+		normalizeFlows = "ovs-ofctl --no-names -O OpenFlow13 diff-flows /dev/stdin /dev/null"
+		replaceFlows   = "ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -"
+		addFlows       = "ovs-ofctl -O OpenFlow13 --bundle add-flows breth0 -"
+	)
+	flowCache := map[string][]string{
+		"DEFAULT":   {"priority=1, actions=drop"},
+		"NodePort1": {"priority=100, tcp, tp_dst=30001, actions=output:1"},
+	}
+	normalizedFlows := "-priority=1 actions=drop\n-priority=100,tcp,tp_dst=30001 actions=output:1\n"
+	updatedFlowCache := map[string][]string{
+		"DEFAULT": {"priority=1, actions=drop"},
+	}
+	normalizedUpdatedFlows := "-priority=1 actions=drop\n"
+	// flows of different keys that ovs-ofctl normalizes to the same flow
+	sharedFlowCache := map[string][]string{
+		"NodePort1": {"priority=110, table=6, ip, nw_dst=10.0.0.1, actions=output:LOCAL"},
+		"NodePort2": {"table=6, priority=110, ip4, nw_dst=10.0.0.1, actions=LOCAL"},
+	}
+	updatedSharedFlowCache := map[string][]string{
+		"NodePort2": {"table=6, priority=110, ip4, nw_dst=10.0.0.1, actions=LOCAL"},
+	}
+	normalizedSharedFlows := "-table=6 priority=110,ip,nw_dst=10.0.0.1 actions=LOCAL\n"
+
+	testCases := []struct {
+		name     string
+		syncs    []map[string][]string
+		expected []*ovntest.ExpectedCmd
+	}{
+		{
+			name:     "first sync replaces all the flows",
+			syncs:    []map[string][]string{flowCache},
+			expected: []*ovntest.ExpectedCmd{{Cmd: replaceFlows}},
+		},
+		{
+			name:     "unchanged flows are not synced again",
+			syncs:    []map[string][]string{flowCache, flowCache},
+			expected: []*ovntest.ExpectedCmd{{Cmd: replaceFlows}},
+		},
+		{
+			name:  "changed flows are synced incrementally",
+			syncs: []map[string][]string{flowCache, updatedFlowCache, flowCache},
+			expected: []*ovntest.ExpectedCmd{
+				{Cmd: replaceFlows},
+				// the flows of the full sync are normalized once needed
+				{Cmd: normalizeFlows, Output: normalizedFlows},
+				{Cmd: normalizeFlows, Output: normalizedUpdatedFlows},
+				{Cmd: addFlows},
+				{Cmd: normalizeFlows, Output: normalizedFlows},
+				{Cmd: addFlows},
+			},
+		},
+		{
+			name:  "equivalent flows of a deleted key are kept for the other keys",
+			syncs: []map[string][]string{sharedFlowCache, updatedSharedFlowCache},
+			expected: []*ovntest.ExpectedCmd{
+				{Cmd: replaceFlows},
+				{Cmd: normalizeFlows, Output: normalizedSharedFlows},
+				{Cmd: normalizeFlows, Output: normalizedSharedFlows},
+			},
+		},
+		{
+			name:  "failed incremental sync is followed by a full sync",
+			syncs: []map[string][]string{flowCache, updatedFlowCache, updatedFlowCache},
+			expected: []*ovntest.ExpectedCmd{
+				{Cmd: replaceFlows},
+				{Cmd: normalizeFlows, Output: normalizedFlows},
+				{Cmd: normalizeFlows, Output: normalizedUpdatedFlows},
+				{Cmd: addFlows, Err: fmt.Errorf("exit status 1")},
+				{Cmd: replaceFlows},
+			},
+		},
+		{
+			name:  "failed normalization is followed by a full sync",
+			syncs: []map[string][]string{flowCache, updatedFlowCache, updatedFlowCache},
+			expected: []*ovntest.ExpectedCmd{
+				{Cmd: replaceFlows},
+				{Cmd: normalizeFlows, Err: fmt.Errorf("exit status 1")},
+				{Cmd: replaceFlows},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fexec := ovntest.NewFakeExec()
+			fexec.AddFakeCmds(tc.expected)
+			if err := util.SetExec(fexec); err != nil {
+				t.Fatalf("Failed to set fake exec: %v", err)
+			}
+			s := newBridgeFlowSyncer("breth0")
+			for _, flowCache := range tc.syncs {
+				s.sync(flowCache)
+			}
+			if !fexec.CalledMatchesExpected() {
+				t.Error(fexec.ErrorDesc())
+			}
+		})
+	}
+}
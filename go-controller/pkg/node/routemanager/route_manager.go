@@ -96,6 +96,47 @@ func (c *Controller) Del(r netlink.Route) error {
 	return c.delRoute(&r)
 }
 
+// RouteState is a route managed by the route manager
+type RouteState struct {
+	Route netlink.Route
+	// Installed tells if the route is installed on the node as wanted, only
+	// set when requested
+	Installed *bool
+}
+
+// List returns the managed routes. If checkInstalled is set, every route is
+// also checked against the routes installed on the node.
+func (c *Controller) List(checkInstalled bool) ([]RouteState, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	var existingByKey map[key][]*netlink.Route
+	if checkInstalled {
+		filter := &netlink.Route{}
+		existing, err := util.GetNetLinkOps().RouteListFiltered(netlink.FAMILY_ALL, filter, netlink.RT_FILTER_TABLE)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list routes: %w", err)
+		}
+		existingByKey = map[key][]*netlink.Route{}
+		for i := range existing {
+			key := keyFromNetlink(&existing[i])
+			existingByKey[key] = append(existingByKey[key], &existing[i])
+		}
+	}
+
+	routes := make([]RouteState, 0, len(c.store))
+	for key, wants := range c.store {
+		state := RouteState{Route: *wants}
+		if checkInstalled {
+			existing := existingByKey[key]
+			installed := len(existing) == 1 && routePartiallyEqualWantedToExisting(wants, existing[0])
+			state.Installed = &installed
+		}
+		routes = append(routes, state)
+	}
+	return routes, nil
+}
+
 // addRoute attempts to add the route and returns with error
 // if it fails to do so.
 func (c *Controller) addRoute(r *netlink.Route) error {
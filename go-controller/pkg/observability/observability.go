@@ -2,19 +2,27 @@ package observability
 
 import (
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
 	"github.com/ovn-kubernetes/libovsdb/ovsdb"
 
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/controller"
+	networkobservabilityapi "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1"
+	networkobservabilityinformer "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/informers/externalversions/networkobservability/v1"
 	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util/batching"
 )
 
 // OVN observ app IDs. Make sure to always add new apps in the end.
@@ -24,24 +32,42 @@ const (
 	ACLEstTrafficSamplingID
 )
 
-// temporary const, until we have dynamic config
+// DefaultObservabilityCollectorSetID is used to sample all features when no NetworkObservability exists.
 const DefaultObservabilityCollectorSetID = 42
 
 // this is inferred from nbdb schema, check Sample_Collector.id
 const maxCollectorID = 255
 const collectorFeaturesExternalID = "sample-features"
 
+// collectorOwnerExternalID stores the name of the NetworkObservability a collector was created for.
+const collectorOwnerExternalID = "network-observability"
+
+// aclSampleUpdateBatchSize limits the number of ACLs updated in one transaction on config changes.
+const aclSampleUpdateBatchSize = 500
+
 // collectorConfig holds the configuration for a collector.
 // It is allowed to set different probabilities for every feature.
 // collectorSetID is used to set up sampling via OVSDB.
 type collectorConfig struct {
+	// name of the NetworkObservability this config is built from, empty for the default config.
+	name           string
 	collectorSetID int
 	// probability in percent, 0 to 100
 	featuresProbability map[libovsdbops.SampleFeature]int
+	// scope limits sampled objects, nil means everything is sampled.
+	scope *libovsdbops.SampleCollectorScope
 }
 
 type Manager struct {
-	nbClient       libovsdbclient.Client
+	nbClient libovsdbclient.Client
+	// observInformer is nil when NetworkObservabilities are not watched, the default config is used then.
+	observInformer networkobservabilityinformer.NetworkObservabilityInformer
+	controller     controller.Controller
+	// configLock serializes config updates
+	configLock sync.Mutex
+	// appliedConfigs are the last configs that were successfully applied
+	appliedConfigs []*collectorConfig
+	sampConfigLock sync.RWMutex
 	sampConfig     *libovsdbops.SamplingConfig
 	collectorsLock sync.Mutex
 	// nbdb Collectors have probability. To allow different probabilities for different features,
@@ -54,29 +80,151 @@ type Manager struct {
 	unusedCollectors              map[string]int
 	unusedCollectorsRetryInterval time.Duration
 	collectorsCleanupRetries      int
+	collectorsCleanupPending      atomic.Bool
 	// Only maxCollectorID collectors are allowed, each should have unique ID.
 	// this set is tracking already assigned IDs.
 	takenCollectorIDs sets.Set[int]
 }
 
-func NewManager(nbClient libovsdbclient.Client) *Manager {
-	return &Manager{
+// NewManager creates an observability Manager. If observInformer is nil, the default config is always used,
+// otherwise the config is built from NetworkObservabilities and updated on every change.
+func NewManager(nbClient libovsdbclient.Client, observInformer networkobservabilityinformer.NetworkObservabilityInformer) *Manager {
+	m := &Manager{
 		nbClient:                      nbClient,
+		observInformer:                observInformer,
 		collectorsLock:                sync.Mutex{},
 		dbCollectors:                  make(map[string]string),
 		unusedCollectors:              make(map[string]int),
 		unusedCollectorsRetryInterval: time.Minute,
 		takenCollectorIDs:             sets.New[int](),
 	}
+	if observInformer != nil {
+		m.controller = controller.NewController[networkobservabilityapi.NetworkObservability](
+			"observability-manager",
+			&controller.ControllerConfig[networkobservabilityapi.NetworkObservability]{
+				RateLimiter:    workqueue.DefaultTypedControllerRateLimiter[string](),
+				Informer:       observInformer.Informer(),
+				Lister:         observInformer.Lister().List,
+				Reconcile:      m.reconcile,
+				ObjNeedsUpdate: networkObservabilityNeedsUpdate,
+				Threadiness:    1,
+			},
+		)
+	}
+	return m
 }
 
 func (m *Manager) SamplingConfig() *libovsdbops.SamplingConfig {
+	m.sampConfigLock.RLock()
+	defer m.sampConfigLock.RUnlock()
 	return m.sampConfig
 }
 
+func (m *Manager) setSamplingConfig(c *libovsdbops.SamplingConfig) {
+	m.sampConfigLock.Lock()
+	defer m.sampConfigLock.Unlock()
+	m.sampConfig = c
+}
+
 func (m *Manager) Init() error {
-	// this will be read from the kube-api in the future
-	currentConfig := &collectorConfig{
+	configs, err := m.getCollectorConfigs()
+	if err != nil {
+		return err
+	}
+	if err = m.initWithConfigs(configs); err != nil {
+		return err
+	}
+	if m.controller != nil {
+		return controller.Start(m.controller)
+	}
+	return nil
+}
+
+// Stop stops watching NetworkObservabilities.
+func (m *Manager) Stop() {
+	if m.controller != nil {
+		controller.Stop(m.controller)
+	}
+}
+
+func (m *Manager) initWithConfig(config *collectorConfig) error {
+	return m.initWithConfigs([]*collectorConfig{config})
+}
+
+func (m *Manager) initWithConfigs(configs []*collectorConfig) error {
+	if err := m.setSamplingAppIDs(); err != nil {
+		return err
+	}
+	m.configLock.Lock()
+	defer m.configLock.Unlock()
+	// existing ACLs will be updated by their controllers on startup
+	return m.applyConfigs(configs)
+}
+
+// applyConfigs creates collectors for the given configs, updates the SamplingConfig and
+// schedules cleanup of the collectors that are not used anymore.
+// Must be called with configLock held.
+func (m *Manager) applyConfigs(configs []*collectorConfig) error {
+	if err := m.setDbCollectors(); err != nil {
+		return err
+	}
+
+	featureCollectors := make(map[libovsdbops.SampleFeature][]string)
+	collectorScopes := make(map[string]*libovsdbops.SampleCollectorScope)
+	for _, conf := range configs {
+		featuresConfig, err := m.addCollector(conf)
+		if err != nil {
+			return fmt.Errorf("failed to add collectors for %s: %w", conf, err)
+		}
+		for feature, collectors := range featuresConfig {
+			featureCollectors[feature] = append(featureCollectors[feature], collectors...)
+			if conf.scope == nil {
+				continue
+			}
+			for _, collector := range collectors {
+				collectorScopes[collector] = conf.scope
+			}
+		}
+	}
+	for _, collectors := range featureCollectors {
+		// ensure predictable Sample collectors
+		slices.Sort(collectors)
+	}
+	m.setSamplingConfig(libovsdbops.NewSamplingConfig(featureCollectors, collectorScopes))
+	m.appliedConfigs = configs
+
+	// now cleanup stale collectors
+	if m.collectorsCleanupPending.CompareAndSwap(false, true) {
+		m.deleteStaleCollectorsWithRetry()
+	}
+	return nil
+}
+
+// getCollectorConfigs returns a config for every NetworkObservability, or the default config
+// if none exist.
+func (m *Manager) getCollectorConfigs() ([]*collectorConfig, error) {
+	if m.observInformer == nil {
+		return []*collectorConfig{getDefaultCollectorConfig()}, nil
+	}
+	observs, err := m.observInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NetworkObservabilities: %w", err)
+	}
+	if len(observs) == 0 {
+		return []*collectorConfig{getDefaultCollectorConfig()}, nil
+	}
+	configs := make([]*collectorConfig, 0, len(observs))
+	for _, observ := range observs {
+		configs = append(configs, newCollectorConfig(observ))
+	}
+	slices.SortFunc(configs, func(a, b *collectorConfig) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return configs, nil
+}
+
+func getDefaultCollectorConfig() *collectorConfig {
+	return &collectorConfig{
 		collectorSetID: DefaultObservabilityCollectorSetID,
 		featuresProbability: map[libovsdbops.SampleFeature]int{
 			libovsdbops.EgressFirewallSample:     100,
@@ -86,27 +234,106 @@ func (m *Manager) Init() error {
 			libovsdbops.UDNIsolationSample:       100,
 		},
 	}
+}
+
+func newCollectorConfig(observ *networkobservabilityapi.NetworkObservability) *collectorConfig {
+	conf := &collectorConfig{
+		name:                observ.Name,
+		collectorSetID:      int(observ.Spec.CollectorSetID),
+		featuresProbability: make(map[libovsdbops.SampleFeature]int, len(observ.Spec.Features)),
+	}
+	for _, feature := range observ.Spec.Features {
+		conf.featuresProbability[string(feature.Name)] = int(feature.Probability)
+	}
+	if len(observ.Spec.Namespaces) > 0 || len(observ.Spec.Networks) > 0 {
+		conf.scope = &libovsdbops.SampleCollectorScope{
+			Namespaces: sets.New(observ.Spec.Namespaces...),
+			Networks:   sets.New(observ.Spec.Networks...),
+		}
+	}
+	return conf
+}
 
-	return m.initWithConfig(currentConfig)
+func (c *collectorConfig) String() string {
+	if c.name == "" {
+		return "default observability config"
+	}
+	return "NetworkObservability " + c.name
 }
 
-func (m *Manager) initWithConfig(config *collectorConfig) error {
-	if err := m.setSamplingAppIDs(); err != nil {
+func networkObservabilityNeedsUpdate(oldObj, newObj *networkobservabilityapi.NetworkObservability) bool {
+	if oldObj == nil || newObj == nil {
+		return true
+	}
+	return !reflect.DeepEqual(oldObj.Spec, newObj.Spec)
+}
+
+// reconcile applies the config built from all existing NetworkObservabilities, every change results in
+// a full resync, since collectors and samples are shared between NetworkObservabilities.
+func (m *Manager) reconcile(key string) error {
+	startTime := time.Now()
+	klog.V(5).Infof("Observability manager reconciling NetworkObservability %s", key)
+	defer func() {
+		klog.V(5).Infof("Observability manager finished reconciling NetworkObservability %s, took %v", key, time.Since(startTime))
+	}()
+
+	configs, err := m.getCollectorConfigs()
+	if err != nil {
 		return err
 	}
-	if err := m.setDbCollectors(); err != nil {
+	m.configLock.Lock()
+	defer m.configLock.Unlock()
+	if reflect.DeepEqual(configs, m.appliedConfigs) {
+		return nil
+	}
+	if err = m.applyConfigs(configs); err != nil {
 		return err
 	}
+	// network controllers only update samples when they update their ACLs, update existing ACLs now.
+	return m.updateACLSamples()
+}
 
-	featuresConfig, err := m.addCollector(config)
+// updateACLSamples updates samples of all sampled ACLs to match the current SamplingConfig.
+func (m *Manager) updateACLSamples() error {
+	acls, err := libovsdbops.FindACLsWithPredicate(m.nbClient, func(acl *nbdb.ACL) bool {
+		return acl.SampleNew != nil || acl.SampleEst != nil || libovsdbops.GetACLSampleFeature(acl) != ""
+	})
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to find sampled ACLs: %w", err)
+	}
+	if len(acls) == 0 {
+		return nil
+	}
+	// ACLs may be owned by a different controller than the network they apply to (e.g. EgressFirewall ACLs),
+	// use the network of the port group the ACL is applied to.
+	aclNetworks := make(map[string]string, len(acls))
+	portGroups, err := libovsdbops.FindPortGroupsWithPredicate(m.nbClient, func(pg *nbdb.PortGroup) bool {
+		return len(pg.ACLs) > 0 && pg.ExternalIDs[libovsdbops.OwnerControllerKey.String()] != ""
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find port groups: %w", err)
+	}
+	for _, pg := range portGroups {
+		network := libovsdbops.GetOwnerControllerNetwork(pg.ExternalIDs)
+		for _, aclUUID := range pg.ACLs {
+			aclNetworks[aclUUID] = network
+		}
 	}
-	m.sampConfig = libovsdbops.NewSamplingConfig(featuresConfig)
 
-	// now cleanup stale collectors
-	m.deleteStaleCollectorsWithRetry()
-	return nil
+	samplingConfig := m.SamplingConfig()
+	return batching.Batch[*nbdb.ACL](aclSampleUpdateBatchSize, acls, func(batchACLs []*nbdb.ACL) error {
+		var ops []ovsdb.Operation
+		for _, acl := range batchACLs {
+			// don't modify the cache
+			acl = acl.DeepCopy()
+			ops, err = libovsdbops.UpdateACLsSamplesOps(m.nbClient, ops, samplingConfig.ForNetwork(aclNetworks[acl.UUID]), acl)
+			if err != nil {
+				return fmt.Errorf("failed to update samples for ACL %s: %w", acl.UUID, err)
+			}
+		}
+		_, err = libovsdbops.TransactAndCheck(m.nbClient, ops)
+		return err
+	})
 }
 
 func (m *Manager) setDbCollectors() error {
@@ -118,7 +345,7 @@ func (m *Manager) setDbCollectors() error {
 		return fmt.Errorf("error getting sample collectors: %w", err)
 	}
 	for _, collector := range collectors {
-		collectorKey := getCollectorKey(collector.SetID, collector.Probability)
+		collectorKey := getCollectorKey(collector.ExternalIDs[collectorOwnerExternalID], collector.SetID, collector.Probability)
 		m.dbCollectors[collectorKey] = collector.UUID
 		m.takenCollectorIDs.Insert(collector.ID)
 		// all collectors are unused, until we update existing configs
@@ -138,12 +365,14 @@ func (m *Manager) deleteStaleCollectorsWithRetry() {
 		if m.collectorsCleanupRetries > 60 {
 			m.collectorsCleanupRetries = 0
 			klog.Errorf("Cleanup stale collectors failed after 30 retries: %v", err)
+			m.collectorsCleanupPending.Store(false)
 			return
 		}
 		time.AfterFunc(m.unusedCollectorsRetryInterval, m.deleteStaleCollectorsWithRetry)
 		return
 	}
 	m.collectorsCleanupRetries = 0
+	m.collectorsCleanupPending.Store(false)
 	klog.Infof("Cleanup stale collectors succeeded.")
 }
 
@@ -231,8 +460,13 @@ func groupByProbability(c *collectorConfig) map[int][]libovsdbops.SampleFeature
 	return probabilities
 }
 
-func getCollectorKey(collectorID int, probability int) string {
-	return fmt.Sprintf("%d-%d", collectorID, probability)
+// getCollectorKey returns a key for a collector. Collectors of different NetworkObservabilities are not
+// shared, as they may have different scopes.
+func getCollectorKey(owner string, collectorID int, probability int) string {
+	if owner == "" {
+		return fmt.Sprintf("%d-%d", collectorID, probability)
+	}
+	return fmt.Sprintf("%s-%d-%d", owner, collectorID, probability)
 }
 
 func (m *Manager) getFreeCollectorID() (int, error) {
@@ -251,12 +485,18 @@ func (m *Manager) addCollector(conf *collectorConfig) (map[libovsdbops.SampleFea
 	probabilityConfig := groupByProbability(conf)
 
 	for probability, features := range probabilityConfig {
-		collectorKey := getCollectorKey(conf.collectorSetID, probability)
+		collectorKey := getCollectorKey(conf.name, conf.collectorSetID, probability)
 		var collectorUUID string
 		var ok bool
 		// ensure predictable externalID
 		slices.Sort(features)
 		collectorFeatures := strings.Join(features, ",")
+		externalIDs := map[string]string{
+			collectorFeaturesExternalID: collectorFeatures,
+		}
+		if conf.name != "" {
+			externalIDs[collectorOwnerExternalID] = conf.name
+		}
 		if collectorUUID, ok = m.dbCollectors[collectorKey]; !ok {
 			collectorID, err := m.getFreeCollectorID()
 			if err != nil {
@@ -266,9 +506,7 @@ func (m *Manager) addCollector(conf *collectorConfig) (map[libovsdbops.SampleFea
 				ID:          collectorID,
 				SetID:       conf.collectorSetID,
 				Probability: probability,
-				ExternalIDs: map[string]string{
-					collectorFeaturesExternalID: collectorFeatures,
-				},
+				ExternalIDs: externalIDs,
 			}
 			err = libovsdbops.CreateOrUpdateSampleCollector(m.nbClient, collector)
 			if err != nil {
@@ -280,10 +518,8 @@ func (m *Manager) addCollector(conf *collectorConfig) (map[libovsdbops.SampleFea
 		} else {
 			// update collector's features
 			collector := &nbdb.SampleCollector{
-				UUID: collectorUUID,
-				ExternalIDs: map[string]string{
-					collectorFeaturesExternalID: collectorFeatures,
-				},
+				UUID:        collectorUUID,
+				ExternalIDs: externalIDs,
 			}
 			err := libovsdbops.UpdateSampleCollectorExternalIDs(m.nbClient, collector)
 			if err != nil {
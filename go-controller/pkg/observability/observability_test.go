@@ -1,11 +1,18 @@
 package observability
 
 import (
+	"context"
 	"strings"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
 
+	networkobservabilityapi "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1"
+	networkobservabilityfake "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/clientset/versioned/fake"
+	networkobservabilityinformerfactory "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/informers/externalversions"
 	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
 	libovsdbtest "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
@@ -30,7 +37,7 @@ var _ = Describe("Observability Manager", func() {
 		nbClient, _, libovsdbCleanup, err = libovsdbtest.NewNBSBTestHarness(libovsdbtest.TestSetup{
 			NBData: data})
 		Expect(err).NotTo(HaveOccurred())
-		manager = NewManager(nbClient)
+		manager = NewManager(nbClient, nil)
 		err = manager.Init()
 		Expect(err).NotTo(HaveOccurred())
 	}
@@ -259,7 +266,7 @@ var _ = Describe("Observability Manager", func() {
 			nbClient, _, libovsdbCleanup, err = libovsdbtest.NewNBSBTestHarness(libovsdbtest.TestSetup{
 				NBData: data})
 			Expect(err).NotTo(HaveOccurred())
-			manager = NewManager(nbClient)
+			manager = NewManager(nbClient, nil)
 			// tweak retry interval for testing
 			manager.unusedCollectorsRetryInterval = time.Second
 			err = manager.initWithConfig(config)
@@ -373,4 +380,272 @@ var _ = Describe("Observability Manager", func() {
 			Eventually(nbClient, 2*manager.unusedCollectorsRetryInterval).Should(libovsdbtest.HaveData(expectedDB))
 		})
 	})
+
+	When("scoped config is used", func() {
+		startManagerWithConfigs := func(data []libovsdbtest.TestData, configs ...*collectorConfig) {
+			var err error
+			nbClient, _, libovsdbCleanup, err = libovsdbtest.NewNBSBTestHarness(libovsdbtest.TestSetup{
+				NBData: data})
+			Expect(err).NotTo(HaveOccurred())
+			manager = NewManager(nbClient, nil)
+			err = manager.initWithConfigs(configs)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		newNetpolACL := func(namespace, controller string) *nbdb.ACL {
+			return &nbdb.ACL{
+				UUID: "acl-" + namespace + "-uuid",
+				ExternalIDs: map[string]string{
+					libovsdbops.OwnerTypeKey.String():       libovsdbops.NetworkPolicyOwnerType,
+					libovsdbops.OwnerControllerKey.String(): controller,
+					libovsdbops.ObjectNameKey.String():      libovsdbops.BuildNamespaceNameKey(namespace, "policy"),
+					// ensure unique sample IDs
+					libovsdbops.PrimaryIDKey.String(): namespace,
+				},
+			}
+		}
+
+		// createACL creates an ACL referenced by a port group, since ACLs are garbage collected otherwise
+		createACL := func(samplingConfig *libovsdbops.SamplingConfig, acl *nbdb.ACL) {
+			ops, err := libovsdbops.CreateOrUpdateACLsOps(nbClient, nil, samplingConfig, acl)
+			Expect(err).NotTo(HaveOccurred())
+			pg := &nbdb.PortGroup{
+				Name: acl.UUID,
+				ACLs: []string{acl.UUID},
+			}
+			ops, err = libovsdbops.CreateOrUpdatePortGroupsOps(nbClient, ops, pg)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = libovsdbops.TransactAndCheck(nbClient, ops)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		getACLCollectors := func(acl *nbdb.ACL) []string {
+			acls, err := libovsdbops.FindACLs(nbClient, []*nbdb.ACL{acl})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acls).To(HaveLen(1))
+			if acls[0].SampleNew == nil {
+				return nil
+			}
+			sample, err := libovsdbops.GetSample(nbClient, &nbdb.Sample{UUID: *acls[0].SampleNew})
+			Expect(err).NotTo(HaveOccurred())
+			return sample.Collectors
+		}
+
+		getCollectorUUID := func(owner string) string {
+			collectors, err := libovsdbops.FindSampleCollectorWithPredicate(nbClient, func(collector *nbdb.SampleCollector) bool {
+				return collector.ExternalIDs[collectorOwnerExternalID] == owner
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collectors).To(HaveLen(1))
+			return collectors[0].UUID
+		}
+
+		It("should only sample ACLs in the configured namespaces", func() {
+			startManagerWithConfigs(samplingApps, &collectorConfig{
+				name:           "scoped",
+				collectorSetID: 10,
+				featuresProbability: map[libovsdbops.SampleFeature]int{
+					libovsdbops.NetworkPolicySample: 100,
+				},
+				scope: &libovsdbops.SampleCollectorScope{
+					Namespaces: sets.New("ns1"),
+				},
+			})
+			collector := getCollectorUUID("scoped")
+
+			acl1 := newNetpolACL("ns1", "default-network-controller")
+			createACL(manager.SamplingConfig(), acl1)
+			acl2 := newNetpolACL("ns2", "default-network-controller")
+			createACL(manager.SamplingConfig(), acl2)
+
+			Expect(getACLCollectors(acl1)).To(Equal([]string{collector}))
+			Expect(getACLCollectors(acl2)).To(BeEmpty())
+		})
+
+		It("should only sample ACLs in the configured networks", func() {
+			startManagerWithConfigs(samplingApps, &collectorConfig{
+				name:           "scoped",
+				collectorSetID: 10,
+				featuresProbability: map[libovsdbops.SampleFeature]int{
+					libovsdbops.NetworkPolicySample: 100,
+				},
+				scope: &libovsdbops.SampleCollectorScope{
+					Networks: sets.New("blue"),
+				},
+			})
+			collector := getCollectorUUID("scoped")
+
+			acl1 := newNetpolACL("ns1", "blue-network-controller")
+			createACL(manager.SamplingConfig(), acl1)
+			acl2 := newNetpolACL("ns2", "default-network-controller")
+			createACL(manager.SamplingConfig(), acl2)
+			// network set explicitly overrides the ACL owner controller
+			acl3 := newNetpolACL("ns3", "default-network-controller")
+			createACL(manager.SamplingConfig().ForNetwork("blue"), acl3)
+
+			Expect(getACLCollectors(acl1)).To(Equal([]string{collector}))
+			Expect(getACLCollectors(acl2)).To(BeEmpty())
+			Expect(getACLCollectors(acl3)).To(Equal([]string{collector}))
+		})
+
+		It("should sample an ACL to collectors of every matching config", func() {
+			startManagerWithConfigs(samplingApps,
+				&collectorConfig{
+					name:           "all",
+					collectorSetID: 10,
+					featuresProbability: map[libovsdbops.SampleFeature]int{
+						libovsdbops.NetworkPolicySample: 100,
+					},
+				},
+				&collectorConfig{
+					name:           "scoped",
+					collectorSetID: 11,
+					featuresProbability: map[libovsdbops.SampleFeature]int{
+						libovsdbops.NetworkPolicySample: 100,
+					},
+					scope: &libovsdbops.SampleCollectorScope{
+						Namespaces: sets.New("ns1"),
+					},
+				},
+			)
+			allCollector := getCollectorUUID("all")
+			scopedCollector := getCollectorUUID("scoped")
+
+			acl1 := newNetpolACL("ns1", "default-network-controller")
+			createACL(manager.SamplingConfig(), acl1)
+			acl2 := newNetpolACL("ns2", "default-network-controller")
+			createACL(manager.SamplingConfig(), acl2)
+
+			Expect(getACLCollectors(acl1)).To(ConsistOf(allCollector, scopedCollector))
+			Expect(getACLCollectors(acl2)).To(Equal([]string{allCollector}))
+		})
+	})
+
+	When("NetworkObservabilities are watched", func() {
+		var (
+			fakeClient *networkobservabilityfake.Clientset
+			stopChan   chan struct{}
+		)
+
+		startManagerWithInformer := func(data []libovsdbtest.TestData, objects ...*networkobservabilityapi.NetworkObservability) {
+			var err error
+			nbClient, _, libovsdbCleanup, err = libovsdbtest.NewNBSBTestHarness(libovsdbtest.TestSetup{
+				NBData: data})
+			Expect(err).NotTo(HaveOccurred())
+			fakeClient = networkobservabilityfake.NewSimpleClientset()
+			for _, object := range objects {
+				_, err = fakeClient.K8sV1().NetworkObservabilities().Create(context.Background(), object, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			informerFactory := networkobservabilityinformerfactory.NewSharedInformerFactory(fakeClient, 0)
+			manager = NewManager(nbClient, informerFactory.K8s().V1().NetworkObservabilities())
+			manager.unusedCollectorsRetryInterval = time.Second
+			stopChan = make(chan struct{})
+			informerFactory.Start(stopChan)
+			informerFactory.WaitForCacheSync(stopChan)
+			err = manager.Init()
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		newNetworkObservability := func(name string, collectorSetID int64, namespaces ...string) *networkobservabilityapi.NetworkObservability {
+			return &networkobservabilityapi.NetworkObservability{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: networkobservabilityapi.NetworkObservabilitySpec{
+					CollectorSetID: collectorSetID,
+					Features: []networkobservabilityapi.SamplingFeature{
+						{
+							Name:        networkobservabilityapi.NetworkPolicyFeature,
+							Probability: 100,
+						},
+					},
+					Namespaces: namespaces,
+				},
+			}
+		}
+
+		AfterEach(func() {
+			manager.Stop()
+			close(stopChan)
+		})
+
+		It("should use NetworkObservabilities instead of the default config", func() {
+			startManagerWithInformer(initialDB, newNetworkObservability("observ", 10))
+			expectedDB := append(samplingApps,
+				&nbdb.SampleCollector{
+					UUID:        collectorUUID + "-2",
+					ID:          2,
+					SetID:       10,
+					Probability: 65535,
+					ExternalIDs: map[string]string{
+						collectorFeaturesExternalID: libovsdbops.NetworkPolicySample,
+						collectorOwnerExternalID:    "observ",
+					},
+				},
+			)
+			Eventually(nbClient).Should(libovsdbtest.HaveData(expectedDB))
+		})
+
+		It("should update existing ACL samples on NetworkObservability changes", func() {
+			acl := &nbdb.ACL{
+				UUID: "acl-uuid",
+				ExternalIDs: map[string]string{
+					libovsdbops.OwnerTypeKey.String():       libovsdbops.NetworkPolicyOwnerType,
+					libovsdbops.OwnerControllerKey.String(): "default-network-controller",
+					libovsdbops.ObjectNameKey.String():      libovsdbops.BuildNamespaceNameKey("ns1", "policy"),
+				},
+			}
+			pg := &nbdb.PortGroup{
+				UUID: "pg-uuid",
+				ACLs: []string{acl.UUID},
+			}
+			defaultSample := &nbdb.Sample{
+				UUID:       "sample-uuid",
+				Metadata:   int(libovsdbops.GetACLSampleID(acl)),
+				Collectors: []string{collectorUUID},
+			}
+			acl.SampleNew = &defaultSample.UUID
+			acl.SampleEst = &defaultSample.UUID
+			startManagerWithInformer(append(initialDB, defaultSample, acl, pg))
+			Eventually(nbClient).Should(libovsdbtest.HaveData(append(initialDB, defaultSample, acl, pg)))
+
+			// NetworkObservability for a different namespace, ACL is not sampled anymore
+			observ := newNetworkObservability("observ", 10, "ns2")
+			_, err := fakeClient.K8sV1().NetworkObservabilities().Create(context.Background(), observ, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			observCollector := &nbdb.SampleCollector{
+				UUID:        collectorUUID + "-2",
+				ID:          2,
+				SetID:       10,
+				Probability: 65535,
+				ExternalIDs: map[string]string{
+					collectorFeaturesExternalID: libovsdbops.NetworkPolicySample,
+					collectorOwnerExternalID:    "observ",
+				},
+			}
+			unsampledACL := acl.DeepCopy()
+			unsampledACL.SampleNew = nil
+			unsampledACL.SampleEst = nil
+			// default collector is removed after the sample is deleted
+			Eventually(nbClient, 2*manager.unusedCollectorsRetryInterval).Should(
+				libovsdbtest.HaveData(append(samplingApps, observCollector, unsampledACL, pg)))
+
+			// update NetworkObservability to include the ACL namespace
+			observ.Spec.Namespaces = []string{"ns1", "ns2"}
+			_, err = fakeClient.K8sV1().NetworkObservabilities().Update(context.Background(), observ, metav1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			observSample := &nbdb.Sample{
+				UUID:       "sample-uuid",
+				Metadata:   int(libovsdbops.GetACLSampleID(acl)),
+				Collectors: []string{observCollector.UUID},
+			}
+			Eventually(nbClient).Should(
+				libovsdbtest.HaveData(append(samplingApps, observCollector, observSample, acl, pg)))
+
+			// delete NetworkObservability, default config is used again
+			err = fakeClient.K8sV1().NetworkObservabilities().Delete(context.Background(), observ.Name, metav1.DeleteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(nbClient, 2*manager.unusedCollectorsRetryInterval).Should(
+				libovsdbtest.HaveData(append(initialDB, defaultSample, acl, pg)))
+		})
+	})
 })
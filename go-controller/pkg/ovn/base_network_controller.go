@@ -136,6 +136,13 @@ type BaseNetworkController struct {
 	namespaces      map[string]*namespaceInfo
 	namespacesMutex sync.Mutex
 
+	// multicastGroups maps the name of a multicast group to the multicast
+	// enabled namespaces that are members of the group. multicastGroupsMutex is
+	// locked while holding the lock of a namespace info, namespace infos should
+	// never be locked while holding multicastGroupsMutex.
+	multicastGroups      map[string]sets.Set[string]
+	multicastGroupsMutex sync.Mutex
+
 	// An address set factory that creates address sets
 	addressSetFactory addressset.AddressSetFactory
 
@@ -2,14 +2,18 @@ package ovn
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	addressset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
 )
 
 type defaultMcastACLTypeID string
@@ -45,27 +49,47 @@ func getMulticastACLMatch() string {
 
 // Allow IGMP traffic (e.g., IGMP queries) and namespace multicast traffic
 // towards pods.
-func getMulticastACLIgrMatchV4(addrSetName string) string {
-	return "(igmp || (ip4.src == $" + addrSetName + " && ip4.mcast))"
+func getMulticastACLIgrMatchV4(addrSetNames ...string) string {
+	return "(igmp || (ip4.src == " + getAddressSetsMatch(addrSetNames) + " && ip4.mcast))"
 }
 
 // Allow MLD traffic (e.g., MLD queries) and namespace multicast traffic
 // towards pods.
-func getMulticastACLIgrMatchV6(addrSetName string) string {
-	return "(mldv1 || mldv2 || (ip6.src == $" + addrSetName + " && " + ipv6DynamicMulticastMatch + "))"
+func getMulticastACLIgrMatchV6(addrSetNames ...string) string {
+	return "(mldv1 || mldv2 || (ip6.src == " + getAddressSetsMatch(addrSetNames) + " && " + ipv6DynamicMulticastMatch + "))"
+}
+
+// getAddressSetsMatch returns the reference to a single address set, or the
+// set of references to multiple address sets.
+func getAddressSetsMatch(addrSetNames []string) string {
+	refs := make([]string, 0, len(addrSetNames))
+	for _, name := range addrSetNames {
+		refs = append(refs, "$"+name)
+	}
+	if len(refs) == 1 {
+		return refs[0]
+	}
+	return "{" + strings.Join(refs, ", ") + "}"
 }
 
 // Creates the match string used for ACLs allowing incoming multicast into a
-// namespace, that is, from IPs that are in the namespace's address set.
-func (bnc *BaseNetworkController) getMulticastACLIgrMatch(nsInfo *namespaceInfo) string {
+// namespace, that is, from IPs that are in the address sets of the source
+// namespaces.
+func (bnc *BaseNetworkController) getMulticastACLIgrMatch(sourceNamespaces []string) string {
 	var ipv4Match, ipv6Match string
-	addrSetNameV4, addrSetNameV6 := nsInfo.addressSet.GetASHashNames()
+	addrSetNamesV4 := make([]string, 0, len(sourceNamespaces))
+	addrSetNamesV6 := make([]string, 0, len(sourceNamespaces))
+	for _, ns := range sourceNamespaces {
+		addrSetNameV4, addrSetNameV6 := addressset.GetHashNamesForAS(getNamespaceAddrSetDbIDs(ns, bnc.controllerName))
+		addrSetNamesV4 = append(addrSetNamesV4, addrSetNameV4)
+		addrSetNamesV6 = append(addrSetNamesV6, addrSetNameV6)
+	}
 	ipv4Mode, ipv6Mode := bnc.IPMode()
 	if ipv4Mode {
-		ipv4Match = getMulticastACLIgrMatchV4(addrSetNameV4)
+		ipv4Match = getMulticastACLIgrMatchV4(addrSetNamesV4...)
 	}
 	if ipv6Mode {
-		ipv6Match = getMulticastACLIgrMatchV6(addrSetNameV6)
+		ipv6Match = getMulticastACLIgrMatchV6(addrSetNamesV6...)
 	}
 	return getACLMatchAF(ipv4Match, ipv6Match, ipv4Mode, ipv6Mode)
 }
@@ -110,9 +134,10 @@ func getNamespaceMcastACLDbIDs(ns string, aclDir libovsdbutil.ACLDirection, cont
 //   - one "from-lport" ACL allowing egress multicast traffic from the pods
 //     in 'ns'
 //   - one "to-lport" ACL allowing ingress multicast traffic to pods in 'ns'.
-//     This matches only traffic originated by pods in 'ns' (based on the
-//     namespace address set).
-func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *namespaceInfo) error {
+//     This matches only traffic originated by pods in 'sourceNamespaces'
+//     (based on the namespace address sets), that is 'ns' and the other
+//     members of its multicast group.
+func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, sourceNamespaces []string) error {
 	portGroupName := bnc.getNamespacePortGroupName(ns)
 
 	aclDir := libovsdbutil.ACLEgress
@@ -122,7 +147,7 @@ func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *
 	egressACL := libovsdbutil.BuildACLWithDefaultTier(dbIDs, types.DefaultMcastAllowPriority, egressMatch, nbdb.ACLActionAllow, nil, aclPipeline)
 
 	aclDir = libovsdbutil.ACLIngress
-	ingressMatch := libovsdbutil.GetACLMatch(portGroupName, bnc.getMulticastACLIgrMatch(nsInfo), aclDir)
+	ingressMatch := libovsdbutil.GetACLMatch(portGroupName, bnc.getMulticastACLIgrMatch(sourceNamespaces), aclDir)
 	dbIDs = getNamespaceMcastACLDbIDs(ns, aclDir, bnc.controllerName)
 	aclPipeline = libovsdbutil.ACLDirectionToACLPipeline(aclDir)
 	ingressACL := libovsdbutil.BuildACLWithDefaultTier(dbIDs, types.DefaultMcastAllowPriority, ingressMatch, nbdb.ACLActionAllow, nil, aclPipeline)
@@ -146,6 +171,64 @@ func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *
 	return nil
 }
 
+// getMulticastSourceNamespaces returns the namespaces allowed to send
+// multicast traffic to 'ns': 'ns' itself and the members of its multicast
+// group. Caller must hold multicastGroupsMutex.
+func (bnc *BaseNetworkController) getMulticastSourceNamespaces(ns, group string) []string {
+	if group == "" {
+		return []string{ns}
+	}
+	return sets.List(bnc.multicastGroups[group].Clone().Insert(ns))
+}
+
+// joinMulticastGroup adds 'ns' to the members of 'group'.
+// Caller must hold multicastGroupsMutex.
+func (bnc *BaseNetworkController) joinMulticastGroup(ns, group string) {
+	if group == "" {
+		return
+	}
+	if bnc.multicastGroups == nil {
+		bnc.multicastGroups = map[string]sets.Set[string]{}
+	}
+	if bnc.multicastGroups[group] == nil {
+		bnc.multicastGroups[group] = sets.New[string]()
+	}
+	bnc.multicastGroups[group].Insert(ns)
+}
+
+// leaveMulticastGroup removes 'ns' from the members of 'group'.
+// Caller must hold multicastGroupsMutex.
+func (bnc *BaseNetworkController) leaveMulticastGroup(ns, group string) {
+	members := bnc.multicastGroups[group]
+	if members == nil {
+		return
+	}
+	members.Delete(ns)
+	if members.Len() == 0 {
+		delete(bnc.multicastGroups, group)
+	}
+}
+
+// updateMulticastGroupPolicies updates the multicast allow policies of the
+// members of 'groups' other than 'ns', after 'ns' joined or left them.
+// Caller must hold multicastGroupsMutex.
+func (bnc *BaseNetworkController) updateMulticastGroupPolicies(ns string, groups ...string) error {
+	for _, group := range sets.List(sets.New(groups...)) {
+		if group == "" {
+			continue
+		}
+		for _, member := range sets.List(bnc.multicastGroups[group]) {
+			if member == ns {
+				continue
+			}
+			if err := bnc.createMulticastAllowPolicy(member, bnc.getMulticastSourceNamespaces(member, group)); err != nil {
+				return fmt.Errorf("failed to update multicast allow policy of namespace %s in group %s: %w", member, group, err)
+			}
+		}
+	}
+	return nil
+}
+
 func (bnc *BaseNetworkController) deleteMulticastAllowPolicy(ns string) error {
 	portGroupName := bnc.getNamespacePortGroupName(ns)
 
@@ -273,6 +356,14 @@ func (bnc *BaseNetworkController) disableMulticast() error {
 	return nil
 }
 
+// isUserDefinedNetworkMulticastSupported returns whether multicast is supported
+// on a user defined network: only primary networks support it, when enabled
+// globally and unless the network opted out.
+func isUserDefinedNetworkMulticastSupported(netInfo util.NetInfo) bool {
+	return netInfo.IsPrimaryNetwork() && util.IsNetworkSegmentationSupportEnabled() && config.EnableMulticast &&
+		netInfo.Multicast() != types.NetworkMulticastDisabled
+}
+
 // syncNsMulticast finds and deletes stale multicast db entries for namespaces that don't exist anymore
 // or have multicast disabled
 func (bnc *BaseNetworkController) syncNsMulticast(k8sNamespaces map[string]bool) error {
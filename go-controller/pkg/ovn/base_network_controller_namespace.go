@@ -56,6 +56,9 @@ type namespaceInfo struct {
 	routingExternalPodGWs map[string]gatewayInfo
 
 	multicastEnabled bool
+	// multicastGroup is the multicast group the namespace is a member of, only
+	// set if multicast is enabled.
+	multicastGroup string
 
 	// If not empty, then it has to be set to a logging a severity level, e.g. "notice", "alert", etc
 	aclLogging libovsdbutil.ACLLoggingLevels
@@ -207,40 +210,62 @@ func (bnc *BaseNetworkController) syncNamespaces(namespaces []interface{}) error
 }
 
 // Creates an explicit "allow" policy for multicast traffic within the
-// namespace if multicast is enabled. Otherwise, removes the "allow" policy.
-// Traffic will be dropped by the default multicast deny ACL.
+// namespace, and its multicast group if any, if multicast is enabled.
+// Otherwise, removes the "allow" policy. Traffic will be dropped by the
+// default multicast deny ACL.
 func (bnc *BaseNetworkController) multicastUpdateNamespace(ns *corev1.Namespace, nsInfo *namespaceInfo) error {
 	if !bnc.multicastSupport {
 		return nil
 	}
 
 	enabled := isNamespaceMulticastEnabled(ns.Annotations)
-	enabledOld := nsInfo.multicastEnabled
-	if enabledOld == enabled {
+	var group string
+	if enabled {
+		group = getNamespaceMulticastGroup(ns.Annotations)
+	}
+	if nsInfo.multicastEnabled == enabled && nsInfo.multicastGroup == group {
 		return nil
 	}
 
+	bnc.multicastGroupsMutex.Lock()
+	defer bnc.multicastGroupsMutex.Unlock()
+	oldGroup := nsInfo.multicastGroup
+	bnc.leaveMulticastGroup(ns.Name, oldGroup)
+	bnc.joinMulticastGroup(ns.Name, group)
+
 	var err error
-	nsInfo.multicastEnabled = enabled
 	if enabled {
-		err = bnc.createMulticastAllowPolicy(ns.Name, nsInfo)
+		err = bnc.createMulticastAllowPolicy(ns.Name, bnc.getMulticastSourceNamespaces(ns.Name, group))
 	} else {
 		err = bnc.deleteMulticastAllowPolicy(ns.Name)
 	}
 	if err != nil {
 		return err
 	}
-	return nil
+	nsInfo.multicastEnabled = enabled
+	nsInfo.multicastGroup = group
+
+	// the other members of the old and new groups have to allow (or stop
+	// allowing) the multicast traffic of this namespace
+	return bnc.updateMulticastGroupPolicies(ns.Name, oldGroup, group)
 }
 
 // Cleans up the multicast policy for this namespace if multicast was
 // previously allowed.
 func (bnc *BaseNetworkController) multicastDeleteNamespace(ns *corev1.Namespace, nsInfo *namespaceInfo) error {
 	if nsInfo.multicastEnabled {
-		nsInfo.multicastEnabled = false
+		bnc.multicastGroupsMutex.Lock()
+		defer bnc.multicastGroupsMutex.Unlock()
+		group := nsInfo.multicastGroup
+		bnc.leaveMulticastGroup(ns.Name, group)
 		if err := bnc.deleteMulticastAllowPolicy(ns.Name); err != nil {
 			return err
 		}
+		nsInfo.multicastEnabled = false
+		nsInfo.multicastGroup = ""
+		if err := bnc.updateMulticastGroupPolicies(ns.Name, group); err != nil {
+			return err
+		}
 	}
 	return nil
 }
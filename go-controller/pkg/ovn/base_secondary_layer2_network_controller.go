@@ -231,6 +231,24 @@ func (oc *BaseLayer2UserDefinedNetworkController) initializeLogicalSwitch(switch
 		acls = getDenyARPAndNSOnMACVRF(oc.controllerName, macvrfportName, nodeLRPMAC, gwIfAddrv4, gwIfAddrv6)
 	}
 
+	// If supported, enable IGMP/MLD snooping and querier on the network switch,
+	// unless already configured for EVPN.
+	if oc.multicastSupport && oc.Transport() != types.NetworkTransportEVPN {
+		logicalSwitch.OtherConfig["mcast_snoop"] = "true"
+		if gwIfAddrv4 != nil || gwIfAddrv6 != nil {
+			logicalSwitch.OtherConfig["mcast_querier"] = "true"
+			logicalSwitch.OtherConfig["mcast_eth_src"] = nodeLRPMAC.String()
+			if gwIfAddrv4 != nil {
+				logicalSwitch.OtherConfig["mcast_ip4_src"] = gwIfAddrv4.IP.String()
+			}
+			if gwIfAddrv6 != nil {
+				logicalSwitch.OtherConfig["mcast_ip6_src"] = util.HWAddrToIPv6LLA(nodeLRPMAC).String()
+			}
+		} else {
+			logicalSwitch.OtherConfig["mcast_querier"] = "false"
+		}
+	}
+
 	if clusterLoadBalancerGroupUUID != "" && switchLoadBalancerGroupUUID != "" {
 		logicalSwitch.LoadBalancerGroup = []string{clusterLoadBalancerGroupUUID, switchLoadBalancerGroupUUID}
 	}
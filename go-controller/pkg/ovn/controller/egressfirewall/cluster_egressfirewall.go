@@ -0,0 +1,359 @@
+package egressfirewall
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-kubernetes/libovsdb/ovsdb"
+
+	egressfirewallapi "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	egressfirewallapply "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/applyconfiguration/egressfirewall/v1"
+	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+const (
+	ClusterEgressFirewallAppliedCorrectly = "ClusterEgressFirewall Rules applied"
+	// clusterEgressFirewallMaxRules is the max number of rules per ClusterEgressFirewall, every priority
+	// gets a range of ACL priorities of this size:
+	// ClusterEgressFirewallBeforeStartPriority - 99 * clusterEgressFirewallMaxRules - (clusterEgressFirewallMaxRules - 1) > EgressFirewallStartPriority
+	// EgressFirewallStartPriority - 99 * clusterEgressFirewallMaxRules - (clusterEgressFirewallMaxRules - 1) > MinimumReservedEgressFirewallPriority
+	clusterEgressFirewallMaxRules = 80
+)
+
+// clusterCacheEntry stores the rendered state of a ClusterEgressFirewall
+type clusterCacheEntry struct {
+	// namespace: port group the namespace ACLs are attached to
+	pgNames         map[string]string
+	hasNodeSelector bool
+}
+
+func (oc *EFController) getClusterEgressFirewallACLDbIDs(cefName, namespace string, ruleIdx int) *libovsdbops.DbObjectIDs {
+	return libovsdbops.NewDbObjectIDs(libovsdbops.ACLClusterEgressFirewall, defaultControllerOwner,
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.ObjectNameKey: cefName,
+			libovsdbops.NamespaceKey:  namespace,
+			libovsdbops.RuleIndex:     strconv.Itoa(ruleIdx),
+		})
+}
+
+func (oc *EFController) getClusterEgressFirewallACLDbIDsNoRule(cefName string) *libovsdbops.DbObjectIDs {
+	return libovsdbops.NewDbObjectIDs(libovsdbops.ACLClusterEgressFirewall, defaultControllerOwner,
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.ObjectNameKey: cefName,
+		})
+}
+
+// getClusterEgressFirewallACLTier returns the ACL tier and the start priority for the ClusterEgressFirewall placement.
+// Rules placed before EgressFirewall share its tier with higher priorities, rules placed after EgressFirewall
+// use the BANP tier with priorities higher than BANP rules.
+func getClusterEgressFirewallACLTier(placement egressfirewallapi.ClusterEgressFirewallPlacement) (int, int) {
+	if placement == egressfirewallapi.ClusterEgressFirewallAfterEgressFirewall {
+		return types.ClusterEgressFirewallAfterACLTier, types.EgressFirewallStartPriority
+	}
+	return types.ClusterEgressFirewallBeforeACLTier, types.ClusterEgressFirewallBeforeStartPriority
+}
+
+// syncClusterEgressFirewalls removes ACLs for ClusterEgressFirewalls that don't exist anymore.
+func (oc *EFController) syncClusterEgressFirewalls() error {
+	cefs, err := oc.cefLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("%s: failed to list cluster egress firewalls: %w", oc.name, err)
+	}
+	existingCEFs := sets.New[string]()
+	for _, cef := range cefs {
+		existingCEFs.Insert(cef.Name)
+	}
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLClusterEgressFirewall, defaultControllerOwner, nil)
+	p := libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, func(acl *nbdb.ACL) bool {
+		return !existingCEFs.Has(acl.ExternalIDs[libovsdbops.ObjectNameKey.String()])
+	})
+	staleACLs, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return fmt.Errorf("cannot find stale cluster egress firewall ACLs: %w", err)
+	}
+	if len(staleACLs) == 0 {
+		return nil
+	}
+	if err = libovsdbops.DeleteACLsFromAllPortGroups(oc.nbClient, staleACLs...); err != nil {
+		return fmt.Errorf("failed to delete stale cluster egress firewall ACLs: %w", err)
+	}
+	return nil
+}
+
+func (oc *EFController) syncClusterEgressFirewall(key string) (updateErr error) {
+	klog.V(5).Infof("Syncing ClusterEgressFirewall %s", key)
+	oc.cefCache.LockKey(key)
+	defer oc.cefCache.UnlockKey(key)
+
+	cef, err := oc.cefLister.Get(key)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		klog.Infof("Removing cluster egress firewall %s", key)
+		p := libovsdbops.GetPredicate[*nbdb.ACL](oc.getClusterEgressFirewallACLDbIDsNoRule(key), nil)
+		acls, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+		if err != nil {
+			return fmt.Errorf("error finding ACLs for cluster egress firewall %s: %w", key, err)
+		}
+		if err := libovsdbops.DeleteACLsFromAllPortGroups(oc.nbClient, acls...); err != nil {
+			return fmt.Errorf("error deleting ACLs for cluster egress firewall %s: %w", key, err)
+		}
+		oc.cefCache.Delete(key)
+		if err := oc.dnsNameResolver.Delete(util.GetClusterEgressFirewallDNSOwner(key)); err != nil {
+			return err
+		}
+		return oc.dnsNameResolver.DeleteStaleAddrSets(oc.nbClient)
+	}
+
+	defer func() {
+		if statusErr := oc.setClusterEgressFirewallStatus(cef, updateErr); statusErr != nil {
+			updateErr = utilerrors.Join(updateErr, fmt.Errorf("failed to update cluster egress firewall status %s, error: %w",
+				cef.Name, statusErr))
+		}
+	}()
+
+	existingEntry, _ := oc.cefCache.Load(key)
+	newEntry, failedNamespaces, updateErr := oc.addClusterEgressFirewall(cef)
+	if newEntry == nil {
+		return updateErr
+	}
+	oc.cefCache.Store(key, newEntry)
+
+	// remove ACLs for rules and namespaces that are not selected anymore, keep the ones for namespaces
+	// that failed to be updated.
+	p := libovsdbops.GetPredicate[*nbdb.ACL](oc.getClusterEgressFirewallACLDbIDsNoRule(key), func(acl *nbdb.ACL) bool {
+		namespace := acl.ExternalIDs[libovsdbops.NamespaceKey.String()]
+		if failedNamespaces.Has(namespace) {
+			return false
+		}
+		if _, selected := newEntry.pgNames[namespace]; !selected {
+			return true
+		}
+		ruleIdx, err := strconv.Atoi(acl.ExternalIDs[libovsdbops.RuleIndex.String()])
+		return err != nil || ruleIdx >= len(cef.Spec.Egress)
+	})
+	staleACLs, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return utilerrors.Join(updateErr, fmt.Errorf("error finding ACLs for cluster egress firewall %s: %w", key, err))
+	}
+	if err := libovsdbops.DeleteACLsFromAllPortGroups(oc.nbClient, staleACLs...); err != nil {
+		return utilerrors.Join(updateErr, fmt.Errorf("error deleting stale ACLs for cluster egress firewall %s: %w", key, err))
+	}
+
+	// If the port group of a namespace changed, remove the ACLs from the previous port group.
+	if existingEntry != nil {
+		for namespace, pgName := range existingEntry.pgNames {
+			newPGName, selected := newEntry.pgNames[namespace]
+			if !selected || newPGName == pgName {
+				continue
+			}
+			p := libovsdbops.GetPredicate[*nbdb.ACL](oc.getClusterEgressFirewallACLDbIDsNoRule(key), func(acl *nbdb.ACL) bool {
+				return acl.ExternalIDs[libovsdbops.NamespaceKey.String()] == namespace
+			})
+			acls, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+			if err != nil {
+				updateErr = utilerrors.Join(updateErr, fmt.Errorf("error finding ACLs for cluster egress firewall %s in namespace %s: %w",
+					key, namespace, err))
+			} else if err := libovsdbops.DeleteACLsFromPortGroups(oc.nbClient, []string{pgName}, acls...); err != nil {
+				updateErr = utilerrors.Join(updateErr, fmt.Errorf("error deleting stale ACL refs for cluster egress firewall %s in namespace %s: %w",
+					key, namespace, err))
+			}
+		}
+	}
+
+	// Clean up any DNS address sets that are no longer referenced by ACLs.
+	if err := oc.dnsNameResolver.DeleteStaleAddrSets(oc.nbClient); err != nil {
+		updateErr = utilerrors.Join(updateErr, fmt.Errorf("error deleting stale DNS address sets for cluster egress firewall %s: %w",
+			key, err))
+	}
+	return updateErr
+}
+
+// addClusterEgressFirewall creates or updates ACLs for every namespace selected by the given ClusterEgressFirewall.
+// It returns the rendered state, and the namespaces that failed to be updated.
+// A nil cache entry is returned if nothing was applied.
+func (oc *EFController) addClusterEgressFirewall(cef *egressfirewallapi.ClusterEgressFirewall) (*clusterCacheEntry, sets.Set[string], error) {
+	klog.Infof("Adding cluster egress firewall %s", cef.Name)
+	if len(cef.Spec.Egress) > clusterEgressFirewallMaxRules {
+		return nil, nil, fmt.Errorf("clusterEgressFirewall %s has too many rules, max allowed number is %d",
+			cef.Name, clusterEgressFirewallMaxRules)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&cef.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+	namespaces, err := oc.namespaceLister.List(selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	dnsOwner := util.GetClusterEgressFirewallDNSOwner(cef.Name)
+	tier, startPriority := getClusterEgressFirewallACLTier(cef.Spec.Placement)
+	startPriority -= int(cef.Spec.Priority) * clusterEgressFirewallMaxRules
+	entry := &clusterCacheEntry{
+		pgNames: map[string]string{},
+	}
+	failedNamespaces := sets.New[string]()
+	var ops []ovsdb.Operation
+	var errorList []error
+	var hasDNS bool
+	for _, namespace := range namespaces {
+		activeNetwork, err := oc.networkManager.GetActiveNetworkForNamespace(namespace.Name)
+		if err != nil {
+			errorList = append(errorList, err)
+			failedNamespaces.Insert(namespace.Name)
+			continue
+		}
+		if activeNetwork == nil {
+			// No active network for this namespace in this controller context
+			continue
+		}
+		ownerController := activeNetwork.GetNetworkName() + "-network-controller"
+		nsEntry := &cacheEntry{
+			pgName:  libovsdbutil.GetPortGroupName(getNamespacePortGroupDbIDs(namespace.Name, ownerController)),
+			subnets: subnetsForNetInfo(activeNetwork),
+		}
+		aclLogging := parseACLLogging(namespace.Annotations[util.AclLoggingAnnotation])
+		nsOps, nsHasDNS, err := oc.getClusterEgressFirewallNamespaceOps(cef, namespace.Name, nsEntry, dnsOwner, startPriority, tier, aclLogging)
+		if err != nil {
+			errorList = append(errorList, fmt.Errorf("cannot create cluster egress firewall rules for namespace %s: %w", namespace.Name, err))
+			failedNamespaces.Insert(namespace.Name)
+			continue
+		}
+		ops = append(ops, nsOps...)
+		hasDNS = hasDNS || nsHasDNS
+		entry.hasNodeSelector = entry.hasNodeSelector || nsEntry.hasNodeSelector
+		entry.pgNames[namespace.Name] = nsEntry.pgName
+	}
+
+	if !hasDNS && len(failedNamespaces) == 0 {
+		if err := oc.dnsNameResolver.Delete(dnsOwner); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if _, err := libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return nil, nil, fmt.Errorf("failed to configure cluster egress firewall %s: %w", cef.Name, err)
+	}
+	return entry, failedNamespaces, utilerrors.Join(errorList...)
+}
+
+// getClusterEgressFirewallNamespaceOps returns the ops to create the ACLs of a ClusterEgressFirewall for a single namespace.
+func (oc *EFController) getClusterEgressFirewallNamespaceOps(cef *egressfirewallapi.ClusterEgressFirewall, namespace string,
+	nsEntry *cacheEntry, dnsOwner string, startPriority, tier int, aclLogging *libovsdbutil.ACLLoggingLevels) ([]ovsdb.Operation, bool, error) {
+	var ops []ovsdb.Operation
+	var hasDNS bool
+	for i, rawRule := range cef.Spec.Egress {
+		rule, err := oc.newEgressFirewallRule(namespace, rawRule, i, nsEntry)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(rule.to.dnsName) > 0 {
+			hasDNS = true
+		}
+		matchTargets, err := oc.getRuleMatchTargets(dnsOwner, rule)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(matchTargets) == 0 {
+			// the ACL will be cleaned up as stale
+			klog.Warningf("Cluster egress firewall %s rule %d has no destination...ignoring", cef.Name, i)
+			continue
+		}
+		acl := libovsdbutil.BuildACL(
+			oc.getClusterEgressFirewallACLDbIDs(cef.Name, namespace, i),
+			startPriority-i,
+			generateMatch(nsEntry.pgName, matchTargets, rule.ports),
+			getACLAction(rule.access),
+			aclLogging,
+			// since egressFirewall has direction to-lport, set type to ingress
+			libovsdbutil.LportIngress,
+			tier,
+		)
+		ops, err = oc.createEgressFirewallACLOps(ops, acl, nsEntry.pgName)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return ops, hasDNS, nil
+}
+
+func (oc *EFController) setClusterEgressFirewallStatus(cef *egressfirewallapi.ClusterEgressFirewall, handlerErr error) error {
+	var newMsg string
+	if handlerErr != nil {
+		newMsg = types.ClusterEgressFirewallErrorMsg + ": " + handlerErr.Error()
+	} else {
+		newMsg = ClusterEgressFirewallAppliedCorrectly
+	}
+
+	newMsg = types.GetZoneStatus(oc.zone, newMsg)
+	if slices.Contains(cef.Status.Messages, newMsg) {
+		return nil
+	}
+
+	applyOptions := metav1.ApplyOptions{
+		Force:        true,
+		FieldManager: oc.zone,
+	}
+
+	applyObj := egressfirewallapply.ClusterEgressFirewall(cef.Name).
+		WithStatus(egressfirewallapply.EgressFirewallStatus().
+			WithMessages(newMsg))
+	_, err := oc.kube.EgressFirewallClient.K8sV1().ClusterEgressFirewalls().ApplyStatus(context.TODO(), applyObj, applyOptions)
+	return err
+}
+
+func cefNamespaceNeedsUpdate(oldNamespace, newNamespace *corev1.Namespace) bool {
+	if oldNamespace == nil || newNamespace == nil {
+		return true
+	}
+	return !reflect.DeepEqual(oldNamespace.Labels, newNamespace.Labels) ||
+		oldNamespace.Annotations[util.AclLoggingAnnotation] != newNamespace.Annotations[util.AclLoggingAnnotation]
+}
+
+// reconcileClusterEgressFirewallsForNamespace queues ClusterEgressFirewalls that select the given namespace,
+// or used to select it.
+func (oc *EFController) reconcileClusterEgressFirewallsForNamespace(namespace string) error {
+	ns, err := oc.namespaceLister.Get(namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	cefs, err := oc.cefLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list cluster egress firewalls: %w", err)
+	}
+	for _, cef := range cefs {
+		reconcile := false
+		if entry, ok := oc.cefCache.Load(cef.Name); ok {
+			_, reconcile = entry.pgNames[namespace]
+		}
+		if !reconcile && ns != nil {
+			selector, err := metav1.LabelSelectorAsSelector(&cef.Spec.NamespaceSelector)
+			if err != nil {
+				klog.Errorf("Invalid namespace selector in cluster egress firewall %s: %v", cef.Name, err)
+				continue
+			}
+			reconcile = selector.Matches(labels.Set(ns.Labels))
+		}
+		if reconcile {
+			oc.cefController.Reconcile(cef.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package egressfirewall
+
+import (
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
+	egressfirewallapi "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	egressfirewalllisters "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/listers/egressfirewall/v1"
+	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	fakenetworkmanager "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/networkmanager"
+	anpovn "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/ovn/controller/admin_network_policy"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/syncmap"
+	libovsdbtest "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/types"
+)
+
+func TestClusterEgressFirewallSync(t *testing.T) {
+	require.NoError(t, config.PrepareTestConfig())
+	_, clusterSubnet, _ := net.ParseCIDR("10.128.0.0/14")
+	config.Default.ClusterSubnets = []config.CIDRNetworkEntry{{CIDR: clusterSubnet, HostSubnetLength: 24}}
+
+	const (
+		cefName     = "cef1"
+		selectedNS  = "selected"
+		otherNS     = "other"
+		zone        = "global"
+		ownerCtrl   = types.DefaultNetworkName + "-network-controller"
+		selectedKey = "team"
+	)
+	selectedPG := libovsdbutil.GetPortGroupName(getNamespacePortGroupDbIDs(selectedNS, ownerCtrl))
+	otherPG := libovsdbutil.GetPortGroupName(getNamespacePortGroupDbIDs(otherNS, ownerCtrl))
+
+	initialDB := libovsdbtest.TestSetup{
+		NBData: []libovsdbtest.TestData{
+			&nbdb.PortGroup{Name: selectedPG, UUID: selectedPG + "-UUID"},
+			&nbdb.PortGroup{Name: otherPG, UUID: otherPG + "-UUID"},
+		},
+	}
+	nbClient, _, cleanup, err := libovsdbtest.NewNBSBTestHarness(initialDB)
+	require.NoError(t, err)
+	t.Cleanup(cleanup.Cleanup)
+
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: selectedNS,
+		Labels: map[string]string{selectedKey: "a"}}}))
+	require.NoError(t, nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: otherNS}}))
+
+	cefIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	cef := &egressfirewallapi.ClusterEgressFirewall{
+		ObjectMeta: metav1.ObjectMeta{Name: cefName},
+		Spec: egressfirewallapi.ClusterEgressFirewallSpec{
+			NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{selectedKey: "a"}},
+			Priority:          2,
+			Egress: []egressfirewallapi.EgressFirewallRule{
+				{
+					Type: egressfirewallapi.EgressFirewallRuleAllow,
+					To:   egressfirewallapi.EgressFirewallDestination{CIDRSelector: "1.2.3.0/24"},
+				},
+				{
+					Type: egressfirewallapi.EgressFirewallRuleDeny,
+					To:   egressfirewallapi.EgressFirewallDestination{CIDRSelector: "0.0.0.0/0"},
+				},
+			},
+		},
+		Status: egressfirewallapi.EgressFirewallStatus{
+			Messages: []string{types.GetZoneStatus(zone, ClusterEgressFirewallAppliedCorrectly)},
+		},
+	}
+	require.NoError(t, cefIndexer.Add(cef))
+
+	oc := &EFController{
+		name:            "test",
+		zone:            zone,
+		cache:           syncmap.NewSyncMap[*cacheEntry](),
+		cefCache:        syncmap.NewSyncMap[*clusterCacheEntry](),
+		nbClient:        nbClient,
+		kube:            nil, // status updates are no-op in this test due to pre-seeded status message
+		namespaceLister: corelisters.NewNamespaceLister(nsIndexer),
+		cefLister:       egressfirewalllisters.NewClusterEgressFirewallLister(cefIndexer),
+		networkManager:  &fakenetworkmanager.FakeNetworkManager{},
+		dnsNameResolver: noopDNSNameResolver{},
+	}
+
+	findACLs := func() []*nbdb.ACL {
+		p := libovsdbops.GetPredicate[*nbdb.ACL](oc.getClusterEgressFirewallACLDbIDsNoRule(cefName), nil)
+		acls, err := libovsdbops.FindACLsWithPredicate(nbClient, p)
+		require.NoError(t, err)
+		return acls
+	}
+	getPG := func(name string) *nbdb.PortGroup {
+		pg, err := libovsdbops.GetPortGroup(nbClient, &nbdb.PortGroup{Name: name})
+		require.NoError(t, err)
+		return pg
+	}
+
+	// rules are only rendered for the selected namespace, in the tier evaluated before egress firewalls
+	require.NoError(t, oc.syncClusterEgressFirewall(cefName))
+	acls := findACLs()
+	require.Len(t, acls, 2)
+	for _, acl := range acls {
+		require.Equal(t, selectedNS, acl.ExternalIDs[libovsdbops.NamespaceKey.String()])
+		require.Equal(t, types.ClusterEgressFirewallBeforeACLTier, acl.Tier)
+		ruleIdx := acl.ExternalIDs[libovsdbops.RuleIndex.String()]
+		switch ruleIdx {
+		case "0":
+			require.Equal(t, types.ClusterEgressFirewallBeforeStartPriority-2*clusterEgressFirewallMaxRules, acl.Priority)
+			require.Equal(t, nbdb.ACLActionAllow, acl.Action)
+			require.Equal(t, "(ip4.dst == 1.2.3.0/24) && inport == @"+selectedPG, acl.Match)
+		case "1":
+			require.Equal(t, types.ClusterEgressFirewallBeforeStartPriority-2*clusterEgressFirewallMaxRules-1, acl.Priority)
+			require.Equal(t, nbdb.ACLActionDrop, acl.Action)
+			require.Equal(t, "(ip4.dst == 0.0.0.0/0 && ip4.dst != 10.128.0.0/14) && inport == @"+selectedPG, acl.Match)
+		default:
+			t.Fatalf("unexpected rule index %s", ruleIdx)
+		}
+	}
+	require.Len(t, getPG(selectedPG).ACLs, 2)
+	require.Empty(t, getPG(otherPG).ACLs)
+
+	// select all namespaces, drop the second rule and move the rules after egress firewalls
+	cef = cef.DeepCopy()
+	cef.Spec.NamespaceSelector = metav1.LabelSelector{}
+	cef.Spec.Placement = egressfirewallapi.ClusterEgressFirewallAfterEgressFirewall
+	cef.Spec.Egress = cef.Spec.Egress[:1]
+	require.NoError(t, cefIndexer.Update(cef))
+	require.NoError(t, oc.syncClusterEgressFirewall(cefName))
+	acls = findACLs()
+	require.Len(t, acls, 2)
+	for _, acl := range acls {
+		require.Equal(t, "0", acl.ExternalIDs[libovsdbops.RuleIndex.String()])
+		require.Equal(t, types.ClusterEgressFirewallAfterACLTier, acl.Tier)
+		require.Equal(t, types.EgressFirewallStartPriority-2*clusterEgressFirewallMaxRules, acl.Priority)
+	}
+	require.Len(t, getPG(selectedPG).ACLs, 1)
+	require.Len(t, getPG(otherPG).ACLs, 1)
+
+	// the cache tracks the port group of every selected namespace
+	entry, ok := oc.cefCache.Load(cefName)
+	require.True(t, ok)
+	require.Equal(t, map[string]string{selectedNS: selectedPG, otherNS: otherPG}, entry.pgNames)
+
+	// deleting the cluster egress firewall removes all its ACLs
+	require.NoError(t, cefIndexer.Delete(cef))
+	require.NoError(t, oc.syncClusterEgressFirewall(cefName))
+	require.Empty(t, findACLs())
+	require.Empty(t, getPG(selectedPG).ACLs)
+	require.Empty(t, getPG(otherPG).ACLs)
+	_, ok = oc.cefCache.Load(cefName)
+	require.False(t, ok)
+}
+
+// evaluateACLTiers returns the verdict OVN applies to a packet matched by all the given ACLs: tiers are
+// evaluated in order, and within a tier the ACL with the highest priority decides, unless it passes the
+// packet to the next tier.
+func evaluateACLTiers(acls ...*nbdb.ACL) nbdb.ACLAction {
+	sorted := append([]*nbdb.ACL{}, acls...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Tier != sorted[j].Tier {
+			return sorted[i].Tier < sorted[j].Tier
+		}
+		return sorted[i].Priority > sorted[j].Priority
+	})
+	tier := -1
+	for _, acl := range sorted {
+		if acl.Tier == tier {
+			// the tier was passed
+			continue
+		}
+		if acl.Action != nbdb.ACLActionPass {
+			return acl.Action
+		}
+		tier = acl.Tier
+	}
+	return nbdb.ACLActionAllow
+}
+
+func TestClusterEgressFirewallACLTierOrder(t *testing.T) {
+	buildACL := func(priority int, action nbdb.ACLAction, tier int) *nbdb.ACL {
+		return &nbdb.ACL{Priority: priority, Action: action, Tier: tier}
+	}
+	clusterEgressFirewallACL := func(placement egressfirewallapi.ClusterEgressFirewallPlacement, priority int32,
+		action nbdb.ACLAction) *nbdb.ACL {
+		tier, startPriority := getClusterEgressFirewallACLTier(placement)
+		return buildACL(startPriority-int(priority)*clusterEgressFirewallMaxRules-(clusterEgressFirewallMaxRules-1), action, tier)
+	}
+	// the highest and lowest priority rules of the other features
+	anpPass := buildACL(anpovn.ANPFlowStartPriority-99*anpovn.ANPMaxRulesPerObject, nbdb.ACLActionPass, types.DefaultANPACLTier)
+	anpAllow := buildACL(anpovn.ANPFlowStartPriority-99*anpovn.ANPMaxRulesPerObject, nbdb.ACLActionAllowRelated, types.DefaultANPACLTier)
+	egressFirewallAllow := buildACL(types.EgressFirewallStartPriority, nbdb.ACLActionAllow, types.DefaultACLTier)
+	egressFirewallDeny := buildACL(types.MinimumReservedEgressFirewallPriority+1, nbdb.ACLActionDrop, types.DefaultACLTier)
+	networkPolicyDeny := buildACL(types.DefaultDenyPriority, nbdb.ACLActionDrop, types.DefaultACLTier)
+	banpAllow := buildACL(anpovn.BANPFlowPriority, nbdb.ACLActionAllowRelated, types.DefaultBANPACLTier)
+
+	before := egressfirewallapi.ClusterEgressFirewallBeforeEgressFirewall
+	after := egressfirewallapi.ClusterEgressFirewallAfterEgressFirewall
+	tests := []struct {
+		name     string
+		acls     []*nbdb.ACL
+		expected nbdb.ACLAction
+	}{
+		{
+			name:     "ANP pass is followed by the cluster egress firewall deny placed before egress firewall",
+			acls:     []*nbdb.ACL{anpPass, clusterEgressFirewallACL(before, 99, nbdb.ACLActionDrop), egressFirewallAllow},
+			expected: nbdb.ACLActionDrop,
+		},
+		{
+			name:     "ANP allow takes precedence over the cluster egress firewall deny",
+			acls:     []*nbdb.ACL{anpAllow, clusterEgressFirewallACL(before, 0, nbdb.ACLActionDrop)},
+			expected: nbdb.ACLActionAllowRelated,
+		},
+		{
+			name:     "cluster egress firewall allow placed before egress firewall takes precedence over egress firewall deny",
+			acls:     []*nbdb.ACL{clusterEgressFirewallACL(before, 99, nbdb.ACLActionAllow), egressFirewallDeny, networkPolicyDeny},
+			expected: nbdb.ACLActionAllow,
+		},
+		{
+			name:     "egress firewall allow takes precedence over the cluster egress firewall deny placed after egress firewall",
+			acls:     []*nbdb.ACL{anpPass, clusterEgressFirewallACL(after, 0, nbdb.ACLActionDrop), egressFirewallAllow},
+			expected: nbdb.ACLActionAllow,
+		},
+		{
+			name:     "ANP pass is followed by the cluster egress firewall deny placed after egress firewall",
+			acls:     []*nbdb.ACL{anpPass, clusterEgressFirewallACL(after, 99, nbdb.ACLActionDrop), banpAllow},
+			expected: nbdb.ACLActionDrop,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, evaluateACLTiers(tt.acls...))
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 	anpfake "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned/fake"
 
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
@@ -97,8 +98,8 @@ var _ = ginkgo.Describe("OVN test basic functions", func() {
 		nbClient, _, nbsbCleanup, err = libovsdbtest.NewNBSBTestHarness(initialDB)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		networkManager := &fakenetworkmanager.FakeNetworkManager{}
-		efController, err = NewEFController("test", "global", kubeInterface, nbClient, iFactory.NamespaceInformer().Lister(),
-			iFactory.NodeCoreInformer(), iFactory.EgressFirewallInformer(), networkManager, nil, nil)
+		efController, err = NewEFController("test", "global", kubeInterface, nbClient, iFactory.NamespaceInformer(),
+			iFactory.NodeCoreInformer(), iFactory.EgressFirewallInformer(), iFactory.ClusterEgressFirewallInformer(), networkManager, nil, nil)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		err = iFactory.Start()
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
@@ -124,7 +125,7 @@ var _ = ginkgo.Describe("OVN test basic functions", func() {
 						Port:     100,
 					},
 				},
-				expectedMatch: "((tcp && ( tcp.dst == 100 )))",
+				expectedMatch: "((tcp && tcp.dst==100))",
 			},
 			{
 				ports: []egressfirewallapi.EgressFirewallPort{
@@ -136,7 +137,7 @@ var _ = ginkgo.Describe("OVN test basic functions", func() {
 						Protocol: "UDP",
 					},
 				},
-				expectedMatch: "((udp) || (tcp && ( tcp.dst == 100 )))",
+				expectedMatch: "((udp) || (tcp && tcp.dst==100))",
 			},
 			{
 				ports: []egressfirewallapi.EgressFirewallPort{
@@ -157,7 +158,43 @@ var _ = ginkgo.Describe("OVN test basic functions", func() {
 						Port:     400,
 					},
 				},
-				expectedMatch: "((udp && ( udp.dst == 400 )) || (tcp && ( tcp.dst == 100 || tcp.dst == 102 )) || (sctp && ( sctp.dst == 13 )))",
+				expectedMatch: "((udp && udp.dst==400) || (tcp && tcp.dst=={100,102}) || (sctp && sctp.dst==13))",
+			},
+			{
+				ports: []egressfirewallapi.EgressFirewallPort{
+					{
+						Protocol: "TCP",
+						Port:     100,
+						EndPort:  ptr.To[int32](200),
+					},
+					{
+						Protocol: "TCP",
+						Port:     443,
+					},
+					{
+						Protocol: "UDP",
+						Port:     53,
+						EndPort:  ptr.To[int32](53),
+					},
+				},
+				expectedMatch: "((udp && udp.dst==53) || (tcp && (tcp.dst==443 || 100<=tcp.dst<=200)))",
+			},
+			{
+				ports: []egressfirewallapi.EgressFirewallPort{
+					{
+						Protocol: "TCP",
+						Port:     100,
+						EndPort:  ptr.To[int32](200),
+					},
+					{
+						Protocol: "TCP",
+					},
+					{
+						Protocol: "UDP",
+						Port:     53,
+					},
+				},
+				expectedMatch: "((udp && udp.dst==53) || (tcp))",
 			},
 		}
 		for _, test := range testcases {
@@ -121,6 +121,8 @@ type EFController struct {
 	// cache stores a mapping of rendered firewall namespace -> nodes + networks
 	// only one firewall (named "default") can exist per namespace
 	cache *syncmap.SyncMap[*cacheEntry]
+	// cefCache stores a mapping of rendered cluster egress firewall name -> selected namespaces
+	cefCache *syncmap.SyncMap[*clusterCacheEntry]
 
 	// libovsdb northbound client interface
 	nbClient libovsdbclient.Client
@@ -129,12 +131,15 @@ type EFController struct {
 	nodeLister      corelisters.NodeLister
 	namespaceLister corelisters.NamespaceLister
 	efLister        v2.EgressFirewallLister
-
-	controller      controller.Controller
-	nodeController  controller.Controller
-	networkManager  networkmanager.Interface
-	nadReconciler   networkmanager.NADReconciler
-	nadReconcilerID uint64
+	cefLister       v2.ClusterEgressFirewallLister
+
+	controller          controller.Controller
+	cefController       controller.Controller
+	nodeController      controller.Controller
+	namespaceController controller.Controller
+	networkManager      networkmanager.Interface
+	nadReconciler       networkmanager.NADReconciler
+	nadReconcilerID     uint64
 	// dnsNameResolver is used for resolving the IP addresses of DNS names
 	// used in egress firewall rules
 	dnsNameResolver dnsnameresolver.DNSNameResolver
@@ -146,9 +151,10 @@ func NewEFController(
 	zone string,
 	kube *kube.KubeOVN,
 	nbClient libovsdbclient.Client,
-	namespaceLister corelisters.NamespaceLister,
+	namespaceInformer coreinformers.NamespaceInformer,
 	nodeInformer coreinformers.NodeInformer,
 	efInformer v1.EgressFirewallInformer,
+	cefInformer v1.ClusterEgressFirewallInformer,
 	networkManager networkmanager.Interface,
 	dnsNameResolver dnsnameresolver.DNSNameResolver,
 	observManager *observability.Manager,
@@ -157,11 +163,13 @@ func NewEFController(
 		name:            name,
 		zone:            zone,
 		cache:           syncmap.NewSyncMap[*cacheEntry](),
+		cefCache:        syncmap.NewSyncMap[*clusterCacheEntry](),
 		nbClient:        nbClient,
 		kube:            kube,
 		nodeLister:      nodeInformer.Lister(),
-		namespaceLister: namespaceLister,
+		namespaceLister: namespaceInformer.Lister(),
 		efLister:        efInformer.Lister(),
+		cefLister:       cefInformer.Lister(),
 		networkManager:  networkManager,
 		dnsNameResolver: dnsNameResolver,
 		observManager:   observManager,
@@ -185,6 +193,40 @@ func NewEFController(
 		controllerConfig,
 	)
 
+	cefControllerConfig := &controller.ControllerConfig[egressfirewallapi.ClusterEgressFirewall]{
+		RateLimiter: workqueue.DefaultTypedControllerRateLimiter[string](),
+		Informer:    cefInformer.Informer(),
+		Lister:      cefInformer.Lister().List,
+		MaxAttempts: controller.InfiniteAttempts,
+		Reconcile:   c.syncClusterEgressFirewall,
+		ObjNeedsUpdate: func(old, new *egressfirewallapi.ClusterEgressFirewall) bool {
+			return old == nil || new == nil || !reflect.DeepEqual(old.Spec, new.Spec)
+		},
+		Threadiness: 1,
+	}
+
+	c.cefController = controller.NewController(
+		c.name+"-cluster",
+		cefControllerConfig,
+	)
+
+	// namespace controller only updates cluster egress firewalls, egress firewalls are updated by the
+	// default network controller namespace handler.
+	namespaceControllerConfig := &controller.ControllerConfig[corev1.Namespace]{
+		RateLimiter:    workqueue.NewTypedItemFastSlowRateLimiter[string](time.Second, 5*time.Second, 5),
+		Informer:       namespaceInformer.Informer(),
+		Lister:         namespaceInformer.Lister().List,
+		MaxAttempts:    controller.InfiniteAttempts,
+		ObjNeedsUpdate: cefNamespaceNeedsUpdate,
+		Reconcile:      c.reconcileClusterEgressFirewallsForNamespace,
+		Threadiness:    1,
+	}
+
+	c.namespaceController = controller.NewController(
+		c.name+"-namespace",
+		namespaceControllerConfig,
+	)
+
 	nodeControllerConfig := &controller.ControllerConfig[corev1.Node]{
 		RateLimiter:    workqueue.NewTypedItemFastSlowRateLimiter[string](time.Second, 5*time.Second, 5),
 		Informer:       nodeInformer.Informer(),
@@ -242,7 +284,7 @@ func (oc *EFController) syncNAD(key string) error {
 	// On delete, NAD controller has already removed the NAD from its cache, so we can't tell if it was
 	// primary or secondary. Reconcile anyway as a safe fallback.
 	oc.controller.Reconcile(namespace + "/" + egressFirewallName)
-	return nil
+	return oc.reconcileClusterEgressFirewallsForNamespace(namespace)
 }
 
 // initialSync deletes stale db entries for previous versions of Egress Firewall implementation and removes
@@ -360,6 +402,10 @@ func (oc *EFController) initialSync() error {
 		return err
 	}
 
+	if err = oc.syncClusterEgressFirewalls(); err != nil {
+		return err
+	}
+
 	// Delete stale address sets related to EgressFirewallDNS which are not referenced by any ACL.
 	return oc.dnsNameResolver.DeleteStaleAddrSets(oc.nbClient)
 }
@@ -371,7 +417,8 @@ func (oc *EFController) Start() error {
 		return err
 	}
 	oc.nadReconcilerID = id
-	return controller.StartWithInitialSync(oc.initialSync, oc.controller, oc.nodeController, oc.nadReconciler)
+	return controller.StartWithInitialSync(oc.initialSync, oc.controller, oc.cefController, oc.nodeController,
+		oc.namespaceController, oc.nadReconciler)
 }
 
 func (oc *EFController) Stop() {
@@ -381,7 +428,7 @@ func (oc *EFController) Stop() {
 			klog.Warningf("%s: failed to deregister NAD reconciler: %v", oc.name, err)
 		}
 	}
-	controller.Stop(oc.nodeController, oc.controller, oc.nadReconciler)
+	controller.Stop(oc.nodeController, oc.namespaceController, oc.controller, oc.cefController, oc.nadReconciler)
 	oc.nadReconciler = nil
 	oc.nadReconcilerID = 0
 }
@@ -733,6 +780,13 @@ func (oc *EFController) updateEgressFirewallForNode(nodeName string) error {
 
 	}
 
+	for _, cefName := range oc.cefCache.GetKeys() {
+		if entry, ok := oc.cefCache.Load(cefName); ok && entry.hasNodeSelector {
+			klog.Infof("Syncing cluster egress firewall %s due to node %q change", cefName, nodeName)
+			oc.cefController.Reconcile(cefName)
+		}
+	}
+
 	return nil
 }
 
@@ -755,57 +809,13 @@ func (oc *EFController) addEgressFirewallRules(ef *egressFirewall, pgName string
 				continue
 			}
 		}
-		var action string
-		var matchTargets []matchTarget
-		if rule.access == egressfirewallapi.EgressFirewallRuleAllow {
-			action = nbdb.ACLActionAllow
-		} else {
-			action = nbdb.ACLActionDrop
-		}
-		if len(rule.to.nodeAddrs) > 0 {
-			// sort node ips to ensure the same order when no changes are present
-			// this ensure ACL recalculation won't happen just because of the order changes
-			allIPs := []string{}
-			for _, nodeIPs := range rule.to.nodeAddrs {
-				allIPs = append(allIPs, nodeIPs...)
-			}
-			slices.Sort(allIPs)
-
-			for _, addr := range allIPs {
-				if utilnet.IsIPv6String(addr) {
-					matchTargets = append(matchTargets, matchTarget{matchKindV6CIDR, addr, nil})
-				} else {
-					matchTargets = append(matchTargets, matchTarget{matchKindV4CIDR, addr, nil})
-				}
-			}
-		} else if rule.to.cidrSelector != "" {
-			if utilnet.IsIPv6CIDRString(rule.to.cidrSelector) {
-				matchTargets = []matchTarget{{matchKindV6CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
-			} else {
-				matchTargets = []matchTarget{{matchKindV4CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
-			}
-		} else if len(rule.to.dnsName) > 0 {
+		action := getACLAction(rule.access)
+		if len(rule.to.dnsName) > 0 {
 			hasDNS = true
-			// rule based on DNS NAME
-			dnsName := rule.to.dnsName
-			// If DNSNameResolver is enabled, then use the egressFirewallExternalDNS to get the address
-			// set corresponding to the DNS name, otherwise use the egressFirewallDNS
-			// to get the address set.
-			if config.OVNKubernetesFeature.EnableDNSNameResolver {
-				// Convert the DNS name to lower case fully qualified domain name.
-				dnsName = util.LowerCaseFQDN(rule.to.dnsName)
-			}
-			dnsNameAddressSets, err := oc.dnsNameResolver.Add(ef.namespace, dnsName)
-			if err != nil {
-				return fmt.Errorf("error with DNSNameResolver - %v", err)
-			}
-			dnsNameIPv4ASHashName, dnsNameIPv6ASHashName := dnsNameAddressSets.GetASHashNames()
-			if dnsNameIPv4ASHashName != "" {
-				matchTargets = append(matchTargets, matchTarget{matchKindV4AddressSet, dnsNameIPv4ASHashName, rule.to.clusterSubnetIntersection})
-			}
-			if dnsNameIPv6ASHashName != "" {
-				matchTargets = append(matchTargets, matchTarget{matchKindV6AddressSet, dnsNameIPv6ASHashName, rule.to.clusterSubnetIntersection})
-			}
+		}
+		matchTargets, err := oc.getRuleMatchTargets(ef.namespace, rule)
+		if err != nil {
+			return err
 		}
 
 		if len(matchTargets) == 0 {
@@ -851,6 +861,65 @@ func (oc *EFController) addEgressFirewallRules(ef *egressFirewall, pgName string
 	return nil
 }
 
+// getACLAction returns the ACL action for the egress firewall rule type.
+func getACLAction(access egressfirewallapi.EgressFirewallRuleType) string {
+	if access == egressfirewallapi.EgressFirewallRuleAllow {
+		return nbdb.ACLActionAllow
+	}
+	return nbdb.ACLActionDrop
+}
+
+// getRuleMatchTargets returns the destinations matched by the given rule. The address sets for DNS names
+// are tracked by the dnsNameResolver on behalf of dnsOwner, which is the namespace for EgressFirewalls.
+func (oc *EFController) getRuleMatchTargets(dnsOwner string, rule *egressFirewallRule) ([]matchTarget, error) {
+	var matchTargets []matchTarget
+	if len(rule.to.nodeAddrs) > 0 {
+		// sort node ips to ensure the same order when no changes are present
+		// this ensure ACL recalculation won't happen just because of the order changes
+		allIPs := []string{}
+		for _, nodeIPs := range rule.to.nodeAddrs {
+			allIPs = append(allIPs, nodeIPs...)
+		}
+		slices.Sort(allIPs)
+
+		for _, addr := range allIPs {
+			if utilnet.IsIPv6String(addr) {
+				matchTargets = append(matchTargets, matchTarget{matchKindV6CIDR, addr, nil})
+			} else {
+				matchTargets = append(matchTargets, matchTarget{matchKindV4CIDR, addr, nil})
+			}
+		}
+	} else if rule.to.cidrSelector != "" {
+		if utilnet.IsIPv6CIDRString(rule.to.cidrSelector) {
+			matchTargets = []matchTarget{{matchKindV6CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
+		} else {
+			matchTargets = []matchTarget{{matchKindV4CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
+		}
+	} else if len(rule.to.dnsName) > 0 {
+		// rule based on DNS NAME
+		dnsName := rule.to.dnsName
+		// If DNSNameResolver is enabled, then use the egressFirewallExternalDNS to get the address
+		// set corresponding to the DNS name, otherwise use the egressFirewallDNS
+		// to get the address set.
+		if config.OVNKubernetesFeature.EnableDNSNameResolver {
+			// Convert the DNS name to lower case fully qualified domain name.
+			dnsName = util.LowerCaseFQDN(rule.to.dnsName)
+		}
+		dnsNameAddressSets, err := oc.dnsNameResolver.Add(dnsOwner, dnsName)
+		if err != nil {
+			return nil, fmt.Errorf("error with DNSNameResolver - %v", err)
+		}
+		dnsNameIPv4ASHashName, dnsNameIPv6ASHashName := dnsNameAddressSets.GetASHashNames()
+		if dnsNameIPv4ASHashName != "" {
+			matchTargets = append(matchTargets, matchTarget{matchKindV4AddressSet, dnsNameIPv4ASHashName, rule.to.clusterSubnetIntersection})
+		}
+		if dnsNameIPv6ASHashName != "" {
+			matchTargets = append(matchTargets, matchTarget{matchKindV6AddressSet, dnsNameIPv6ASHashName, rule.to.clusterSubnetIntersection})
+		}
+	}
+	return matchTargets, nil
+}
+
 // moveACLsToNamespacedPortGroups syncs db from the previous version where all ACLs were attached to the ClusterPortGroup
 // to the new version where ACLs are attached to the namespace port groups.
 func (oc *EFController) moveACLsToNamespacedPortGroups(existingEFNamespaces map[string]bool, efACLs []*nbdb.ACL) error {
@@ -921,7 +990,7 @@ func (oc *EFController) moveACLsToNamespacedPortGroups(existingEFNamespaces map[
 // acls for all node switches
 func (oc *EFController) createEgressFirewallACLOps(ops []ovsdb.Operation, egressFirewallACL *nbdb.ACL, pgName string) ([]ovsdb.Operation, error) {
 	var err error
-	ops, err = libovsdbops.CreateOrUpdateACLsOps(oc.nbClient, ops, oc.GetSamplingConfig(), egressFirewallACL)
+	ops, err = libovsdbops.CreateOrUpdateACLsOps(oc.nbClient, ops, oc.getPortGroupSamplingConfig(pgName), egressFirewallACL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create egressFirewall ACL %#v: %v", egressFirewallACL, err)
 	}
@@ -1053,6 +1122,21 @@ func (oc *EFController) GetSamplingConfig() *libovsdbops.SamplingConfig {
 	return nil
 }
 
+// getPortGroupSamplingConfig returns the SamplingConfig for the network of a given port group, since
+// egress firewall ACLs are always owned by the default network controller.
+func (oc *EFController) getPortGroupSamplingConfig(pgName string) *libovsdbops.SamplingConfig {
+	samplingConfig := oc.GetSamplingConfig()
+	if samplingConfig == nil {
+		return nil
+	}
+	pg, err := libovsdbops.GetPortGroup(oc.nbClient, &nbdb.PortGroup{Name: pgName})
+	if err != nil {
+		klog.Warningf("Failed to get port group %s to find egress firewall network, using default network: %v", pgName, err)
+		return samplingConfig
+	}
+	return samplingConfig.ForNetwork(libovsdbops.GetOwnerControllerNetwork(pg.ExternalIDs))
+}
+
 // getNamespaceACLLogging retrieves ACLLoggingLevels for the Namespace
 func (oc *EFController) getNamespaceACLLogging(namespace string) (*libovsdbutil.ACLLoggingLevels, error) {
 	ns, err := oc.namespaceLister.Get(namespace)
@@ -1165,71 +1249,37 @@ func generateMatch(pgName string, destinations []matchTarget, dstPorts []egressf
 	return match
 }
 
-// egressGetL4Match generates the rules for when ports are specified in an egressFirewall Rule
-// since the ports can be specified in any order in an egressFirewallRule the best way to build up
-// a single rule is to build up each protocol as you walk through the list and place the appropriate logic
-// between the elements.
+// egressGetL4Match generates the rules for when ports are specified in an egressFirewall Rule.
+// The ports are matched like network policy ports, a port range being set with endPort, and
+// a port without a port number matches all the ports of its protocol.
 func egressGetL4Match(ports []egressfirewallapi.EgressFirewallPort) string {
-	var udpString string
-	var tcpString string
-	var sctpString string
+	allPorts := sets.New[string]()
 	for _, port := range ports {
-		if corev1.Protocol(port.Protocol) == corev1.ProtocolUDP && udpString != "udp" {
-			if port.Port == 0 {
-				udpString = "udp"
-			} else {
-				udpString = fmt.Sprintf("%s udp.dst == %d ||", udpString, port.Port)
-			}
-		} else if corev1.Protocol(port.Protocol) == corev1.ProtocolTCP && tcpString != "tcp" {
-			if port.Port == 0 {
-				tcpString = "tcp"
-			} else {
-				tcpString = fmt.Sprintf("%s tcp.dst == %d ||", tcpString, port.Port)
-			}
-		} else if corev1.Protocol(port.Protocol) == corev1.ProtocolSCTP && sctpString != "sctp" {
-			if port.Port == 0 {
-				sctpString = "sctp"
-			} else {
-				sctpString = fmt.Sprintf("%s sctp.dst == %d ||", sctpString, port.Port)
-			}
+		if port.Port == 0 {
+			allPorts.Insert(libovsdbutil.ConvertK8sProtocolToOVNProtocol(corev1.Protocol(port.Protocol)))
 		}
 	}
-	// build the l4 match
-	var l4Match string
-	type tuple struct {
-		protocolName     string
-		protocolFormated string
-	}
-	list := []tuple{
-		{
-			protocolName:     "udp",
-			protocolFormated: udpString,
-		},
-		{
-			protocolName:     "tcp",
-			protocolFormated: tcpString,
-		},
-		{
-			protocolName:     "sctp",
-			protocolFormated: sctpString,
-		},
-	}
-	for _, entry := range list {
-		if entry.protocolName == entry.protocolFormated {
-			if l4Match == "" {
-				l4Match = fmt.Sprintf("(%s)", entry.protocolName)
-			} else {
-				l4Match = fmt.Sprintf("%s || (%s)", l4Match, entry.protocolName)
-			}
-		} else {
-			if l4Match == "" && entry.protocolFormated != "" {
-				l4Match = fmt.Sprintf("(%s && (%s))", entry.protocolName, entry.protocolFormated[:len(entry.protocolFormated)-2])
-			} else if entry.protocolFormated != "" {
-				l4Match = fmt.Sprintf("%s || (%s && (%s))", l4Match, entry.protocolName, entry.protocolFormated[:len(entry.protocolFormated)-2])
-			}
+	var rulePorts []*libovsdbutil.NetworkPolicyPort
+	for _, port := range ports {
+		var endPort int32
+		if port.EndPort != nil {
+			endPort = *port.EndPort
+		}
+		rulePort := libovsdbutil.GetNetworkPolicyPort(corev1.Protocol(port.Protocol), port.Port, endPort)
+		if port.Port != 0 && allPorts.Has(rulePort.Protocol) {
+			continue
+		}
+		rulePorts = append(rulePorts, rulePort)
+	}
+	l4Matches := libovsdbutil.GetL4MatchesFromNetworkPolicyPorts(rulePorts)
+	// build the l4 match in a stable protocol order
+	var l4Match []string
+	for _, protocol := range []string{"udp", "tcp", "sctp"} {
+		if match, ok := l4Matches[protocol]; ok {
+			l4Match = append(l4Match, fmt.Sprintf("(%s)", match))
 		}
 	}
-	return fmt.Sprintf("(%s)", l4Match)
+	return fmt.Sprintf("(%s)", strings.Join(l4Match, " || "))
 }
 
 func getV4ClusterSubnetsExclusion(subnets []*net.IPNet) string {
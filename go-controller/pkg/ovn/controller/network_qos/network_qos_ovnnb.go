@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"slices"
-	"strconv"
 
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
 	"github.com/ovn-kubernetes/libovsdb/ovsdb"
@@ -35,7 +34,7 @@ func (c *Controller) addQoSToLogicalSwitch(qosState *networkQoSState, switchName
 	// construct qoses
 	qoses := []*nbdb.QoS{}
 	ipv4Enabled, ipv6Enabled := c.IPMode()
-	for index, rule := range qosState.EgressRules {
+	for index, rule := range qosState.getRules() {
 		dbIDs := qosState.getDbObjectIDs(c.controllerName, index)
 		qos := &nbdb.QoS{
 			Action:      map[string]int{},
@@ -120,15 +119,10 @@ func (c *Controller) cleanupStaleOvnObjects(qosState *networkQoSState) error {
 		return fmt.Errorf("error looking up existing QoSes for %s/%s: %v", qosState.namespace, qosState.name, err)
 	}
 	staleSwitchQoSMap := map[string][]*nbdb.QoS{}
-	totalNumOfRules := len(qosState.EgressRules)
+	rules := qosState.getRules()
 	for _, qos := range existingQoSes {
-		index := qos.ExternalIDs[libovsdbops.RuleIndex.String()]
-		numIndex, convError := strconv.Atoi(index)
-		indexWithinRange := false
-		if index != "" && convError == nil && numIndex < totalNumOfRules {
-			// rule index is valid
-			indexWithinRange = true
-		}
+		// rule index is valid if it's within range of egress or ingress rules
+		_, indexWithinRange := rules[qos.ExternalIDs[libovsdbops.RuleIndex.String()]]
 		// qos is considered stale since the index is out of range
 		// get switches that reference to the stale qos
 		switches, err := libovsdbops.FindLogicalSwitchesWithPredicate(c.nbClient, func(ls *nbdb.LogicalSwitch) bool {
@@ -541,6 +541,145 @@ var _ = Describe("NetworkQoS Controller", func() {
 					eventuallyAddressSetHas(defaultAddrsetFactory, nqosNamespace, nqosName, "2", "0", defaultControllerName, "10.194.188.4")
 				}
 
+				By("adds QoS rule matching traffic to source pods when an Ingress rule is added")
+				{
+					nqosUpdate, err := fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Get(context.TODO(), nqosName, metav1.GetOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					nqosUpdate.Spec.Ingress = []nqostype.IngressRule{
+						{
+							DSCP: 20,
+							Bandwidth: nqostype.Bandwidth{
+								Rate: 5000,
+							},
+							Classifier: nqostype.IngressClassifier{
+								From: []nqostype.Source{
+									{
+										NamespaceSelector: &metav1.LabelSelector{
+											MatchLabels: map[string]string{
+												"app": "app3",
+											},
+										},
+									},
+									{
+										IPBlock: &networkingv1.IPBlock{
+											CIDR: "128.120.0.0/17",
+										},
+									},
+								},
+								Ports: []*nqostype.Port{
+									{
+										Protocol: "tcp",
+										Port:     &port8080,
+									},
+								},
+							},
+						},
+					}
+					nqosUpdate.ResourceVersion = time.Now().String()
+					_, err = fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Update(context.TODO(), nqosUpdate, metav1.UpdateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					eventuallyAddressSetHas(defaultAddrsetFactory, nqosNamespace, nqosName, "ingress-0", "0", defaultControllerName, "10.195.188.4")
+					sourceAddrSet, err := findAddressSet(defaultAddrsetFactory, nqosNamespace, nqosName, "src", "0", defaultControllerName)
+					Expect(err).NotTo(HaveOccurred())
+					peerAddrSet, err := findAddressSet(defaultAddrsetFactory, nqosNamespace, nqosName, "ingress-0", "0", defaultControllerName)
+					Expect(err).NotTo(HaveOccurred())
+					srcHashName4, _ := sourceAddrSet.GetASHashNames()
+					peerHashName4, _ := peerAddrSet.GetASHashNames()
+					var qos *nbdb.QoS
+					Eventually(func() bool {
+						qos, _ = findQoSByRuleIndex(defaultControllerName, nqosNamespace, nqosName, "ingress-0")
+						return qos != nil
+					}).WithTimeout(10 * time.Second).Should(BeTrue())
+					Expect(qos.Match).To(Equal(fmt.Sprintf("ip4.dst == {$%s} && (ip4.src == {$%s} || ip4.src == 128.120.0.0/17) && tcp && tcp.dst == 8080", srcHashName4, peerHashName4)))
+					Expect(qos.Direction).To(Equal(nbdb.QoSDirectionToLport))
+					Expect(qos.Action).To(Equal(map[string]int{nbdb.QoSActionDSCP: 20}))
+					Expect(qos.Bandwidth).To(Equal(map[string]int{nbdb.QoSBandwidthRate: 5000}))
+					eventuallySwitchHasQoS("node1", qos)
+					// egress rules are kept
+					eventuallyExpectQoS(defaultControllerName, nqosNamespace, nqosName, 2)
+				}
+
+				By("removes QoS rule and address sets when the Ingress rule is removed")
+				{
+					nqosUpdate, err := fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Get(context.TODO(), nqosName, metav1.GetOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					nqosUpdate.Spec.Ingress = nil
+					nqosUpdate.ResourceVersion = time.Now().String()
+					_, err = fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Update(context.TODO(), nqosUpdate, metav1.UpdateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(func() bool {
+						qos, _ := findQoSByRuleIndex(defaultControllerName, nqosNamespace, nqosName, "ingress-0")
+						return qos == nil
+					}).WithTimeout(10 * time.Second).Should(BeTrue())
+					Eventually(func() ([]*nbdb.AddressSet, error) {
+						dbIDs := GetNetworkQoSAddrSetDbIDs(nqosNamespace, nqosName, "ingress-0", "0", defaultControllerName)
+						return libovsdbops.FindAddressSetsWithPredicate(nbClient, libovsdbops.GetPredicate[*nbdb.AddressSet](dbIDs, nil))
+					}).WithTimeout(10 * time.Second).Should(BeEmpty())
+					eventuallyExpectQoS(defaultControllerName, nqosNamespace, nqosName, 2)
+				}
+
+				By("gives distinct priorities to egress and ingress rules selecting the same pods")
+				{
+					nqosUpdate, err := fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Get(context.TODO(), nqosName, metav1.GetOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					egressRules := nqosUpdate.Spec.Egress
+					clientPeer := nqostype.Destination{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "client",
+							},
+						},
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "client",
+							},
+						},
+					}
+					nqosUpdate.Spec.Egress = append(nqosUpdate.Spec.Egress, nqostype.Rule{
+						DSCP: 30,
+						Classifier: nqostype.Classifier{
+							To: []nqostype.Destination{clientPeer},
+						},
+					})
+					nqosUpdate.Spec.Ingress = []nqostype.IngressRule{
+						{
+							DSCP: 40,
+							Classifier: nqostype.IngressClassifier{
+								From: []nqostype.Source{nqostype.Source(clientPeer)},
+							},
+						},
+					}
+					nqosUpdate.ResourceVersion = time.Now().String()
+					_, err = fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Update(context.TODO(), nqosUpdate, metav1.UpdateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					egressQoS := eventuallyExpectQoS(defaultControllerName, nqosNamespace, nqosName, len(egressRules))
+					var ingressQoS *nbdb.QoS
+					Eventually(func() bool {
+						ingressQoS, _ = findQoSByRuleIndex(defaultControllerName, nqosNamespace, nqosName, "ingress-0")
+						return ingressQoS != nil
+					}).WithTimeout(10 * time.Second).Should(BeTrue())
+					Expect(egressQoS.Direction).To(Equal(ingressQoS.Direction))
+					Expect(egressQoS.Priority).To(Equal(11000 + len(egressRules)))
+					Expect(ingressQoS.Priority).To(Equal(6000))
+					for index := range nqosUpdate.Spec.Egress {
+						qos := eventuallyExpectQoS(defaultControllerName, nqosNamespace, nqosName, index)
+						Expect(qos.Priority).To(BeNumerically(">", ingressQoS.Priority))
+					}
+
+					nqosUpdate, err = fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Get(context.TODO(), nqosName, metav1.GetOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					nqosUpdate.Spec.Egress = egressRules
+					nqosUpdate.Spec.Ingress = nil
+					nqosUpdate.ResourceVersion = time.Now().String()
+					_, err = fakeNQoSClient.K8sV1alpha1().NetworkQoSes(nqosNamespace).Update(context.TODO(), nqosUpdate, metav1.UpdateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					eventuallyExpectNoQoS(defaultControllerName, nqosNamespace, nqosName, len(egressRules))
+					Eventually(func() bool {
+						qos, _ := findQoSByRuleIndex(defaultControllerName, nqosNamespace, nqosName, "ingress-0")
+						return qos == nil
+					}).WithTimeout(10 * time.Second).Should(BeTrue())
+				}
+
 				nqos4StreamNet := &nqostype.NetworkQoS{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: nqosNamespace,
@@ -1124,15 +1263,19 @@ func eventuallyExpectNoQoS(controllerName, qosNamespace, qosName string, index i
 }
 
 func findQoS(controllerName, qosNamespace, qosName string, index int) (*nbdb.QoS, error) {
+	return findQoSByRuleIndex(controllerName, qosNamespace, qosName, strconv.Itoa(index))
+}
+
+func findQoSByRuleIndex(controllerName, qosNamespace, qosName, ruleIndex string) (*nbdb.QoS, error) {
 	qosKey := joinMetaNamespaceAndName(qosNamespace, qosName, ":")
 	dbIDs := libovsdbops.NewDbObjectIDs(libovsdbops.NetworkQoS, controllerName, map[libovsdbops.ExternalIDKey]string{
 		libovsdbops.ObjectNameKey: qosKey,
-		libovsdbops.RuleIndex:     fmt.Sprintf("%d", index),
+		libovsdbops.RuleIndex:     ruleIndex,
 	})
 	predicate := libovsdbops.GetPredicate(dbIDs, func(item *nbdb.QoS) bool {
 		return item.ExternalIDs[libovsdbops.OwnerControllerKey.String()] == controllerName &&
 			item.ExternalIDs[libovsdbops.ObjectNameKey.String()] == qosKey &&
-			item.ExternalIDs[libovsdbops.RuleIndex.String()] == strconv.Itoa(index)
+			item.ExternalIDs[libovsdbops.RuleIndex.String()] == ruleIndex
 	})
 	qoses, err := libovsdbops.FindQoSesWithPredicate(nbClient, predicate)
 	if err != nil {
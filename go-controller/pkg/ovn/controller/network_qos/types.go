@@ -37,19 +37,41 @@ type networkQoSState struct {
 
 	// egressRules stores the objects needed to track .Spec.Egress changes
 	EgressRules []*GressRule
+	// ingressRules stores the objects needed to track .Spec.Ingress changes
+	IngressRules []*GressRule
 }
 
 func (nqosState *networkQoSState) getObjectNameKey() string {
 	return joinMetaNamespaceAndName(nqosState.namespace, nqosState.name, ":")
 }
 
-func (nqosState *networkQoSState) getDbObjectIDs(controller string, ruleIndex int) *libovsdbops.DbObjectIDs {
+func (nqosState *networkQoSState) getDbObjectIDs(controller string, ruleIndex string) *libovsdbops.DbObjectIDs {
 	return libovsdbops.NewDbObjectIDs(libovsdbops.NetworkQoS, controller, map[libovsdbops.ExternalIDKey]string{
 		libovsdbops.ObjectNameKey: nqosState.getObjectNameKey(),
-		libovsdbops.RuleIndex:     fmt.Sprintf("%d", ruleIndex),
+		libovsdbops.RuleIndex:     ruleIndex,
 	})
 }
 
+// ingressRuleIndexPrefix distinguishes the rule index of ingress rules from egress rules,
+// egress rules use plain numbers for backward compatibility.
+const ingressRuleIndexPrefix = "ingress-"
+
+func getIngressRuleIndex(ruleIndex int) string {
+	return ingressRuleIndexPrefix + strconv.Itoa(ruleIndex)
+}
+
+// getRules returns egress and ingress rules, mapped by the rule index used in OVN objects external IDs.
+func (nqosState *networkQoSState) getRules() map[string]*GressRule {
+	rules := make(map[string]*GressRule, len(nqosState.EgressRules)+len(nqosState.IngressRules))
+	for index, rule := range nqosState.EgressRules {
+		rules[strconv.Itoa(index)] = rule
+	}
+	for index, rule := range nqosState.IngressRules {
+		rules[getIngressRuleIndex(index)] = rule
+	}
+	return rules
+}
+
 func (nqosState *networkQoSState) initAddressSets(addressSetFactory addressset.AddressSetFactory, controllerName string) error {
 	var err error
 	// init source address set
@@ -57,13 +79,13 @@ func (nqosState *networkQoSState) initAddressSets(addressSetFactory addressset.A
 	if err != nil {
 		return fmt.Errorf("failed to init source address set for %s/%s: %w", nqosState.namespace, nqosState.name, err)
 	}
-	// ensure destination address sets
-	for ruleIndex, rule := range nqosState.EgressRules {
+	// ensure destination address sets, for ingress rules these hold the source peers
+	for ruleIndex, rule := range nqosState.getRules() {
 		for destIndex, dest := range rule.Classifier.Destinations {
 			if dest.NamespaceSelector == nil && dest.PodSelector == nil {
 				continue
 			}
-			dest.DestAddrSet, err = addressSetFactory.EnsureAddressSet(GetNetworkQoSAddrSetDbIDs(nqosState.namespace, nqosState.name, strconv.Itoa(ruleIndex), strconv.Itoa(destIndex), controllerName))
+			dest.DestAddrSet, err = addressSetFactory.EnsureAddressSet(GetNetworkQoSAddrSetDbIDs(nqosState.namespace, nqosState.name, ruleIndex, strconv.Itoa(destIndex), controllerName))
 			if err != nil {
 				return fmt.Errorf("failed to init destination address set for %s/%s: %w", nqosState.namespace, nqosState.name, err)
 			}
@@ -167,7 +189,7 @@ func (nqosState *networkQoSState) getAddressSetHashNames() []string {
 		v4Hash, v6Hash := nqosState.SrcAddrSet.GetASHashNames()
 		addrsetNames = append(addrsetNames, v4Hash, v6Hash)
 	}
-	for _, rule := range nqosState.EgressRules {
+	for _, rule := range nqosState.getRules() {
 		for _, dest := range rule.Classifier.Destinations {
 			if dest.DestAddrSet != nil {
 				v4Hash, v6Hash := dest.DestAddrSet.GetASHashNames()
@@ -194,8 +216,8 @@ func (nqosState *networkQoSState) cleanupStaleAddresses(addressSetMap map[string
 			}
 		}
 	}
-	for _, egress := range nqosState.EgressRules {
-		for _, dest := range egress.Classifier.Destinations {
+	for _, rule := range nqosState.getRules() {
+		for _, dest := range rule.Classifier.Destinations {
 			if dest.DestAddrSet == nil {
 				continue
 			}
@@ -235,33 +257,47 @@ const (
 )
 
 type Classifier struct {
+	// Destinations are the peers of the rule, for ingress rules they are the sources
+	// of the traffic sent to the selected pods.
 	Destinations []*Destination
 	Ports        []*networkqosv1alpha1.Port
+	// Ingress is set for classifiers of ingress rules
+	Ingress bool
+}
+
+// peerDirection returns the direction of the classifier peers in the QoS match.
+func (c *Classifier) peerDirection() trafficDirection {
+	if c != nil && c.Ingress {
+		return trafficDirSource
+	}
+	return trafficDirDest
 }
 
 // ToQosMatchString generates dest and protocol/port part of QoS match string, based on
 // Classifier's destinations, protocol and port fields, example:
 // (ip4.dst == $addr_set_name || (ip4.dst == 128.116.0.0/17 && ip4.dst != {128.116.0.0,128.116.0.255})) && tcp && tcp.dst == 8080
-// Multiple destinations will be connected by "||".
+// Multiple destinations will be connected by "||". For ingress rules peers are matched by
+// the source address instead, while ports are still matched on the destination port.
 // See https://github.com/ovn-org/ovn/blob/2bdf1129c19d5bd2cd58a3ddcb6e2e7254b05054/ovn-nb.xml#L2942-L3025 for details
 func (c *Classifier) ToQosMatchString(ipv4Enabled, ipv6Enabled bool) string {
 	if c == nil {
 		return ""
 	}
+	peerDir := c.peerDirection()
 	destMatchStrings := []string{}
 	for _, dest := range c.Destinations {
-		match := "ip4.dst == 0.0.0.0/0 || ip6.dst == ::/0"
+		match := fmt.Sprintf("ip4.%s == 0.0.0.0/0 || ip6.%s == ::/0", peerDir, peerDir)
 		if dest.DestAddrSet != nil {
-			match = addressSetToMatchString(dest.DestAddrSet, trafficDirDest, ipv4Enabled, ipv6Enabled)
+			match = addressSetToMatchString(dest.DestAddrSet, peerDir, ipv4Enabled, ipv6Enabled)
 		} else if dest.IpBlock != nil && dest.IpBlock.CIDR != "" {
 			ipVersion := "ip4"
 			if utilnet.IsIPv6CIDRString(dest.IpBlock.CIDR) {
 				ipVersion = "ip6"
 			}
 			if len(dest.IpBlock.Except) == 0 {
-				match = fmt.Sprintf("%s.%s == %s", ipVersion, trafficDirDest, dest.IpBlock.CIDR)
+				match = fmt.Sprintf("%s.%s == %s", ipVersion, peerDir, dest.IpBlock.CIDR)
 			} else {
-				match = fmt.Sprintf("%s.%s == %s && %s.%s != {%s}", ipVersion, trafficDirDest, dest.IpBlock.CIDR, ipVersion, trafficDirDest, strings.Join(dest.IpBlock.Except, ","))
+				match = fmt.Sprintf("%s.%s == %s && %s.%s != {%s}", ipVersion, peerDir, dest.IpBlock.CIDR, ipVersion, peerDir, strings.Join(dest.IpBlock.Except, ","))
 			}
 		}
 		destMatchStrings = append(destMatchStrings, match)
@@ -374,3 +410,10 @@ func (dest *Destination) removePod(fullPodName string, addresses []string) error
 func getQoSRulePriority(qosPriority, ruleIndex int) int {
 	return 10000 + qosPriority*10 + ruleIndex
 }
+
+// getQoSIngressRulePriority returns the priority of an ingress rule. Egress and ingress rules are
+// both to-lport QoS rules, ingress rules get their own lower range so that a packet matching both
+// an egress and an ingress rule, e.g. between two selected pods, is handled by the egress rule.
+func getQoSIngressRulePriority(qosPriority, ruleIndex int) int {
+	return 5000 + qosPriority*10 + ruleIndex
+}
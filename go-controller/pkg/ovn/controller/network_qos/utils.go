@@ -5,6 +5,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	networkqosv1alpha1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1"
 	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	addressset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	ovnkutil "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
@@ -31,6 +32,20 @@ func GetNetworkQoSAddrSetDbIDs(nqosNamespace, nqosName, ruleIndex, ipBlockIndex,
 		})
 }
 
+// getPeers returns the destinations of egress rules and the sources of ingress rules.
+func getPeers(nqos *networkqosv1alpha1.NetworkQoS) []networkqosv1alpha1.Destination {
+	peers := []networkqosv1alpha1.Destination{}
+	for _, egress := range nqos.Spec.Egress {
+		peers = append(peers, egress.Classifier.To...)
+	}
+	for _, ingress := range nqos.Spec.Ingress {
+		for _, source := range ingress.Classifier.From {
+			peers = append(peers, networkqosv1alpha1.Destination(source))
+		}
+	}
+	return peers
+}
+
 func getPodAddresses(pod *corev1.Pod, networkInfo ovnkutil.NetInfo, resolver func(nadKey string) string) ([]string, error) {
 	// check annotation "k8s.ovn.org/pod-networks" before calling GetPodIPsOfNetwork,
 	// as it's no easy to check if the error is caused by missing annotation, while
@@ -52,7 +67,12 @@ func getPodAddresses(pod *corev1.Pod, networkInfo ovnkutil.NetInfo, resolver fun
 }
 
 func generateNetworkQoSMatch(qosState *networkQoSState, rule *GressRule, ipv4Enabled, ipv6Enabled bool) string {
-	match := addressSetToMatchString(qosState.SrcAddrSet, trafficDirSource, ipv4Enabled, ipv6Enabled)
+	// selected pods send egress traffic and receive ingress traffic
+	podDir := trafficDirSource
+	if rule.Classifier != nil && rule.Classifier.Ingress {
+		podDir = trafficDirDest
+	}
+	match := addressSetToMatchString(qosState.SrcAddrSet, podDir, ipv4Enabled, ipv6Enabled)
 
 	classiferMatchString := rule.Classifier.ToQosMatchString(ipv4Enabled, ipv6Enabled)
 	if classiferMatchString != "" {
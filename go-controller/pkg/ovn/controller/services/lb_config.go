@@ -31,6 +31,7 @@ type lbConfig struct {
 
 	clusterEndpoints util.LBEndpoints            // addresses of cluster-wide endpoints
 	nodeEndpoints    map[string]util.LBEndpoints // node -> addresses of local endpoints
+	zoneEndpoints    map[string]util.LBEndpoints // topology zone -> addresses of the endpoints preferred by that zone
 
 	// if true, then vips added on the router are in "local" mode
 	// that means, skipSNAT, and remove any non-local endpoints.
@@ -126,10 +127,11 @@ var protos = []corev1.Protocol{
 // - services with host-network endpoints
 // - services with ExternalTrafficPolicy=Local
 // - services with InternalTrafficPolicy=Local
+// - services with topology aware routing (zone hints or trafficDistribution)
 //
 // Template LBs will be created for
-//   - services with NodePort set but *without* ExternalTrafficPolicy=Local or
-//     affinity timeout set.
+//   - services with NodePort set but *without* ExternalTrafficPolicy=Local,
+//     affinity timeout or topology aware routing set.
 func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.EndpointSlice, nodeInfos []nodeInfo,
 	useLBGroup, useTemplates bool, netInfo util.NetInfo) (perNodeConfigs, templateConfigs, clusterConfigs []lbConfig) {
 
@@ -149,9 +151,14 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 			klog.Warningf("Failed to get endpoints for service during LB config build: %v", err)
 		}
 	}
+	// get the endpoints preferred by each topology zone, if the service uses topology aware routing
+	portToZoneToEndpoints := util.GetZoneEndpointsForService(endpointSlices, service)
 	for _, svcPort := range service.Spec.Ports {
 		svcPortKey := util.GetServicePortKey(svcPort.Protocol, svcPort.Name)
 		clusterEndpoints := portToClusterEndpoints[svcPortKey]
+		// zone endpoints are only preferred over cluster endpoints, local
+		// endpoints of local traffic policies always take precedence.
+		zoneEndpoints := portToZoneToEndpoints[svcPortKey]
 		nodeEndpoints := portToNodeToEndpoints[svcPortKey]
 		if nodeEndpoints == nil {
 			nodeEndpoints = make(map[string]util.LBEndpoints)
@@ -170,13 +177,14 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 				vips:                 []string{placeholderNodeIPs}, // shortcut for all-physical-ips
 				clusterEndpoints:     clusterEndpoints,
 				nodeEndpoints:        nodeEndpoints,
+				zoneEndpoints:        zoneEndpoints,
 				externalTrafficLocal: externalTrafficLocal,
 				internalTrafficLocal: false, // always false for non-ClusterIPs
 				hasNodePort:          true,
 			}
-			// Only "plain" NodePort services (no ETP, no affinity timeout)
-			// can use load balancer templates.
-			if !useLBGroup || !useTemplates || externalTrafficLocal || needsAffinityTimeout {
+			// Only "plain" NodePort services (no ETP, no affinity timeout,
+			// no topology aware routing) can use load balancer templates.
+			if !useLBGroup || !useTemplates || externalTrafficLocal || needsAffinityTimeout || len(zoneEndpoints) > 0 {
 				perNodeConfigs = append(perNodeConfigs, nodePortLBConfig)
 			} else {
 				templateConfigs = append(templateConfigs, nodePortLBConfig)
@@ -197,6 +205,7 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 				vips:                 externalVips,
 				clusterEndpoints:     clusterEndpoints,
 				nodeEndpoints:        nodeEndpoints,
+				zoneEndpoints:        zoneEndpoints,
 				externalTrafficLocal: true,
 				internalTrafficLocal: false, // always false for non-ClusterIPs
 				hasNodePort:          false,
@@ -214,6 +223,7 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 			vips:                 vips,
 			clusterEndpoints:     clusterEndpoints,
 			nodeEndpoints:        nodeEndpoints,
+			zoneEndpoints:        zoneEndpoints,
 			externalTrafficLocal: false, // always false for ClusterIPs
 			internalTrafficLocal: internalTrafficLocal,
 			hasNodePort:          false,
@@ -223,9 +233,11 @@ func buildServiceLBConfigs(service *corev1.Service, endpointSlices []*discovery.
 		// unless any of the following are true:
 		// - Any of the endpoints are host-network
 		// - ETP=local service backed by non-local-host-networked endpoints
+		// - the service uses topology aware routing
 		//
 		// In that case, we need to create per-node LBs.
-		if hasHostEndpoints(clusterEndpoints.V4IPs, netInfo) || hasHostEndpoints(clusterEndpoints.V6IPs, netInfo) || internalTrafficLocal {
+		if hasHostEndpoints(clusterEndpoints.V4IPs, netInfo) || hasHostEndpoints(clusterEndpoints.V6IPs, netInfo) || internalTrafficLocal ||
+			len(zoneEndpoints) > 0 {
 			perNodeConfigs = append(perNodeConfigs, clusterIPConfig)
 		} else {
 			clusterConfigs = append(clusterConfigs, clusterIPConfig)
@@ -618,6 +630,11 @@ func buildPerNodeLBs(service *corev1.Service, configs []lbConfig, nodes []nodeIn
 			switchRules := make([]LBRule, 0, len(configs))
 
 			for _, cfg := range configs {
+				// with topology aware routing, the node's zone endpoints
+				// replace the cluster endpoints.
+				if zoneEndpoints, ok := cfg.zoneEndpoints[node.topologyZone]; ok && node.topologyZone != "" {
+					cfg.clusterEndpoints = zoneEndpoints
+				}
 
 				switchV4TargetIPs, switchV6TargetIPs, _, _ := makeNodeSwitchTargetIPs(node.name, &cfg)
 
@@ -821,6 +838,19 @@ func lbOpts(service *corev1.Service) LBOpts {
 	if affinity {
 		lbOptions.AffinityTimeOut = getSessionAffinityTimeOut(service)
 	}
+
+	if svcOpts := getLBServiceOptions(service); svcOpts != nil {
+		// ClientIP session affinity takes precedence over the hash fields
+		if !affinity {
+			lbOptions.HashFields = svcOpts.HashFields
+		}
+		if svcOpts.EmptyBackends == EmptyBackendsDrop {
+			lbOptions.Reject = false
+		}
+		lbOptions.HairpinSNATIPs = svcOpts.HairpinSNATIPs
+	}
+
+	lbOptions.HealthCheck = getLBHealthCheck(service)
 	return lbOptions
 }
 
@@ -830,6 +860,8 @@ func lbTemplateOpts(service *corev1.Service, addressFamily corev1.IPFamily) LBOp
 	// Only template LBs need an explicit address family.
 	lbOptions.AddressFamily = addressFamily
 	lbOptions.Template = true
+	// OVN health checks need the actual VIPs, template VIPs are not supported.
+	lbOptions.HealthCheck = nil
 	return lbOptions
 }
 
@@ -3202,6 +3202,240 @@ func Test_buildPerNodeLBs(t *testing.T) {
 
 }
 
+func Test_buildLBsTopologyAware(t *testing.T) {
+	oldClusterSubnet := globalconfig.Default.ClusterSubnets
+	oldGwMode := globalconfig.Gateway.Mode
+	oldServiceCIDRs := globalconfig.Kubernetes.ServiceCIDRs
+	oldIPv4Mode := globalconfig.IPv4Mode
+	defer func() {
+		globalconfig.IPv4Mode = oldIPv4Mode
+		globalconfig.Gateway.Mode = oldGwMode
+		globalconfig.Default.ClusterSubnets = oldClusterSubnet
+		globalconfig.Kubernetes.ServiceCIDRs = oldServiceCIDRs
+	}()
+	_, cidr4, _ := net.ParseCIDR("10.128.0.0/16")
+	globalconfig.Default.ClusterSubnets = []globalconfig.CIDRNetworkEntry{{CIDR: cidr4, HostSubnetLength: 24}}
+	_, svcCIDRv4, _ := net.ParseCIDR("192.168.0.0/24")
+	globalconfig.Kubernetes.ServiceCIDRs = []*net.IPNet{svcCIDRv4}
+	globalconfig.IPv4Mode = true
+	globalconfig.Gateway.Mode = globalconfig.GatewayModeShared
+
+	name := "foo"
+	namespace := "testns"
+	nodeC := "node-c"
+	portName := "http"
+	proto := corev1.ProtocolTCP
+	outport := int32(8080)
+
+	l3UDN, err := getSampleUDNNetInfo(namespace, "layer3")
+	require.NoError(t, err)
+
+	nodes := []nodeInfo{
+		{
+			name:               nodeA,
+			l3gatewayAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			hostAddresses:      []net.IP{net.ParseIP("10.0.0.1")},
+			gatewayRouterName:  "gr-node-a",
+			switchName:         "switch-node-a",
+			topologyZone:       "zone-a",
+		},
+		{
+			name:               nodeB,
+			l3gatewayAddresses: []net.IP{net.ParseIP("10.0.0.2")},
+			hostAddresses:      []net.IP{net.ParseIP("10.0.0.2")},
+			gatewayRouterName:  "gr-node-b",
+			switchName:         "switch-node-b",
+			topologyZone:       "zone-b",
+		},
+		{
+			name:               nodeC,
+			l3gatewayAddresses: []net.IP{net.ParseIP("10.0.0.3")},
+			hostAddresses:      []net.IP{net.ParseIP("10.0.0.3")},
+			gatewayRouterName:  "gr-node-c",
+			switchName:         "switch-node-c",
+			topologyZone:       "zone-c",
+		},
+	}
+
+	makeEndpoint := func(node, ip, zone string, hints ...string) discovery.Endpoint {
+		ep := kubetest.MakeReadyEndpoint(node, ip)
+		ep.Zone = &zone
+		if len(hints) > 0 {
+			ep.Hints = &discovery.EndpointHints{}
+			for _, hint := range hints {
+				ep.Hints.ForZones = append(ep.Hints.ForZones, discovery.ForZone{Name: hint})
+			}
+		}
+		return ep
+	}
+	makeSlices := func(endpoints ...discovery.Endpoint) []*discovery.EndpointSlice {
+		return []*discovery.EndpointSlice{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "ab1",
+				Namespace: namespace,
+				Labels:    map[string]string{discovery.LabelServiceName: name},
+			},
+			Ports: []discovery.EndpointPort{{
+				Protocol: &proto,
+				Port:     &outport,
+				Name:     &portName,
+			}},
+			AddressType: discovery.AddressTypeIPv4,
+			Endpoints:   endpoints,
+		}}
+	}
+	makeService := func(trafficDistribution *string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.ServiceSpec{
+				Type:                corev1.ServiceTypeClusterIP,
+				ClusterIP:           "192.168.1.1",
+				ClusterIPs:          []string{"192.168.1.1"},
+				Ports:               []corev1.ServicePort{{Name: portName, Port: 80, Protocol: proto, TargetPort: intstr.FromInt32(outport)}},
+				TrafficDistribution: trafficDistribution,
+			},
+		}
+	}
+	makeLB := func(node, router, nodeSwitch string, targets ...string) LB {
+		lb := LB{
+			Name:        "Service_testns/foo_TCP_node_router+switch_" + node,
+			ExternalIDs: loadBalancerExternalIDs(namespacedServiceName(namespace, name)),
+			Routers:     []string{router},
+			Switches:    []string{nodeSwitch},
+			Protocol:    "TCP",
+			Rules:       []LBRule{{Source: Addr{IP: "192.168.1.1", Port: 80}}},
+			Opts:        LBOpts{Reject: true},
+		}
+		for _, target := range targets {
+			lb.Rules[0].Targets = append(lb.Rules[0].Targets, Addr{IP: target, Port: outport})
+		}
+		return lb
+	}
+
+	type testCase struct {
+		name                string
+		service             *corev1.Service
+		slices              []*discovery.EndpointSlice
+		expectedZones       map[string]util.LBEndpoints
+		expectedPerNodeLBs  []LB
+		expectedClusterWide bool
+	}
+	// endpoints ipA and ipB run on nodes A (zone-a) and B (zone-b)
+	makeTests := func(ipA, ipB string) []testCase {
+		return []testCase{
+			{
+				name:    "endpoints with zone hints are preferred by their zone",
+				service: makeService(nil),
+				slices: makeSlices(
+					makeEndpoint(nodeA, ipA, "zone-a", "zone-a"),
+					makeEndpoint(nodeB, ipB, "zone-b", "zone-b"),
+				),
+				expectedZones: map[string]util.LBEndpoints{
+					"zone-a": {V4IPs: []string{ipA}, Port: outport},
+					"zone-b": {V4IPs: []string{ipB}, Port: outport},
+				},
+				expectedPerNodeLBs: []LB{
+					makeLB(nodeA, "gr-node-a", "switch-node-a", ipA),
+					makeLB(nodeB, "gr-node-b", "switch-node-b", ipB),
+					// zone without endpoints falls back to all the endpoints
+					makeLB(nodeC, "gr-node-c", "switch-node-c", ipA, ipB),
+				},
+			},
+			{
+				name:    "endpoints hinted for another zone",
+				service: makeService(nil),
+				slices: makeSlices(
+					makeEndpoint(nodeA, ipA, "zone-a", "zone-a", "zone-c"),
+					makeEndpoint(nodeB, ipB, "zone-b", "zone-b"),
+				),
+				expectedZones: map[string]util.LBEndpoints{
+					"zone-a": {V4IPs: []string{ipA}, Port: outport},
+					"zone-b": {V4IPs: []string{ipB}, Port: outport},
+					"zone-c": {V4IPs: []string{ipA}, Port: outport},
+				},
+				expectedPerNodeLBs: func() []LB {
+					// nodes of zones with the same endpoints share the same LB
+					merged := makeLB(nodeA, "gr-node-a", "switch-node-a", ipA)
+					merged.Name += "_merged"
+					merged.Routers = append(merged.Routers, "gr-node-c")
+					merged.Switches = append(merged.Switches, "switch-node-c")
+					return []LB{merged, makeLB(nodeB, "gr-node-b", "switch-node-b", ipB)}
+				}(),
+			},
+			{
+				name:    "endpoints without hints are ignored without trafficDistribution",
+				service: makeService(nil),
+				slices: makeSlices(
+					makeEndpoint(nodeA, ipA, "zone-a"),
+					makeEndpoint(nodeB, ipB, "zone-b"),
+				),
+				expectedClusterWide: true,
+			},
+			{
+				name:    "trafficDistribution PreferClose uses the endpoint zones without hints",
+				service: makeService(ptr.To(corev1.ServiceTrafficDistributionPreferClose)),
+				slices: makeSlices(
+					makeEndpoint(nodeA, ipA, "zone-a"),
+					makeEndpoint(nodeB, ipB, "zone-b"),
+				),
+				expectedZones: map[string]util.LBEndpoints{
+					"zone-a": {V4IPs: []string{ipA}, Port: outport},
+					"zone-b": {V4IPs: []string{ipB}, Port: outport},
+				},
+				expectedPerNodeLBs: []LB{
+					makeLB(nodeA, "gr-node-a", "switch-node-a", ipA),
+					makeLB(nodeB, "gr-node-b", "switch-node-b", ipB),
+					makeLB(nodeC, "gr-node-c", "switch-node-c", ipA, ipB),
+				},
+			},
+			{
+				name:    "partially hinted endpoints fall back to all the endpoints",
+				service: makeService(nil),
+				slices: makeSlices(
+					makeEndpoint(nodeA, ipA, "zone-a", "zone-a"),
+					makeEndpoint(nodeB, ipB, "zone-b"),
+				),
+				expectedClusterWide: true,
+			},
+		}
+	}
+
+	for _, network := range []struct {
+		netInfo  util.NetInfo
+		ipA, ipB string
+	}{
+		{netInfo: &util.DefaultNetInfo{}, ipA: "10.128.0.2", ipB: "10.128.1.2"},
+		{netInfo: l3UDN, ipA: "192.168.200.2", ipB: "192.168.201.2"},
+	} {
+		netInfo := network.netInfo
+		for i, tt := range makeTests(network.ipA, network.ipB) {
+			t.Run(fmt.Sprintf("%s_%d_%s", netInfo.GetNetworkName(), i, tt.name), func(t *testing.T) {
+				perNode, template, clusterWide := buildServiceLBConfigs(tt.service, tt.slices, nodes, true, true, netInfo)
+				assert.Empty(t, template)
+				if tt.expectedClusterWide {
+					assert.Empty(t, perNode)
+					require.Len(t, clusterWide, 1)
+					assert.Nil(t, clusterWide[0].zoneEndpoints)
+					return
+				}
+				assert.Empty(t, clusterWide)
+				require.Len(t, perNode, 1)
+				assert.Equal(t, tt.expectedZones, perNode[0].zoneEndpoints)
+
+				expected := make([]LB, len(tt.expectedPerNodeLBs))
+				copy(expected, tt.expectedPerNodeLBs)
+				if !netInfo.IsDefault() {
+					for idx := range expected {
+						expected[idx].ExternalIDs = loadBalancerExternalIDsForNetwork(namespacedServiceName(namespace, name), netInfo.GetNetworkName())
+						expected[idx].Name = netInfo.GetNetworkScopedLoadBalancerName(expected[idx].Name)
+					}
+				}
+				assert.Equal(t, expected, buildPerNodeLBs(tt.service, perNode, nodes, netInfo))
+			})
+		}
+	}
+}
+
 func Test_idledServices(t *testing.T) {
 	serviceName := "foo"
 	ns := "testns"
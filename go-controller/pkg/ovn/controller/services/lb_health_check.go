@@ -0,0 +1,314 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+	"github.com/ovn-kubernetes/libovsdb/ovsdb"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// HealthCheckAnnotation enables OVN load balancer health checks for the
+// endpoints of a service. Its value is a JSON object with the optional fields
+// of LBHealthCheck, e.g. '{"interval": 2, "failureCount": 2}'; '{}' enables
+// health checks with the default values.
+const HealthCheckAnnotation = "k8s.ovn.org/health-check"
+
+const (
+	defaultHealthCheckInterval     = 5
+	defaultHealthCheckTimeout      = 3
+	defaultHealthCheckSuccessCount = 3
+	defaultHealthCheckFailureCount = 3
+)
+
+// LBHealthCheck is the configuration of the OVN health checks of the VIPs of
+// a load balancer. OVN probes each backend with a TCP SYN or a UDP datagram
+// and stops sending traffic to the backends that fail to reply.
+type LBHealthCheck struct {
+	// Interval is the time between two health checks of a backend, in seconds.
+	Interval int32 `json:"interval,omitempty"`
+	// Timeout is the time to wait for the reply of a backend, in seconds.
+	Timeout int32 `json:"timeout,omitempty"`
+	// SuccessCount is the number of successful health checks after which a
+	// backend is considered healthy.
+	SuccessCount int32 `json:"successCount,omitempty"`
+	// FailureCount is the number of failed health checks after which a
+	// backend is considered unhealthy.
+	FailureCount int32 `json:"failureCount,omitempty"`
+}
+
+func (hc *LBHealthCheck) options() map[string]string {
+	return map[string]string{
+		"interval":      fmt.Sprintf("%d", hc.Interval),
+		"timeout":       fmt.Sprintf("%d", hc.Timeout),
+		"success_count": fmt.Sprintf("%d", hc.SuccessCount),
+		"failure_count": fmt.Sprintf("%d", hc.FailureCount),
+	}
+}
+
+// parseLBHealthCheck parses the HealthCheckAnnotation of a service, returning
+// nil if the service didn't request health checks.
+func parseLBHealthCheck(service *corev1.Service) (*LBHealthCheck, error) {
+	value, ok := service.Annotations[HealthCheckAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	hc := &LBHealthCheck{}
+	if err := json.Unmarshal([]byte(value), hc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation %q: %w", HealthCheckAnnotation, value, err)
+	}
+	if hc.Interval < 0 || hc.Timeout < 0 || hc.SuccessCount < 0 || hc.FailureCount < 0 {
+		return nil, fmt.Errorf("invalid %s annotation %q: values must be positive", HealthCheckAnnotation, value)
+	}
+	if hc.Interval == 0 {
+		hc.Interval = defaultHealthCheckInterval
+	}
+	if hc.Timeout == 0 {
+		hc.Timeout = defaultHealthCheckTimeout
+	}
+	if hc.SuccessCount == 0 {
+		hc.SuccessCount = defaultHealthCheckSuccessCount
+	}
+	if hc.FailureCount == 0 {
+		hc.FailureCount = defaultHealthCheckFailureCount
+	}
+	return hc, nil
+}
+
+// getLBHealthCheck returns the health check configuration requested by the
+// service, if health checks are enabled.
+func getLBHealthCheck(service *corev1.Service) *LBHealthCheck {
+	if !config.OVNKubernetesFeature.EnableServiceHealthChecks {
+		return nil
+	}
+	hc, err := parseLBHealthCheck(service)
+	if err != nil {
+		klog.Warningf("Ignoring health checks of service %s/%s: %v", service.Namespace, service.Name, err)
+		return nil
+	}
+	return hc
+}
+
+// buildLBIPPortMappings builds the OVN load balancer ip_port_mappings of the
+// endpoints of a service, mapping each endpoint IP to the logical port of its
+// pod and to the source IP of the health checks on the pod's node switch.
+// Only the endpoints of pods local to this zone can be health checked:
+// endpoints without a mapping are never considered unhealthy by OVN.
+func (c *Controller) buildLBIPPortMappings(service *corev1.Service, endpointSlices []*discovery.EndpointSlice) map[string]string {
+	nodes := make(map[string]*nodeInfo, len(c.nodeInfos))
+	for i := range c.nodeInfos {
+		nodes[c.nodeInfos[i].name] = &c.nodeInfos[i]
+	}
+
+	// source IPs that can't be used, per switch
+	srcIPsInUse := map[string]map[string]bool{}
+	mappings := map[string]string{}
+	for _, slice := range endpointSlices {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" || endpoint.NodeName == nil {
+				continue
+			}
+			node := nodes[*endpoint.NodeName]
+			if node == nil || node.zone != c.nodeTracker.zone {
+				continue
+			}
+			logicalPort, err := c.getPodLogicalPortName(endpoint.TargetRef.Namespace, endpoint.TargetRef.Name)
+			if err != nil {
+				klog.Warningf("Unable to health check endpoint of service %s/%s: %v", service.Namespace, service.Name, err)
+				continue
+			}
+			for _, address := range endpoint.Addresses {
+				ip := net.ParseIP(address)
+				if ip == nil {
+					continue
+				}
+				for _, subnet := range node.podSubnets {
+					if !subnet.Contains(ip) {
+						continue
+					}
+					srcIP := util.GetNodeServiceMonitorIfAddr(&subnet)
+					if srcIP == nil {
+						break
+					}
+					if srcIPsInUse[node.switchName] == nil {
+						srcIPsInUse[node.switchName] = c.getLogicalSwitchIPs(node.switchName)
+					}
+					if srcIPsInUse[node.switchName][srcIP.IP.String()] {
+						klog.Warningf("Unable to health check endpoint %s of service %s/%s: source IP %s is in use on switch %s",
+							address, service.Namespace, service.Name, srcIP.IP, node.switchName)
+						break
+					}
+					mappings[address] = logicalPort + ":" + formatIPPortMappingIP(srcIP.IP.String())
+					break
+				}
+			}
+		}
+	}
+	return mappings
+}
+
+// getLogicalSwitchIPs returns the IPs of the ports of a logical switch. The
+// health check source IP of a node subnet is reserved for new pods, but it is
+// in use by a pod if it was allocated before health checks were enabled.
+func (c *Controller) getLogicalSwitchIPs(switchName string) map[string]bool {
+	ips := map[string]bool{}
+	sw, err := libovsdbops.GetLogicalSwitch(c.nbClient, &nbdb.LogicalSwitch{Name: switchName})
+	if err != nil {
+		if !errors.Is(err, libovsdbclient.ErrNotFound) {
+			klog.Warningf("Unable to get logical switch %s: %v", switchName, err)
+		}
+		return ips
+	}
+	ports := sets.New(sw.Ports...)
+	lsps, err := libovsdbops.FindLogicalSwitchPortWithPredicate(c.nbClient, func(lsp *nbdb.LogicalSwitchPort) bool {
+		return ports.Has(lsp.UUID)
+	})
+	if err != nil {
+		klog.Warningf("Unable to get the ports of logical switch %s: %v", switchName, err)
+		return ips
+	}
+	for _, lsp := range lsps {
+		for _, address := range lsp.Addresses {
+			// addresses are formatted as "MAC IP1 IP2..."
+			fields := strings.Fields(address)
+			for i := 1; i < len(fields); i++ {
+				ips[fields[i]] = true
+			}
+		}
+	}
+	return ips
+}
+
+// formatIPPortMappingIP formats an IP for the ip_port_mappings of a load
+// balancer, OVN expects IPv6 addresses enclosed in brackets.
+func formatIPPortMappingIP(ip string) string {
+	if utilnet.IsIPv6String(ip) {
+		return "[" + ip + "]"
+	}
+	return ip
+}
+
+func (c *Controller) getPodLogicalPortName(namespace, name string) (string, error) {
+	if c.netInfo.IsDefault() {
+		return util.GetLogicalPortName(namespace, name), nil
+	}
+	nadName, err := c.networkManager.GetPrimaryNADForNamespace(namespace)
+	if err != nil {
+		return "", err
+	}
+	return util.GetUserDefinedNetworkLogicalPortName(namespace, name, nadName), nil
+}
+
+// setLBIPPortMappings sets the ip_port_mappings of the load balancers with
+// health checks to the mappings of their targets.
+func setLBIPPortMappings(lbs []LB, mappings map[string]string) {
+	for i := range lbs {
+		lb := &lbs[i]
+		// OVN only supports TCP and UDP health checks
+		if lb.Opts.HealthCheck == nil || strings.EqualFold(lb.Protocol, string(corev1.ProtocolSCTP)) {
+			continue
+		}
+		for _, rule := range lb.Rules {
+			for _, target := range rule.Targets {
+				mapping, ok := mappings[target.IP]
+				if !ok {
+					continue
+				}
+				if lb.IPPortMappings == nil {
+					lb.IPPortMappings = map[string]string{}
+				}
+				lb.IPPortMappings[formatIPPortMappingIP(target.IP)] = mapping
+			}
+		}
+	}
+}
+
+// buildLBHealthChecks returns the OVN health checks of a load balancer, one
+// per VIP, reusing the existing health checks of the same VIPs so that OVN
+// doesn't reset the state of their monitors.
+func buildLBHealthChecks(lb *LB, existing []*nbdb.LoadBalancerHealthCheck) []*nbdb.LoadBalancerHealthCheck {
+	if lb.Opts.HealthCheck == nil || len(lb.IPPortMappings) == 0 {
+		return nil
+	}
+	existingByVip := make(map[string]*nbdb.LoadBalancerHealthCheck, len(existing))
+	for _, hc := range existing {
+		existingByVip[hc.Vip] = hc
+	}
+	hcs := make([]*nbdb.LoadBalancerHealthCheck, 0, len(lb.Rules))
+	for _, rule := range lb.Rules {
+		if rule.Source.Template != nil || !hasMappedTarget(rule, lb.IPPortMappings) {
+			continue
+		}
+		vip := rule.Source.String()
+		hc := libovsdbops.BuildLoadBalancerHealthCheck(vip, lb.Opts.HealthCheck.options(), lb.ExternalIDs)
+		if existingHC, ok := existingByVip[vip]; ok {
+			hc.UUID = existingHC.UUID
+		}
+		hcs = append(hcs, hc)
+	}
+	return hcs
+}
+
+func hasMappedTarget(rule LBRule, mappings map[string]string) bool {
+	for _, target := range rule.Targets {
+		if _, ok := mappings[formatIPPortMappingIP(target.IP)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureLBHealthChecksOps returns the ops to create or update the health checks
+// of the provided load balancer and updates its health check references and
+// ip_port_mappings accordingly. Health checks that are no longer referenced
+// are garbage collected by OVSDB.
+func ensureLBHealthChecksOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation, lb *LB, nbLB *nbdb.LoadBalancer) ([]ovsdb.Operation, error) {
+	var existing []*nbdb.LoadBalancerHealthCheck
+	if nbLB.UUID != "" {
+		existingLB, err := libovsdbops.GetLoadBalancer(nbClient, &nbdb.LoadBalancer{UUID: nbLB.UUID})
+		if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get load balancer %s: %w", nbLB.Name, err)
+		}
+		if existingLB != nil {
+			existing, err = libovsdbops.GetLoadBalancerHealthChecks(nbClient, existingLB)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get health checks of load balancer %s: %w", nbLB.Name, err)
+			}
+		}
+	}
+
+	hcs := buildLBHealthChecks(lb, existing)
+	if len(hcs) == 0 {
+		if len(existing) > 0 {
+			// clear health checks that are not wanted anymore
+			nbLB.HealthCheck = []string{}
+			nbLB.IPPortMappings = map[string]string{}
+		}
+		return ops, nil
+	}
+
+	ops, err := libovsdbops.CreateOrUpdateLoadBalancerHealthChecksOps(nbClient, ops, hcs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ops for health checks of load balancer %s: %w", nbLB.Name, err)
+	}
+	nbLB.HealthCheck = make([]string, 0, len(hcs))
+	for _, hc := range hcs {
+		nbLB.HealthCheck = append(nbLB.HealthCheck, hc.UUID)
+	}
+	nbLB.IPPortMappings = lb.IPPortMappings
+	return ops, nil
+}
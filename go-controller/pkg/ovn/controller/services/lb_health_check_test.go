@@ -0,0 +1,232 @@
+package services
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func TestParseLBHealthCheck(t *testing.T) {
+	tests := []struct {
+		desc        string
+		annotations map[string]string
+		expected    *LBHealthCheck
+		expectErr   bool
+	}{
+		{
+			desc: "no annotation",
+		},
+		{
+			desc:        "empty object uses the defaults",
+			annotations: map[string]string{HealthCheckAnnotation: "{}"},
+			expected:    &LBHealthCheck{Interval: 5, Timeout: 3, SuccessCount: 3, FailureCount: 3},
+		},
+		{
+			desc:        "explicit values",
+			annotations: map[string]string{HealthCheckAnnotation: `{"interval": 2, "timeout": 1, "successCount": 1, "failureCount": 2}`},
+			expected:    &LBHealthCheck{Interval: 2, Timeout: 1, SuccessCount: 1, FailureCount: 2},
+		},
+		{
+			desc:        "invalid JSON",
+			annotations: map[string]string{HealthCheckAnnotation: "true"},
+			expectErr:   true,
+		},
+		{
+			desc:        "negative value",
+			annotations: map[string]string{HealthCheckAnnotation: `{"interval": -1}`},
+			expectErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: tt.annotations}}
+			hc, err := parseLBHealthCheck(service)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, hc)
+		})
+	}
+}
+
+func TestBuildLBIPPortMappings(t *testing.T) {
+	const (
+		nodeA = "node-a"
+		nodeB = "node-b"
+	)
+	_, subnetA4, _ := net.ParseCIDR("10.128.0.0/24")
+	_, subnetA6, _ := net.ParseCIDR("fd00:10:128::/64")
+	_, subnetB4, _ := net.ParseCIDR("10.128.1.0/24")
+	// a pod of node-b got the health check source IP before health checks were
+	// enabled, the backends of node-b can't be health checked until it is gone
+	nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+		NBData: []libovsdbtest.TestData{
+			&nbdb.LogicalSwitch{UUID: nodeA + "-UUID", Name: nodeA},
+			&nbdb.LogicalSwitchPort{UUID: "old-pod-UUID", Name: "testns_old-pod", Addresses: []string{"0a:58:0a:80:01:fe 10.128.1.254"}},
+			&nbdb.LogicalSwitch{UUID: nodeB + "-UUID", Name: nodeB, Ports: []string{"old-pod-UUID"}},
+		},
+	}, nil)
+	require.NoError(t, err)
+	t.Cleanup(cleanup.Cleanup)
+
+	c := &Controller{
+		nbClient:    nbClient,
+		netInfo:     &util.DefaultNetInfo{},
+		nodeTracker: &nodeTracker{zone: "zone"},
+		nodeInfos: []nodeInfo{
+			{name: nodeA, switchName: nodeA, zone: "zone", podSubnets: []net.IPNet{*subnetA4, *subnetA6}},
+			{name: nodeB, switchName: nodeB, zone: "zone", podSubnets: []net.IPNet{*subnetB4}},
+		},
+	}
+	endpoint := func(podName, nodeName string, addresses ...string) discovery.Endpoint {
+		return discovery.Endpoint{
+			Addresses: addresses,
+			NodeName:  ptr.To(nodeName),
+			TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: podName},
+		}
+	}
+	endpointSlices := []*discovery.EndpointSlice{
+		{Endpoints: []discovery.Endpoint{
+			endpoint("pod1", nodeA, "10.128.0.5"),
+			endpoint("pod2", nodeB, "10.128.1.5"),
+		}},
+		{Endpoints: []discovery.Endpoint{
+			endpoint("pod1", nodeA, "fd00:10:128::5"),
+		}},
+	}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	mappings := c.buildLBIPPortMappings(service, endpointSlices)
+	assert.Equal(t, map[string]string{
+		"10.128.0.5":     "testns_pod1:10.128.0.254",
+		"fd00:10:128::5": "testns_pod1:[fd00:10:128:0:ffff:ffff:ffff:fffe]",
+	}, mappings)
+
+	// IPv6 backends are enclosed in brackets in the load balancer mappings
+	lbs := []LB{{
+		Protocol: "TCP",
+		Opts:     LBOpts{HealthCheck: &LBHealthCheck{}},
+		Rules: []LBRule{{
+			Source:  Addr{IP: "fd00:10:96::1", Port: 80},
+			Targets: []Addr{{IP: "fd00:10:128::5", Port: 8080}},
+		}},
+	}}
+	setLBIPPortMappings(lbs, mappings)
+	assert.Equal(t, map[string]string{"[fd00:10:128::5]": "testns_pod1:[fd00:10:128:0:ffff:ffff:ffff:fffe]"}, lbs[0].IPPortMappings)
+	assert.Len(t, buildLBHealthChecks(&lbs[0], nil), 1)
+}
+
+func TestSetLBIPPortMappings(t *testing.T) {
+	hc := &LBHealthCheck{Interval: 5, Timeout: 3, SuccessCount: 3, FailureCount: 3}
+	mappings := map[string]string{
+		"10.128.0.5": "testns_pod1:10.128.1.254",
+		"10.128.1.5": "testns_pod2:10.128.1.254",
+	}
+	lbs := []LB{
+		{
+			Name:     "with health check",
+			Protocol: "TCP",
+			Opts:     LBOpts{HealthCheck: hc},
+			Rules: []LBRule{{
+				Source:  Addr{IP: "192.168.0.1", Port: 80},
+				Targets: []Addr{{IP: "10.128.0.5", Port: 8080}, {IP: "10.129.0.5", Port: 8080}},
+			}},
+		},
+		{
+			Name:     "without health check",
+			Protocol: "TCP",
+			Rules: []LBRule{{
+				Source:  Addr{IP: "192.168.0.1", Port: 80},
+				Targets: []Addr{{IP: "10.128.0.5", Port: 8080}},
+			}},
+		},
+		{
+			Name:     "sctp",
+			Protocol: "SCTP",
+			Opts:     LBOpts{HealthCheck: hc},
+			Rules: []LBRule{{
+				Source:  Addr{IP: "192.168.0.1", Port: 80},
+				Targets: []Addr{{IP: "10.128.0.5", Port: 8080}},
+			}},
+		},
+	}
+	setLBIPPortMappings(lbs, mappings)
+	assert.Equal(t, map[string]string{"10.128.0.5": "testns_pod1:10.128.1.254"}, lbs[0].IPPortMappings)
+	assert.Nil(t, lbs[1].IPPortMappings)
+	assert.Nil(t, lbs[2].IPPortMappings)
+}
+
+func TestEnsureLBsHealthChecks(t *testing.T) {
+	nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{}, nil)
+	require.NoError(t, err)
+	t.Cleanup(cleanup.Cleanup)
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	newLB := func(hc *LBHealthCheck, mappings map[string]string) []LB {
+		return []LB{{
+			Name:           "Service_testns/foo_TCP_cluster",
+			ExternalIDs:    loadBalancerExternalIDs(namespacedServiceName(namespace, name)),
+			Protocol:       "TCP",
+			Opts:           LBOpts{HealthCheck: hc},
+			IPPortMappings: mappings,
+			Rules: []LBRule{
+				{
+					Source:  Addr{IP: "192.168.0.1", Port: 80},
+					Targets: []Addr{{IP: "10.128.0.5", Port: 8080}},
+				},
+				{
+					Source:  Addr{IP: "192.168.0.1", Port: 443},
+					Targets: []Addr{{IP: "10.128.0.6", Port: 8443}},
+				},
+			},
+		}}
+	}
+	getLB := func(lbs []LB) (*nbdb.LoadBalancer, []*nbdb.LoadBalancerHealthCheck) {
+		lb, err := libovsdbops.GetLoadBalancer(nbClient, &nbdb.LoadBalancer{UUID: lbs[0].UUID})
+		require.NoError(t, err)
+		hcs, err := libovsdbops.GetLoadBalancerHealthChecks(nbClient, lb)
+		require.NoError(t, err)
+		return lb, hcs
+	}
+	mappings := map[string]string{"10.128.0.5": "testns_pod1:10.128.0.254"}
+
+	// only the VIP with a health checked backend gets a health check
+	lbs := newLB(&LBHealthCheck{Interval: 5, Timeout: 3, SuccessCount: 3, FailureCount: 3}, mappings)
+	require.NoError(t, EnsureLBs(nbClient, service, nil, lbs, &util.DefaultNetInfo{}))
+	lb, hcs := getLB(lbs)
+	assert.Equal(t, mappings, lb.IPPortMappings)
+	require.Len(t, hcs, 1)
+	assert.Equal(t, "192.168.0.1:80", hcs[0].Vip)
+	assert.Equal(t, map[string]string{"interval": "5", "timeout": "3", "success_count": "3", "failure_count": "3"}, hcs[0].Options)
+	hcUUID := hcs[0].UUID
+
+	// updating the options keeps the same health check
+	existing := lbs
+	lbs = newLB(&LBHealthCheck{Interval: 2, Timeout: 1, SuccessCount: 3, FailureCount: 2}, mappings)
+	require.NoError(t, EnsureLBs(nbClient, service, existing, lbs, &util.DefaultNetInfo{}))
+	_, hcs = getLB(lbs)
+	require.Len(t, hcs, 1)
+	assert.Equal(t, hcUUID, hcs[0].UUID)
+	assert.Equal(t, "2", hcs[0].Options["interval"])
+
+	// disabling health checks removes them
+	existing = lbs
+	lbs = newLB(nil, nil)
+	require.NoError(t, EnsureLBs(nbClient, service, existing, lbs, &util.DefaultNetInfo{}))
+	lb, hcs = getLB(lbs)
+	assert.Empty(t, lb.IPPortMappings)
+	assert.Empty(t, hcs)
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+)
+
+// LoadBalancerOptionsAnnotation customizes the OVN load balancers of a
+// service. Its value is a JSON object with the optional fields of
+// LBServiceOptions, e.g. '{"hashFields": ["ip_src", "tp_src"], "emptyBackends": "drop"}'.
+const LoadBalancerOptionsAnnotation = "k8s.ovn.org/load-balancer-options"
+
+const (
+	// EmptyBackendsReject sends back a TCP RST or an ICMP port unreachable
+	// for the traffic to a service without endpoints. This is the default.
+	EmptyBackendsReject = "reject"
+	// EmptyBackendsDrop silently drops the traffic to a service without
+	// endpoints.
+	EmptyBackendsDrop = "drop"
+)
+
+// validHashFields are the packet fields OVN can hash to select a backend.
+var validHashFields = sets.New[nbdb.LoadBalancerSelectionFields](
+	nbdb.LoadBalancerSelectionFieldsEthSrc,
+	nbdb.LoadBalancerSelectionFieldsEthDst,
+	nbdb.LoadBalancerSelectionFieldsIPSrc,
+	nbdb.LoadBalancerSelectionFieldsIPDst,
+	nbdb.LoadBalancerSelectionFieldsIpv6Src,
+	nbdb.LoadBalancerSelectionFieldsIpv6Dst,
+	nbdb.LoadBalancerSelectionFieldsTpSrc,
+	nbdb.LoadBalancerSelectionFieldsTpDst,
+)
+
+// LBServiceOptions are the load balancer options a service can set through
+// the LoadBalancerOptionsAnnotation.
+type LBServiceOptions struct {
+	// HashFields are the packet fields hashed by OVN to select the backend of
+	// a connection, instead of the default 5-tuple. Ignored for services with
+	// ClientIP session affinity.
+	HashFields []nbdb.LoadBalancerSelectionFields `json:"hashFields,omitempty"`
+	// EmptyBackends is the behaviour for traffic to a service without
+	// endpoints, either "reject" (default) or "drop".
+	EmptyBackends string `json:"emptyBackends,omitempty"`
+	// HairpinSNATIPs are the source IPs used for the traffic sent by a
+	// backend to itself through the service, at most one per IP family of
+	// the cluster. Families without an IP use the default hairpin masquerade
+	// IP. Only the hairpin masquerade IPs are supported, since they are the
+	// only sources of hairpin traffic the network policies allow.
+	HairpinSNATIPs []string `json:"hairpinSNATIPs,omitempty"`
+}
+
+// parseLBServiceOptions parses the LoadBalancerOptionsAnnotation of a
+// service, returning nil if the service doesn't have it.
+func parseLBServiceOptions(service *corev1.Service) (*LBServiceOptions, error) {
+	value, ok := service.Annotations[LoadBalancerOptionsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	opts := &LBServiceOptions{}
+	if err := json.Unmarshal([]byte(value), opts); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation %q: %w", LoadBalancerOptionsAnnotation, value, err)
+	}
+	for _, field := range opts.HashFields {
+		if !validHashFields.Has(field) {
+			return nil, fmt.Errorf("invalid %s annotation %q: unsupported hash field %q", LoadBalancerOptionsAnnotation, value, field)
+		}
+	}
+	switch opts.EmptyBackends {
+	case "", EmptyBackendsReject, EmptyBackendsDrop:
+	default:
+		return nil, fmt.Errorf("invalid %s annotation %q: emptyBackends must be %q or %q",
+			LoadBalancerOptionsAnnotation, value, EmptyBackendsReject, EmptyBackendsDrop)
+	}
+	var hasV4, hasV6 bool
+	for _, ip := range opts.HairpinSNATIPs {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return nil, fmt.Errorf("invalid %s annotation %q: invalid hairpin SNAT IP %q", LoadBalancerOptionsAnnotation, value, ip)
+		}
+		isIPv6 := utilnet.IsIPv6(parsed)
+		if isIPv6 && !config.IPv6Mode || !isIPv6 && !config.IPv4Mode {
+			return nil, fmt.Errorf("invalid %s annotation %q: hairpin SNAT IP %q is not of an IP family of the cluster",
+				LoadBalancerOptionsAnnotation, value, ip)
+		}
+		if isIPv6 && hasV6 || !isIPv6 && hasV4 {
+			return nil, fmt.Errorf("invalid %s annotation %q: only one hairpin SNAT IP per IP family is supported", LoadBalancerOptionsAnnotation, value)
+		}
+		// the hairpin traffic is only allowed by the network policies from the hairpin masquerade IPs
+		masqueradeIP := config.Gateway.MasqueradeIPs.V4OVNServiceHairpinMasqueradeIP
+		if isIPv6 {
+			masqueradeIP = config.Gateway.MasqueradeIPs.V6OVNServiceHairpinMasqueradeIP
+		}
+		if !parsed.Equal(masqueradeIP) {
+			return nil, fmt.Errorf("invalid %s annotation %q: hairpin SNAT IP %q is not the hairpin masquerade IP %s",
+				LoadBalancerOptionsAnnotation, value, ip, masqueradeIP)
+		}
+		hasV6 = hasV6 || isIPv6
+		hasV4 = hasV4 || !isIPv6
+	}
+	return opts, nil
+}
+
+// getLBServiceOptions returns the load balancer options requested by the
+// service, ignoring and logging invalid ones.
+func getLBServiceOptions(service *corev1.Service) *LBServiceOptions {
+	opts, err := parseLBServiceOptions(service)
+	if err != nil {
+		klog.Warningf("Ignoring load balancer options of service %s/%s: %v", service.Namespace, service.Name, err)
+		return nil
+	}
+	return opts
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	globalconfig "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
+)
+
+func TestParseLBServiceOptions(t *testing.T) {
+	oldIPv4Mode, oldIPv6Mode := globalconfig.IPv4Mode, globalconfig.IPv6Mode
+	defer func() {
+		globalconfig.IPv4Mode, globalconfig.IPv6Mode = oldIPv4Mode, oldIPv6Mode
+	}()
+
+	tests := []struct {
+		ipv6Mode    bool
+		desc        string
+		annotations map[string]string
+		expected    *LBServiceOptions
+		expectErr   bool
+	}{
+		{
+			desc: "no annotation",
+		},
+		{
+			desc:        "all options",
+			ipv6Mode:    true,
+			annotations: map[string]string{LoadBalancerOptionsAnnotation: `{"hashFields": ["ip_src", "tp_src"], "emptyBackends": "drop", "hairpinSNATIPs": ["169.254.169.5", "fd69::5"]}`},
+			expected: &LBServiceOptions{
+				HashFields:     []nbdb.LoadBalancerSelectionFields{"ip_src", "tp_src"},
+				EmptyBackends:  EmptyBackendsDrop,
+				HairpinSNATIPs: []string{"169.254.169.5", "fd69::5"},
+			},
+		},
+		{
+			desc:        "invalid JSON",
+			annotations: map[string]string{LoadBalancerOptionsAnnotation: `{"hashFields": "ip_src"}`},
+			expectErr:   true,
+		},
+		{
+			desc:        "unsupported hash field",
+			annotations: map[string]string{LoadBalancerOptionsAnnotation: `{"hashFields": ["vlan"]}`},
+			expectErr:   true,
+		},
+		{
+			desc:        "unsupported empty backends behaviour",
+			annotations: map[string]string{LoadBalancerOptionsAnnotation: `{"emptyBackends": "accept"}`},
+			expectErr:   true,
+		},
+		{
+			desc:        "invalid hairpin SNAT IP",
+			annotations: map[string]string{LoadBalancerOptionsAnnotation: `{"hairpinSNATIPs": ["169.254.169"]}`},
+			expectErr:   true,
+		},
+		{
+			desc:        "two hairpin SNAT IPs of the same family",
+			annotations: map[string]string{LoadBalancerOptionsAnnotation: `{"hairpinSNATIPs": ["169.254.169.5", "169.254.169.5"]}`},
+			expectErr:   true,
+		},
+		{
+			desc:        "hairpin SNAT IP of an IP family not in the cluster",
+			annotations: map[string]string{LoadBalancerOptionsAnnotation: `{"hairpinSNATIPs": ["fd69::5"]}`},
+			expectErr:   true,
+		},
+		{
+			desc:        "hairpin SNAT IP other than the hairpin masquerade IP",
+			annotations: map[string]string{LoadBalancerOptionsAnnotation: `{"hairpinSNATIPs": ["169.254.169.50"]}`},
+			expectErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			globalconfig.IPv4Mode, globalconfig.IPv6Mode = true, tt.ipv6Mode
+			service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: tt.annotations}}
+			opts, err := parseLBServiceOptions(service)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, opts)
+		})
+	}
+}
+
+func TestLBOptsServiceOptions(t *testing.T) {
+	oldIPv4Mode := globalconfig.IPv4Mode
+	defer func() {
+		globalconfig.IPv4Mode = oldIPv4Mode
+	}()
+	globalconfig.IPv4Mode = true
+
+	annotations := map[string]string{LoadBalancerOptionsAnnotation: `{"hashFields": ["ip_src"], "emptyBackends": "drop", "hairpinSNATIPs": ["169.254.169.5"]}`}
+	tests := []struct {
+		desc     string
+		service  *corev1.Service
+		expected LBOpts
+	}{
+		{
+			desc:    "options are applied",
+			service: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: annotations}},
+			expected: LBOpts{
+				Reject:         false,
+				HashFields:     []nbdb.LoadBalancerSelectionFields{"ip_src"},
+				HairpinSNATIPs: []string{"169.254.169.5"},
+			},
+		},
+		{
+			desc: "session affinity takes precedence over the hash fields",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: annotations},
+				Spec:       corev1.ServiceSpec{SessionAffinity: corev1.ServiceAffinityClientIP},
+			},
+			expected: LBOpts{
+				Reject:          false,
+				AffinityTimeOut: 10800,
+				HairpinSNATIPs:  []string{"169.254.169.5"},
+			},
+		},
+		{
+			desc: "invalid options are ignored",
+			service: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace,
+				Annotations: map[string]string{LoadBalancerOptionsAnnotation: `{"emptyBackends": "accept"}`}}},
+			expected: LBOpts{
+				Reject: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lbOpts(tt.service))
+		})
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/apis/core"
+	utilnet "k8s.io/utils/net"
 
 	libovsdbclient "github.com/ovn-kubernetes/libovsdb/client"
+	"github.com/ovn-kubernetes/libovsdb/ovsdb"
 
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
@@ -32,6 +34,10 @@ type LB struct {
 
 	Templates TemplateMap // Templates that this LB uses as backends.
 
+	// IPPortMappings maps the health checked backends to their logical port
+	// and the health check source IP, as expected by OVN.
+	IPPortMappings map[string]string
+
 	// the names of logical switches, routers and LB groups that this LB should be attached to
 	Switches []string
 	Routers  []string
@@ -56,6 +62,16 @@ type LBOpts struct {
 
 	// Only useful for template LBs.
 	AddressFamily corev1.IPFamily
+
+	// If not nil, then OVN health checks the backends of each VIP.
+	HealthCheck *LBHealthCheck
+
+	// If not empty, the packet fields hashed to select a backend.
+	HashFields []nbdb.LoadBalancerSelectionFields
+
+	// If not empty, the source IPs of the hairpin traffic, overriding the
+	// default hairpin masquerade IP of their family.
+	HairpinSNATIPs []string
 }
 
 type Addr struct {
@@ -143,6 +159,8 @@ func EnsureLBs(nbClient libovsdbclient.Client, service *corev1.Service, existing
 		toDelete[lb.UUID] = lb
 	}
 
+	var ops []ovsdb.Operation
+	var err error
 	tlbs := make([]*templateLoadBalancer, 0, len(LBs))
 	addLBsToSwitch := map[string][]*templateLoadBalancer{}
 	removeLBsFromSwitch := map[string][]*templateLoadBalancer{}
@@ -175,9 +193,15 @@ func EnsureLBs(nbClient libovsdbclient.Client, service *corev1.Service, existing
 		mapLBDifferenceByKey(removesLBsFromRouter, existingRouters, wantRouters, blb)
 		mapLBDifferenceByKey(addLBsToGroups, wantGroups, existingGroups, blb)
 		mapLBDifferenceByKey(removeLBsFromGroups, existingGroups, wantGroups, blb)
+
+		ops, err = ensureLBHealthChecksOps(nbClient, ops, &lb, blb.nbLB)
+		if err != nil {
+			return fmt.Errorf("failed to create ops for health checks of service %s/%s: %w",
+				service.Namespace, service.Name, err)
+		}
 	}
 
-	ops, err := libovsdbops.CreateOrUpdateLoadBalancersOps(nbClient, nil, toNBLoadBalancerList(tlbs)...)
+	ops, err = libovsdbops.CreateOrUpdateLoadBalancersOps(nbClient, ops, toNBLoadBalancerList(tlbs)...)
 	if err != nil {
 		return err
 	}
@@ -353,17 +377,27 @@ func buildLB(lb *LB) *templateLoadBalancer {
 		emptyLb = "true"
 	}
 
+	hairpinSNATIPV4 := config.Gateway.MasqueradeIPs.V4OVNServiceHairpinMasqueradeIP.String()
+	hairpinSNATIPV6 := config.Gateway.MasqueradeIPs.V6OVNServiceHairpinMasqueradeIP.String()
+	for _, ip := range lb.Opts.HairpinSNATIPs {
+		if utilnet.IsIPv6String(ip) {
+			hairpinSNATIPV6 = ip
+		} else {
+			hairpinSNATIPV4 = ip
+		}
+	}
+
 	options := map[string]string{
 		"reject":             reject,
 		"event":              emptyLb,
 		"skip_snat":          skipSNAT,
 		"neighbor_responder": "none",
-		"hairpin_snat_ip":    fmt.Sprintf("%s %s", config.Gateway.MasqueradeIPs.V4OVNServiceHairpinMasqueradeIP.String(), config.Gateway.MasqueradeIPs.V6OVNServiceHairpinMasqueradeIP.String()),
+		"hairpin_snat_ip":    fmt.Sprintf("%s %s", hairpinSNATIPV4, hairpinSNATIPV6),
 	}
 
 	// Session affinity
 	// If enabled, then bucket flows by 3-tuple (proto, srcip, dstip) for the specific timeout value
-	// otherwise, use default ovn value
+	// otherwise, use the hash fields requested by the service or the default ovn value
 	selectionFields := []nbdb.LoadBalancerSelectionFields{}
 	if lb.Opts.AffinityTimeOut > 0 {
 		if lb.Opts.AffinityTimeOut != core.MaxClientIPServiceAffinitySeconds {
@@ -374,6 +408,8 @@ func buildLB(lb *LB) *templateLoadBalancer {
 				nbdb.LoadBalancerSelectionFieldsIPDst,
 			}
 		}
+	} else if len(lb.Opts.HashFields) > 0 {
+		selectionFields = lb.Opts.HashFields
 	}
 
 	if lb.Opts.Template {
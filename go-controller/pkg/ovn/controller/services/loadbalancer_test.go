@@ -187,6 +187,51 @@ func TestEnsureLBs(t *testing.T) {
 				ExternalIDs: loadBalancerExternalIDs(namespacedServiceName(namespace, name)),
 			},
 		},
+		{
+			desc: "create service with custom hash fields, drop and hairpin SNAT IP",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeClusterIP,
+				},
+			},
+			LBs: []LB{
+				{
+					Name:        "Service_foo/testns_TCP_cluster",
+					ExternalIDs: loadBalancerExternalIDs(namespacedServiceName(namespace, name)),
+					Routers:     []string{"gr-node-a"},
+					Protocol:    "TCP",
+					Rules: []LBRule{
+						{
+							Source:  Addr{IP: "192.168.1.1", Port: 80},
+							Targets: []Addr{{IP: "10.0.244.3", Port: 8080}},
+						},
+					},
+					UUID: "test-UUID",
+					Opts: LBOpts{
+						Reject:         false,
+						HashFields:     []nbdb.LoadBalancerSelectionFields{"ip_src", "tp_src"},
+						HairpinSNATIPs: []string{"169.254.169.50"},
+					},
+				},
+			},
+			finalLB: &nbdb.LoadBalancer{
+				UUID: clusterWideTCPServiceLoadBalancerName(name, namespace),
+				Name: clusterWideTCPServiceLoadBalancerName(name, namespace),
+				Options: func() map[string]string {
+					options := servicesOptions()
+					options["reject"] = "false"
+					options["hairpin_snat_ip"] = "169.254.169.50 fd69::5"
+					return options
+				}(),
+				Protocol: &nbdb.LoadBalancerProtocolTCP,
+				Vips: map[string]string{
+					"192.168.1.1:80": "10.0.244.3:8080",
+				},
+				ExternalIDs:     loadBalancerExternalIDs(namespacedServiceName(namespace, name)),
+				SelectionFields: []string{"ip_src", "tp_src"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -57,6 +57,10 @@ type nodeInfo struct {
 	// The node's zone
 	zone string
 
+	// The node's topology zone (topology.kubernetes.io/zone label), used for
+	// topology aware routing
+	topologyZone string
+
 	// The list of node's management IPs
 	mgmtIPs []net.IP
 }
@@ -116,6 +120,7 @@ func (nt *nodeTracker) Start(nodeInformer coreinformers.NodeInformer) (cache.Res
 			// - the name of the node (very rare) has changed
 			// - the `host-cidrs` annotation changed
 			// - node changes its zone
+			// - node changes its topology zone label
 			// - node becomes a hybrid overlay node from a ovn node or vice verse
 			// . No need to trigger update for any other field change.
 			if util.NodeSubnetAnnotationChangedForNetwork(oldObj, newObj, nt.netInfo.GetNetworkName()) ||
@@ -123,6 +128,7 @@ func (nt *nodeTracker) Start(nodeInformer coreinformers.NodeInformer) (cache.Res
 				oldObj.Name != newObj.Name ||
 				util.NodeHostCIDRsAnnotationChanged(oldObj, newObj) ||
 				util.NodeZoneAnnotationChanged(oldObj, newObj) ||
+				oldObj.Labels[corev1.LabelTopologyZone] != newObj.Labels[corev1.LabelTopologyZone] ||
 				util.NoHostSubnet(oldObj) != util.NoHostSubnet(newObj) {
 				nt.updateNode(newObj)
 			}
@@ -149,7 +155,7 @@ func (nt *nodeTracker) Start(nodeInformer coreinformers.NodeInformer) (cache.Res
 
 // updateNodeInfo updates the node info cache, and syncs all services
 // if it changed.
-func (nt *nodeTracker) updateNodeInfo(nodeName, switchName, routerName, chassisID string, l3gatewayAddresses, hostAddresses []net.IP, podSubnets []*net.IPNet, mgmtIPs []net.IP, zone string, nodePortDisabled bool) {
+func (nt *nodeTracker) updateNodeInfo(nodeName, switchName, routerName, chassisID string, l3gatewayAddresses, hostAddresses []net.IP, podSubnets []*net.IPNet, mgmtIPs []net.IP, zone, topologyZone string, nodePortDisabled bool) {
 	ni := nodeInfo{
 		name:               nodeName,
 		l3gatewayAddresses: l3gatewayAddresses,
@@ -161,6 +167,7 @@ func (nt *nodeTracker) updateNodeInfo(nodeName, switchName, routerName, chassisI
 		chassisID:          chassisID,
 		nodePortDisabled:   nodePortDisabled,
 		zone:               zone,
+		topologyZone:       topologyZone,
 	}
 	for i := range podSubnets {
 		ni.podSubnets = append(ni.podSubnets, *podSubnets[i]) // de-pointer
@@ -268,6 +275,7 @@ func (nt *nodeTracker) updateNode(node *corev1.Node) {
 		hsn,
 		mgmtIPs,
 		util.GetNodeZone(node),
+		node.Labels[corev1.LabelTopologyZone],
 		!nodePortEnabled,
 	)
 }
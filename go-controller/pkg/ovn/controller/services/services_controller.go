@@ -482,6 +482,10 @@ func (c *Controller) syncService(key string) error {
 	lbs := append(clusterLBs, templateLBs...)
 	lbs = append(lbs, perNodeLBs...)
 
+	if globalconfig.OVNKubernetesFeature.EnableServiceHealthChecks {
+		setLBIPPortMappings(lbs, c.buildLBIPPortMappings(service, endpointSlices))
+	}
+
 	// Short-circuit if nothing has changed
 	c.alreadyAppliedRWLock.RLock()
 	alreadyAppliedLbs, alreadyAppliedKeyExists := c.alreadyApplied[key]
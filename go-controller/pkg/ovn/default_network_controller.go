@@ -515,8 +515,8 @@ func (oc *DefaultNetworkController) run(_ context.Context) error {
 		}
 
 		oc.efController, err = efcontroller.NewEFController("egress-firewall-controller", oc.zone, oc.kube, oc.nbClient,
-			oc.watchFactory.NamespaceInformer().Lister(), oc.watchFactory.NodeCoreInformer(), oc.watchFactory.EgressFirewallInformer(),
-			oc.networkManager, oc.dnsNameResolver, oc.observManager)
+			oc.watchFactory.NamespaceInformer(), oc.watchFactory.NodeCoreInformer(), oc.watchFactory.EgressFirewallInformer(),
+			oc.watchFactory.ClusterEgressFirewallInformer(), oc.networkManager, oc.dnsNameResolver, oc.observManager)
 		if err != nil {
 			return err
 		}
@@ -662,6 +662,12 @@ func (h *defaultNetworkControllerEventHandler) FilterOutResource(_ interface{})
 // equal and an update needs be executed. This is regardless of how the update is carried out (whether with a dedicated update
 // function or with a delete on the old obj followed by an add on the new obj).
 func (h *defaultNetworkControllerEventHandler) AreResourcesEqual(obj1, obj2 interface{}) (bool, error) {
+	if h.objType == factory.EgressIPType {
+		// skip the status messages reported by the zones, including this one
+		oldEIP := obj1.(*egressipv1.EgressIP)
+		newEIP := obj2.(*egressipv1.EgressIP)
+		return h.oc.eIPC.isZoneStatusUpdate(oldEIP, newEIP), nil
+	}
 	return h.baseHandler.areResourcesEqual(h.objType, obj1, obj2)
 }
 
@@ -846,7 +852,11 @@ func (h *defaultNetworkControllerEventHandler) AddResource(obj interface{}, from
 
 	case factory.EgressIPType:
 		eIP := obj.(*egressipv1.EgressIP)
-		return h.oc.eIPC.reconcileEgressIP(nil, eIP)
+		err := h.oc.eIPC.reconcileEgressIP(nil, eIP)
+		if statusErr := h.oc.eIPC.setEgressIPStatus(eIP, err); statusErr != nil {
+			err = utilerrors.Join(err, fmt.Errorf("failed to update EgressIP %s status: %w", eIP.Name, statusErr))
+		}
+		return err
 
 	case factory.EgressIPNamespaceType:
 		namespace := obj.(*corev1.Namespace)
@@ -1058,7 +1068,11 @@ func (h *defaultNetworkControllerEventHandler) UpdateResource(oldObj, newObj int
 	case factory.EgressIPType:
 		oldEIP := oldObj.(*egressipv1.EgressIP)
 		newEIP := newObj.(*egressipv1.EgressIP)
-		return h.oc.eIPC.reconcileEgressIP(oldEIP, newEIP)
+		err := h.oc.eIPC.reconcileEgressIP(oldEIP, newEIP)
+		if statusErr := h.oc.eIPC.setEgressIPStatus(newEIP, err); statusErr != nil {
+			err = utilerrors.Join(err, fmt.Errorf("failed to update EgressIP %s status: %w", newEIP.Name, statusErr))
+		}
+		return err
 
 	case factory.EgressIPNamespaceType:
 		oldNamespace := oldObj.(*corev1.Namespace)
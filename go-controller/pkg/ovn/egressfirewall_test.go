@@ -273,9 +273,10 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 			fakeOVN.controller.zone,
 			fakeOVN.controller.kube,
 			fakeOVN.controller.nbClient,
-			fakeOVN.controller.watchFactory.NamespaceInformer().Lister(),
+			fakeOVN.controller.watchFactory.NamespaceInformer(),
 			fakeOVN.controller.watchFactory.NodeCoreInformer(),
 			fakeOVN.controller.watchFactory.EgressFirewallInformer(),
+			fakeOVN.controller.watchFactory.ClusterEgressFirewallInformer(),
 			fakeOVN.controller.networkManager,
 			fakeOVN.controller.dnsNameResolver,
 			fakeOVN.controller.observManager,
@@ -626,7 +627,7 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 					gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 					expectedDatabaseState := getEFExpectedDb(initialData, fakeOVN, namespace1.Name,
-						"(ip4.dst == 1.2.3.4/23)", "((udp && ( udp.dst == 100 )))", nbdb.ACLActionDrop)
+						"(ip4.dst == 1.2.3.4/23)", "((udp && udp.dst==100))", nbdb.ACLActionDrop)
 					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
 					return nil
 				}
@@ -670,7 +671,7 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 					gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 					expectedDatabaseState := getEFExpectedDbUDN(initialData, fakeOVN, namespace1.Name,
-						"(ip4.dst == 1.2.3.4/23)", "((udp && ( udp.dst == 100 )))", nbdb.ACLActionDrop, networkConfig.GetNetworkName())
+						"(ip4.dst == 1.2.3.4/23)", "((udp && udp.dst==100))", nbdb.ACLActionDrop, networkConfig.GetNetworkName())
 					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
 					return nil
 				}
@@ -699,7 +700,7 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 					startOvn(dbSetup, []corev1.Namespace{namespace1}, []egressfirewallapi.EgressFirewall{*egressFirewall}, true)
 
 					expectedDatabaseState := getEFExpectedDb(initialData, fakeOVN, namespace1.Name,
-						"(ip4.dst == 1.2.3.5/23)", "((tcp && ( tcp.dst == 100 )))", nbdb.ACLActionAllow)
+						"(ip4.dst == 1.2.3.5/23)", "((tcp && tcp.dst==100))", nbdb.ACLActionAllow)
 					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
 
 					err := fakeOVN.fakeClient.EgressFirewallClient.K8sV1().EgressFirewalls(egressFirewall.Namespace).Delete(context.TODO(), egressFirewall.Name, *metav1.NewDeleteOptions(0))
@@ -749,7 +750,7 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 					startOvn(dbSetup, []corev1.Namespace{namespace1}, []egressfirewallapi.EgressFirewall{*egressFirewall}, true)
 
 					expectedDatabaseState := getEFExpectedDbUDN(initialData, fakeOVN, namespace1.Name,
-						"(ip4.dst == 1.2.3.5/23)", "((tcp && ( tcp.dst == 100 )))", nbdb.ACLActionAllow, networkConfig.GetNetworkName())
+						"(ip4.dst == 1.2.3.5/23)", "((tcp && tcp.dst==100))", nbdb.ACLActionAllow, networkConfig.GetNetworkName())
 					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
 
 					err = fakeOVN.fakeClient.EgressFirewallClient.K8sV1().EgressFirewalls(egressFirewall.Namespace).Delete(context.TODO(), egressFirewall.Name, *metav1.NewDeleteOptions(0))
@@ -803,7 +804,7 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 					startOvn(dbSetup, []corev1.Namespace{namespace1}, []egressfirewallapi.EgressFirewall{*egressFirewall}, true)
 
 					expectedDatabaseState := getEFExpectedDbUDN(initialData, fakeOVN, namespace1.Name,
-						"(ip4.dst == 1.2.3.5/23)", "((tcp && ( tcp.dst == 100 )))", nbdb.ACLActionAllow, networkConfig.GetNetworkName())
+						"(ip4.dst == 1.2.3.5/23)", "((tcp && tcp.dst==100))", nbdb.ACLActionAllow, networkConfig.GetNetworkName())
 					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
 
 					ginkgo.By("triggering fake network manager to simulate NAD deletion, OVN EF config should be removed")
@@ -826,7 +827,7 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 					fakeNM.Unlock()
 					fakeNM.TriggerHandlers(netconf.nadName, networkConfig2, false)
 					expectedDatabaseState2 := getEFExpectedDbUDN(initialData, fakeOVN, namespace1.Name,
-						"(ip4.dst == 1.2.3.5/23)", "((tcp && ( tcp.dst == 100 )))", nbdb.ACLActionAllow, networkConfig2.GetNetworkName())
+						"(ip4.dst == 1.2.3.5/23)", "((tcp && tcp.dst==100))", nbdb.ACLActionAllow, networkConfig2.GetNetworkName())
 					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState2))
 
 					return nil
@@ -1049,7 +1050,7 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 						}, true)
 
 					expectedDatabaseState := getEFExpectedDb(initialData, fakeOVN, namespace1.Name,
-						"(ip4.dst == 1.2.3.5/23)", "((tcp && ( tcp.dst == 100 )))", nbdb.ACLActionAllow)
+						"(ip4.dst == 1.2.3.5/23)", "((tcp && tcp.dst==100))", nbdb.ACLActionAllow)
 					gomega.Eventually(fakeOVN.nbClient).Should(libovsdb.HaveData(expectedDatabaseState))
 					ginkgo.By("Bringing down NBDB")
 					// inject transient problem, nbdb is down
@@ -36,6 +36,7 @@ import (
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/controller"
 	egressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressipapply "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/applyconfiguration/egressip/v1"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/generator/udn"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/kube"
@@ -542,6 +543,62 @@ func (e *EgressIPController) reconcileEgressIP(old, new *egressipv1.EgressIP) (e
 	return utilerrors.Join(errs...)
 }
 
+// EgressIPProgrammedCorrectly is the status message of a zone that programmed an EgressIP
+const EgressIPProgrammedCorrectly = "EgressIP programmed"
+
+// setEgressIPStatus reports in the EgressIP status messages whether this zone
+// programmed the EgressIP, the cluster manager derives the Programmed condition
+// from the messages of all the zones. The message is written for the version of
+// the EgressIP that was reconciled, so that it is rejected if the assignments
+// changed meanwhile: the cluster manager resets the messages when they change.
+func (e *EgressIPController) setEgressIPStatus(eIP *egressipv1.EgressIP, handlerErr error) error {
+	newMsg := EgressIPProgrammedCorrectly
+	if handlerErr != nil {
+		newMsg = types.EgressIPErrorMsg + ": " + handlerErr.Error()
+	}
+	newMsg = types.GetZoneStatus(e.zone, newMsg)
+	if slices.Contains(eIP.Status.Messages, newMsg) {
+		return nil
+	}
+
+	applyOptions := metav1.ApplyOptions{
+		Force:        true,
+		FieldManager: e.zone,
+	}
+
+	applyObj := egressipapply.EgressIP(eIP.Name).
+		WithResourceVersion(eIP.ResourceVersion).
+		WithStatus(egressipapply.EgressIPStatus().
+			WithMessages(newMsg))
+	_, err := e.kube.EIPClient.K8sV1().EgressIPs().Apply(context.TODO(), applyObj, applyOptions)
+	if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+		// the EgressIP changed or was deleted meanwhile, the status of the
+		// newer version is reported when it is reconciled
+		return nil
+	}
+	return err
+}
+
+// isZoneStatusUpdate returns true if the EgressIPs only differ by the status
+// messages of the zones while this zone already reported its status: there is
+// nothing to reconcile nor to report again. Reconciling the EgressIP when this
+// zone reports its status would otherwise drop a pending retry of the EgressIP.
+func (e *EgressIPController) isZoneStatusUpdate(old, new *egressipv1.EgressIP) bool {
+	if !slices.ContainsFunc(new.Status.Messages, func(message string) bool {
+		return types.GetZoneFromStatus(message) == e.zone
+	}) {
+		return false
+	}
+	oldEIP, newEIP := old.DeepCopy(), new.DeepCopy()
+	for _, eIP := range []*egressipv1.EgressIP{oldEIP, newEIP} {
+		eIP.TypeMeta = metav1.TypeMeta{}
+		eIP.ResourceVersion = ""
+		eIP.ManagedFields = nil
+		eIP.Status.Messages = nil
+	}
+	return reflect.DeepEqual(oldEIP, newEIP)
+}
+
 // reconcileEgressIPNamespace reconciles the database configuration setup in nbdb
 // based on received namespace objects.
 // NOTE: we only care about namespace label updates
@@ -14,13 +14,17 @@ import (
 	"github.com/urfave/cli/v2"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	clienttesting "k8s.io/client-go/testing"
 	utilnet "k8s.io/utils/net"
 	"k8s.io/utils/ptr"
 
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
 	egressipv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressipfake "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
 	libovsdbops "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/nbdb"
 	addressset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/ovn/address_set"
@@ -6407,6 +6411,57 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations cluster default network"
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 	})
+
+	ginkgo.Context("EgressIP status", func() {
+		ginkgo.It("should report whether the zone programmed the EgressIP", func() {
+			app.Action = func(*cli.Context) error {
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: []string{"192.168.126.101"},
+					},
+				}
+				fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{}, &egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}})
+				zone := fakeOvn.controller.eIPC.zone
+				getMessages := func() []string {
+					tmp, err := fakeOvn.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), egressIPName, metav1.GetOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					return tmp.Status.Messages
+				}
+
+				ginkgo.By("reporting the error of the zone")
+				err := fakeOvn.controller.eIPC.setEgressIPStatus(&eIP, fmt.Errorf("failed to add pod"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(getMessages()).To(gomega.ContainElement(
+					types.GetZoneStatus(zone, types.EgressIPErrorMsg+": failed to add pod")))
+
+				ginkgo.By("reporting the EgressIP programmed by the zone")
+				err = fakeOvn.controller.eIPC.setEgressIPStatus(&eIP, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(getMessages()).To(gomega.ContainElement(types.GetZoneStatus(zone, EgressIPProgrammedCorrectly)))
+
+				ginkgo.By("not writing the message again")
+				updated, err := fakeOvn.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), egressIPName, metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				applied := 0
+				fakeOvn.fakeClient.EgressIPClient.(*egressipfake.Clientset).PrependReactor("patch", "egressips", func(clienttesting.Action) (bool, runtime.Object, error) {
+					applied++
+					return true, nil, apierrors.NewConflict(egressipv1.Resource("egressips"), egressIPName, fmt.Errorf("outdated"))
+				})
+				err = fakeOvn.controller.eIPC.setEgressIPStatus(updated, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(applied).To(gomega.Equal(0))
+
+				ginkgo.By("ignoring the conflicts with a newer version of the EgressIP")
+				err = fakeOvn.controller.eIPC.setEgressIPStatus(updated, fmt.Errorf("failed to add pod"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(applied).To(gomega.Equal(1))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
 	ginkgo.Context("WatchEgressNodes", func() {
 
 		ginkgo.It("should populated egress node data as they are tagged `egress assignable` with variants of IPv4/IPv6", func() {
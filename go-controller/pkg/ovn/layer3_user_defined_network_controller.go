@@ -423,9 +423,10 @@ func NewLayer3UserDefinedNetworkController(
 		oc.podAnnotationAllocator = podAnnotationAllocator
 	}
 
-	// enable multicast support for UDN only for primaries + multicast enabled
+	// enable multicast support for UDN only for primaries + multicast enabled,
+	// unless the network opted out
 	// TBD: changes needs to be made to support multicast beyond primary UDN
-	oc.multicastSupport = oc.IsPrimaryNetwork() && util.IsNetworkSegmentationSupportEnabled() && config.EnableMulticast
+	oc.multicastSupport = isUserDefinedNetworkMulticastSupported(oc.GetNetInfo())
 
 	oc.initRetryFramework()
 	return oc, nil
@@ -8,6 +8,7 @@ import (
 
 	ipam "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/allocator/ip"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/allocator/ip/subnet"
+	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/util"
 )
 
@@ -25,7 +26,7 @@ type LogicalSwitchManager struct {
 // networks.
 func NewLogicalSwitchManager() *LogicalSwitchManager {
 	return &LogicalSwitchManager{
-		allocator:  subnet.NewAllocator(),
+		allocator:  NewServiceMonitorIPAllocator(),
 		reserveIPs: true,
 	}
 }
@@ -70,7 +71,14 @@ func (manager *LogicalSwitchManager) AddOrUpdateSwitch(switchName string, hostSu
 				mgmtIP = util.GetNodeManagementIfAddr(hostSubnet)
 			}
 
-			for _, ip := range []*net.IPNet{gwIP, mgmtIP} {
+			reservedIPs := []*net.IPNet{gwIP, mgmtIP}
+			if config.OVNKubernetesFeature.EnableServiceHealthChecks {
+				if svcMonitorIP := util.GetNodeServiceMonitorExcludeIP(hostSubnet); svcMonitorIP != nil {
+					reservedIPs = append(reservedIPs, svcMonitorIP)
+				}
+			}
+
+			for _, ip := range reservedIPs {
 				excludeIP := &net.IPNet{IP: ip.IP, Mask: util.GetIPFullMask(ip.IP)}
 				if !util.IsContainedInAnyCIDR(excludeIP, excludeSubnets...) {
 					excludeSubnets = append(excludeSubnets, excludeIP)
@@ -195,3 +203,67 @@ func (manager *LogicalSwitchManager) ForSwitch(switchName string) subnet.NamedAl
 func (manager *LogicalSwitchManager) GetSubnetName(subnets []*net.IPNet) (string, bool) {
 	return manager.allocator.GetSubnetName(subnets)
 }
+
+// svcMonitorIPAllocator is a subnet allocator that never releases the service
+// health check source IPs of its subnets. They are excluded from allocation,
+// but a pod that got one before health checks were enabled keeps it: once the
+// pod is deleted, the IP must remain reserved rather than go to another pod.
+type svcMonitorIPAllocator struct {
+	subnet.Allocator
+}
+
+// NewServiceMonitorIPAllocator returns a subnet allocator that, when service
+// health checks are enabled, doesn't release their source IPs.
+func NewServiceMonitorIPAllocator() subnet.Allocator {
+	return &svcMonitorIPAllocator{
+		Allocator: subnet.NewAllocator(),
+	}
+}
+
+func (allocator *svcMonitorIPAllocator) ReleaseIPs(name string, ips []*net.IPNet) error {
+	return allocator.Allocator.ReleaseIPs(name, allocator.releasableIPs(name, ips))
+}
+
+func (allocator *svcMonitorIPAllocator) ForSubnet(name string) subnet.NamedAllocator {
+	return &svcMonitorIPNamedAllocator{
+		NamedAllocator: allocator.Allocator.ForSubnet(name),
+		allocator:      allocator,
+		name:           name,
+	}
+}
+
+// releasableIPs filters out the service health check source IPs of the
+// subnets from ips
+func (allocator *svcMonitorIPAllocator) releasableIPs(name string, ips []*net.IPNet) []*net.IPNet {
+	if !config.OVNKubernetesFeature.EnableServiceHealthChecks {
+		return ips
+	}
+	subnets, err := allocator.GetSubnets(name)
+	if err != nil {
+		return ips
+	}
+	releasable := make([]*net.IPNet, 0, len(ips))
+	for _, ip := range ips {
+		excluded := false
+		for _, subnet := range subnets {
+			if svcMonitorIP := util.GetNodeServiceMonitorExcludeIP(subnet); svcMonitorIP != nil && svcMonitorIP.IP.Equal(ip.IP) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			releasable = append(releasable, ip)
+		}
+	}
+	return releasable
+}
+
+type svcMonitorIPNamedAllocator struct {
+	subnet.NamedAllocator
+	allocator *svcMonitorIPAllocator
+	name      string
+}
+
+func (allocator *svcMonitorIPNamedAllocator) ReleaseIPs(ips []*net.IPNet) error {
+	return allocator.NamedAllocator.ReleaseIPs(allocator.allocator.releasableIPs(allocator.name, ips))
+}
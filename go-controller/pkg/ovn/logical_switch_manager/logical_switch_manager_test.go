@@ -116,6 +116,37 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
+		ginkgo.It("reserves the service health check source IP, also when it was allocated to a pod before", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				config.OVNKubernetesFeature.EnableServiceHealthChecks = true
+
+				testNode := testNodeSubnetData{
+					switchName: "testNode1",
+					subnets: []string{
+						"10.1.1.0/24",
+						"2000::/64",
+					},
+				}
+				err = lsManager.AddOrUpdateSwitch(testNode.switchName, ovntest.MustParseIPNets(testNode.subnets...), nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(lsManager.isAllocatedIP(testNode.switchName, "10.1.1.254/32")).To(gomega.BeTrue())
+				// the IPAM of the IPv6 subnet doesn't include its last addresses
+				err = lsManager.AllocateIPs(testNode.switchName, ovntest.MustParseIPNets("2000::ffff:ffff:ffff:fffe/64"))
+				gomega.Expect(err).To(gomega.HaveOccurred())
+
+				// a pod that got the address before health checks were enabled is
+				// deleted after the upgrade, the address remains reserved
+				err = lsManager.ReleaseIPs(testNode.switchName, ovntest.MustParseIPNets("10.1.1.254/24"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(lsManager.isAllocatedIP(testNode.switchName, "10.1.1.254/32")).To(gomega.BeTrue())
+
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
 		ginkgo.It("creates IPAM for each subnet and reserves a non-default IP address for hybrid overlay", func() {
 			app.Action = func(ctx *cli.Context) error {
 				_, err := config.InitConfig(ctx, fexec, nil)
@@ -66,6 +66,19 @@ func (oc *DefaultNetworkController) SetupMaster() error {
 		return err
 	}
 
+	// Encrypt the tunnels between the chassis of the zone as configured. Unless explicitly
+	// disabled, IPsec may be managed outside of ovnkube and is left as it is.
+	switch config.IPsec.Encryption {
+	case config.IPsecEncryptionEnabled:
+		if err := libovsdbops.UpdateNBGlobalIPsec(oc.nbClient, true); err != nil {
+			return fmt.Errorf("unable to enable IPsec: %w", err)
+		}
+	case config.IPsecEncryptionDisabled:
+		if err := libovsdbops.UpdateNBGlobalIPsec(oc.nbClient, false); err != nil {
+			return fmt.Errorf("unable to disable IPsec: %w", err)
+		}
+	}
+
 	// Create OVNJoinSwitch that will be used to connect gateway routers to the distributed router.
 	return oc.gatewayTopologyFactory.NewJoinSwitch(logicalRouter, oc.GetNetInfo(), oc.ovnClusterLRPToJoinIfAddrs)
 }
@@ -1170,6 +1170,35 @@ var _ = ginkgo.Describe("Default network controller operations", func() {
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	ginkgo.DescribeTable("sets up the IPsec encryption of the tunnels",
+		func(args []string, expectedIPsec bool) {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, nil, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				// IPsec enabled outside of ovnkube
+				nbGlobal := &nbdb.NBGlobal{Name: types.OvnDefaultZone, Ipsec: true}
+				ops, err := nbClient.Create(nbGlobal)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = libovsdbops.TransactAndCheck(nbClient, ops)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Expect(oc.SetupMaster()).To(gomega.Succeed())
+
+				nbGlobal, err = libovsdbops.GetNBGlobal(nbClient, &nbdb.NBGlobal{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(nbGlobal.Ipsec).To(gomega.Equal(expectedIPsec))
+				return nil
+			}
+
+			err := app.Run(append([]string{app.Name, "-cluster-subnets=" + clusterCIDR}, args...))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		},
+		ginkgo.Entry("leaves IPsec as it is when the encryption is not configured", nil, true),
+		ginkgo.Entry("leaves IPsec as it is when the encryption is unmanaged", []string{"--ipsec-encryption=unmanaged"}, true),
+		ginkgo.Entry("disables IPsec when the encryption is disabled", []string{"--ipsec-encryption=disabled"}, false),
+	)
+
 	ginkgo.It("clears stale ovn_cluster_router routes in local gw", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := config.InitConfig(ctx, nil, nil)
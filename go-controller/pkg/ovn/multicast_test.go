@@ -147,13 +147,24 @@ func getMulticastStaleData(netInfo util.NetInfo, clusterPortGroup, clusterRtrPor
 }
 
 func getMulticastPolicyExpectedData(netInfo util.NetInfo, ns string, ports []string) []libovsdb.TestData {
+	return getMulticastGroupPolicyExpectedData(netInfo, ns, []string{ns}, ports)
+}
+
+// getMulticastGroupPolicyExpectedData returns the multicast policy of 'ns'
+// allowing the multicast traffic of the pods in 'sourceNamespaces'.
+func getMulticastGroupPolicyExpectedData(netInfo util.NetInfo, ns string, sourceNamespaces []string, ports []string) []libovsdb.TestData {
 	netControllerName := getNetworkControllerName(netInfo.GetNetworkName())
 	fakeController := getFakeController(netControllerName)
 	pg_hash := fakeController.getNamespacePortGroupName(ns)
 	egressMatch := libovsdbutil.GetACLMatch(pg_hash, fakeController.getMulticastACLEgrMatch(), libovsdbutil.ACLEgress)
 
-	ip4AddressSet, ip6AddressSet := getNsAddrSetHashNames(netControllerName, ns)
-	mcastMatch := getACLMatchAF(getMulticastACLIgrMatchV4(ip4AddressSet), getMulticastACLIgrMatchV6(ip6AddressSet), config.IPv4Mode, config.IPv6Mode)
+	var ip4AddressSets, ip6AddressSets []string
+	for _, sourceNs := range sourceNamespaces {
+		ip4AddressSet, ip6AddressSet := getNsAddrSetHashNames(netControllerName, sourceNs)
+		ip4AddressSets = append(ip4AddressSets, ip4AddressSet)
+		ip6AddressSets = append(ip6AddressSets, ip6AddressSet)
+	}
+	mcastMatch := getACLMatchAF(getMulticastACLIgrMatchV4(ip4AddressSets...), getMulticastACLIgrMatchV6(ip6AddressSets...), config.IPv4Mode, config.IPv6Mode)
 	ingressMatch := libovsdbutil.GetACLMatch(pg_hash, mcastMatch, libovsdbutil.ACLIngress)
 
 	aclIDs := getNamespaceMcastACLDbIDs(ns, libovsdbutil.ACLEgress, netControllerName)
@@ -332,6 +343,7 @@ func startBaseNetworkController(fakeOvn *FakeOVN, nad *nadapi.NetworkAttachmentD
 var _ = Describe("OVN Multicast with IP Address Family", func() {
 	const (
 		namespaceName1         = "namespace1"
+		namespaceName2         = "namespace2"
 		longnamespaceName1Name = "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijk" // create with 63 characters
 
 	)
@@ -702,6 +714,83 @@ var _ = Describe("OVN Multicast with IP Address Family", func() {
 			Entry("[Network Segmentation] IPv6", false, true, nadFromIPMode(namespaceName1, false, true)),
 		)
 
+		DescribeTable("tests multicast groups across namespaces", func(useIPv4, useIPv6 bool, nad *nadapi.NetworkAttachmentDefinition) {
+			app.Action = func(*cli.Context) error {
+				config.IPv4Mode = useIPv4
+				config.IPv6Mode = useIPv6
+
+				netInfo := getNetInfoFromNAD(nad)
+				namespace1 := *ovntest.NewNamespace(namespaceName1)
+				namespace1.Annotations[util.NsMulticastAnnotation] = "true"
+				namespace1.Annotations[util.NsMulticastGroupAnnotation] = "group1"
+				namespace2 := *ovntest.NewNamespace(namespaceName2)
+				namespace2.Annotations[util.NsMulticastAnnotation] = "true"
+				namespace2.Annotations[util.NsMulticastGroupAnnotation] = "group1"
+
+				objs := []runtime.Object{&corev1.NamespaceList{
+					Items: []corev1.Namespace{
+						namespace1,
+						namespace2,
+					},
+				}}
+				if nad != nil {
+					// both namespaces are attached to the primary network
+					objs = append(objs, &nadapi.NetworkAttachmentDefinitionList{
+						Items: []nadapi.NetworkAttachmentDefinition{*nad, *nadFromIPMode(namespaceName2, useIPv4, useIPv6)},
+					})
+				}
+
+				fakeOvn.startWithDBSetup(libovsdb.TestSetup{}, objs...)
+
+				if nad != nil {
+					Expect(fakeOvn.networkManager.Start()).To(Succeed())
+					defer fakeOvn.networkManager.Stop()
+				}
+
+				bnc, _ := startBaseNetworkController(fakeOvn, nad)
+				Expect(bnc.WatchNamespaces()).To(Succeed())
+
+				// both namespaces accept the multicast traffic of the group
+				group := []string{namespaceName1, namespaceName2}
+				expectedData := getMulticastGroupPolicyExpectedData(netInfo, namespaceName1, group, nil)
+				expectedData = append(expectedData, getMulticastGroupPolicyExpectedData(netInfo, namespaceName2, group, nil)...)
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+				// namespace2 leaves the group: each namespace only accepts its own multicast traffic
+				ns, err := fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Get(context.TODO(), namespaceName2, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				delete(ns.Annotations, util.NsMulticastGroupAnnotation)
+				_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				expectedData = getMulticastPolicyExpectedData(netInfo, namespaceName1, nil)
+				expectedData = append(expectedData, getMulticastPolicyExpectedData(netInfo, namespaceName2, nil)...)
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+				// namespace2 joins the group again, then disables multicast
+				ns.Annotations[util.NsMulticastGroupAnnotation] = "group1"
+				_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				expectedData = getMulticastGroupPolicyExpectedData(netInfo, namespaceName1, group, nil)
+				expectedData = append(expectedData, getMulticastGroupPolicyExpectedData(netInfo, namespaceName2, group, nil)...)
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+				updateMulticast(fakeOvn, ns, false)
+				expectedData = getMulticastPolicyExpectedData(netInfo, namespaceName1, nil)
+				expectedData = append(expectedData, getNamespacePG(namespaceName2, bnc.controllerName))
+				Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			Expect(err).NotTo(HaveOccurred())
+		},
+			Entry("IPv4", true, false, nil),
+			Entry("IPv6", false, true, nil),
+			Entry("[Network Segmentation] IPv4", true, false, nadFromIPMode(namespaceName1, true, false)),
+			Entry("[Network Segmentation] IPv6", false, true, nadFromIPMode(namespaceName1, false, true)),
+		)
+
 		DescribeTable("tests enabling multicast in a namespace with a pod", func(useIPv4, useIPv6 bool, nad *nadapi.NetworkAttachmentDefinition) {
 			app.Action = func(*cli.Context) error {
 				config.IPv4Mode = useIPv4
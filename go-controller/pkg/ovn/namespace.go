@@ -92,6 +92,10 @@ func isNamespaceMulticastEnabled(annotations map[string]string) bool {
 	return annotations[util.NsMulticastAnnotation] == "true"
 }
 
+func getNamespaceMulticastGroup(annotations map[string]string) string {
+	return annotations[util.NsMulticastGroupAnnotation]
+}
+
 // AddNamespace creates corresponding addressset in ovn db
 func (oc *DefaultNetworkController) AddNamespace(ns *corev1.Namespace) error {
 	klog.Infof("[%s] adding namespace", ns.Name)
@@ -117,6 +117,13 @@ const (
 	DefaultANPACLTier = 1
 	// Default Tier for all ACLs belonging to Baseline Admin Network Policy
 	DefaultBANPACLTier = 3
+	// OVN only supports 4 tiers, ClusterEgressFirewall ACLs placed before EgressFirewall share the default
+	// tier, with priorities above EgressFirewall ACLs, so that ANP pass rules are delegated to them.
+	// ClusterEgressFirewall ACLs placed after EgressFirewall share the BANP tier, with priorities above BANP ACLs.
+	ClusterEgressFirewallBeforeACLTier = DefaultACLTier
+	ClusterEgressFirewallAfterACLTier  = DefaultBANPACLTier
+	// Priority of the ClusterEgressFirewall ACLs placed before EgressFirewall, down to EgressFirewallStartPriority + 1
+	ClusterEgressFirewallBeforeStartPriority = 18000
 
 	// priority of logical router policies on the OVNClusterRouter
 	EgressFirewallStartPriority           = 10000
@@ -270,6 +277,10 @@ const (
 	NetworkTransportNoOverlay = "no-overlay"
 	NetworkTransportEVPN      = "evpn"
 
+	// Network multicast modes - canonical format (lowercase)
+	NetworkMulticastEnabled  = "enabled"
+	NetworkMulticastDisabled = "disabled"
+
 	// NoOverlaySNATEnabled enables SNAT for outbound traffic
 	NoOverlaySNATEnabled = "enabled"
 	// NoOverlaySNATDisabled disables SNAT for outbound traffic
@@ -6,10 +6,12 @@ import (
 )
 
 const (
-	APBRouteErrorMsg       = "failed to apply policy"
-	EgressFirewallErrorMsg = "EgressFirewall Rules not correctly applied"
-	EgressQoSErrorMsg      = "EgressQoS Rules not correctly applied"
-	NetworkQoSErrorMsg     = "NetworkQoS Destinations not correctly applied"
+	APBRouteErrorMsg              = "failed to apply policy"
+	EgressFirewallErrorMsg        = "EgressFirewall Rules not correctly applied"
+	EgressIPErrorMsg              = "EgressIP not correctly programmed"
+	ClusterEgressFirewallErrorMsg = "ClusterEgressFirewall Rules not correctly applied"
+	EgressQoSErrorMsg             = "EgressQoS Rules not correctly applied"
+	NetworkQoSErrorMsg            = "NetworkQoS Destinations not correctly applied"
 )
 
 func GetZoneStatus(zoneID, message string) string {
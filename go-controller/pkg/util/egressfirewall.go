@@ -63,10 +63,27 @@ func LowerCaseFQDN(dnsName string) string {
 // GetDNSNames iterates through the egress firewall rules and returns the DNS
 // names present in them after validating the rules.
 func GetDNSNames(ef *egressfirewallv1.EgressFirewall) []string {
+	return getDNSNames(ef.Spec.Egress, "egressFirewall for namespace "+ef.Namespace)
+}
+
+// GetClusterEgressFirewallDNSNames iterates through the cluster egress firewall rules
+// and returns the DNS names present in them after validating the rules.
+func GetClusterEgressFirewallDNSNames(cef *egressfirewallv1.ClusterEgressFirewall) []string {
+	return getDNSNames(cef.Spec.Egress, "clusterEgressFirewall "+cef.Name)
+}
+
+// GetClusterEgressFirewallDNSOwner returns the key used in place of a namespace to
+// track the DNS names used by a cluster egress firewall. It can't collide with a
+// namespace name, since those can't contain a '/'.
+func GetClusterEgressFirewallDNSOwner(name string) string {
+	return "ClusterEgressFirewall/" + name
+}
+
+func getDNSNames(rules []egressfirewallv1.EgressFirewallRule, owner string) []string {
 	var dnsNameSlice []string
-	for i, egressFirewallRule := range ef.Spec.Egress {
+	for i, egressFirewallRule := range rules {
 		if i > types.EgressFirewallStartPriority-types.MinimumReservedEgressFirewallPriority {
-			klog.Warningf("egressFirewall for namespace %s has too many rules, the rest will be ignored", ef.Namespace)
+			klog.Warningf("%s has too many rules, the rest will be ignored", owner)
 			break
 		}
 
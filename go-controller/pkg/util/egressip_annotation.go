@@ -1,12 +1,15 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 )
 
 const (
 	EgressIPMarkAnnotation = "k8s.ovn.org/egressip-mark"
+	// EgressIPPoolAnnotation holds the egress IPs allocated to an EgressIP from an EgressIPPool
+	EgressIPPoolAnnotation = "k8s.ovn.org/egressip-pool-ips"
 	EgressIPMarkBase       = 50000
 	EgressIPMarkMax        = 55000
 )
@@ -66,3 +69,25 @@ func IsEgressIPMarkValid(mark int) bool {
 func EgressIPMarkAnnotationChanged(annotationA, annotationB map[string]string) bool {
 	return annotationA[EgressIPMarkAnnotation] != annotationB[EgressIPMarkAnnotation]
 }
+
+// EgressIPPoolIPs are the egress IPs allocated to an EgressIP from an EgressIPPool
+type EgressIPPoolIPs struct {
+	// Pool is the name of the EgressIPPool the egress IPs are allocated from
+	Pool string `json:"pool"`
+	// IPs are the egress IPs allocated
+	IPs []string `json:"ips"`
+}
+
+// ParseEgressIPPoolIPs returns the egress IPs allocated to an EgressIP from an EgressIPPool,
+// or nil if no egress IPs are allocated
+func ParseEgressIPPoolIPs(annotations map[string]string) (*EgressIPPoolIPs, error) {
+	annotation, ok := annotations[EgressIPPoolAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	poolIPs := &EgressIPPoolIPs{}
+	if err := json.Unmarshal([]byte(annotation), poolIPs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal EgressIP pool annotation %q: %v", annotation, err)
+	}
+	return poolIPs, nil
+}
@@ -33,6 +33,8 @@ import (
 	egressqosfake "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned/fake"
 	egressservice "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1"
 	egressservicefake "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned/fake"
+	networkobservabilityv1 "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1"
+	networkobservabilityfake "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/clientset/versioned/fake"
 	networkqos "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1"
 	networkqosfake "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1/apis/clientset/versioned/fake"
 	routeadvertisements "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1"
@@ -61,11 +63,12 @@ func GetOVNClientset(objects ...runtime.Object) *OVNClientset {
 	frrObjects := []runtime.Object{}
 	networkConnectObjects := []runtime.Object{}
 	vtepObjects := []runtime.Object{}
+	networkObservabilityObjects := []runtime.Object{}
 	for _, object := range objects {
 		switch object.(type) {
 		case *egressip.EgressIP:
 			egressIPObjects = append(egressIPObjects, object)
-		case *egressfirewall.EgressFirewall:
+		case *egressfirewall.EgressFirewall, *egressfirewall.ClusterEgressFirewall:
 			egressFirewallObjects = append(egressFirewallObjects, object)
 		case *egressqos.EgressQoS:
 			egressQoSObjects = append(egressQoSObjects, object)
@@ -95,6 +98,8 @@ func GetOVNClientset(objects ...runtime.Object) *OVNClientset {
 			networkConnectObjects = append(networkConnectObjects, object)
 		case *vtepv1.VTEP:
 			vtepObjects = append(vtepObjects, object)
+		case *networkobservabilityv1.NetworkObservability:
+			networkObservabilityObjects = append(networkObservabilityObjects, object)
 		default:
 			v1Objects = append(v1Objects, object)
 		}
@@ -108,23 +113,24 @@ func GetOVNClientset(objects ...runtime.Object) *OVNClientset {
 	populateTracker(nadClient, nads...)
 
 	return &OVNClientset{
-		KubeClient:                fake.NewSimpleClientset(v1Objects...),
-		ANPClient:                 anpfake.NewSimpleClientset(anpObjects...),
-		EgressIPClient:            egressipfake.NewSimpleClientset(egressIPObjects...),
-		EgressFirewallClient:      egressfirewallfake.NewSimpleClientset(egressFirewallObjects...),
-		CloudNetworkClient:        cloudservicefake.NewSimpleClientset(cloudObjects...),
-		EgressQoSClient:           egressqosfake.NewSimpleClientset(egressQoSObjects...),
-		NetworkAttchDefClient:     nadClient,
-		MultiNetworkPolicyClient:  mnpfake.NewSimpleClientset(multiNetworkPolicyObjects...),
-		EgressServiceClient:       egressservicefake.NewSimpleClientset(egressServiceObjects...),
-		AdminPolicyRouteClient:    adminpolicybasedroutefake.NewSimpleClientset(apbExternalRouteObjects...),
-		OCPNetworkClient:          ocpnetworkclientfake.NewSimpleClientset(dnsNameResolverObjects...),
-		UserDefinedNetworkClient:  udnfake.NewSimpleClientset(udnObjects...),
-		RouteAdvertisementsClient: routeadvertisementsfake.NewSimpleClientset(raObjects...),
-		FRRClient:                 frrfake.NewSimpleClientset(frrObjects...),
-		NetworkQoSClient:          networkqosfake.NewSimpleClientset(networkQoSObjects...),
-		NetworkConnectClient:      networkconnectfake.NewSimpleClientset(networkConnectObjects...),
-		VTEPClient:                vtepfake.NewSimpleClientset(vtepObjects...),
+		KubeClient:                 fake.NewSimpleClientset(v1Objects...),
+		ANPClient:                  anpfake.NewSimpleClientset(anpObjects...),
+		EgressIPClient:             egressipfake.NewSimpleClientset(egressIPObjects...),
+		EgressFirewallClient:       egressfirewallfake.NewSimpleClientset(egressFirewallObjects...),
+		CloudNetworkClient:         cloudservicefake.NewSimpleClientset(cloudObjects...),
+		EgressQoSClient:            egressqosfake.NewSimpleClientset(egressQoSObjects...),
+		NetworkAttchDefClient:      nadClient,
+		MultiNetworkPolicyClient:   mnpfake.NewSimpleClientset(multiNetworkPolicyObjects...),
+		EgressServiceClient:        egressservicefake.NewSimpleClientset(egressServiceObjects...),
+		AdminPolicyRouteClient:     adminpolicybasedroutefake.NewSimpleClientset(apbExternalRouteObjects...),
+		OCPNetworkClient:           ocpnetworkclientfake.NewSimpleClientset(dnsNameResolverObjects...),
+		UserDefinedNetworkClient:   udnfake.NewSimpleClientset(udnObjects...),
+		RouteAdvertisementsClient:  routeadvertisementsfake.NewSimpleClientset(raObjects...),
+		FRRClient:                  frrfake.NewSimpleClientset(frrObjects...),
+		NetworkQoSClient:           networkqosfake.NewSimpleClientset(networkQoSObjects...),
+		NetworkConnectClient:       networkconnectfake.NewSimpleClientset(networkConnectObjects...),
+		VTEPClient:                 vtepfake.NewSimpleClientset(vtepObjects...),
+		NetworkObservabilityClient: networkobservabilityfake.NewSimpleClientset(networkObservabilityObjects...),
 	}
 }
 
@@ -51,6 +51,7 @@ import (
 	egressipclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned"
 	egressqosclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned"
 	egressserviceclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned"
+	networkobservabilityclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkobservability/v1/apis/clientset/versioned"
 	networkqosclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/networkqos/v1alpha1/apis/clientset/versioned"
 	routeadvertisementsclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/routeadvertisements/v1/apis/clientset/versioned"
 	userdefinednetworkclientset "github.com/ovn-kubernetes/ovn-kubernetes/go-controller/pkg/crd/userdefinednetwork/v1/apis/clientset/versioned"
@@ -59,64 +60,67 @@ import (
 
 // OVNClientset is a wrapper around all clientsets used by OVN-Kubernetes
 type OVNClientset struct {
-	KubeClient                kubernetes.Interface
-	ANPClient                 anpclientset.Interface
-	EgressIPClient            egressipclientset.Interface
-	EgressFirewallClient      egressfirewallclientset.Interface
-	OCPNetworkClient          ocpnetworkclientset.Interface
-	CloudNetworkClient        ocpcloudnetworkclientset.Interface
-	EgressQoSClient           egressqosclientset.Interface
-	NetworkAttchDefClient     networkattchmentdefclientset.Interface
-	MultiNetworkPolicyClient  multinetworkpolicyclientset.Interface
-	EgressServiceClient       egressserviceclientset.Interface
-	AdminPolicyRouteClient    adminpolicybasedrouteclientset.Interface
-	IPAMClaimsClient          ipamclaimssclientset.Interface
-	UserDefinedNetworkClient  userdefinednetworkclientset.Interface
-	NetworkConnectClient      networkconnectclientset.Interface
-	RouteAdvertisementsClient routeadvertisementsclientset.Interface
-	FRRClient                 frrclientset.Interface
-	NetworkQoSClient          networkqosclientset.Interface
-	VTEPClient                vtepclientset.Interface
+	KubeClient                 kubernetes.Interface
+	ANPClient                  anpclientset.Interface
+	EgressIPClient             egressipclientset.Interface
+	EgressFirewallClient       egressfirewallclientset.Interface
+	OCPNetworkClient           ocpnetworkclientset.Interface
+	CloudNetworkClient         ocpcloudnetworkclientset.Interface
+	EgressQoSClient            egressqosclientset.Interface
+	NetworkAttchDefClient      networkattchmentdefclientset.Interface
+	MultiNetworkPolicyClient   multinetworkpolicyclientset.Interface
+	EgressServiceClient        egressserviceclientset.Interface
+	AdminPolicyRouteClient     adminpolicybasedrouteclientset.Interface
+	IPAMClaimsClient           ipamclaimssclientset.Interface
+	UserDefinedNetworkClient   userdefinednetworkclientset.Interface
+	NetworkConnectClient       networkconnectclientset.Interface
+	RouteAdvertisementsClient  routeadvertisementsclientset.Interface
+	FRRClient                  frrclientset.Interface
+	NetworkQoSClient           networkqosclientset.Interface
+	VTEPClient                 vtepclientset.Interface
+	NetworkObservabilityClient networkobservabilityclientset.Interface
 }
 
 // OVNMasterClientset
 type OVNMasterClientset struct {
-	KubeClient                kubernetes.Interface
-	ANPClient                 anpclientset.Interface
-	EgressIPClient            egressipclientset.Interface
-	CloudNetworkClient        ocpcloudnetworkclientset.Interface
-	EgressFirewallClient      egressfirewallclientset.Interface
-	OCPNetworkClient          ocpnetworkclientset.Interface
-	EgressQoSClient           egressqosclientset.Interface
-	MultiNetworkPolicyClient  multinetworkpolicyclientset.Interface
-	EgressServiceClient       egressserviceclientset.Interface
-	AdminPolicyRouteClient    adminpolicybasedrouteclientset.Interface
-	IPAMClaimsClient          ipamclaimssclientset.Interface
-	NetworkAttchDefClient     networkattchmentdefclientset.Interface
-	UserDefinedNetworkClient  userdefinednetworkclientset.Interface
-	RouteAdvertisementsClient routeadvertisementsclientset.Interface
-	FRRClient                 frrclientset.Interface
-	NetworkQoSClient          networkqosclientset.Interface
-	VTEPClient                vtepclientset.Interface
+	KubeClient                 kubernetes.Interface
+	ANPClient                  anpclientset.Interface
+	EgressIPClient             egressipclientset.Interface
+	CloudNetworkClient         ocpcloudnetworkclientset.Interface
+	EgressFirewallClient       egressfirewallclientset.Interface
+	OCPNetworkClient           ocpnetworkclientset.Interface
+	EgressQoSClient            egressqosclientset.Interface
+	MultiNetworkPolicyClient   multinetworkpolicyclientset.Interface
+	EgressServiceClient        egressserviceclientset.Interface
+	AdminPolicyRouteClient     adminpolicybasedrouteclientset.Interface
+	IPAMClaimsClient           ipamclaimssclientset.Interface
+	NetworkAttchDefClient      networkattchmentdefclientset.Interface
+	UserDefinedNetworkClient   userdefinednetworkclientset.Interface
+	RouteAdvertisementsClient  routeadvertisementsclientset.Interface
+	FRRClient                  frrclientset.Interface
+	NetworkQoSClient           networkqosclientset.Interface
+	VTEPClient                 vtepclientset.Interface
+	NetworkObservabilityClient networkobservabilityclientset.Interface
 }
 
 // OVNKubeControllerClientset
 type OVNKubeControllerClientset struct {
-	KubeClient                kubernetes.Interface
-	ANPClient                 anpclientset.Interface
-	EgressIPClient            egressipclientset.Interface
-	EgressFirewallClient      egressfirewallclientset.Interface
-	OCPNetworkClient          ocpnetworkclientset.Interface
-	EgressQoSClient           egressqosclientset.Interface
-	MultiNetworkPolicyClient  multinetworkpolicyclientset.Interface
-	EgressServiceClient       egressserviceclientset.Interface
-	AdminPolicyRouteClient    adminpolicybasedrouteclientset.Interface
-	IPAMClaimsClient          ipamclaimssclientset.Interface
-	NetworkAttchDefClient     networkattchmentdefclientset.Interface
-	UserDefinedNetworkClient  userdefinednetworkclientset.Interface
-	RouteAdvertisementsClient routeadvertisementsclientset.Interface
-	NetworkQoSClient          networkqosclientset.Interface
-	NetworkConnectClient      networkconnectclientset.Interface
+	KubeClient                 kubernetes.Interface
+	ANPClient                  anpclientset.Interface
+	EgressIPClient             egressipclientset.Interface
+	EgressFirewallClient       egressfirewallclientset.Interface
+	OCPNetworkClient           ocpnetworkclientset.Interface
+	EgressQoSClient            egressqosclientset.Interface
+	MultiNetworkPolicyClient   multinetworkpolicyclientset.Interface
+	EgressServiceClient        egressserviceclientset.Interface
+	AdminPolicyRouteClient     adminpolicybasedrouteclientset.Interface
+	IPAMClaimsClient           ipamclaimssclientset.Interface
+	NetworkAttchDefClient      networkattchmentdefclientset.Interface
+	UserDefinedNetworkClient   userdefinednetworkclientset.Interface
+	RouteAdvertisementsClient  routeadvertisementsclientset.Interface
+	NetworkQoSClient           networkqosclientset.Interface
+	NetworkConnectClient       networkconnectclientset.Interface
+	NetworkObservabilityClient networkobservabilityclientset.Interface
 }
 
 type OVNNodeClientset struct {
@@ -161,62 +165,65 @@ var (
 
 func (cs *OVNClientset) GetMasterClientset() *OVNMasterClientset {
 	return &OVNMasterClientset{
-		KubeClient:                cs.KubeClient,
-		ANPClient:                 cs.ANPClient,
-		EgressIPClient:            cs.EgressIPClient,
-		CloudNetworkClient:        cs.CloudNetworkClient,
-		EgressFirewallClient:      cs.EgressFirewallClient,
-		OCPNetworkClient:          cs.OCPNetworkClient,
-		EgressQoSClient:           cs.EgressQoSClient,
-		MultiNetworkPolicyClient:  cs.MultiNetworkPolicyClient,
-		EgressServiceClient:       cs.EgressServiceClient,
-		AdminPolicyRouteClient:    cs.AdminPolicyRouteClient,
-		IPAMClaimsClient:          cs.IPAMClaimsClient,
-		NetworkAttchDefClient:     cs.NetworkAttchDefClient,
-		UserDefinedNetworkClient:  cs.UserDefinedNetworkClient,
-		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
-		FRRClient:                 cs.FRRClient,
-		NetworkQoSClient:          cs.NetworkQoSClient,
-		VTEPClient:                cs.VTEPClient,
+		KubeClient:                 cs.KubeClient,
+		ANPClient:                  cs.ANPClient,
+		EgressIPClient:             cs.EgressIPClient,
+		CloudNetworkClient:         cs.CloudNetworkClient,
+		EgressFirewallClient:       cs.EgressFirewallClient,
+		OCPNetworkClient:           cs.OCPNetworkClient,
+		EgressQoSClient:            cs.EgressQoSClient,
+		MultiNetworkPolicyClient:   cs.MultiNetworkPolicyClient,
+		EgressServiceClient:        cs.EgressServiceClient,
+		AdminPolicyRouteClient:     cs.AdminPolicyRouteClient,
+		IPAMClaimsClient:           cs.IPAMClaimsClient,
+		NetworkAttchDefClient:      cs.NetworkAttchDefClient,
+		UserDefinedNetworkClient:   cs.UserDefinedNetworkClient,
+		RouteAdvertisementsClient:  cs.RouteAdvertisementsClient,
+		FRRClient:                  cs.FRRClient,
+		NetworkQoSClient:           cs.NetworkQoSClient,
+		VTEPClient:                 cs.VTEPClient,
+		NetworkObservabilityClient: cs.NetworkObservabilityClient,
 	}
 }
 
 func (cs *OVNMasterClientset) GetOVNKubeControllerClientset() *OVNKubeControllerClientset {
 	return &OVNKubeControllerClientset{
-		KubeClient:                cs.KubeClient,
-		ANPClient:                 cs.ANPClient,
-		EgressIPClient:            cs.EgressIPClient,
-		EgressFirewallClient:      cs.EgressFirewallClient,
-		OCPNetworkClient:          cs.OCPNetworkClient,
-		EgressQoSClient:           cs.EgressQoSClient,
-		MultiNetworkPolicyClient:  cs.MultiNetworkPolicyClient,
-		EgressServiceClient:       cs.EgressServiceClient,
-		AdminPolicyRouteClient:    cs.AdminPolicyRouteClient,
-		IPAMClaimsClient:          cs.IPAMClaimsClient,
-		NetworkAttchDefClient:     cs.NetworkAttchDefClient,
-		UserDefinedNetworkClient:  cs.UserDefinedNetworkClient,
-		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
-		NetworkQoSClient:          cs.NetworkQoSClient,
+		KubeClient:                 cs.KubeClient,
+		ANPClient:                  cs.ANPClient,
+		EgressIPClient:             cs.EgressIPClient,
+		EgressFirewallClient:       cs.EgressFirewallClient,
+		OCPNetworkClient:           cs.OCPNetworkClient,
+		EgressQoSClient:            cs.EgressQoSClient,
+		MultiNetworkPolicyClient:   cs.MultiNetworkPolicyClient,
+		EgressServiceClient:        cs.EgressServiceClient,
+		AdminPolicyRouteClient:     cs.AdminPolicyRouteClient,
+		IPAMClaimsClient:           cs.IPAMClaimsClient,
+		NetworkAttchDefClient:      cs.NetworkAttchDefClient,
+		UserDefinedNetworkClient:   cs.UserDefinedNetworkClient,
+		RouteAdvertisementsClient:  cs.RouteAdvertisementsClient,
+		NetworkQoSClient:           cs.NetworkQoSClient,
+		NetworkObservabilityClient: cs.NetworkObservabilityClient,
 	}
 }
 
 func (cs *OVNClientset) GetOVNKubeControllerClientset() *OVNKubeControllerClientset {
 	return &OVNKubeControllerClientset{
-		KubeClient:                cs.KubeClient,
-		ANPClient:                 cs.ANPClient,
-		EgressIPClient:            cs.EgressIPClient,
-		EgressFirewallClient:      cs.EgressFirewallClient,
-		OCPNetworkClient:          cs.OCPNetworkClient,
-		EgressQoSClient:           cs.EgressQoSClient,
-		MultiNetworkPolicyClient:  cs.MultiNetworkPolicyClient,
-		EgressServiceClient:       cs.EgressServiceClient,
-		AdminPolicyRouteClient:    cs.AdminPolicyRouteClient,
-		IPAMClaimsClient:          cs.IPAMClaimsClient,
-		NetworkAttchDefClient:     cs.NetworkAttchDefClient,
-		UserDefinedNetworkClient:  cs.UserDefinedNetworkClient,
-		RouteAdvertisementsClient: cs.RouteAdvertisementsClient,
-		NetworkQoSClient:          cs.NetworkQoSClient,
-		NetworkConnectClient:      cs.NetworkConnectClient,
+		KubeClient:                 cs.KubeClient,
+		ANPClient:                  cs.ANPClient,
+		EgressIPClient:             cs.EgressIPClient,
+		EgressFirewallClient:       cs.EgressFirewallClient,
+		OCPNetworkClient:           cs.OCPNetworkClient,
+		EgressQoSClient:            cs.EgressQoSClient,
+		MultiNetworkPolicyClient:   cs.MultiNetworkPolicyClient,
+		EgressServiceClient:        cs.EgressServiceClient,
+		AdminPolicyRouteClient:     cs.AdminPolicyRouteClient,
+		IPAMClaimsClient:           cs.IPAMClaimsClient,
+		NetworkAttchDefClient:      cs.NetworkAttchDefClient,
+		UserDefinedNetworkClient:   cs.UserDefinedNetworkClient,
+		RouteAdvertisementsClient:  cs.RouteAdvertisementsClient,
+		NetworkQoSClient:           cs.NetworkQoSClient,
+		NetworkConnectClient:       cs.NetworkConnectClient,
+		NetworkObservabilityClient: cs.NetworkObservabilityClient,
 	}
 }
 
@@ -558,25 +565,31 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 		return nil, err
 	}
 
+	networkObservabilityClientset, err := networkobservabilityclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &OVNClientset{
-		KubeClient:                kclientset,
-		ANPClient:                 anpClientset,
-		EgressIPClient:            egressIPClientset,
-		EgressFirewallClient:      egressFirewallClientset,
-		OCPNetworkClient:          networkClientset,
-		CloudNetworkClient:        cloudNetworkClientset,
-		EgressQoSClient:           egressqosClientset,
-		NetworkAttchDefClient:     networkAttchmntDefClientset,
-		MultiNetworkPolicyClient:  multiNetworkPolicyClientset,
-		EgressServiceClient:       egressserviceClientset,
-		AdminPolicyRouteClient:    adminPolicyBasedRouteClientset,
-		IPAMClaimsClient:          ipamClaimsClientset,
-		UserDefinedNetworkClient:  userDefinedNetworkClientSet,
-		NetworkConnectClient:      networkConnectClientset,
-		RouteAdvertisementsClient: routeAdvertisementsClientset,
-		FRRClient:                 frrClientset,
-		NetworkQoSClient:          networkqosClientset,
-		VTEPClient:                vtepClientset,
+		KubeClient:                 kclientset,
+		ANPClient:                  anpClientset,
+		EgressIPClient:             egressIPClientset,
+		EgressFirewallClient:       egressFirewallClientset,
+		OCPNetworkClient:           networkClientset,
+		CloudNetworkClient:         cloudNetworkClientset,
+		EgressQoSClient:            egressqosClientset,
+		NetworkAttchDefClient:      networkAttchmntDefClientset,
+		MultiNetworkPolicyClient:   multiNetworkPolicyClientset,
+		EgressServiceClient:        egressserviceClientset,
+		AdminPolicyRouteClient:     adminPolicyBasedRouteClientset,
+		IPAMClaimsClient:           ipamClaimsClientset,
+		UserDefinedNetworkClient:   userDefinedNetworkClientSet,
+		NetworkConnectClient:       networkConnectClientset,
+		RouteAdvertisementsClient:  routeAdvertisementsClientset,
+		FRRClient:                  frrClientset,
+		NetworkQoSClient:           networkqosClientset,
+		VTEPClient:                 vtepClientset,
+		NetworkObservabilityClient: networkObservabilityClientset,
 	}, nil
 }
 
@@ -57,6 +57,7 @@ type NetInfo interface {
 	AllowsPersistentIPs() bool
 	PhysicalNetworkName() string
 	Transport() string
+	Multicast() string
 	OutboundSNAT() string
 	EVPNVTEPName() string
 	EVPNMACVRFVNI() int32
@@ -666,6 +667,12 @@ func (nInfo *DefaultNetInfo) Transport() string {
 	return config.Default.Transport
 }
 
+// Multicast returns empty as multicast on the default network is only
+// controlled by the global configuration
+func (nInfo *DefaultNetInfo) Multicast() string {
+	return ""
+}
+
 // OutboundSNAT() string returns the outbound SNAT configuration for the default network when using no-overlay transport.
 func (nInfo *DefaultNetInfo) OutboundSNAT() string {
 	return config.NoOverlay.OutboundSNAT
@@ -740,6 +747,7 @@ type userDefinedNetInfo struct {
 	managementIPs       []net.IP
 
 	transport string
+	multicast string
 	evpn      *ovncnitypes.EVPNConfig
 }
 
@@ -876,6 +884,12 @@ func (nInfo *userDefinedNetInfo) Transport() string {
 	return nInfo.transport
 }
 
+// Multicast returns the multicast mode requested for this network, empty if
+// it follows the global configuration
+func (nInfo *userDefinedNetInfo) Multicast() string {
+	return nInfo.multicast
+}
+
 // OutboundSNAT() string returns the outbound SNAT configuration for this network when using no-overlay transport.
 func (nInfo *userDefinedNetInfo) OutboundSNAT() string {
 	// TODO: implement per-network no-overlay outbound SNAT configuration
@@ -1054,6 +1068,9 @@ func (nInfo *userDefinedNetInfo) canReconcile(other NetInfo) bool {
 	if nInfo.Transport() != other.Transport() {
 		return false
 	}
+	if nInfo.multicast != other.Multicast() {
+		return false
+	}
 	if nInfo.EVPNVTEPName() != other.EVPNVTEPName() {
 		return false
 	}
@@ -1112,6 +1129,7 @@ func (nInfo *userDefinedNetInfo) copy() *userDefinedNetInfo {
 		defaultGatewayIPs:     nInfo.defaultGatewayIPs,
 		managementIPs:         nInfo.managementIPs,
 		transport:             nInfo.transport,
+		multicast:             nInfo.multicast,
 		evpn:                  nInfo.evpn,
 	}
 	// copy mutables
@@ -1137,6 +1155,7 @@ func newLayer3NetConfInfo(netconf *ovncnitypes.NetConf) (MutableNetInfo, error)
 		joinSubnets:    joinSubnets,
 		mtu:            netconf.MTU,
 		transport:      netconf.Transport,
+		multicast:      netconf.Multicast,
 		evpn:           netconf.EVPN,
 		mutableNetInfo: mutableNetInfo{
 			id:   types.InvalidID,
@@ -1214,6 +1233,7 @@ func newLayer2NetConfInfo(netconf *ovncnitypes.NetConf) (MutableNetInfo, error)
 		defaultGatewayIPs:     defaultGatewayIPs,
 		managementIPs:         managementIPs,
 		transport:             netconf.Transport,
+		multicast:             netconf.Multicast,
 		evpn:                  netconf.EVPN,
 		mutableNetInfo: mutableNetInfo{
 			id:   types.InvalidID,
@@ -1555,6 +1575,18 @@ func ValidateNetConf(nadName string, netconf *ovncnitypes.NetConf) error {
 		})
 	}
 
+	if netconf.Multicast != "" {
+		if netconf.Multicast != types.NetworkMulticastEnabled && netconf.Multicast != types.NetworkMulticastDisabled {
+			return fmt.Errorf("invalid multicast %q: must be one of %q", netconf.Multicast, []string{
+				types.NetworkMulticastEnabled,
+				types.NetworkMulticastDisabled,
+			})
+		}
+		if netconf.Role != types.NetworkRolePrimary || netconf.Topology == types.LocalnetTopology {
+			return fmt.Errorf("multicast is only supported for primary layer2 and layer3 networks")
+		}
+	}
+
 	if netconf.JoinSubnet != "" && netconf.Topology == types.LocalnetTopology {
 		return fmt.Errorf("localnet topology does not allow specifying join-subnet as services are not supported")
 	}
@@ -503,6 +503,35 @@ func TestParseNetconf(t *testing.T) {
 `,
 			expectedError: fmt.Errorf("localnet topology does not allow specifying join-subnet as services are not supported"),
 		},
+		{
+			desc: "invalid attachment definition for a secondary network with multicast",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "layer3",
+            "subnets": "192.168.200.0/16",
+            "multicast": "enabled",
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedError: fmt.Errorf("multicast is only supported for primary layer2 and layer3 networks"),
+		},
+		{
+			desc: "invalid multicast mode",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "layer3",
+            "subnets": "192.168.200.0/16",
+            "role": "primary",
+            "multicast": "sometimes",
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedError: fmt.Errorf("invalid multicast \"sometimes\": must be one of [\"enabled\" \"disabled\"]"),
+		},
 		{
 			desc: "A layer2 primary UDN requires a subnet",
 			inputNetAttachDefConfigSpec: `
@@ -1836,6 +1865,13 @@ func TestAreNetworksCompatible(t *testing.T) {
 			expectedResult:         true,
 			expectationDescription: "networks with no EVPN config should be compatible",
 		},
+		{
+			desc:                   "multicast mode update",
+			aNetwork:               &userDefinedNetInfo{multicast: ""},
+			anotherNetwork:         &userDefinedNetInfo{multicast: "disabled"},
+			expectedResult:         false,
+			expectationDescription: "we should reconcile on multicast mode updates",
+		},
 	}
 
 	for _, test := range tests {
@@ -14,6 +14,9 @@ import (
 const (
 	// Annotation used to enable/disable multicast in the namespace
 	NsMulticastAnnotation = "k8s.ovn.org/multicast-enabled"
+	// Annotation used to allow multicast traffic between the multicast enabled
+	// namespaces annotated with the same group name
+	NsMulticastGroupAnnotation = "k8s.ovn.org/multicast-group"
 	// Annotations used by multiple external gateways feature
 	RoutingExternalGWsAnnotation    = "k8s.ovn.org/routing-external-gws"
 	RoutingNamespaceAnnotation      = "k8s.ovn.org/routing-namespaces"
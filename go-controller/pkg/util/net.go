@@ -70,6 +70,39 @@ func GetNodeHybridOverlayIfAddr(subnet *net.IPNet) *net.IPNet {
 	return &net.IPNet{IP: iputils.NextIP(mgmtIfAddr.IP), Mask: subnet.Mask}
 }
 
+// GetNodeServiceMonitorIfAddr returns the node logical switch address used as
+// source of OVN load balancer health checks (the last address before the
+// broadcast address), return nil if the subnet is invalid
+func GetNodeServiceMonitorIfAddr(subnet *net.IPNet) *net.IPNet {
+	if subnet == nil {
+		return nil
+	}
+	ip := iputils.PrevIP(SubnetBroadcastIP(*subnet))
+	if ip == nil || !subnet.Contains(ip) {
+		return nil
+	}
+	return &net.IPNet{IP: ip, Mask: subnet.Mask}
+}
+
+// maxIPv6IPAMRangeSize is the number of addresses of an IPv6 subnet managed by
+// its IPAM, which is limited to the first addresses of larger subnets
+const maxIPv6IPAMRangeSize = 65536
+
+// GetNodeServiceMonitorExcludeIP returns the source address of OVN load
+// balancer health checks to exclude from the IPAM of the subnet, return nil
+// if the subnet is invalid or if its IPAM never allocates that address, as for
+// large IPv6 subnets
+func GetNodeServiceMonitorExcludeIP(subnet *net.IPNet) *net.IPNet {
+	svcMonitorIP := GetNodeServiceMonitorIfAddr(subnet)
+	if svcMonitorIP == nil {
+		return nil
+	}
+	if utilnet.IsIPv6CIDR(subnet) && utilnet.RangeSize(subnet) > maxIPv6IPAMRangeSize {
+		return nil
+	}
+	return &net.IPNet{IP: svcMonitorIP.IP, Mask: GetIPFullMask(svcMonitorIP.IP)}
+}
+
 // IsNodeHybridOverlayIfAddr returns whether the provided IP is a node hybrid
 // overlay address on any of the provided subnets
 func IsNodeHybridOverlayIfAddr(ip net.IP, subnets []*net.IPNet) bool {
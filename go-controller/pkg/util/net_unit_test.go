@@ -470,3 +470,35 @@ func TestContainsCIDR(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodeServiceMonitorExcludeIP(t *testing.T) {
+	tests := []struct {
+		desc   string
+		inp    *net.IPNet
+		outExp *net.IPNet
+	}{
+		{
+			desc:   "IPv4 subnet",
+			inp:    ovntest.MustParseIPNet("10.244.1.0/24"),
+			outExp: ovntest.MustParseIPNet("10.244.1.254/32"),
+		},
+		{
+			desc:   "IPv6 subnet managed entirely by its IPAM",
+			inp:    ovntest.MustParseIPNet("fd00:10:244:1::/112"),
+			outExp: ovntest.MustParseIPNet("fd00:10:244:1::fffe/128"),
+		},
+		{
+			desc: "IPv6 subnet larger than its IPAM range",
+			inp:  ovntest.MustParseIPNet("fd00:10:244:1::/64"),
+		},
+		{
+			desc: "no subnet",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			res := GetNodeServiceMonitorExcludeIP(tc.inp)
+			assert.Equal(t, tc.outExp, res)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -740,6 +741,18 @@ func ReplaceOFFlows(bridgeName string, flows []string) (string, string, error) {
 	return strings.Trim(stdout.String(), "\" \n"), stderr.String(), err
 }
 
+// ModifyOFFlows atomically applies a slice of flow mods to the bridge. Every flow mod
+// starts with the add, modify, modify_strict, delete or delete_strict command.
+func ModifyOFFlows(bridgeName string, flowMods []string) (string, string, error) {
+	args := []string{"-O", "OpenFlow13", "--bundle", "add-flows", bridgeName, "-"}
+	stdin := &openFlowStdinReader{flows: flowMods}
+
+	cmd := runner.exec.Command(runner.ofctlPath, args...)
+	cmd.SetStdin(stdin)
+	stdout, stderr, err := runCmd(cmd, runner.ofctlPath, args...)
+	return strings.Trim(stdout.String(), "\" \n"), stderr.String(), err
+}
+
 // GetOFFlows gets all the flows from a bridge
 func GetOFFlows(bridgeName string) ([]string, error) {
 	stdout, stderr, err := RunOVSOfctl("dump-flows", bridgeName)
@@ -758,6 +771,59 @@ func GetOFFlows(bridgeName string) ([]string, error) {
 	return flows, nil
 }
 
+// DiffOFFlows compares flows to the flows installed on a bridge, both normalized the way OVS
+// prints them. It returns the lines of ovs-ofctl diff-flows: flows that are not installed as
+// they are in flows, prefixed with "-", and installed flows that are not in flows, prefixed
+// with "+". Versions of the same flow that differ are printed one after the other.
+func DiffOFFlows(bridgeName string, flows []string) ([]string, error) {
+	return diffOFFlows(flows, bridgeName)
+}
+
+// NormalizeOFFlows returns flows the way OVS prints them once parsed, so that flows with
+// equivalent matches are printed the same: "table=<table> <priority and match> <cookie and
+// other flow attributes> actions=<actions>", the table and the attributes with their default
+// value being omitted. Flows with the same table, priority and match are merged into the
+// last one of them.
+func NormalizeOFFlows(flows []string) ([]string, error) {
+	// every flow is only on the first side of the diff
+	diff, err := diffOFFlows(flows, "/dev/null")
+	if err != nil {
+		return nil, err
+	}
+	normalized := make([]string, 0, len(diff))
+	for _, line := range diff {
+		if flow, found := strings.CutPrefix(line, "-"); found {
+			normalized = append(normalized, flow)
+		}
+	}
+	return normalized, nil
+}
+
+// diffOFFlows returns the lines of ovs-ofctl diff-flows comparing flows to the flows of
+// source, a bridge or a file
+func diffOFFlows(flows []string, source string) ([]string, error) {
+	args := []string{"--no-names", "-O", "OpenFlow13", "diff-flows", "/dev/stdin", source}
+	stdin := &openFlowStdinReader{flows: flows}
+
+	cmd := runner.exec.Command(runner.ofctlPath, args...)
+	cmd.SetStdin(stdin)
+	stdout, stderr, err := runCmd(cmd, runner.ofctlPath, args...)
+	// ovs-ofctl diff-flows exits with status 2 when there are differences
+	var exitErr kexec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitStatus() == 2) {
+		return nil, fmt.Errorf("failed to diff flows with %q, stderr: %q, error: %v",
+			source, stderr, err)
+	}
+
+	var diff []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if strings.HasPrefix(line, "-") || strings.HasPrefix(line, "+") {
+			diff = append(diff, line)
+		}
+	}
+	return diff, nil
+}
+
 // GetOpenFlowPorts names or numbers for a given bridge
 func GetOpenFlowPorts(bridgeName string, namedPorts bool) ([]string, error) {
 	stdout, stderr, err := RunOVSOfctl("show", bridgeName)
@@ -884,6 +884,107 @@ func GetEndpointsForService(endpointSlices []*discoveryv1.EndpointSlice, service
 	return globalEndpoints, localEndpoints, errors.Join(validationErrors...)
 }
 
+// PortToZoneToLBEndpoints maps service port keys to topology zones and the load balancer endpoints
+// that should serve the traffic originating from that zone.
+// e.g. map["TCP/http"]["zone-a"] = LBEndpoints{Port: 8080, V4IPs: []string{"192.168.1.10"}}.
+type PortToZoneToLBEndpoints map[string]map[string]LBEndpoints
+
+// GetZoneEndpointsForService groups the eligible endpoints of a service by the topology zone they should
+// serve, following the same rules as kube-proxy:
+//   - if all the eligible endpoints of a port have zone hints, the endpoints are grouped by their hinted zones.
+//   - otherwise, if the service has trafficDistribution PreferClose (or PreferSameZone) and all the eligible
+//     endpoints of a port have a zone, the endpoints are grouped by their own zone.
+//
+// Ports not meeting these conditions are not included in the result and their traffic must be load balanced
+// to all the cluster endpoints. The same applies to zones without any endpoints.
+func GetZoneEndpointsForService(endpointSlices []*discoveryv1.EndpointSlice, service *corev1.Service) PortToZoneToLBEndpoints {
+	zoneEndpoints := make(PortToZoneToLBEndpoints)
+	targetEndpoints := newTargetEndpoints(endpointSlices)
+
+	validServicePortKeys := map[string]bool{}
+	for _, servicePort := range service.Spec.Ports {
+		validServicePortKeys[GetServicePortKey(servicePort.Protocol, servicePort.Name)] = true
+	}
+
+	for portName, protocolMap := range targetEndpoints {
+		for protocol, portNumberMap := range protocolMap {
+			slicePortKey := GetServicePortKey(protocol, portName)
+			if !validServicePortKeys[slicePortKey] || len(portNumberMap) == 0 {
+				continue
+			}
+			// mirror GetEndpointsForService and only consider the first target port number
+			portNumbers := maps.Keys(portNumberMap)
+			slices.Sort(portNumbers)
+			targetPortNumber := portNumbers[0]
+
+			endpointsByZone := groupEndpointsByZone(getEligibleEndpoints(portNumberMap[targetPortNumber], service), service)
+			for zone, endpoints := range endpointsByZone {
+				lbe, err := buildLBEndpoints(service, targetPortNumber, endpoints)
+				if err != nil {
+					klog.Warningf("Failed to build zone endpoints for zone %s port %s: %v", zone, slicePortKey, err)
+					continue
+				}
+				if _, ok := zoneEndpoints[slicePortKey]; !ok {
+					zoneEndpoints[slicePortKey] = map[string]LBEndpoints{}
+				}
+				zoneEndpoints[slicePortKey][zone] = lbe
+			}
+		}
+	}
+
+	if len(zoneEndpoints) > 0 {
+		klog.V(5).Infof("Zone endpoints for %s/%s: %v", service.Namespace, service.Name, zoneEndpoints)
+	}
+	return zoneEndpoints
+}
+
+// serviceTrafficDistributionPreferZone returns true if the service requested its traffic to be preferably
+// delivered to endpoints in the same zone as the client.
+func serviceTrafficDistributionPreferZone(service *corev1.Service) bool {
+	if service.Spec.TrafficDistribution == nil {
+		return false
+	}
+	switch *service.Spec.TrafficDistribution {
+	case corev1.ServiceTrafficDistributionPreferClose, corev1.ServiceTrafficDistributionPreferSameZone:
+		return true
+	}
+	return false
+}
+
+// groupEndpointsByZone organizes a list of eligible endpoints by the zones they should serve, according to
+// their zone hints or, for services with a zone trafficDistribution, to their zone.
+// It returns nil if any of the endpoints can't be assigned to a zone, in which case the endpoints must
+// serve all zones.
+func groupEndpointsByZone(endpoints []discoveryv1.Endpoint, service *corev1.Service) map[string][]discoveryv1.Endpoint {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	byHints := map[string][]discoveryv1.Endpoint{}
+	byZone := map[string][]discoveryv1.Endpoint{}
+	useHints, useZone := true, serviceTrafficDistributionPreferZone(service)
+	for _, endpoint := range endpoints {
+		if endpoint.Hints == nil || len(endpoint.Hints.ForZones) == 0 {
+			useHints = false
+		} else {
+			for _, hint := range endpoint.Hints.ForZones {
+				byHints[hint.Name] = append(byHints[hint.Name], endpoint)
+			}
+		}
+		if endpoint.Zone == nil || *endpoint.Zone == "" {
+			useZone = false
+		} else {
+			byZone[*endpoint.Zone] = append(byZone[*endpoint.Zone], endpoint)
+		}
+	}
+	switch {
+	case useHints:
+		return byHints
+	case useZone:
+		return byZone
+	}
+	return nil
+}
+
 // FindServicePortForEndpointSlicePort returns the ServicePort that corresponds to an EndpointSlice port
 // by matching the port name and protocol. This is the canonical way to map EndpointSlice ports to
 // Service ports, as Kubernetes guarantees that ServicePort.Name matches EndpointPort.Name.